@@ -0,0 +1,396 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb"
+	"github.com/oracle/nosql-go-sdk/nosqldb/format"
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/shell"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{
+			name:  "query",
+			args:  "<statement>",
+			usage: "execute a query and print the results",
+			run:   runQuery,
+			flags: func(fs *flag.FlagSet) {
+				fs.String("format", "json", "print the results in the specified `format`: json, text or csv")
+				fs.Int("trace", 0, "trace the query execution on the server at the specified `level`,\n"+
+					"and print the traces to the standard error")
+			},
+		},
+		{
+			name:  "get",
+			args:  "<table> <key JSON>",
+			usage: "get a row",
+			run:   runGet,
+		},
+		{
+			name:  "put",
+			args:  "<table> <value JSON>",
+			usage: "put a row",
+			run:   runPut,
+		},
+		{
+			name:  "delete",
+			args:  "<table> <key JSON>",
+			usage: "delete a row",
+			run:   runDelete,
+		},
+		{
+			name:  "ddl",
+			args:  "<statement>",
+			usage: "execute a table DDL statement and wait for it",
+			run:   runDDL,
+		},
+		{
+			name:  "tables",
+			usage: "list the tables",
+			run:   runTables,
+		},
+		{
+			name:  "usage",
+			args:  "<table>",
+			usage: "print the usage records of a table",
+			run:   runUsage,
+			flags: func(fs *flag.FlagSet) {
+				fs.Duration("since", time.Hour, "print the records of the specified `duration` until now")
+			},
+		},
+		{
+			name:  "export",
+			args:  "<table>",
+			usage: "write the rows of a table as JSON lines",
+			run:   runExport,
+			flags: func(fs *flag.FlagSet) {
+				fs.String("o", "", "write to the specified `file` instead of the standard output")
+			},
+		},
+		{
+			name:  "import",
+			args:  "<table>",
+			usage: "put rows read as JSON lines",
+			run:   runImport,
+			flags: func(fs *flag.FlagSet) {
+				fs.String("i", "", "read from the specified `file` instead of the standard input")
+			},
+		},
+		{
+			name:        "shell",
+			usage:       "start an interactive SQL shell",
+			run:         runShell,
+			interactive: true,
+		},
+	}
+}
+
+// checkArgs checks that the command has n positional arguments.
+func checkArgs(fs *flag.FlagSet, n int) error {
+	if fs.NArg() != n {
+		fs.Usage()
+		return fmt.Errorf("%s: expected %d arguments, got %d", fs.Name(), n, fs.NArg())
+	}
+	return nil
+}
+
+// parseRow parses a row or primary key specified as a JSON object.
+func parseRow(s string) (*types.MapValue, error) {
+	v, err := types.NewMapValueFromJSON(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON object %q: %v", s, err)
+	}
+	return v, nil
+}
+
+func runQuery(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 1); err != nil {
+		return err
+	}
+	f, err := format.ParseFormat(fs.Lookup("format").Value.String())
+	if err != nil {
+		return err
+	}
+	trace, _ := strconv.Atoi(fs.Lookup("trace").Value.String())
+	return query(ctx, client, &nosqldb.QueryRequest{Statement: fs.Arg(0), TraceLevel: trace}, f, out)
+}
+
+// query executes the query and writes the results in the specified format.
+// JSON results are written as they are read, one row per line.
+func query(ctx context.Context, client *nosqldb.Client, req *nosqldb.QueryRequest, f format.Format, out io.Writer) error {
+	defer req.Close()
+
+	opts := &format.Options{Format: f}
+	var all []*types.MapValue
+	for {
+		res, err := client.QueryWithContext(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.GetResults()
+		if err != nil {
+			return err
+		}
+
+		for _, t := range res.Traces() {
+			fmt.Fprintf(os.Stderr, "-- trace of %s:\n%s\n", t.Batch, t.Trace)
+		}
+
+		if f == format.JSON {
+			if err = format.Write(out, rows, opts); err != nil {
+				return err
+			}
+		} else {
+			all = append(all, rows...)
+		}
+
+		if req.IsDone() {
+			break
+		}
+	}
+
+	if f == format.JSON {
+		return nil
+	}
+	return format.Write(out, all, opts)
+}
+
+func runGet(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 2); err != nil {
+		return err
+	}
+	key, err := parseRow(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	res, err := client.GetWithContext(ctx, &nosqldb.GetRequest{
+		TableName: fs.Arg(0),
+		Key:       key,
+	})
+	if err != nil {
+		return err
+	}
+	if !res.RowExists() {
+		return errors.New("row not found")
+	}
+	_, err = fmt.Fprintln(out, jsonutil.AsJSON(res.Value.Map()))
+	return err
+}
+
+func runPut(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 2); err != nil {
+		return err
+	}
+	value, err := parseRow(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	res, err := client.PutWithContext(ctx, &nosqldb.PutRequest{
+		TableName: fs.Arg(0),
+		Value:     value,
+	})
+	if err != nil {
+		return err
+	}
+	if !res.Success() {
+		return errors.New("put failed")
+	}
+	return nil
+}
+
+func runDelete(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 2); err != nil {
+		return err
+	}
+	key, err := parseRow(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	res, err := client.DeleteWithContext(ctx, &nosqldb.DeleteRequest{
+		TableName: fs.Arg(0),
+		Key:       key,
+	})
+	if err != nil {
+		return err
+	}
+	if !res.Success {
+		return errors.New("row not found")
+	}
+	return nil
+}
+
+func runDDL(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 1); err != nil {
+		return err
+	}
+
+	wait := *timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		wait = time.Until(deadline)
+	}
+	res, err := client.DoTableRequestAndWait(&nosqldb.TableRequest{Statement: fs.Arg(0)}, wait, time.Second)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s: %s\n", res.TableName, res.State)
+	return err
+}
+
+func runTables(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 0); err != nil {
+		return err
+	}
+
+	res, err := client.ListTables(&nosqldb.ListTablesRequest{})
+	if err != nil {
+		return err
+	}
+	for _, t := range res.Tables {
+		if _, err = fmt.Fprintln(out, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runUsage(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 1); err != nil {
+		return err
+	}
+
+	since := fs.Lookup("since").Value.(flag.Getter).Get().(time.Duration)
+	now := time.Now()
+	res, err := client.GetTableUsage(&nosqldb.TableUsageRequest{
+		TableName: fs.Arg(0),
+		StartTime: now.Add(-since),
+		EndTime:   now,
+	})
+	if err != nil {
+		return err
+	}
+	for _, u := range res.UsageRecords {
+		if _, err = fmt.Fprintln(out, jsonutil.AsJSON(u)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runExport(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 1); err != nil {
+		return err
+	}
+
+	if file := fs.Lookup("o").Value.String(); file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return query(ctx, client, &nosqldb.QueryRequest{Statement: "SELECT * FROM " + fs.Arg(0)}, format.JSON, out)
+}
+
+func runImport(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 1); err != nil {
+		return err
+	}
+
+	var in io.Reader = os.Stdin
+	if file := fs.Lookup("i").Value.String(); file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	rows, err := nosqldb.ReadJSONLines(in)
+	if err != nil {
+		return err
+	}
+	n, err := importRows(ctx, client, fs.Arg(0), rows)
+	fmt.Fprintf(out, "imported %d rows\n", n)
+	return err
+}
+
+// importRows puts the rows in the table with WriteMultiple requests, and
+// returns the number of rows written.
+func importRows(ctx context.Context, client *nosqldb.Client, table string, rows []*types.MapValue) (int, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	shardKey, err := client.TableShardKey("", table)
+	if err != nil {
+		return 0, err
+	}
+
+	ops := make([]*nosqldb.WriteOperation, len(rows))
+	for i, r := range rows {
+		ops[i] = &nosqldb.WriteOperation{
+			PutRequest: &nosqldb.PutRequest{TableName: table, Value: r},
+		}
+	}
+	batches, err := nosqldb.PlanWriteBatches(ops, shardKey, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, b := range batches {
+		req := &nosqldb.WriteMultipleRequest{TableName: table}
+		for _, op := range b.Operations {
+			if err = req.AddPutRequest(op.PutRequest, true); err != nil {
+				return n, err
+			}
+		}
+
+		res, err := client.WriteMultipleWithContext(ctx, req)
+		if err != nil {
+			return n, err
+		}
+		if !res.IsSuccess() {
+			i := b.Indexes[res.FailedOperationIndex]
+			return n, fmt.Errorf("failed to put row %d: %s", i+1, jsonutil.AsJSON(rows[i].Map()))
+		}
+		n += len(b.Operations)
+	}
+	return n, nil
+}
+
+func runShell(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error {
+	if err := checkArgs(fs, 0); err != nil {
+		return err
+	}
+
+	sh := shell.New(client, os.Stdin, out)
+	sh.Err = os.Stderr
+	sh.DDLTimeout = *timeout
+	return sh.Run(ctx)
+}
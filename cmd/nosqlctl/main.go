@@ -0,0 +1,215 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+// Command nosqlctl is a command line tool for the Oracle NoSQL Database,
+// built on the Go SDK. It executes queries, reads and writes rows, executes
+// table DDL statements, reports table usage, and imports and exports tables,
+// which is useful for operations and for checking the behavior of the SDK
+// against an environment.
+//
+// Usage:
+//
+//	nosqlctl [OPTIONS] <command> [arguments]
+//
+// The commands are:
+//
+//	query   <statement>            execute a query and print the results
+//	get     <table> <key JSON>     get a row
+//	put     <table> <value JSON>   put a row
+//	delete  <table> <key JSON>     delete a row
+//	ddl     <statement>            execute a table DDL statement and wait for it
+//	tables                         list the tables
+//	usage   <table>                print the usage records of a table
+//	export  <table>                write the rows of a table as JSON lines
+//	import  <table>                put rows read as JSON lines
+//	shell                          start an interactive SQL shell
+//
+// Rows are printed, exported and imported as JSON, one row per line. The
+// options select the service to connect to, as for the examples:
+//
+//	# Oracle NoSQL Cloud Service, with the DEFAULT profile of ~/.oci/config
+//	nosqlctl -config=cloud -endpoint=us-ashburn-1 tables
+//
+//	# Oracle NoSQL Cloud Simulator
+//	nosqlctl -config=cloudsim -endpoint=localhost:8080 query "SELECT * FROM users"
+//
+//	# Oracle NoSQL Database on-premise, with security enabled
+//	nosqlctl -config=onprem -configFile=~/kvstore_config -endpoint=https://localhost:8080 get users '{"id": 1}'
+//
+// The service can also be specified by a connection string, with the -dsn
+// option or the NOSQL_DSN environment variable:
+//
+//	NOSQL_DSN="nosql+cloud://region=us-ashburn-1?auth=instance-principal" nosqlctl tables
+//
+// To build it, use the command:
+//
+//	go build -o bin/nosqlctl ./cmd/nosqlctl
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb"
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/cloudsim"
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/kvstore"
+	"github.com/oracle/nosql-go-sdk/nosqldb/common"
+)
+
+// Command line flags.
+var (
+	config = flag.String("config", "cloud", "Specify the `configuration` of the Oracle NoSQL Database to connect to:\n"+
+		"\tcloud    : the Oracle NoSQL Cloud Service with IAM configuration\n"+
+		"\tonprem   : the Oracle NoSQL Database Server on-premise\n"+
+		"\tcloudsim : the Oracle NoSQL Cloud Simulator\n")
+	endpoint = flag.String("endpoint", "", "Specify the service `endpoint`, or the region id for the cloud service.\n"+
+		"For the cloud service, the region of the IAM configuration file is used if not specified.")
+	configFile = flag.String("configFile", "", "Specify the path to the IAM configuration `file` for -config=cloud\n"+
+		"(defaults to ~/.oci/config), or to the file with the username and password for -config=onprem.")
+	profileID     = flag.String("iamProfileID", "", "(optional) `profile ID` to find in IAM config file (defaults to \"DEFAULT\").")
+	compartmentID = flag.String("iamCompartmentID", "", "(optional) IAM `compartment ID` to use for requests (defaults to tenantID).")
+	timeout       = flag.Duration("timeout", time.Minute, "Specify the `timeout` of the command.")
+	insecure      = flag.Bool("insecure", false, "Skip the verification of the server certificate.")
+	dsn           = flag.String("dsn", os.Getenv("NOSQL_DSN"), "Specify the `connection string` of the service, as for nosqldb.Open,\n"+
+		"instead of the other options. Defaults to the NOSQL_DSN environment variable.")
+)
+
+// command represents a subcommand of nosqlctl.
+type command struct {
+	name  string
+	args  string
+	usage string
+	run   func(ctx context.Context, client *nosqldb.Client, fs *flag.FlagSet, out io.Writer) error
+
+	// flags defines the flags of the command, if any.
+	flags func(fs *flag.FlagSet)
+
+	// interactive reports whether the command runs until the user exits it,
+	// in which case the -timeout flag does not apply.
+	interactive bool
+}
+
+func main() {
+	flag.CommandLine.SetOutput(os.Stderr)
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), flag.Args()[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "nosqlctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(name string, args []string, out io.Writer) error {
+	cmd := findCommand(name)
+	if cmd == nil {
+		printUsage()
+		return fmt.Errorf("unknown command %q", name)
+	}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n  nosqlctl [OPTIONS] %s %s\n\n%s\n", cmd.name, cmd.args, cmd.usage)
+		fs.PrintDefaults()
+	}
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if !cmd.interactive {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	return cmd.run(ctx, client, fs, out)
+}
+
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// createClient creates a client with the configuration specified by the
+// command line flags.
+func createClient() (*nosqldb.Client, error) {
+	if *dsn != "" {
+		return nosqldb.Open(*dsn)
+	}
+
+	var p nosqldb.AuthorizationProvider
+	var err error
+	switch *config {
+	case "cloud":
+		file := *configFile
+		if file == "" {
+			file = "~/.oci/config"
+		}
+		p, err = iam.NewSignatureProviderFromFile(file, *profileID, "", *compartmentID)
+	case "cloudsim":
+		p = &cloudsim.AccessTokenProvider{TenantID: "nosqlctl"}
+	case "onprem":
+		if *configFile != "" {
+			p, err = kvstore.NewAccessTokenProviderFromFile(*configFile)
+		}
+	default:
+		return nil, fmt.Errorf("invalid configuration %q", *config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := nosqldb.Config{
+		Mode:                  *config,
+		AuthorizationProvider: p,
+	}
+	cfg.InsecureSkipVerify = *insecure
+
+	if cfg.IsCloud() && *endpoint != "" {
+		if region, err := common.StringToRegion(*endpoint); err == nil {
+			cfg.Region = region
+		} else {
+			cfg.Endpoint = *endpoint
+		}
+	} else {
+		cfg.Endpoint = *endpoint
+	}
+
+	return nosqldb.NewClient(cfg)
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n  nosqlctl [OPTIONS] <command> [arguments]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %-24s %s\n", cmd.name, cmd.args, cmd.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nUse \"nosqlctl <command> -h\" for the options of a command.\n\nOPTIONS:\n\n")
+	flag.PrintDefaults()
+}
@@ -0,0 +1,104 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+)
+
+// SessionAffinityConfig specifies how a Client keeps consecutive requests on
+// the same proxy node when the proxies are deployed behind a load balancer.
+//
+// This is useful for queries that return results in multiple batches, as the
+// continuation key of a batch is more efficiently handled by the proxy node
+// that served the previous batch.
+//
+// By default the Client only returns the "session" cookie set by the load
+// balancer of the cloud service.
+type SessionAffinityConfig struct {
+	// UseCookieJar specifies whether all cookies set by the server are
+	// stored and returned in subsequent requests, as a web browser does.
+	// Use this if the load balancer maintains stickiness with a cookie other
+	// than "session".
+	UseCookieJar bool `json:"useCookieJar,omitempty"`
+
+	// Header specifies the name of an HTTP header that carries an affinity
+	// key, for load balancers that route requests by hashing a header value.
+	// The affinity key is unique to each QueryRequest, so that all batches
+	// of a query carry the same key. Other requests carry a key that is
+	// unique to the Client.
+	Header string `json:"header,omitempty"`
+}
+
+// newAffinityKey returns a random affinity key.
+func newAffinityKey() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// initAffinity sets up session affinity for the Client.
+func (c *Client) initAffinity() (err error) {
+	if c.SessionAffinity.UseCookieJar {
+		if c.cookieJar, err = cookiejar.New(nil); err != nil {
+			return err
+		}
+	}
+
+	if c.SessionAffinity.Header != "" {
+		c.affinityKey = newAffinityKey()
+	}
+
+	return nil
+}
+
+// setAffinityKey assigns an affinity key to a query request that does not
+// have one. Internal requests created for the query inherit the key.
+func (c *Client) setAffinityKey(req Request) {
+	if c.SessionAffinity.Header == "" {
+		return
+	}
+
+	if qr, ok := req.(*QueryRequest); ok && !qr.isInternal && qr.affinityKey == "" {
+		qr.affinityKey = newAffinityKey()
+	}
+}
+
+// addAffinityHeaders adds the affinity header and cookies to the request.
+func (c *Client) addAffinityHeaders(httpReq *http.Request, req Request) {
+	if h := c.SessionAffinity.Header; h != "" {
+		key := c.affinityKey
+		if qr, ok := req.(*QueryRequest); ok && qr.affinityKey != "" {
+			key = qr.affinityKey
+		}
+		httpReq.Header.Set(h, key)
+	}
+
+	if c.cookieJar != nil {
+		for _, cookie := range c.cookieJar.Cookies(httpReq.URL) {
+			httpReq.AddCookie(cookie)
+		}
+	}
+}
+
+// saveCookies stores the cookies set by the server if a cookie jar is used.
+func (c *Client) saveCookies(u *url.URL, httpResp *http.Response) {
+	if c.cookieJar == nil || u == nil {
+		return
+	}
+
+	if cookies := httpResp.Cookies(); len(cookies) > 0 {
+		c.cookieJar.SetCookies(u, cookies)
+	}
+}
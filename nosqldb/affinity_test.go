@@ -0,0 +1,52 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionAffinity(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	client.SessionAffinity = SessionAffinityConfig{UseCookieJar: true, Header: "X-Affinity"}
+	require.NoError(t, client.initAffinity())
+
+	u, _ := url.Parse(client.requestURL)
+	httpResp := &http.Response{Header: http.Header{}}
+	httpResp.Header.Add("Set-Cookie", "lb=node1; Path=/")
+	client.saveCookies(u, httpResp)
+
+	// A query and its internal requests carry the same affinity key.
+	queryReq := &QueryRequest{Statement: "select * from T1"}
+	client.setAffinityKey(queryReq)
+	require.NotEmpty(t, queryReq.affinityKey)
+	internalReq := queryReq.copyInternal()
+	client.setAffinityKey(internalReq)
+
+	for _, req := range []Request{queryReq, internalReq, &GetRequest{TableName: "T1"}} {
+		httpReq, _ := http.NewRequest(http.MethodPost, client.requestURL, nil)
+		client.addAffinityHeaders(httpReq, req)
+		cookie, err := httpReq.Cookie("lb")
+		if assert.NoErrorf(t, err, "missing cookie for %T", req) {
+			assert.Equal(t, "node1", cookie.Value)
+		}
+
+		expect := queryReq.affinityKey
+		if _, ok := req.(*GetRequest); ok {
+			expect = client.affinityKey
+		}
+		assert.Equalf(t, expect, httpReq.Header.Get("X-Affinity"), "unexpected affinity key for %T", req)
+	}
+	assert.NotEqual(t, client.affinityKey, queryReq.affinityKey)
+}
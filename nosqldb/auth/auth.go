@@ -26,6 +26,11 @@ const (
 	// Signature authorization scheme.
 	// This is used for NoSQL cloud service that uses OCI IAM for request authorization.
 	Signature string = "Signature"
+
+	// Negotiate represents the SPNEGO authorization scheme.
+	// This is used for the on-premise Oracle NoSQL server that authenticates
+	// clients with Kerberos.
+	Negotiate string = "Negotiate"
 )
 
 // Token represents the credentials used to authorize the requests to access protected resources.
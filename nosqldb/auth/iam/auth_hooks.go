@@ -0,0 +1,95 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+// This software is dual-licensed to you under the Universal Permissive License (UPL) 1.0 as shown at https://oss.oracle.com/licenses/upl or Apache License 2.0 as shown at http://www.apache.org/licenses/LICENSE-2.0. You may choose either license.
+
+package iam
+
+import (
+	"crypto/x509"
+	"time"
+)
+
+// AuthHooks specifies functions that a SignatureProvider calls on events of
+// the lifecycle of its credentials, which can be used to emit metrics and
+// alerts on the health of authentication, rather than only seeing the errors
+// of the requests that fail.
+//
+// Any of the functions may be nil. They are called synchronously, on the
+// goroutine that signs a request or renews a token, so they should return
+// quickly and must be safe for concurrent use.
+type AuthHooks struct {
+	// TokenRefreshed is called after the security token of an instance
+	// principal, resource principal or workload identity is renewed, with the
+	// expiration time of the new token.
+	TokenRefreshed func(expiration time.Time)
+
+	// RefreshFailed is called when the renewal of a security token fails,
+	// with the error of the renewal.
+	RefreshFailed func(err error)
+
+	// CertificateRotated is called when the renewal of the security token of
+	// an instance principal finds that the leaf certificate of the instance
+	// has changed, with the new certificate.
+	CertificateRotated func(cert *x509.Certificate)
+
+	// SignatureComputed is called after a signature is computed for a
+	// request, with the time it took and the error, if any. It is not called
+	// when a cached signature is reused.
+	SignatureComputed func(elapsed time.Duration, err error)
+
+	// KeyReloaded is called when a private key file watched with
+	// SignatureProvider.WatchKeyFile has changed and a new key is read from
+	// it, with the fingerprint of the new key.
+	KeyReloaded func(fingerprint string)
+}
+
+// authHooksSetter is implemented by the configuration providers and
+// federation clients that report events to AuthHooks.
+type authHooksSetter interface {
+	setAuthHooks(hooks *AuthHooks)
+}
+
+// setAuthHooks sets the hooks of v, if it reports events to AuthHooks.
+func setAuthHooks(v interface{}, hooks *AuthHooks) {
+	if s, ok := v.(authHooksSetter); ok {
+		s.setAuthHooks(hooks)
+	}
+}
+
+// tokenRenewed calls the TokenRefreshed or the RefreshFailed hook for the
+// renewal that returned token and err. It may be called on a nil *AuthHooks.
+func (h *AuthHooks) tokenRenewed(token securityToken, err error) {
+	switch {
+	case h == nil:
+	case err != nil:
+		if h.RefreshFailed != nil {
+			h.RefreshFailed(err)
+		}
+	case h.TokenRefreshed != nil:
+		h.TokenRefreshed(token.ExpirationTime())
+	}
+}
+
+// certificateRotated calls the CertificateRotated hook if cert differs from
+// the previous certificate. It may be called on a nil *AuthHooks.
+func (h *AuthHooks) certificateRotated(previous, cert *x509.Certificate) {
+	if h == nil || h.CertificateRotated == nil || previous == nil || cert == nil || previous.Equal(cert) {
+		return
+	}
+	h.CertificateRotated(cert)
+}
+
+// signatureComputed calls the SignatureComputed hook for a signature that
+// was computed since start. It may be called on a nil *AuthHooks.
+func (h *AuthHooks) signatureComputed(start time.Time, err error) {
+	if h != nil && h.SignatureComputed != nil {
+		h.SignatureComputed(time.Since(start), err)
+	}
+}
+
+// keyReloaded calls the KeyReloaded hook for a new key with the specified
+// fingerprint. It may be called on a nil *AuthHooks.
+func (h *AuthHooks) keyReloaded(fingerprint string) {
+	if h != nil && h.KeyReloaded != nil {
+		h.KeyReloaded(fingerprint)
+	}
+}
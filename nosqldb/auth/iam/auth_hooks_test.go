@@ -0,0 +1,122 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package iam
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHooks_TokenRefresh(t *testing.T) {
+	var refreshed []time.Time
+	var failures []error
+	hooks := &AuthHooks{
+		TokenRefreshed: func(expiration time.Time) { refreshed = append(refreshed, expiration) },
+		RefreshFailed:  func(err error) { failures = append(failures, err) },
+	}
+
+	expiration := time.Now().Add(time.Hour)
+	fail := true
+	client := &genericFederationClient{
+		SessionKeySupplier: &genericKeySupplier{
+			RefreshFn: func() (*rsa.PrivateKey, []byte, error) { return nil, nil, nil },
+		},
+		RefreshSecurityToken: func() (securityToken, error) {
+			if fail {
+				return nil, errors.New("federation endpoint unavailable")
+			}
+			return &expiringToken{"token", expiration}, nil
+		},
+	}
+	// The hooks are set through the configuration provider.
+	setAuthHooks(&okeWorkloadIdentityConfigurationProvider{&resourcePrincipalKeyProvider{FederationClient: client}}, hooks)
+
+	_, err := client.SecurityToken()
+	assert.Error(t, err)
+	if assert.Len(t, failures, 1) {
+		assert.Contains(t, failures[0].Error(), "federation endpoint unavailable")
+	}
+	assert.Empty(t, refreshed)
+
+	fail = false
+	_, err = client.SecurityToken()
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{expiration}, refreshed)
+	assert.Len(t, failures, 1)
+}
+
+func TestAuthHooks_CertificateRotated(t *testing.T) {
+	var rotated []*x509.Certificate
+	hooks := &AuthHooks{
+		CertificateRotated: func(cert *x509.Certificate) { rotated = append(rotated, cert) },
+	}
+
+	cert := parseCertificate(leafCertPem)
+	_, newCertPem := generateRandomCertificate()
+	newCert := parseCertificate(string(newCertPem))
+
+	hooks.certificateRotated(nil, cert)
+	hooks.certificateRotated(cert, parseCertificate(leafCertPem))
+	assert.Empty(t, rotated)
+	hooks.certificateRotated(cert, newCert)
+	assert.Equal(t, []*x509.Certificate{newCert}, rotated)
+
+	// Hooks are optional.
+	var nilHooks *AuthHooks
+	nilHooks.certificateRotated(cert, newCert)
+	nilHooks.tokenRenewed(nil, errors.New("renewal failed"))
+	nilHooks.signatureComputed(time.Now(), nil)
+}
+
+func TestAuthHooks_SignatureComputed(t *testing.T) {
+	var errs []error
+	hooks := &AuthHooks{
+		SignatureComputed: func(elapsed time.Duration, err error) {
+			assert.True(t, elapsed >= 0)
+			errs = append(errs, err)
+		},
+	}
+
+	fail := false
+	p, err := NewSignatureProviderWithAuthorizationStringProvider(AuthorizationStringProviderFunc(
+		func(req *http.Request) (string, error) {
+			if fail {
+				return "", errors.New("signing service unavailable")
+			}
+			return `Signature version="1"`, nil
+		}), "ocid1.compartment.oc1..test")
+	require.NoError(t, err)
+	p.SetAuthHooks(hooks)
+
+	sign := func() error {
+		req, err := http.NewRequest(http.MethodPost, "https://nosql.us-ashburn-1.oci.oraclecloud.com/V2/nosql/data",
+			bytes.NewBufferString(testBody))
+		require.NoError(t, err)
+		return p.SignHTTPRequest(req)
+	}
+
+	// The cached signature is reused by the second request.
+	assert.NoError(t, sign())
+	assert.NoError(t, sign())
+	assert.Equal(t, []error{nil}, errs)
+
+	fail = true
+	p.SetClock(func() time.Time { return time.Now().Add(time.Hour) })
+	assert.Error(t, sign())
+	if assert.Len(t, errs, 2) {
+		assert.Error(t, errs[1])
+	}
+}
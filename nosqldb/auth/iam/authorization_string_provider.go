@@ -0,0 +1,132 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package iam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AuthorizationStringProvider is an interface used to obtain the complete
+// value of the Authorization header of a request from outside the process,
+// for example from an external signing service or hardware security module
+// that holds the private key.
+//
+// Implementations of this interface must be safe for concurrent use by
+// multiple goroutines.
+type AuthorizationStringProvider interface {
+	// AuthorizationString returns the value of the Authorization header for
+	// the specified request, in the format described by the OCI request
+	// signature specification:
+	//
+	//	Signature version="1",headers="date (request-target) host",keyId="...",algorithm="rsa-sha256",signature="..."
+	//
+	// The "date" header and the host of the request are set when this method
+	// is called. If a delegation token is used, the "opc-obo-token" header is
+	// set and must be included in the signature. If the request body must be
+	// included in the signature, the request has the "X-Nosql-Hash-Body: true"
+	// header, and the "content-length", "content-type" and "x-content-sha256"
+	// headers are set. This method must not modify the request.
+	AuthorizationString(req *http.Request) (string, error)
+}
+
+// AuthorizationStringProviderFunc is an adapter that allows the use of an
+// ordinary function as an AuthorizationStringProvider.
+type AuthorizationStringProviderFunc func(req *http.Request) (string, error)
+
+// AuthorizationString calls f(req).
+func (f AuthorizationStringProviderFunc) AuthorizationString(req *http.Request) (string, error) {
+	return f(req)
+}
+
+// externalRequestSigner is an HTTPRequestSigner that sets the Authorization
+// header of requests to the value returned by an AuthorizationStringProvider.
+type externalRequestSigner struct {
+	provider       AuthorizationStringProvider
+	shouldHashBody SignerBodyHashPredicate
+}
+
+// Sign computes the hash of the request body if required, and sets the
+// Authorization header of the request.
+func (s externalRequestSigner) Sign(req *http.Request) error {
+	if s.shouldHashBody(req) {
+		if err := calculateHashOfBody(req.Context(), req); err != nil {
+			return err
+		}
+	}
+
+	authValue, err := s.provider.AuthorizationString(req)
+	if err != nil {
+		return err
+	}
+	if authValue == "" {
+		return errors.New("AuthorizationStringProvider returned an empty Authorization header")
+	}
+
+	req.Header.Set(requestHeaderAuthorization, authValue)
+	return nil
+}
+
+// ExpirationTime returns a time in the future, as the Authorization headers
+// do not depend on a key that expires.
+func (s externalRequestSigner) ExpirationTime() time.Time {
+	return time.Now().Add(24 * time.Hour)
+}
+
+// NewSignatureProviderWithAuthorizationStringProvider creates a signature
+// provider that obtains the Authorization header of requests from the
+// specified AuthorizationStringProvider, rather than signing the requests with
+// a private key in the process.
+//
+// As with the other signature providers, an Authorization header that does
+// not include the request body is reused for up to 5 minutes. A delegation
+// token can be set with SetDelegationToken.
+//
+// The compartmentID specifies the OCID of compartment to which the Oracle
+// NoSQL tables belong. It is required, as the tenancy OCID cannot be
+// determined without a configuration. The Client must be configured with an
+// Endpoint or a Region.
+func NewSignatureProviderWithAuthorizationStringProvider(provider AuthorizationStringProvider, compartmentID string) (*SignatureProvider, error) {
+	if provider == nil {
+		return nil, errors.New("AuthorizationStringProvider must be non-nil")
+	}
+
+	if compartmentID == "" {
+		return nil, errors.New("compartmentID must be non-empty")
+	}
+
+	p := &SignatureProvider{
+		expiryInterval: 5 * time.Minute,
+		compartmentID:  compartmentID,
+		signer: externalRequestSigner{
+			provider:       provider,
+			shouldHashBody: hashBodyIfRequested,
+		},
+	}
+
+	return p, nil
+}
+
+// hashBodyIfRequested is the predicate used by the signature providers, which
+// only hash the body of a request if explicitly told to.
+func hashBodyIfRequested(r *http.Request) bool {
+	return r.Header.Get("X-Nosql-Hash-Body") == "true"
+}
+
+// setExternalDelegationToken sets the delegation token of a signature provider
+// that uses an AuthorizationStringProvider, keeping its signer.
+func (p *SignatureProvider) setExternalDelegationToken(delegationToken string) (*SignatureProvider, error) {
+	if delegationToken != "" && len(strings.Split(delegationToken, ".")) != 3 {
+		return nil, fmt.Errorf("given delegation token \"%s\" is not in valid JWT format", delegationToken)
+	}
+	p.delegationToken = delegationToken
+	return p, nil
+}
@@ -0,0 +1,69 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package iam
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizationStringProvider(t *testing.T) {
+	_, err := NewSignatureProviderWithAuthorizationStringProvider(nil, "ocid1.compartment.oc1..test")
+	assert.Error(t, err)
+
+	var calls int32
+	external := AuthorizationStringProviderFunc(func(req *http.Request) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		if req.Header.Get(requestHeaderDate) == "" {
+			return "", errors.New("missing date header")
+		}
+		return `Signature version="1",signature="` + req.Header.Get(requestHeaderXContentSHA256) + `"`, nil
+	})
+
+	p, err := NewSignatureProviderWithAuthorizationStringProvider(external, "ocid1.compartment.oc1..test")
+	require.NoError(t, err)
+	assert.Nil(t, p.Profile())
+
+	newRequest := func() *http.Request {
+		req, err := http.NewRequest(http.MethodPost, "https://nosql.us-ashburn-1.oci.oraclecloud.com/V2/nosql/data",
+			bytes.NewBufferString(testBody))
+		require.NoError(t, err)
+		return req
+	}
+
+	// The Authorization header is reused if the body is not hashed.
+	for i := 0; i < 2; i++ {
+		req := newRequest()
+		require.NoError(t, p.SignHTTPRequest(req))
+		assert.Equal(t, `Signature version="1",signature=""`, req.Header.Get(requestHeaderAuthorization))
+		assert.Equal(t, "ocid1.compartment.oc1..test", req.Header.Get(requestHeaderXNoSQLCompartmentID))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	req := newRequest()
+	req.Header.Set("X-Nosql-Hash-Body", "true")
+	require.NoError(t, p.SignHTTPRequest(req))
+	assert.Equal(t, `Signature version="1",signature="V9Z20UJTvkvpJ50flBzKE32+6m2zJjweHpDMX/U4Uy0="`,
+		req.Header.Get(requestHeaderAuthorization))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// The signer is kept when a delegation token is set.
+	_, err = p.SetDelegationToken(validJwtTokenString)
+	require.NoError(t, err)
+	req = newRequest()
+	req.Header.Set("X-Nosql-Hash-Body", "true")
+	require.NoError(t, p.SignHTTPRequest(req))
+	assert.Equal(t, validJwtTokenString, req.Header.Get(requestHeaderDelegationToken))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
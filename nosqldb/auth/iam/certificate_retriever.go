@@ -10,6 +10,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/oracle/nosql-go-sdk/nosqldb/httputil"
 )
@@ -34,6 +35,62 @@ type urlBasedX509CertificateRetriever struct {
 	privateKey        *rsa.PrivateKey
 	mux               sync.Mutex
 	httpClient        httputil.RequestExecutor
+
+	// stop is closed to stop the background refresh, see startBackgroundRefresh.
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// minCertificateRefreshDelay is the shortest delay between the background
+// refreshes of a certificate that are scheduled ahead of its expiration, so
+// that a certificate that is about to expire, or that the metadata service
+// fails to renew, is not refreshed continuously.
+const minCertificateRefreshDelay = 10 * time.Second
+
+// CertificateRefreshOptions specifies when the certificates of an instance
+// principal are refreshed in the background, so that they do not expire while
+// requests are signed. Certificates are otherwise refreshed when the security
+// token is renewed.
+//
+// If both Interval and BeforeExpiry are set, the certificates are refreshed at
+// whichever comes first.
+type CertificateRefreshOptions struct {
+	// Interval specifies the interval at which the certificates are
+	// refreshed. If not set, they are not refreshed periodically.
+	Interval time.Duration
+
+	// BeforeExpiry specifies how long before their expiration the
+	// certificates are refreshed. If not set, they are not refreshed ahead of
+	// their expiration.
+	BeforeExpiry time.Duration
+}
+
+// enabled reports whether certificates are refreshed in the background.
+func (o CertificateRefreshOptions) enabled() bool {
+	return o.Interval > 0 || o.BeforeExpiry > 0
+}
+
+// nextRefresh returns the delay until the next refresh of cert at now, or a
+// negative delay if cert is not refreshed in the background.
+func (o CertificateRefreshOptions) nextRefresh(now time.Time, cert *x509.Certificate) time.Duration {
+	delay := time.Duration(-1)
+	if o.Interval > 0 {
+		delay = o.Interval
+	}
+	if o.BeforeExpiry > 0 {
+		// A certificate that has not been retrieved yet is retrieved soon.
+		d := minCertificateRefreshDelay
+		if cert != nil {
+			d = cert.NotAfter.Add(-o.BeforeExpiry).Sub(now)
+		}
+		if d < minCertificateRefreshDelay {
+			d = minCertificateRefreshDelay
+		}
+		if delay < 0 || d < delay {
+			delay = d
+		}
+	}
+	return delay
 }
 
 func newURLBasedX509CertificateRetriever(client httputil.RequestExecutor, certURL, privateKeyURL, passphrase string) x509CertificateRetriever {
@@ -46,6 +103,48 @@ func newURLBasedX509CertificateRetriever(client httputil.RequestExecutor, certUR
 	}
 }
 
+// startBackgroundRefresh starts a goroutine that refreshes the certificate as
+// specified by options, until Stop is called. It does nothing if options do
+// not enable background refreshes. Failed refreshes are retried at the next
+// scheduled refresh, and the previous certificate is kept meanwhile.
+func (r *urlBasedX509CertificateRetriever) startBackgroundRefresh(options CertificateRefreshOptions) {
+	if !options.enabled() {
+		return
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.stop != nil {
+		return
+	}
+	r.stop = make(chan struct{})
+
+	go func(stop chan struct{}) {
+		for {
+			delay := options.nextRefresh(time.Now(), r.Certificate())
+			if delay < 0 {
+				return
+			}
+			timer := time.NewTimer(delay)
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+				r.Refresh()
+			}
+		}
+	}(r.stop)
+}
+
+// Stop stops the background refresh of the certificate, if it was started.
+func (r *urlBasedX509CertificateRetriever) Stop() {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.stop != nil {
+		r.stopOnce.Do(func() { close(r.stop) })
+	}
+}
+
 // Refresh() is failure atomic, i.e., CertificatePemRaw(), Certificate(), PrivateKeyPemRaw(), and PrivateKey() would
 // return their previous values if Refresh() fails.
 func (r *urlBasedX509CertificateRetriever) Refresh() error {
@@ -13,6 +13,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -255,3 +256,52 @@ func TestBadStaticCertificateRetriever(t *testing.T) {
 	k := retriever.PrivateKey()
 	assert.Nil(t, k)
 }
+
+func TestUrlBasedX509CertificateRetriever_BackgroundRefresh(t *testing.T) {
+	var requests int32
+	certServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, cert := generateRandomCertificate()
+		w.Write(cert)
+	}))
+	defer certServer.Close()
+
+	retriever := newURLBasedX509CertificateRetriever(&http.Client{}, certServer.URL, "", "").(*urlBasedX509CertificateRetriever)
+	assert.NoError(t, retriever.Refresh())
+	first := retriever.Certificate()
+
+	retriever.startBackgroundRefresh(CertificateRefreshOptions{Interval: 10 * time.Millisecond})
+	// Starting the refresh again has no effect.
+	retriever.startBackgroundRefresh(CertificateRefreshOptions{Interval: time.Millisecond})
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&requests) >= 3 }, 5*time.Second, 5*time.Millisecond)
+	assert.False(t, first.Equal(retriever.Certificate()))
+
+	retriever.Stop()
+	retriever.Stop()
+	time.Sleep(20 * time.Millisecond)
+	n := atomic.LoadInt32(&requests)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, n, atomic.LoadInt32(&requests))
+
+	// A retriever whose refresh was not started can be stopped.
+	newURLBasedX509CertificateRetriever(&http.Client{}, certServer.URL, "", "").(*urlBasedX509CertificateRetriever).Stop()
+}
+
+func TestCertificateRefreshOptions(t *testing.T) {
+	now := time.Now()
+	cert := &x509.Certificate{NotAfter: now.Add(time.Hour)}
+
+	assert.False(t, CertificateRefreshOptions{}.enabled())
+	assert.True(t, CertificateRefreshOptions{BeforeExpiry: time.Minute}.enabled())
+	assert.Equal(t, time.Duration(-1), CertificateRefreshOptions{}.nextRefresh(now, cert))
+	assert.Equal(t, 5*time.Minute, CertificateRefreshOptions{Interval: 5 * time.Minute}.nextRefresh(now, cert))
+	assert.Equal(t, 50*time.Minute, CertificateRefreshOptions{BeforeExpiry: 10 * time.Minute}.nextRefresh(now, cert))
+	assert.Equal(t, 20*time.Minute,
+		CertificateRefreshOptions{Interval: 20 * time.Minute, BeforeExpiry: 10 * time.Minute}.nextRefresh(now, cert))
+	assert.Equal(t, 50*time.Minute,
+		CertificateRefreshOptions{Interval: 2 * time.Hour, BeforeExpiry: 10 * time.Minute}.nextRefresh(now, cert))
+
+	// Certificates that are about to expire or not retrieved are not refreshed continuously.
+	assert.Equal(t, minCertificateRefreshDelay, CertificateRefreshOptions{BeforeExpiry: 2 * time.Hour}.nextRefresh(now, cert))
+	assert.Equal(t, minCertificateRefreshDelay, CertificateRefreshOptions{BeforeExpiry: time.Minute}.nextRefresh(now, nil))
+}
@@ -3,6 +3,7 @@
 package iam
 
 import (
+	"crypto"
 	"crypto/rsa"
 	"errors"
 	"fmt"
@@ -35,7 +36,11 @@ func IsConfigurationProviderValid(conf ConfigurationProvider) (ok bool, err erro
 		}
 	}
 
-	_, err = conf.PrivateRSAKey()
+	if sp, isSigner := conf.(SignerKeyProvider); isSigner {
+		_, err = sp.PrivateKeySigner()
+	} else {
+		_, err = conf.PrivateRSAKey()
+	}
 	ok = err == nil
 	if err != nil {
 		return
@@ -76,6 +81,12 @@ func (p rawConfigurationProvider) PrivateRSAKey() (key *rsa.PrivateKey, err erro
 	return PrivateKeyFromBytes([]byte(p.privateKey), p.privateKeyPassphrase)
 }
 
+// PrivateKeySigner returns the private key, which may be an RSA or an ECDSA
+// key. It implements the SignerKeyProvider interface.
+func (p rawConfigurationProvider) PrivateKeySigner() (crypto.Signer, error) {
+	return PrivateKeySignerFromBytes([]byte(p.privateKey), p.privateKeyPassphrase)
+}
+
 func (p rawConfigurationProvider) ExpirationTime() time.Time {
 	// raw configs don't expire
 	return time.Now().Add(24 * time.Hour)
@@ -386,36 +397,66 @@ func (p fileConfigurationProvider) KeyID() (string, error) {
 }
 
 func (p fileConfigurationProvider) PrivateRSAKey() (key *rsa.PrivateKey, err error) {
-	info, err := p.readAndParseConfigFile()
+	pemFileContent, password, err := p.readPrivateKey()
 	if err != nil {
-		err = fmt.Errorf("can not read tenancy configuration due to: %s", err.Error())
 		return
 	}
 
-	filePath, err := presentOrError(info.KeyFilePath, hasKeyFile, info.PresentConfiguration, "key file path")
+	key, err = PrivateKeyFromBytes(pemFileContent, &password)
+	return
+}
+
+// PrivateKeySigner returns the private key, which may be an RSA or an ECDSA
+// key. It implements the SignerKeyProvider interface.
+func (p fileConfigurationProvider) PrivateKeySigner() (crypto.Signer, error) {
+	pemFileContent, password, err := p.readPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return PrivateKeySignerFromBytes(pemFileContent, &password)
+}
+
+// readPrivateKey reads the private key file specified in the configuration
+// file, and returns its content and the passphrase of the key.
+func (p fileConfigurationProvider) readPrivateKey() (pemFileContent []byte, password string, err error) {
+	expandedPath, password, err := p.keyFile()
 	if err != nil {
 		return
 	}
 
-	expandedPath, err := sdkutil.ExpandPath(filePath)
+	pemFileContent, err = os.ReadFile(expandedPath)
 	if err != nil {
-		err = fmt.Errorf("can not read PrivateKey %s from configuration file due to: %s", filePath, err.Error())
+		err = fmt.Errorf("can not read PrivateKey %s from configuration file due to: %s", expandedPath, err.Error())
+	}
+	return
+}
+
+// keyFile returns the expanded path of the private key file specified in the
+// configuration file, and the passphrase of the key.
+func (p fileConfigurationProvider) keyFile() (expandedPath, password string, err error) {
+	info, err := p.readAndParseConfigFile()
+	if err != nil {
+		err = fmt.Errorf("can not read tenancy configuration due to: %s", err.Error())
 		return
 	}
 
-	pemFileContent, err := os.ReadFile(expandedPath)
+	filePath, err := presentOrError(info.KeyFilePath, hasKeyFile, info.PresentConfiguration, "key file path")
+	if err != nil {
+		return
+	}
+
+	expandedPath, err = sdkutil.ExpandPath(filePath)
 	if err != nil {
 		err = fmt.Errorf("can not read PrivateKey %s from configuration file due to: %s", filePath, err.Error())
 		return
 	}
 
-	password := p.PrivateKeyPassword
+	password = p.PrivateKeyPassword
 
 	if password == "" && ((info.PresentConfiguration & hasPassphrase) == hasPassphrase) {
 		password = info.Passphrase
 	}
-
-	key, err = PrivateKeyFromBytes(pemFileContent, &password)
 	return
 }
 
@@ -461,9 +502,10 @@ func SessionTokenProviderFromFileWithProfile(configFilePath, profile, privateKey
 		return nil, err
 	}
 	// read the session token file, verify it has contents
-	_, err = provider.KeyID()
+	sessionProvider := newSessionTokenConfigurationProvider(provider)
+	_, err = sessionProvider.KeyID()
 	if err != nil {
 		return nil, err
 	}
-	return provider, nil
+	return sessionProvider, nil
 }
@@ -3,11 +3,16 @@
 package iam
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -370,6 +375,80 @@ region=someregion
 	assert.NotNil(t, c)
 }
 
+// testSessionJWT returns an unsigned JWT that expires at exp.
+func testSessionJWT(exp time.Time) string {
+	enc := base64.RawURLEncoding.EncodeToString
+	payload, _ := json.Marshal(map[string]interface{}{"sub": "someuser", "exp": exp.Unix()})
+	return enc([]byte(`{"alg":"RS256"}`)) + "." + enc(payload) + ".sig"
+}
+
+func TestSessionConfigurationProvider_Refresh(t *testing.T) {
+	dataTpl := `[DEFAULT]
+user=someuser
+fingerprint=somefingerprint
+key_file=%s
+security_token_file=%s
+tenancy=sometenancy
+region=us-ashburn-1
+`
+
+	oldToken := testSessionJWT(time.Now().Add(2 * time.Minute))
+	newToken := testSessionJWT(time.Now().Add(time.Hour))
+	var refreshes int
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refreshes++
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		if fail || r.URL.Path != sessionTokenRefreshPath || req["currentToken"] != oldToken ||
+			!strings.Contains(r.Header.Get("Authorization"), `keyId="ST$`+oldToken+`"`) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": newToken})
+	}))
+	defer server.Close()
+
+	keyFile := writeTempFile(testPrivateKeyConf)
+	tokenFile := writeTempFile(oldToken)
+	tmpConfFile := writeTempFile(fmt.Sprintf(dataTpl, keyFile, tokenFile))
+	defer removeFileFn(tmpConfFile)
+	defer removeFileFn(keyFile)
+	defer removeFileFn(tokenFile)
+
+	c, err := ConfigurationProviderFromFileWithProfile(tmpConfFile, "DEFAULT", "")
+	assert.NoError(t, err)
+	p := newSessionTokenConfigurationProvider(c)
+	p.refreshURL = server.URL + sessionTokenRefreshPath
+
+	// The token is refreshed before it expires, and written to the file.
+	keyID, err := p.KeyID()
+	assert.NoError(t, err)
+	assert.Equal(t, "ST$"+newToken, keyID)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), p.ExpirationTime(), 5*time.Second)
+	content, _ := os.ReadFile(tokenFile)
+	assert.Equal(t, newToken, string(content))
+	assert.Equal(t, 1, refreshes)
+
+	// The current token is used until it expires if it cannot be refreshed.
+	fail = true
+	os.WriteFile(tokenFile, []byte(oldToken), 0600)
+	keyID, err = p.KeyID()
+	assert.NoError(t, err)
+	assert.Equal(t, "ST$"+oldToken, keyID)
+	assert.Equal(t, 2, refreshes)
+	_, err = p.KeyID()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, refreshes, "the refresh should not be retried immediately")
+
+	// An expired token that cannot be refreshed is an error.
+	os.WriteFile(tokenFile, []byte(testSessionJWT(time.Now().Add(-time.Minute))), 0600)
+	_, err = p.KeyID()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "oci session authenticate")
+	}
+}
+
 func TestSessionConfigurationProvider_MissingToken(t *testing.T) {
 	// note missing "security_token_file" field
 	dataTpl := `[DEFAULT]
@@ -586,3 +665,62 @@ func TestIsRegionValid(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSignatureProviderFromEnvironment(t *testing.T) {
+	keyFile := writeTempFile(testPrivateKeyConf)
+	defer removeFileFn(keyFile)
+
+	env := map[string]string{
+		cliTenancyEnvVar:     "ocid1.tenancy.oc1..test",
+		cliUserEnvVar:        "ocid1.user.oc1..test",
+		cliFingerprintEnvVar: "somefingerprint",
+		cliKeyFileEnvVar:     keyFile,
+		cliRegionEnvVar:      "us-ashburn-1",
+	}
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+
+	p, err := NewSignatureProviderFromEnvironment("")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ocid1.tenancy.oc1..test", p.CompartmentID())
+		region, _ := p.Profile().Region()
+		assert.Equal(t, "us-ashburn-1", region)
+		keyID, _ := p.Profile().KeyID()
+		assert.Equal(t, "ocid1.tenancy.oc1..test/ocid1.user.oc1..test/somefingerprint", keyID)
+	}
+
+	t.Setenv(cliKeyFileEnvVar, keyFile+".missing")
+	_, err = NewSignatureProviderFromEnvironment("")
+	assert.Error(t, err)
+
+	// The key content takes precedence over the key file, with its line
+	// breaks escaped or not.
+	for _, content := range []string{testPrivateKeyConf, strings.Replace(testPrivateKeyConf, "\n", `\n`, -1)} {
+		t.Setenv(cliKeyContentEnvVar, content)
+		p, err = NewSignatureProviderFromEnvironment("")
+		if assert.NoError(t, err) {
+			_, err = p.Profile().PrivateRSAKey()
+			assert.NoError(t, err)
+		}
+	}
+	t.Setenv(cliKeyFileEnvVar, "")
+	_, err = NewSignatureProviderFromEnvironment("")
+	assert.NoError(t, err, "key content without key file")
+
+	t.Setenv(cliKeyContentEnvVar, "not a key")
+	_, err = NewSignatureProviderFromEnvironment("")
+	assert.Error(t, err)
+
+	t.Setenv(cliKeyContentEnvVar, "")
+	_, err = NewSignatureProviderFromEnvironment("")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), cliKeyContentEnvVar)
+	}
+
+	t.Setenv(cliUserEnvVar, "")
+	_, err = NewSignatureProviderFromEnvironment("")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), cliUserEnvVar)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+// This software is dual-licensed to you under the Universal Permissive License (UPL) 1.0 as shown at https://oss.oracle.com/licenses/upl or Apache License 2.0 as shown at http://www.apache.org/licenses/LICENSE-2.0. You may choose either license.
+
+package iam
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultDelegationTokenCheckInterval is the default interval at which a
+// FileDelegationTokenProvider checks whether its file has changed.
+const defaultDelegationTokenCheckInterval = time.Second
+
+// DelegationTokenProvider provides the delegation token of a SignatureProvider,
+// for tokens that change while the application runs.
+// See SignatureProvider.SetDelegationTokenProvider.
+type DelegationTokenProvider interface {
+	// DelegationToken returns the current delegation token.
+	DelegationToken() (string, error)
+}
+
+// FileDelegationTokenProvider is a DelegationTokenProvider that reads the
+// delegation token from a file, and reads it again when the file changes, so
+// that tokens renewed by another process, such as a sidecar container, are
+// used without restarting the application.
+//
+// The file must contain the token on its first line. Its modification time
+// and size are checked at most once per check interval, when the token is
+// requested. If the file cannot be read or is empty, such as while it is
+// being replaced, the token read previously is used.
+type FileDelegationTokenProvider struct {
+	file          string
+	checkInterval time.Duration
+
+	mux       sync.Mutex
+	token     string
+	modTime   time.Time
+	size      int64
+	lastCheck time.Time
+}
+
+// NewFileDelegationTokenProvider creates a FileDelegationTokenProvider that
+// reads the token from the specified file, and checks whether the file has
+// changed at most once per checkInterval. If checkInterval is not positive,
+// the file is checked at most once per second.
+//
+// An error is returned if the file does not exist or does not contain a token.
+func NewFileDelegationTokenProvider(delegationTokenFile string, checkInterval time.Duration) (*FileDelegationTokenProvider, error) {
+	file, ok := fileExists(delegationTokenFile)
+	if !ok {
+		return nil, fmt.Errorf("delegation token file \"%s\" does not exist", delegationTokenFile)
+	}
+	if checkInterval <= 0 {
+		checkInterval = defaultDelegationTokenCheckInterval
+	}
+
+	p := &FileDelegationTokenProvider{
+		file:          file,
+		checkInterval: checkInterval,
+	}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+	if p.token == "" {
+		return nil, fmt.Errorf("delegation token file %s is empty", file)
+	}
+	return p, nil
+}
+
+// DelegationToken returns the token read from the file, reading the file again
+// if it has changed since it was last read.
+func (p *FileDelegationTokenProvider) DelegationToken() (string, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if time.Since(p.lastCheck) >= p.checkInterval {
+		// Errors are ignored, the previous token is used until the file is
+		// readable again.
+		p.reload()
+	}
+	return p.token, nil
+}
+
+// reload reads the token from the file if its modification time or size has
+// changed. It must be called with p.mux held.
+func (p *FileDelegationTokenProvider) reload() error {
+	p.lastCheck = time.Now()
+	info, err := os.Stat(p.file)
+	if err != nil {
+		return fmt.Errorf("cannot read delegation token file %s: %v", p.file, err)
+	}
+	if p.token != "" && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.file)
+	if err != nil {
+		return fmt.Errorf("cannot read delegation token file %s: %v", p.file, err)
+	}
+	token := strings.TrimSpace(strings.Split(string(data), "\n")[0])
+	if token == "" {
+		return nil
+	}
+	p.token, p.modTime, p.size = token, info.ModTime(), info.Size()
+	return nil
+}
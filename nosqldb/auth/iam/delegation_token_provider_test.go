@@ -0,0 +1,90 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package iam
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileDelegationTokenProvider(t *testing.T) {
+	_, err := NewFileDelegationTokenProvider("/nonexistent/delegation/token", 0)
+	assert.Error(t, err)
+
+	empty := writeTempFile("")
+	defer removeFileFn(empty)
+	_, err = NewFileDelegationTokenProvider(empty, 0)
+	assert.Error(t, err)
+
+	file := writeTempFile("a.b.c\n")
+	defer removeFileFn(file)
+	provider, err := NewFileDelegationTokenProvider(file, time.Millisecond)
+	require.NoError(t, err)
+
+	p, err := NewSignatureProviderWithAuthorizationStringProvider(AuthorizationStringProviderFunc(
+		func(req *http.Request) (string, error) { return `Signature version="1"`, nil }), "ocid1.compartment.oc1..test")
+	require.NoError(t, err)
+	_, err = p.SetDelegationTokenProvider(provider)
+	require.NoError(t, err)
+
+	sign := func() string {
+		req, err := http.NewRequest(http.MethodPost, "https://nosql.us-ashburn-1.oci.oraclecloud.com/V2/nosql/data",
+			bytes.NewBufferString(testBody))
+		require.NoError(t, err)
+		require.NoError(t, p.SignHTTPRequest(req))
+		return req.Header.Get(requestHeaderDelegationToken)
+	}
+	assert.Equal(t, "a.b.c", sign())
+
+	// The new token is used once the file changes.
+	require.NoError(t, os.WriteFile(file, []byte("aa.bb.cc\n"), 0600))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, "aa.bb.cc", sign())
+
+	// The previous token is kept while the file is missing or empty.
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, "aa.bb.cc", sign())
+	require.NoError(t, os.Remove(file))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, "aa.bb.cc", sign())
+
+	// Setting a token replaces the provider.
+	require.NoError(t, os.WriteFile(file, []byte("aaa.bbb.ccc\n"), 0600))
+	_, err = p.SetDelegationToken("x.y.z")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, "x.y.z", sign())
+
+	// The token can be changed while requests are signed.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				req, _ := http.NewRequest(http.MethodPost, "https://nosql.us-ashburn-1.oci.oraclecloud.com/V2/nosql/data", nil)
+				assert.NoError(t, p.SignHTTPRequest(req))
+			}
+		}()
+	}
+	for j := 0; j < 50; j++ {
+		_, err = p.SetDelegationTokenProvider(provider)
+		assert.NoError(t, err)
+		_, err = p.SetDelegationToken("x.y.z")
+		assert.NoError(t, err)
+	}
+	wg.Wait()
+}
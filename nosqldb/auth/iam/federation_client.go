@@ -5,6 +5,7 @@ package iam
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -13,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	mrand "math/rand"
 	"net/http"
 	"net/url"
 	"os"
@@ -33,9 +35,19 @@ type federationClient interface {
 	claimHolder
 	PrivateKey() (*rsa.PrivateKey, error)
 	SecurityToken() (string, error)
+	SecurityTokenContext(ctx context.Context) (string, error)
 	ExpirationTime() time.Time
 }
 
+// closeFederationClient stops the background renewals of the security token
+// of fc, if it supports them.
+func closeFederationClient(fc federationClient) error {
+	if c, ok := fc.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 // claimHolder is implemented by any token interface that provides access to the
 // security claims embedded in the token.
 type claimHolder interface {
@@ -47,6 +59,9 @@ type genericFederationClient struct {
 	RefreshSecurityToken func() (securityToken, error)
 
 	securityToken securityToken
+	refresher     tokenRefresher
+	renewals      sdkutil.SingleFlight
+	hooks         *AuthHooks
 	mux           sync.Mutex
 }
 
@@ -72,6 +87,25 @@ func (c *genericFederationClient) SecurityToken() (token string, err error) {
 	return c.securityToken.String(), nil
 }
 
+// SecurityTokenContext is like SecurityToken, but stops waiting for the
+// renewal of the security token when ctx is done. The renewal continues, and
+// its token is returned to the next callers.
+func (c *genericFederationClient) SecurityTokenContext(ctx context.Context) (token string, err error) {
+	// c.mux is held while the token is renewed, in which case wait for the
+	// renewal without it. The renewal does nothing if the token is valid.
+	valid := false
+	if c.mux.TryLock() {
+		valid = c.securityToken != nil && c.securityToken.Valid()
+		c.mux.Unlock()
+	}
+	if !valid {
+		if _, err, _ = c.renewals.DoContext(ctx, c.renewIfNotValid); err != nil {
+			return "", err
+		}
+	}
+	return c.SecurityToken()
+}
+
 func (c *genericFederationClient) ExpirationTime() time.Time {
 	c.mux.Lock()
 	defer c.mux.Unlock()
@@ -82,16 +116,42 @@ func (c *genericFederationClient) ExpirationTime() time.Time {
 	return c.securityToken.ExpirationTime()
 }
 
+// renewKeyAndSecurityTokenIfNotValid renews the security token if it is not valid. It must
+// be called with c.mux held.
+//
+// Concurrent callers share a single renewal and its error, rather than each
+// sending its own request, so c.mux is released while the renewal runs.
 func (c *genericFederationClient) renewKeyAndSecurityTokenIfNotValid() (err error) {
-	if c.securityToken == nil || !c.securityToken.Valid() {
-		if err = c.renewKeyAndSecurityToken(); err != nil {
-			return fmt.Errorf("failed to renew security token: %s", err.Error())
+	for c.securityToken == nil || !c.securityToken.Valid() {
+		c.mux.Unlock()
+		_, err, _ = c.renewals.Do(c.renewIfNotValid)
+		c.mux.Lock()
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// renewIfNotValid is the renewal shared by the concurrent callers of
+// renewKeyAndSecurityTokenIfNotValid.
+func (c *genericFederationClient) renewIfNotValid() (interface{}, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.securityToken == nil || !c.securityToken.Valid() {
+		if err := c.renewKeyAndSecurityToken(); err != nil {
+			return nil, fmt.Errorf("failed to renew security token: %s", err.Error())
+		}
+	}
+	return nil, nil
+}
+
 func (c *genericFederationClient) renewKeyAndSecurityToken() (err error) {
+	defer func() {
+		c.hooks.tokenRenewed(c.securityToken, err)
+	}()
+
 	if err = c.SessionKeySupplier.Refresh(); err != nil {
 		return fmt.Errorf("failed to refresh session key: %s", err.Error())
 	}
@@ -100,9 +160,34 @@ func (c *genericFederationClient) renewKeyAndSecurityToken() (err error) {
 		return fmt.Errorf("failed to refresh security token: %s", err.Error())
 	}
 
+	c.refresher.schedule(c.securityToken.ExpirationTime(), c.renewInBackground)
 	return nil
 }
 
+// renewInBackground renews the key and security token ahead of the expiration
+// of the token. If the renewal fails, the token is discarded, as the session
+// key it was issued for may have been replaced, and the next request renews it.
+func (c *genericFederationClient) renewInBackground() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.renewKeyAndSecurityToken(); err != nil {
+		c.securityToken = nil
+	}
+}
+
+// Close stops the background renewals of the security token.
+func (c *genericFederationClient) Close() error {
+	c.refresher.stop()
+	return nil
+}
+
+func (c *genericFederationClient) setAuthHooks(hooks *AuthHooks) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.hooks = hooks
+}
+
 func (c *genericFederationClient) GetClaim(key string) (interface{}, error) {
 	c.mux.Lock()
 	defer c.mux.Unlock()
@@ -153,13 +238,18 @@ type x509FederationClient struct {
 	sessionKeySupplier                sessionKeySupplier
 	leafCertificateRetriever          x509CertificateRetriever
 	intermediateCertificateRetrievers []x509CertificateRetriever
+	leafCertificate                   *x509.Certificate
 	securityToken                     securityToken
 	authClient                        *authClient
+	refresher                         tokenRefresher
+	renewals                          sdkutil.SingleFlight
+	hooks                             *AuthHooks
 	mux                               sync.Mutex
 }
 
 func newX509FederationClient(region common.Region, tenancyID string,
-	leafCertificateRetriever x509CertificateRetriever, intermediateCertificateRetrievers []x509CertificateRetriever) (federationClient, error) {
+	leafCertificateRetriever x509CertificateRetriever, intermediateCertificateRetrievers []x509CertificateRetriever,
+	options FederationClientOptions) (federationClient, error) {
 
 	client := &x509FederationClient{
 		tenancyID:                         tenancyID,
@@ -172,9 +262,195 @@ func newX509FederationClient(region common.Region, tenancyID string,
 	if err != nil {
 		return nil, err
 	}
+	client.authClient.Options = options
+	client.authClient.HTTPClient.Timeout = options.timeout()
+	client.refresher.options = options
 	return client, nil
 }
 
+const (
+	defaultFederationMaxRetries     = 4
+	defaultFederationInitialBackoff = 250 * time.Millisecond
+	defaultFederationMaxBackoff     = 4 * time.Second
+	defaultFederationTimeout        = 60 * time.Second
+	defaultFederationPurpose        = "DEFAULT"
+)
+
+// FederationClientOptions represents options for the requests that a
+// signature provider sends to get security tokens, such as the requests to
+// the federation endpoint of the Auth service for instance principals.
+//
+// Requests that fail with a transient error, which is a network error or a
+// response with status code 429 or 5xx, are retried with exponential backoff.
+// Other errors are not retried.
+type FederationClientOptions struct {
+	// MaxRetries specifies the maximum number of times a request is retried.
+	// If set to 0, requests are retried up to 4 times. If set to a negative
+	// value, requests are not retried.
+	MaxRetries int
+
+	// InitialBackoff specifies the time to wait before the first retry. The
+	// time is doubled for each subsequent retry, up to MaxBackoff.
+	// If not set, 250 milliseconds is used.
+	InitialBackoff time.Duration
+
+	// MaxBackoff specifies the maximum time to wait between retries.
+	// If not set, 4 seconds is used.
+	MaxBackoff time.Duration
+
+	// Timeout specifies the timeout of each request.
+	// If not set, 60 seconds is used.
+	Timeout time.Duration
+
+	// RefreshAhead specifies the fraction of the lifetime of a security token
+	// after which it is renewed in the background, such as 0.8 to renew it
+	// at 80% of its lifetime, so that requests do not wait for the renewal
+	// when the token expires. It must be between 0 and 1.
+	// If not set, tokens are renewed by the first request after they expire.
+	//
+	// If a background renewal fails, the token is renewed by the next request.
+	// The background renewals stop when the signature provider is closed.
+	RefreshAhead float64
+
+	// RefreshJitter specifies the maximum random variation of the time at
+	// which a token is renewed, as a fraction of its lifetime, so that many
+	// clients started together do not renew their tokens at the same time.
+	// For example, a RefreshAhead of 0.8 and a RefreshJitter of 0.1 renew
+	// tokens between 70% and 90% of their lifetime.
+	// It is only used if RefreshAhead is set.
+	RefreshJitter float64
+
+	// Purpose specifies the purpose of the security tokens requested from
+	// the federation endpoint with instance certificates, such as
+	// "SERVICE_PRINCIPAL" for services that run with service principal
+	// certificates. If not set, "DEFAULT" is used.
+	Purpose string
+}
+
+func (o FederationClientOptions) maxRetries() int {
+	switch {
+	case o.MaxRetries < 0:
+		return 0
+	case o.MaxRetries == 0:
+		return defaultFederationMaxRetries
+	}
+	return o.MaxRetries
+}
+
+func (o FederationClientOptions) purpose() string {
+	if o.Purpose == "" {
+		return defaultFederationPurpose
+	}
+	return o.Purpose
+}
+
+func (o FederationClientOptions) timeout() time.Duration {
+	if o.Timeout <= 0 {
+		return defaultFederationTimeout
+	}
+	return o.Timeout
+}
+
+// do sends the requests created by newRequest until one of them does not fail
+// with a transient error, or the retries are exhausted. A new request is
+// created for each attempt, so that its body can be sent again and its
+// signature is up to date. The response of the last attempt is returned, and
+// the caller is responsible for checking its status code.
+func (o FederationClientOptions) do(httpClient *http.Client, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	backoff := o.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultFederationInitialBackoff
+	}
+	maxBackoff := o.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultFederationMaxBackoff
+	}
+
+	for retry := 0; ; retry++ {
+		request, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := httpClient.Do(request)
+		if (err == nil && !isTransientStatusCode(response.StatusCode)) || retry >= o.maxRetries() {
+			return response, err
+		}
+
+		closeBodyIfValid(response)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func isTransientStatusCode(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode/100 == 5
+}
+
+// refreshAt returns the time at which a token obtained at now that expires at
+// expiration is renewed in the background, or the zero time if the token is
+// not renewed ahead of its expiration.
+func (o FederationClientOptions) refreshAt(now, expiration time.Time) time.Time {
+	lifetime := expiration.Sub(now)
+	if o.RefreshAhead <= 0 || o.RefreshAhead >= 1 || lifetime <= 0 {
+		return time.Time{}
+	}
+
+	ratio := o.RefreshAhead
+	if o.RefreshJitter > 0 {
+		ratio += o.RefreshJitter * (2*mrand.Float64() - 1)
+	}
+	if ratio < 0 {
+		ratio = 0
+	} else if ratio > 1 {
+		ratio = 1
+	}
+	return now.Add(time.Duration(ratio * float64(lifetime)))
+}
+
+// tokenRefresher schedules the background renewals of the security tokens of
+// a federation client, see FederationClientOptions.RefreshAhead.
+//
+// The zero value renews no tokens.
+type tokenRefresher struct {
+	options FederationClientOptions
+	mux     sync.Mutex
+	timer   *time.Timer
+	stopped bool
+}
+
+// schedule schedules renew to be called ahead of expiration, replacing any
+// renewal scheduled previously.
+func (r *tokenRefresher) schedule(expiration time.Time, renew func()) {
+	at := r.options.refreshAt(time.Now(), expiration)
+	if at.IsZero() {
+		return
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.stopped {
+		return
+	}
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.timer = time.AfterFunc(time.Until(at), renew)
+}
+
+// stop cancels the scheduled renewal, and prevents further renewals.
+func (r *tokenRefresher) stop() {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.stopped = true
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
 var (
 	genericHeaders = []string{"date", "(request-target)"}
 	bodyHeaders    = []string{"content-length", "content-type", "x-content-sha256"}
@@ -195,6 +471,9 @@ type authClient struct {
 
 	//Base path for all operations of this client
 	BasePath string
+
+	//Options specifies how requests are retried
+	Options FederationClientOptions
 }
 
 func newAuthClient(region common.Region, provider KeyProvider) (*authClient, error) {
@@ -220,20 +499,27 @@ func newAuthClient(region common.Region, provider KeyProvider) (*authClient, err
 	return client, nil
 }
 
-func (client *authClient) Call(request *http.Request) (*http.Response, error) {
-	request.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
-	err := client.Signer.Sign(request)
-	if err != nil {
-		return nil, err
-	}
-
-	response, err := client.HTTPClient.Do(request)
+// Call signs and sends the requests created by newRequest, retrying on
+// transient errors as specified by the Options of the client.
+func (client *authClient) Call(newRequest func() (*http.Request, error)) (*http.Response, error) {
+	response, err := client.Options.do(client.HTTPClient, func() (*http.Request, error) {
+		request, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+		if err = client.Signer.Sign(request); err != nil {
+			return nil, err
+		}
+		return request, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	code := response.StatusCode / 100
 	if code == 4 || code == 5 {
+		defer closeBodyIfValid(response)
 		body, _ := io.ReadAll(response.Body)
 		if len(body) == 0 {
 			return nil, fmt.Errorf("error status code: %d", response.StatusCode)
@@ -280,6 +566,25 @@ func (c *x509FederationClient) SecurityToken() (token string, err error) {
 	return c.securityToken.String(), nil
 }
 
+// SecurityTokenContext is like SecurityToken, but stops waiting for the
+// renewal of the security token when ctx is done. The renewal continues, and
+// its token is returned to the next callers.
+func (c *x509FederationClient) SecurityTokenContext(ctx context.Context) (token string, err error) {
+	// c.mux is held while the token is renewed, in which case wait for the
+	// renewal without it. The renewal does nothing if the token is valid.
+	valid := false
+	if c.mux.TryLock() {
+		valid = c.securityToken != nil && c.securityToken.Valid()
+		c.mux.Unlock()
+	}
+	if !valid {
+		if _, err, _ = c.renewals.DoContext(ctx, c.renewIfNotValid); err != nil {
+			return "", err
+		}
+	}
+	return c.SecurityToken()
+}
+
 func (c *x509FederationClient) ExpirationTime() time.Time {
 	c.mux.Lock()
 	defer c.mux.Unlock()
@@ -290,16 +595,42 @@ func (c *x509FederationClient) ExpirationTime() time.Time {
 	return c.securityToken.ExpirationTime()
 }
 
+// renewSecurityTokenIfNotValid renews the security token if it is not valid. It must
+// be called with c.mux held.
+//
+// Concurrent callers share a single renewal and its error, rather than each
+// sending its own request, so c.mux is released while the renewal runs.
 func (c *x509FederationClient) renewSecurityTokenIfNotValid() (err error) {
-	if c.securityToken == nil || !c.securityToken.Valid() {
-		if err = c.renewSecurityToken(); err != nil {
-			return fmt.Errorf("failed to renew security token: %s", err.Error())
+	for c.securityToken == nil || !c.securityToken.Valid() {
+		c.mux.Unlock()
+		_, err, _ = c.renewals.Do(c.renewIfNotValid)
+		c.mux.Lock()
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// renewIfNotValid is the renewal shared by the concurrent callers of
+// renewSecurityTokenIfNotValid.
+func (c *x509FederationClient) renewIfNotValid() (interface{}, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.securityToken == nil || !c.securityToken.Valid() {
+		if err := c.renewSecurityToken(); err != nil {
+			return nil, fmt.Errorf("failed to renew security token: %s", err.Error())
+		}
+	}
+	return nil, nil
+}
+
 func (c *x509FederationClient) renewSecurityToken() (err error) {
+	defer func() {
+		c.hooks.tokenRenewed(c.securityToken, err)
+	}()
+
 	if err = c.sessionKeySupplier.Refresh(); err != nil {
 		return fmt.Errorf("failed to refresh session key: %s", err.Error())
 	}
@@ -307,8 +638,13 @@ func (c *x509FederationClient) renewSecurityToken() (err error) {
 	if err = c.leafCertificateRetriever.Refresh(); err != nil {
 		return fmt.Errorf("failed to refresh leaf certificate: %s", err.Error())
 	}
+	// Compare with the certificate of the previous renewal, as the retriever
+	// may also refresh the certificate in the background.
+	leafCertificate := c.leafCertificateRetriever.Certificate()
+	c.hooks.certificateRotated(c.leafCertificate, leafCertificate)
+	c.leafCertificate = leafCertificate
 
-	updatedTenancyID := extractTenancyIDFromCertificate(c.leafCertificateRetriever.Certificate())
+	updatedTenancyID := extractTenancyIDFromCertificate(leafCertificate)
 	if c.tenancyID != updatedTenancyID {
 		err = fmt.Errorf("unexpected update of tenancy OCID in the leaf certificate. Previous tenancy: %s, Updated: %s", c.tenancyID, updatedTenancyID)
 		return
@@ -324,9 +660,40 @@ func (c *x509FederationClient) renewSecurityToken() (err error) {
 		return fmt.Errorf("failed to get security token: %s", err.Error())
 	}
 
+	c.refresher.schedule(c.securityToken.ExpirationTime(), c.renewInBackground)
 	return nil
 }
 
+// renewInBackground renews the security token ahead of its expiration. If the
+// renewal fails, the token is discarded, as the session key it was issued for
+// may have been replaced, and the next request renews it.
+func (c *x509FederationClient) renewInBackground() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.renewSecurityToken(); err != nil {
+		c.securityToken = nil
+	}
+}
+
+// Close stops the background renewals of the security token, and the
+// background refreshes of the certificates.
+func (c *x509FederationClient) Close() error {
+	c.refresher.stop()
+	for _, r := range append([]x509CertificateRetriever{c.leafCertificateRetriever}, c.intermediateCertificateRetrievers...) {
+		if s, ok := r.(interface{ Stop() }); ok {
+			s.Stop()
+		}
+	}
+	return nil
+}
+
+func (c *x509FederationClient) setAuthHooks(hooks *AuthHooks) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.hooks = hooks
+}
+
 func (c *x509FederationClient) makeHTTPRequest(request *x509FederationRequest) (*http.Request, error) {
 	httpRequest := http.Request{
 		Proto:      "HTTP/1.1",
@@ -376,19 +743,13 @@ func (c *x509FederationClient) makeHTTPRequest(request *x509FederationRequest) (
 
 func (c *x509FederationClient) getSecurityToken() (securityToken, error) {
 	request := c.makeX509FederationRequest()
-	httpRequest, err := c.makeHTTPRequest(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make http request: %s", err.Error())
-	}
-
-	var httpResponse *http.Response
-
-	for retry := 0; retry < 5; retry++ {
-		if httpResponse, err = c.authClient.Call(httpRequest); err == nil {
-			break
+	httpResponse, err := c.authClient.Call(func() (*http.Request, error) {
+		httpRequest, err := c.makeHTTPRequest(request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make http request: %s", err.Error())
 		}
-		time.Sleep(250 * time.Microsecond)
-	}
+		return httpRequest, nil
+	})
 
 	defer closeBodyIfValid(httpResponse)
 
@@ -461,7 +822,7 @@ func (c *x509FederationClient) makeX509FederationRequest() *x509FederationReques
 		IntermediateCertificates: intermediateCertificates,
 		PublicKey:                publicKey,
 		FingerprintAlgorithm:     `SHA256`,
-		Purpose:                  `DEFAULT`,
+		Purpose:                  c.authClient.Options.purpose(),
 		// },
 	}
 }
@@ -4,13 +4,17 @@ package iam
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -273,7 +277,11 @@ func TestX509FederationClient_RenewSecurityTokenUnexpectedTenancyIdUpdateError(t
 }
 
 func TestX509FederationClient_AuthServerInternalError(t *testing.T) {
-	authServer := httptest.NewServer(http.HandlerFunc(internalServerError))
+	attempts := 0
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		internalServerError(w, r)
+	}))
 	defer authServer.Close()
 
 	mockSessionKeySupplier := new(mockSessionKeySupplier)
@@ -300,10 +308,292 @@ func TestX509FederationClient_AuthServerInternalError(t *testing.T) {
 	// Overwrite with the authServer's URL
 	federationClient.authClient.Host = authServer.URL
 	federationClient.authClient.BasePath = ""
+	federationClient.authClient.Options = FederationClientOptions{MaxRetries: 2, InitialBackoff: time.Millisecond}
 
 	_, err := federationClient.SecurityToken()
 
 	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestX509FederationClient_Purpose(t *testing.T) {
+	var purposes []string
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req x509FederationRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		purposes = append(purposes, req.Purpose)
+		fmt.Fprintf(w, "\n{\n  \"token\" : \"%s\"\n}\n", expectedSecurityToken)
+	}))
+	defer authServer.Close()
+
+	for _, purpose := range []string{"", "SERVICE_PRINCIPAL"} {
+		mockSessionKeySupplier := new(mockSessionKeySupplier)
+		mockSessionKeySupplier.On("Refresh").Return(nil)
+		mockSessionKeySupplier.On("PublicKeyPemRaw").Return([]byte(sessionPublicKeyPem))
+
+		mockLeafCertificateRetriever := new(mockCertificateRetriever)
+		mockLeafCertificateRetriever.On("Refresh").Return(nil)
+		mockLeafCertificateRetriever.On("CertificatePemRaw").Return([]byte(leafCertPem))
+		mockLeafCertificateRetriever.On("Certificate").Return(parseCertificate(leafCertPem))
+		mockLeafCertificateRetriever.On("PrivateKey").Return(parsePrivateKey(leafCertPrivateKeyPem))
+
+		federationClient := &x509FederationClient{
+			tenancyID:                tenancyID,
+			sessionKeySupplier:       mockSessionKeySupplier,
+			leafCertificateRetriever: mockLeafCertificateRetriever,
+		}
+		federationClient.authClient, _ = newAuthClient(whateverRegion, federationClient)
+		federationClient.authClient.Host = authServer.URL
+		federationClient.authClient.BasePath = ""
+		federationClient.authClient.Options = FederationClientOptions{Purpose: purpose}
+
+		_, err := federationClient.SecurityToken()
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"DEFAULT", "SERVICE_PRINCIPAL"}, purposes)
+}
+
+func TestX509FederationClient_RetryTransientError(t *testing.T) {
+	testCases := []struct {
+		statusCode       int
+		expectedAttempts int
+		expectError      bool
+	}{
+		{http.StatusServiceUnavailable, 2, false},
+		{http.StatusTooManyRequests, 2, false},
+		{http.StatusBadRequest, 1, true},
+	}
+
+	for _, tc := range testCases {
+		var bodies []string
+		authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var buf bytes.Buffer
+			buf.ReadFrom(r.Body)
+			bodies = append(bodies, buf.String())
+			if len(bodies) == 1 {
+				http.Error(w, http.StatusText(tc.statusCode), tc.statusCode)
+				return
+			}
+			fmt.Fprintf(w, "{\"token\": \"%s\"}", expectedSecurityToken)
+		}))
+
+		mockSessionKeySupplier := new(mockSessionKeySupplier)
+		mockSessionKeySupplier.On("Refresh").Return(nil)
+		mockSessionKeySupplier.On("PublicKeyPemRaw").Return([]byte(sessionPublicKeyPem))
+
+		mockLeafCertificateRetriever := new(mockCertificateRetriever)
+		mockLeafCertificateRetriever.On("Refresh").Return(nil)
+		mockLeafCertificateRetriever.On("CertificatePemRaw").Return([]byte(leafCertPem))
+		mockLeafCertificateRetriever.On("Certificate").Return(parseCertificate(leafCertPem))
+		mockLeafCertificateRetriever.On("PrivateKey").Return(parsePrivateKey(leafCertPrivateKeyPem))
+
+		mockIntermediateCertificateRetriever := new(mockCertificateRetriever)
+		mockIntermediateCertificateRetriever.On("Refresh").Return(nil)
+		mockIntermediateCertificateRetriever.On("CertificatePemRaw").Return([]byte(intermediateCertPem))
+
+		federationClient := &x509FederationClient{
+			tenancyID:                         tenancyID,
+			sessionKeySupplier:                mockSessionKeySupplier,
+			leafCertificateRetriever:          mockLeafCertificateRetriever,
+			intermediateCertificateRetrievers: []x509CertificateRetriever{mockIntermediateCertificateRetriever},
+		}
+		federationClient.authClient, _ = newAuthClient(whateverRegion, federationClient)
+		federationClient.authClient.Host = authServer.URL
+		federationClient.authClient.BasePath = ""
+		federationClient.authClient.Options = FederationClientOptions{InitialBackoff: time.Millisecond}
+
+		token, err := federationClient.SecurityToken()
+		authServer.Close()
+
+		if tc.expectError {
+			assert.Error(t, err, "status code %d", tc.statusCode)
+		} else if assert.NoError(t, err, "status code %d", tc.statusCode) {
+			assert.Equal(t, expectedSecurityToken, token)
+		}
+		if assert.Equal(t, tc.expectedAttempts, len(bodies), "status code %d", tc.statusCode) && len(bodies) > 1 {
+			// The request body is sent in full on retry.
+			assert.NotEmpty(t, bodies[1])
+			assert.Equal(t, bodies[0], bodies[1])
+		}
+	}
+}
+
+func TestFederationClientOptions_Do(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		internalServerError(w, r)
+	}))
+	defer server.Close()
+
+	newRequest := func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	}
+
+	// Retries are disabled with a negative MaxRetries.
+	resp, err := FederationClientOptions{MaxRetries: -1}.do(&http.Client{}, newRequest)
+	if assert.NoError(t, err) {
+		closeBodyIfValid(resp)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	}
+	assert.Equal(t, 1, attempts)
+
+	// The backoff is bounded by MaxBackoff.
+	attempts = 0
+	start := time.Now()
+	options := FederationClientOptions{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	resp, err = options.do(&http.Client{}, newRequest)
+	if assert.NoError(t, err) {
+		closeBodyIfValid(resp)
+	}
+	assert.Equal(t, 4, attempts)
+	assert.True(t, time.Since(start) < time.Second)
+
+	assert.Equal(t, defaultFederationMaxRetries, FederationClientOptions{}.maxRetries())
+	assert.Equal(t, defaultFederationTimeout, FederationClientOptions{}.timeout())
+}
+
+func TestFederationClientOptions_RefreshAt(t *testing.T) {
+	now := time.Now()
+	expiration := now.Add(time.Hour)
+	assert.True(t, FederationClientOptions{}.refreshAt(now, expiration).IsZero())
+	assert.True(t, FederationClientOptions{RefreshAhead: 1}.refreshAt(now, expiration).IsZero())
+	assert.True(t, FederationClientOptions{RefreshAhead: 0.8}.refreshAt(now, now.Add(-time.Second)).IsZero())
+	assert.Equal(t, now.Add(48*time.Minute), FederationClientOptions{RefreshAhead: 0.8}.refreshAt(now, expiration))
+
+	options := FederationClientOptions{RefreshAhead: 0.8, RefreshJitter: 0.1}
+	for i := 0; i < 100; i++ {
+		at := options.refreshAt(now, expiration)
+		assert.False(t, at.Before(now.Add(42*time.Minute)), "refresh at %v is too early", at)
+		assert.False(t, at.After(now.Add(54*time.Minute)), "refresh at %v is too late", at)
+	}
+}
+
+func TestGenericFederationClient_RefreshAhead(t *testing.T) {
+	var mux sync.Mutex
+	renewals := 0
+	client := &genericFederationClient{
+		SessionKeySupplier: &genericKeySupplier{
+			RefreshFn: func() (*rsa.PrivateKey, []byte, error) { return nil, nil, nil },
+		},
+		RefreshSecurityToken: func() (securityToken, error) {
+			mux.Lock()
+			defer mux.Unlock()
+			renewals++
+			return &expiringToken{fmt.Sprintf("token%d", renewals), time.Now().Add(100 * time.Millisecond)}, nil
+		},
+	}
+	client.refresher.options = FederationClientOptions{RefreshAhead: 0.5, RefreshJitter: 0.1}
+
+	token, err := client.SecurityToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "token1", token)
+
+	// The token is renewed in the background before it expires.
+	time.Sleep(250 * time.Millisecond)
+	mux.Lock()
+	n := renewals
+	mux.Unlock()
+	assert.True(t, n > 2, "expected the token to be renewed in the background, got %d renewals", n)
+
+	// No renewals are scheduled after the client is closed, except the one
+	// that may be running.
+	assert.NoError(t, closeFederationClient(client))
+	mux.Lock()
+	n = renewals
+	mux.Unlock()
+	time.Sleep(150 * time.Millisecond)
+	mux.Lock()
+	assert.True(t, renewals <= n+1, "expected no renewals after close, got %d", renewals-n)
+	mux.Unlock()
+}
+
+func TestGenericFederationClient_ConcurrentRenewal(t *testing.T) {
+	var mux sync.Mutex
+	renewals := 0
+	fail := true
+	client := &genericFederationClient{
+		SessionKeySupplier: &genericKeySupplier{
+			RefreshFn: func() (*rsa.PrivateKey, []byte, error) { return nil, nil, nil },
+		},
+		RefreshSecurityToken: func() (securityToken, error) {
+			time.Sleep(20 * time.Millisecond)
+			mux.Lock()
+			defer mux.Unlock()
+			renewals++
+			if fail {
+				return nil, fmt.Errorf("federation endpoint unavailable")
+			}
+			return &expiringToken{"token", time.Now().Add(time.Hour)}, nil
+		},
+	}
+
+	// Concurrent callers share a renewal and its error.
+	const n = 50
+	run := func(wantErr bool) {
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				token, err := client.SecurityToken()
+				if wantErr {
+					assert.Error(t, err)
+				} else if assert.NoError(t, err) {
+					assert.Equal(t, "token", token)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	run(true)
+	assert.True(t, renewals < n, "expected failed renewals to be shared, got %d renewals", renewals)
+
+	mux.Lock()
+	fail, renewals = false, 0
+	mux.Unlock()
+	run(false)
+	assert.Equal(t, 1, renewals)
+}
+
+func TestGenericFederationClient_SecurityTokenContext(t *testing.T) {
+	release := make(chan struct{})
+	var renewals int32
+	client := &genericFederationClient{
+		SessionKeySupplier: &genericKeySupplier{
+			RefreshFn: func() (*rsa.PrivateKey, []byte, error) { return nil, nil, nil },
+		},
+		RefreshSecurityToken: func() (securityToken, error) {
+			// A hung federation endpoint.
+			<-release
+			atomic.AddInt32(&renewals, 1)
+			return &expiringToken{"token", time.Now().Add(time.Hour)}, nil
+		},
+	}
+
+	// The caller stops waiting for the renewal when its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.SecurityTokenContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < time.Second, "SecurityTokenContext() returned after %v", time.Since(start))
+
+	// The signer of a request returns the error of the context of the request.
+	signer := RequestSignerExcludeBody(&resourcePrincipalKeyProvider{FederationClient: client})
+	req, err := http.NewRequest(http.MethodPost, "https://nosql.us-ashburn-1.oci.oraclecloud.com/V2/nosql/data", nil)
+	assert.NoError(t, err)
+	req.Header.Set(requestHeaderDate, time.Now().UTC().Format(http.TimeFormat))
+	err = signer.Sign(req.WithContext(ctx))
+	assert.Error(t, err)
+
+	// The renewal continues, and its token is used by the next callers.
+	close(release)
+	token, err := client.SecurityToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&renewals))
 }
 
 func TestX509FederationClient_ClientHost(t *testing.T) {
@@ -576,3 +866,25 @@ func (m *mockSecurityToken) GetClaim(key string) (interface{}, error) {
 	args := m.Called(key)
 	return args.Get(0), args.Error(1)
 }
+
+// expiringToken is a securityToken that expires at the specified time.
+type expiringToken struct {
+	value      string
+	expiration time.Time
+}
+
+func (t *expiringToken) String() string {
+	return t.value
+}
+
+func (t *expiringToken) Valid() bool {
+	return time.Now().Before(t.expiration)
+}
+
+func (t *expiringToken) ExpirationTime() time.Time {
+	return t.expiration
+}
+
+func (t *expiringToken) GetClaim(key string) (interface{}, error) {
+	return nil, errNoSuchClaim
+}
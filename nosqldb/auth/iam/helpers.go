@@ -5,6 +5,8 @@ package iam
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
@@ -30,32 +32,93 @@ func PrivateKeyFromBytes(pemData []byte, password *string) (key *rsa.PrivateKey,
 // PrivateKeyFromBytesWithPassword is a helper function that will produce a RSA private
 // key from bytes and a password.
 func PrivateKeyFromBytesWithPassword(pemData, password []byte) (key *rsa.PrivateKey, e error) {
-	if pemBlock, _ := pem.Decode(pemData); pemBlock != nil {
-		decrypted := pemBlock.Bytes
-		if x509.IsEncryptedPEMBlock(pemBlock) {
-			if password == nil {
-				e = fmt.Errorf("private key password is required for encrypted private keys")
-				return
-			}
-			if decrypted, e = x509.DecryptPEMBlock(pemBlock, password); e != nil {
-				return
-			}
+	parsed, e := parsePrivateKeyPEM(pemData, password)
+	if e != nil {
+		return
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		e = fmt.Errorf("private key of type %T is not an RSA private key", parsed)
+	}
+	return
+}
+
+// PrivateKeySignerFromBytes is a helper function that will produce a
+// crypto.Signer from the bytes of a PEM encoded private key and an optional
+// password. It supports RSA keys, and ECDSA keys on the P-256 and P-384
+// curves, in PKCS#1, PKCS#8 or SEC 1 format. Encrypted keys may use legacy PEM
+// encryption, or be encrypted PKCS#8 keys, as written by "openssl genpkey".
+func PrivateKeySignerFromBytes(pemData []byte, password *string) (crypto.Signer, error) {
+	var pwd []byte
+	if password != nil {
+		pwd = []byte(*password)
+	}
+
+	parsed, err := parsePrivateKeyPEM(pemData, pwd)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		if _, _, err = ecdsaHash(&key.PublicKey); err != nil {
+			return nil, err
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", parsed)
+	}
+}
+
+// parsePrivateKeyPEM decodes and, if it is encrypted, decrypts the PEM block
+// in pemData, and parses the private key it contains. The PEM block may be
+// encrypted with legacy PEM encryption, or be an encrypted PKCS#8 key.
+func parsePrivateKeyPEM(pemData, password []byte) (interface{}, error) {
+	pemBlock, _ := pem.Decode(pemData)
+	if pemBlock == nil {
+		return nil, fmt.Errorf("PEM data was not found in buffer")
+	}
+
+	if pemBlock.Type == pemTypeEncryptedPrivateKey {
+		if password == nil {
+			return nil, fmt.Errorf("private key password is required for encrypted private keys")
 		}
+		decrypted, err := decryptPKCS8PrivateKey(pemBlock.Bytes, password)
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParsePKCS8PrivateKey(decrypted)
+		if err != nil {
+			return nil, fmt.Errorf("can not parse private key: %s", err.Error())
+		}
+		return key, nil
+	}
 
-		key, e = x509.ParsePKCS1PrivateKey(decrypted)
-		if e != nil {
-			e = nil
-			parseResult, e := x509.ParsePKCS8PrivateKey(decrypted)
-			if e == nil {
-				key = parseResult.(*rsa.PrivateKey)
-			}
+	decrypted := pemBlock.Bytes
+	if x509.IsEncryptedPEMBlock(pemBlock) {
+		if password == nil {
+			return nil, fmt.Errorf("private key password is required for encrypted private keys")
 		}
+		var err error
+		if decrypted, err = x509.DecryptPEMBlock(pemBlock, password); err != nil {
+			return nil, err
+		}
+	}
 
-	} else {
-		e = fmt.Errorf("PEM data was not found in buffer")
-		return
+	if key, err := x509.ParsePKCS1PrivateKey(decrypted); err == nil {
+		return key, nil
 	}
-	return
+	if key, err := x509.ParseECPrivateKey(decrypted); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(decrypted)
+	if err != nil {
+		return nil, fmt.Errorf("can not parse private key: %s", err.Error())
+	}
+	return key, nil
 }
 
 func makeACopy(original []string) []string {
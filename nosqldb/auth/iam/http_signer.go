@@ -4,16 +4,25 @@ package iam
 
 import (
 	"bytes"
+	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"hash"
 	"io"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/logger"
 )
 
 // HTTPRequestSigner the interface to sign a request
@@ -22,6 +31,22 @@ type HTTPRequestSigner interface {
 	ExpirationTime() time.Time
 }
 
+// ContextHTTPRequestSigner is an HTTPRequestSigner that honors the deadline
+// and cancellation of a context while it signs a request, such as while it
+// waits for a security token to be renewed.
+type ContextHTTPRequestSigner interface {
+	HTTPRequestSigner
+	SignContext(ctx context.Context, r *http.Request) error
+}
+
+// signContext signs r with signer, using ctx if signer supports it.
+func signContext(ctx context.Context, signer HTTPRequestSigner, r *http.Request) error {
+	if s, ok := signer.(ContextHTTPRequestSigner); ok {
+		return s.SignContext(ctx, r)
+	}
+	return signer.Sign(r)
+}
+
 // KeyProvider interface that wraps information about the key's account owner
 type KeyProvider interface {
 	PrivateRSAKey() (*rsa.PrivateKey, error)
@@ -29,8 +54,64 @@ type KeyProvider interface {
 	ExpirationTime() time.Time
 }
 
+// ContextKeyProvider is a KeyProvider that honors the deadline and
+// cancellation of a context while it gets the key id, which may require a
+// security token to be renewed from a federation endpoint.
+//
+// If the KeyProvider of a request signer implements ContextKeyProvider,
+// KeyIDContext is called with the context of the request before the private
+// key is used, so that a request does not wait for a token longer than its
+// timeout. The renewal of the token continues when the context is done, and
+// its result is used by the next requests.
+type ContextKeyProvider interface {
+	KeyProvider
+	KeyIDContext(ctx context.Context) (string, error)
+}
+
+// SignerKeyProvider is a KeyProvider that provides its private key as a
+// crypto.Signer, which allows requests to be signed with keys other than RSA
+// keys, such as ECDSA P-256 and P-384 keys.
+//
+// If the KeyProvider of a request signer implements SignerKeyProvider, the
+// signer returned by PrivateKeySigner is used instead of PrivateRSAKey, and
+// the algorithm of the Authorization header is set according to its public
+// key: "rsa-sha256" for RSA keys, "ecdsa-sha256" for P-256 keys and
+// "ecdsa-sha384" for P-384 keys.
+type SignerKeyProvider interface {
+	KeyProvider
+	PrivateKeySigner() (crypto.Signer, error)
+}
+
 const signerVersion = "1"
 
+// SigningAlgorithm represents the algorithm used to sign requests, which is
+// sent in the algorithm field of the Authorization header.
+type SigningAlgorithm string
+
+const (
+	// DefaultSigningAlgorithm selects the algorithm from the type of the
+	// private key: "rsa-sha256" for RSA keys, and "ecdsa-sha256" or
+	// "ecdsa-sha384" for ECDSA keys.
+	DefaultSigningAlgorithm SigningAlgorithm = ""
+
+	// RSASHA256 signs requests with RSASSA-PKCS1-v1_5 and SHA-256.
+	RSASHA256 SigningAlgorithm = "rsa-sha256"
+
+	// RSAPSSSHA256 signs requests with RSASSA-PSS and SHA-256, with a salt of
+	// the length of the hash. It requires an RSA key.
+	RSAPSSSHA256 SigningAlgorithm = "rsa-pss-sha256"
+)
+
+// validate checks that the algorithm is supported.
+func (a SigningAlgorithm) validate() error {
+	switch a {
+	case DefaultSigningAlgorithm, RSASHA256, RSAPSSSHA256:
+		return nil
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q, expected %q or %q", string(a), RSASHA256, RSAPSSSHA256)
+	}
+}
+
 // SignerBodyHashPredicate a function that allows to disable/enable body hashing
 // of requests and headers associated with body content
 type SignerBodyHashPredicate func(r *http.Request) bool
@@ -42,8 +123,43 @@ type ociRequestSigner struct {
 	GenericHeaders []string
 	BodyHeaders    []string
 	ShouldHashBody SignerBodyHashPredicate
+	Algorithm      SigningAlgorithm
+
+	// KeyIDTenancy replaces the tenancy of the key id of API keys, which is
+	// the first part of "tenancy/user/fingerprint", if it is not empty.
+	KeyIDTenancy string
+
+	// HostNormalization specifies how the host of requests is normalized.
+	HostNormalization HostNormalization
+
+	// AlgorithmIdentifier replaces the name of the signing algorithm in the
+	// Authorization header, if it is not empty.
+	AlgorithmIdentifier string
+
+	// Trace logs the string to sign, the signed headers and the key id of
+	// each signed request at Debug level, if it is not nil.
+	Trace *logger.Logger
 }
 
+// HostNormalization specifies how a request signer normalizes the host of a
+// request before it signs it. The Host field of the request is set to the
+// normalized host, so that the Host header sent with the request is the one
+// that was signed.
+type HostNormalization int
+
+const (
+	// HostAsSent signs the host as it is sent: the Host field of the request,
+	// or the host of its URL if the Host field is empty. IPv6 literals are
+	// enclosed in brackets, and their zone identifiers are removed.
+	HostAsSent HostNormalization = iota
+
+	// HostWithoutDefaultPort is like HostAsSent, and also removes the port if
+	// it is the default port of the scheme of the request URL: 443 for https
+	// and wss, and 80 for http and ws. This is needed for servers behind
+	// proxies that remove default ports from the Host header.
+	HostWithoutDefaultPort
+)
+
 var (
 	defaultGenericHeaders    = []string{"date", "(request-target)", "host"}
 	defaultDelegationHeaders = []string{"date", "(request-target)", "host", "opc-obo-token"}
@@ -107,10 +223,15 @@ func DelegationRequestSignerExcludeBody(provider KeyProvider) HTTPRequestSigner
 func NewSignerFromOCIRequestSigner(oldSigner HTTPRequestSigner, predicate SignerBodyHashPredicate) (HTTPRequestSigner, error) {
 	if oldS, ok := oldSigner.(ociRequestSigner); ok {
 		s := ociRequestSigner{
-			KeyProvider:    oldS.KeyProvider,
-			GenericHeaders: oldS.GenericHeaders,
-			BodyHeaders:    oldS.BodyHeaders,
-			ShouldHashBody: predicate,
+			KeyProvider:         oldS.KeyProvider,
+			GenericHeaders:      oldS.GenericHeaders,
+			BodyHeaders:         oldS.BodyHeaders,
+			ShouldHashBody:      predicate,
+			Algorithm:           oldS.Algorithm,
+			KeyIDTenancy:        oldS.KeyIDTenancy,
+			HostNormalization:   oldS.HostNormalization,
+			AlgorithmIdentifier: oldS.AlgorithmIdentifier,
+			Trace:               oldS.Trace,
 		}
 		return s, nil
 
@@ -128,6 +249,22 @@ func RequestSigner(provider KeyProvider, genericHeaders, bodyHeaders []string) H
 		ShouldHashBody: defaultBodyHashPredicate}
 }
 
+// RequestSignerWithAlgorithm creates a signer that utilizes the specified
+// headers and algorithm for signing, and the default predicate for using the
+// body of the request as part of the signature. For example, use RSAPSSSHA256
+// for gateways that require RSASSA-PSS signatures.
+//
+// An error is returned when a request is signed if the algorithm is not
+// supported, or does not match the type of the private key.
+func RequestSignerWithAlgorithm(provider KeyProvider, genericHeaders, bodyHeaders []string, algorithm SigningAlgorithm) HTTPRequestSigner {
+	return ociRequestSigner{
+		KeyProvider:    provider,
+		GenericHeaders: genericHeaders,
+		BodyHeaders:    bodyHeaders,
+		ShouldHashBody: defaultBodyHashPredicate,
+		Algorithm:      algorithm}
+}
+
 // RequestSignerWithBodyHashingPredicate creates a signer that utilizes the specified headers for signing, as well as a predicate for using
 // the body of the request and bodyHeaders parameter as part of the signature
 func RequestSignerWithBodyHashingPredicate(provider KeyProvider, genericHeaders, bodyHeaders []string, shouldHashBody SignerBodyHashPredicate) HTTPRequestSigner {
@@ -166,10 +303,7 @@ func (signer ociRequestSigner) getSigningString(request *http.Request) string {
 		case "(request-target)":
 			value = getRequestTarget(request)
 		case "host":
-			value = request.URL.Host
-			if len(value) == 0 {
-				value = request.Host
-			}
+			value = signer.signingHost(request)
 		default:
 			value = request.Header.Get(part)
 		}
@@ -181,14 +315,68 @@ func (signer ociRequestSigner) getSigningString(request *http.Request) string {
 
 }
 
+// requestHost returns the value of the Host header that is sent with the
+// request, including the port if the URL of the request specifies one.
+func requestHost(request *http.Request) string {
+	// The Host field overrides the host of the URL, see http.Request.
+	if request.Host != "" {
+		return request.Host
+	}
+	return request.URL.Host
+}
+
+// signingHost returns the host of the request normalized as specified by the
+// HostNormalization of the signer.
+func (signer ociRequestSigner) signingHost(request *http.Request) string {
+	name, port := splitHost(requestHost(request))
+	if name == "" {
+		return ""
+	}
+	if strings.Contains(name, ":") {
+		name = "[" + name + "]"
+	}
+	if port == "" || signer.HostNormalization == HostWithoutDefaultPort && port == defaultPort(request.URL.Scheme) {
+		return name
+	}
+	return name + ":" + port
+}
+
+// splitHost splits host into the host name or address and the port, which is
+// empty if host does not specify one. IPv6 literals are returned without
+// brackets and without zone identifier.
+func splitHost(host string) (name, port string) {
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		name, port = h, p
+	} else {
+		// host has no port, or is an IPv6 literal without brackets.
+		name = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	}
+	if i := strings.IndexByte(name, '%'); i >= 0 && strings.Contains(name, ":") {
+		name = name[:i]
+	}
+	return name, port
+}
+
+// defaultPort returns the default port of the URL scheme.
+func defaultPort(scheme string) string {
+	switch strings.ToLower(scheme) {
+	case "https", "wss":
+		return "443"
+	case "http", "ws":
+		return "80"
+	default:
+		return ""
+	}
+}
+
 func getRequestTarget(request *http.Request) string {
 	lowercaseMethod := strings.ToLower(request.Method)
 	return fmt.Sprintf("%s %s", lowercaseMethod, request.URL.RequestURI())
 }
 
-func calculateHashOfBody(request *http.Request) (err error) {
+func calculateHashOfBody(ctx context.Context, request *http.Request) (err error) {
 	var hash string
-	hash, err = GetBodyHash(request)
+	hash, err = getBodyHash(ctx, request)
 	if err != nil {
 		return
 	}
@@ -196,75 +384,158 @@ func calculateHashOfBody(request *http.Request) (err error) {
 	return
 }
 
-// drainBody reads all of b to memory and then returns two equivalent
-// ReadClosers yielding the same bytes.
-//
-// It returns an error if the initial slurp of all bytes fails. It does not attempt
-// to make the returned ReadClosers have identical error-matching behavior.
-func drainBody(b io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
-	if b == http.NoBody {
-		// No copying needed. Preserve the magic sentinel meaning of NoBody.
-		return http.NoBody, http.NoBody, nil
-	}
-	var buf bytes.Buffer
-	if _, err = buf.ReadFrom(b); err != nil {
-		return nil, b, err
-	}
-	if err = b.Close(); err != nil {
-		return nil, b, err
-	}
-	return io.NopCloser(&buf), io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+// sha256Pool is a pool of SHA-256 hashers, which are used to hash the body
+// and the signing string of every request. The crypto/sha256 package uses the
+// SHA extensions of the CPU when they are available.
+var sha256Pool = sync.Pool{
+	New: func() interface{} {
+		return sha256.New()
+	},
+}
+
+// sha256Sum writes the SHA-256 hash of data, which is written to a pooled
+// hasher by write, into sum.
+func sha256Sum(sum *[sha256.Size]byte, write func(h hash.Hash)) {
+	h := sha256Pool.Get().(hash.Hash)
+	h.Reset()
+	write(h)
+	h.Sum(sum[:0])
+	sha256Pool.Put(h)
 }
 
 func hashAndEncode(data []byte) string {
-	hashedContent := sha256.Sum256(data)
-	hash := base64.StdEncoding.EncodeToString(hashedContent[:])
-	return hash
+	var sum [sha256.Size]byte
+	sha256Sum(&sum, func(h hash.Hash) { h.Write(data) })
+
+	// Encode into a fixed size array so that the string is the only allocation.
+	var encoded [44]byte
+	base64.StdEncoding.Encode(encoded[:], sum[:])
+	return string(encoded[:])
+}
+
+// bodyHashChunkSize is the number of bytes of a request body that are hashed
+// between the checks for the cancellation of the request.
+const bodyHashChunkSize = 64 * 1024
+
+// hashAndEncodeContext is like hashAndEncode, but hashes data in chunks and
+// returns the error of ctx if ctx is done before all of data is hashed.
+func hashAndEncodeContext(ctx context.Context, data []byte) (string, error) {
+	if len(data) <= bodyHashChunkSize {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		return hashAndEncode(data), nil
+	}
+
+	var sum [sha256.Size]byte
+	var err error
+	sha256Sum(&sum, func(h hash.Hash) {
+		for rest := data; len(rest) > 0; {
+			if err = ctx.Err(); err != nil {
+				return
+			}
+			n := len(rest)
+			if n > bodyHashChunkSize {
+				n = bodyHashChunkSize
+			}
+			h.Write(rest[:n])
+			rest = rest[n:]
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sum[:]), nil
 }
 
 // GetBodyHash creates a base64 string from the hash of body the request
 func GetBodyHash(request *http.Request) (hashString string, err error) {
-	if request.Body == nil {
+	return getBodyHash(context.Background(), request)
+}
+
+// getBodyHash is like GetBodyHash, but stops hashing the body with the error
+// of ctx when ctx is done, so that the body of a cancelled request is not
+// hashed in full.
+func getBodyHash(ctx context.Context, request *http.Request) (hashString string, err error) {
+	if request.Body == nil || request.Body == http.NoBody {
 		request.ContentLength = 0
-		request.Header.Set("Content-Length", fmt.Sprintf("%v", request.ContentLength))
-		return hashAndEncode([]byte("")), nil
+		request.Header.Set("Content-Length", "0")
+		return hashAndEncode(nil), nil
 	}
 
-	var data []byte
-	var bReader io.ReadCloser
-	bReader, request.Body, err = drainBody(request.Body)
-	if err != nil {
+	// Read the body once, and replace it with a reader over the same bytes.
+	var buf bytes.Buffer
+	if request.ContentLength > 0 {
+		// ReadFrom needs bytes.MinRead bytes of free space to detect EOF.
+		buf.Grow(int(request.ContentLength) + bytes.MinRead)
+	}
+	if _, err = buf.ReadFrom(request.Body); err != nil {
 		return "", fmt.Errorf("can not read body of request while calculating body hash: %s", err.Error())
 	}
-
-	data, err = io.ReadAll(bReader)
-	if err != nil {
+	if err = request.Body.Close(); err != nil {
 		return "", fmt.Errorf("can not read body of request while calculating body hash: %s", err.Error())
 	}
+	data := buf.Bytes()
+	request.Body = io.NopCloser(bytes.NewReader(data))
 
 	// Since the request can be coming from a binary body. Make an attempt to set the body length
 	request.ContentLength = int64(len(data))
-	request.Header.Set("Content-Length", fmt.Sprintf("%v", request.ContentLength))
+	request.Header.Set("Content-Length", strconv.Itoa(len(data)))
 
-	hashString = hashAndEncode(data)
-	return
+	return hashAndEncodeContext(ctx, data)
 }
 
-func (signer ociRequestSigner) computeSignature(request *http.Request) (signature string, err error) {
-	signingString := signer.getSigningString(request)
-	hasher := sha256.New()
-	hasher.Write([]byte(signingString))
-	hashed := hasher.Sum(nil)
-
-	privateKey, err := signer.KeyProvider.PrivateRSAKey()
+func (signer ociRequestSigner) computeSignature(request *http.Request) (signature, algorithm string, err error) {
+	var key crypto.Signer
+	if sp, ok := signer.KeyProvider.(SignerKeyProvider); ok {
+		key, err = sp.PrivateKeySigner()
+	} else {
+		key, err = signer.KeyProvider.PrivateRSAKey()
+	}
 	if err != nil {
 		return
 	}
 
+	signingString := signer.getSigningString(request)
 	var unencodedSig []byte
-	unencodedSig, e := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed)
-	if e != nil {
-		err = fmt.Errorf("can not compute signature while signing the request %s: ", e.Error())
+	switch pub := key.Public().(type) {
+	case *rsa.PublicKey:
+		var hashed [sha256.Size]byte
+		sha256Sum(&hashed, func(h hash.Hash) { io.WriteString(h, signingString) })
+		switch signer.Algorithm {
+		case DefaultSigningAlgorithm, RSASHA256:
+			algorithm = string(RSASHA256)
+			unencodedSig, err = key.Sign(rand.Reader, hashed[:], crypto.SHA256)
+		case RSAPSSSHA256:
+			algorithm = string(RSAPSSSHA256)
+			unencodedSig, err = key.Sign(rand.Reader, hashed[:], &rsa.PSSOptions{
+				SaltLength: rsa.PSSSaltLengthEqualsHash,
+				Hash:       crypto.SHA256,
+			})
+		default:
+			err = signer.Algorithm.validate()
+			return
+		}
+
+	case *ecdsa.PublicKey:
+		if signer.Algorithm != DefaultSigningAlgorithm {
+			err = fmt.Errorf("signing algorithm %q can not be used with an ECDSA key", string(signer.Algorithm))
+			return
+		}
+		var h crypto.Hash
+		if h, algorithm, err = ecdsaHash(pub); err != nil {
+			return
+		}
+		hasher := h.New()
+		io.WriteString(hasher, signingString)
+		unencodedSig, err = key.Sign(rand.Reader, hasher.Sum(nil), h)
+
+	default:
+		err = fmt.Errorf("unsupported public key type %T", pub)
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("can not compute signature while signing the request %s: ", err.Error())
 		return
 	}
 
@@ -272,33 +543,153 @@ func (signer ociRequestSigner) computeSignature(request *http.Request) (signatur
 	return
 }
 
+// overrideKeyIDTenancy returns the key id with its tenancy replaced by
+// tenancyID, if tenancyID is not empty and the key id is the id of an API key,
+// which has the format "tenancy/user/fingerprint". The key ids of security
+// tokens are returned unchanged.
+func overrideKeyIDTenancy(keyID, tenancyID string) string {
+	if tenancyID == "" {
+		return keyID
+	}
+	parts := strings.SplitN(keyID, "/", 3)
+	if len(parts) != 3 || strings.HasPrefix(keyID, "ST$") {
+		return keyID
+	}
+	return tenancyID + "/" + parts[1] + "/" + parts[2]
+}
+
+// ecdsaHash returns the hash function and the name of the algorithm used to
+// sign requests with an ECDSA key on the curve of pub, which must be P-256 or
+// P-384.
+func ecdsaHash(pub *ecdsa.PublicKey) (crypto.Hash, string, error) {
+	switch pub.Curve {
+	case elliptic.P256():
+		return crypto.SHA256, "ecdsa-sha256", nil
+	case elliptic.P384():
+		return crypto.SHA384, "ecdsa-sha384", nil
+	default:
+		return 0, "", fmt.Errorf("unsupported ECDSA curve %s, only P-256 and P-384 are supported", pub.Curve.Params().Name)
+	}
+}
+
 // Sign signs the http request, by inspecting the necessary headers. Once signed
 // the request will have the proper 'Authorization' header set, otherwise
 // an error is returned
 func (signer ociRequestSigner) Sign(request *http.Request) (err error) {
+	return signer.SignContext(request.Context(), request)
+}
+
+// SignContext is like Sign, but honors the deadline and cancellation of ctx
+// while it gets the key id, if the KeyProvider implements ContextKeyProvider,
+// and while it hashes the request body.
+func (signer ociRequestSigner) SignContext(ctx context.Context, request *http.Request) (err error) {
+	// Get the key id first, so that a security token that must be renewed
+	// is renewed with ctx, rather than while the private key is read.
+	var keyID string
+	if p, ok := signer.KeyProvider.(ContextKeyProvider); ok {
+		if keyID, err = p.KeyIDContext(ctx); err != nil {
+			return
+		}
+	}
+
 	if signer.ShouldHashBody(request) {
-		err = calculateHashOfBody(request)
+		err = calculateHashOfBody(ctx, request)
 		if err != nil {
 			return
 		}
 	}
 
-	var signature string
-	if signature, err = signer.computeSignature(request); err != nil {
+	// Send the host that is signed.
+	if host := signer.signingHost(request); host != requestHost(request) {
+		request.Host = host
+	}
+
+	var signature, algorithm string
+	if signature, algorithm, err = signer.computeSignature(request); err != nil {
 		return
 	}
+	if signer.AlgorithmIdentifier != "" {
+		algorithm = signer.AlgorithmIdentifier
+	}
 
 	signingHeaders := strings.Join(signer.getSigningHeaders(request), " ")
 
-	var keyID string
-	if keyID, err = signer.KeyProvider.KeyID(); err != nil {
-		return
+	if keyID == "" {
+		if keyID, err = signer.KeyProvider.KeyID(); err != nil {
+			return
+		}
+	}
+	keyID = overrideKeyIDTenancy(keyID, signer.KeyIDTenancy)
+	if signer.Trace != nil {
+		signer.traceSigning(request, keyID, signingHeaders, algorithm)
 	}
 
-	authValue := fmt.Sprintf("Signature version=\"%s\",headers=\"%s\",keyId=\"%s\",algorithm=\"rsa-sha256\",signature=\"%s\"",
-		signerVersion, signingHeaders, keyID, signature)
+	authValue := fmt.Sprintf("Signature version=\"%s\",headers=\"%s\",keyId=\"%s\",algorithm=\"%s\",signature=\"%s\"",
+		signerVersion, signingHeaders, keyID, algorithm, signature)
 
 	request.Header.Set("Authorization", authValue)
 
 	return
 }
+
+// traceSigning logs the string to sign, the signed headers and the key id of
+// the request. Neither the signature nor the security tokens in the key id and
+// in the string to sign are logged.
+func (signer ociRequestSigner) traceSigning(request *http.Request, keyID, signingHeaders, algorithm string) {
+	signer.Trace.LogWithFn(logger.Debug, func() string {
+		if strings.HasPrefix(keyID, "ST$") {
+			keyID = "ST$<redacted>"
+		}
+		lines := strings.Split(signer.getSigningString(request), "\n")
+		for i, line := range lines {
+			if strings.HasPrefix(line, requestHeaderDelegationToken+": ") {
+				lines[i] = requestHeaderDelegationToken + ": <redacted>"
+			}
+		}
+		return fmt.Sprintf("Signing request with keyId=\"%s\", algorithm=\"%s\", headers=\"%s\", string to sign:\n%s",
+			keyID, algorithm, signingHeaders, strings.Join(lines, "\n"))
+	})
+}
+
+// SignWithBody sets the body of the request to body and signs the request with
+// signer, so that requests to any OCI service can be signed with the
+// signers and key providers of this package. The body is hashed if signer
+// hashes the body of the request, and GetBody is set so that the request can
+// be retried or redirected.
+func SignWithBody(signer HTTPRequestSigner, request *http.Request, body []byte) error {
+	if signer == nil || request == nil {
+		return fmt.Errorf("can not sign the request, signer and request must be non-nil")
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	request.ContentLength = int64(len(body))
+	return signContext(request.Context(), signer, request)
+}
+
+// StringToSign returns the string that signer signs for the request, which
+// can be compared with the string a service expects when it rejects a
+// signature. If signer hashes the body of the request, the body is hashed and
+// the headers of the body are set on the request, as Sign does.
+//
+// An error is returned if signer was not created by this package.
+func StringToSign(signer HTTPRequestSigner, request *http.Request) (string, error) {
+	if request == nil {
+		return "", fmt.Errorf("can not get the string to sign, request must be non-nil")
+	}
+	if d, ok := signer.(delegationRequestSigner); ok {
+		request.Header.Set(requestHeaderDelegationToken, d.delegationToken)
+		signer = d.HTTPRequestSigner
+	}
+	s, ok := signer.(ociRequestSigner)
+	if !ok {
+		return "", fmt.Errorf("can not get the string to sign, input signer needs to be of type ociRequestSigner")
+	}
+	if s.ShouldHashBody(request) {
+		if err := calculateHashOfBody(request.Context(), request); err != nil {
+			return "", err
+		}
+	}
+	return s.getSigningString(request), nil
+}
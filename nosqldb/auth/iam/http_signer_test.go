@@ -4,16 +4,27 @@ package iam
 
 import (
 	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/oracle/nosql-go-sdk/nosqldb/logger"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -170,9 +181,10 @@ func TestOCIRequestSigner_ComputeSignature(t *testing.T) {
 	}
 	r.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
 	r.Method = http.MethodGet
-	signature, err := s.computeSignature(&r)
+	signature, algorithm, err := s.computeSignature(&r)
 
 	assert.NoError(t, err)
+	assert.Equal(t, "rsa-sha256", algorithm)
 	assert.Equal(t, expectedSignature, signature)
 }
 
@@ -226,7 +238,7 @@ func TestOCIRequestSigner_SignString2(t *testing.T) {
 	r.Header.Set(requestHeaderContentType, "application/json")
 	r.Header.Set(requestHeaderContentLength, strconv.FormatInt(r.ContentLength, 10))
 	r.Method = http.MethodPost
-	calculateHashOfBody(&r)
+	calculateHashOfBody(context.Background(), &r)
 	signingString := s.getSigningString(&r)
 
 	assert.Equal(t, r.ContentLength, int64(316))
@@ -254,8 +266,8 @@ func TestOCIRequestSigner_ComputeSignature2(t *testing.T) {
 	r.Header.Set(requestHeaderContentType, "application/json")
 	r.Header.Set(requestHeaderContentLength, strconv.FormatInt(r.ContentLength, 10))
 	r.Method = http.MethodPost
-	calculateHashOfBody(&r)
-	signature, err := s.computeSignature(&r)
+	calculateHashOfBody(context.Background(), &r)
+	signature, _, err := s.computeSignature(&r)
 
 	assert.NoError(t, err)
 	assert.Equal(t, r.ContentLength, int64(316))
@@ -422,3 +434,661 @@ func TestDefaultHeadersReturnsCopy(t *testing.T) {
 	assert.NotEqual(t, defaultGenericHeaders, genericHeaders)
 	assert.NotEqual(t, defaultBodyHeaders, bodyHeaders)
 }
+
+// cachedKeyProvider is a testKeyProvider that parses the private key once,
+// so that benchmarks only measure signing.
+type cachedKeyProvider struct {
+	testKeyProvider
+	key *rsa.PrivateKey
+}
+
+func (kp cachedKeyProvider) PrivateRSAKey() (*rsa.PrivateKey, error) {
+	return kp.key, nil
+}
+
+func newBenchmarkRequest(b *testing.B, body []byte) *http.Request {
+	r, err := http.NewRequest(http.MethodPost, testURL2, bytes.NewReader(body))
+	if err != nil {
+		b.Fatal(err)
+	}
+	r.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+	r.Header.Set(requestHeaderContentType, "application/json")
+	return r
+}
+
+func TestHashAndEncode(t *testing.T) {
+	assert.Equal(t, "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", hashAndEncode(nil))
+	assert.Equal(t, "V9Z20UJTvkvpJ50flBzKE32+6m2zJjweHpDMX/U4Uy0=", hashAndEncode([]byte(testBody)))
+}
+
+func BenchmarkGetBodyHash(b *testing.B) {
+	for _, size := range []int{1 << 10, 64 << 10, 1 << 20} {
+		body := bytes.Repeat([]byte("a"), size)
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				r := newBenchmarkRequest(b, body)
+				if _, err := GetBodyHash(r); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	key, err := testKeyProvider{}.PrivateRSAKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := DefaultRequestSigner(cachedKeyProvider{key: key})
+	body := []byte(testBody)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r := newBenchmarkRequest(b, body)
+		if err := s.Sign(r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type testSignerKeyProvider struct {
+	testKeyProvider
+	key crypto.Signer
+}
+
+func (kp testSignerKeyProvider) PrivateKeySigner() (crypto.Signer, error) {
+	return kp.key, nil
+}
+
+func TestOCIRequestSigner_ECDSA(t *testing.T) {
+	tests := []struct {
+		curve     elliptic.Curve
+		hash      crypto.Hash
+		algorithm string
+	}{
+		{elliptic.P256(), crypto.SHA256, "ecdsa-sha256"},
+		{elliptic.P384(), crypto.SHA384, "ecdsa-sha384"},
+	}
+
+	for _, r := range tests {
+		key, err := ecdsa.GenerateKey(r.curve, rand.Reader)
+		assert.NoError(t, err)
+
+		// The key is parsed from both SEC 1 and PKCS#8 PEM encodings.
+		sec1, err := x509.MarshalECPrivateKey(key)
+		assert.NoError(t, err)
+		pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+		assert.NoError(t, err)
+		for _, block := range []*pem.Block{{Type: "EC PRIVATE KEY", Bytes: sec1}, {Type: "PRIVATE KEY", Bytes: pkcs8}} {
+			parsed, err := PrivateKeySignerFromBytes(pem.EncodeToMemory(block), nil)
+			if assert.NoErrorf(t, err, "PrivateKeySignerFromBytes(%s) got error", block.Type) {
+				assert.Truef(t, key.Equal(parsed), "PrivateKeySignerFromBytes(%s) returned a different key", block.Type)
+			}
+		}
+
+		s := RequestSigner(testSignerKeyProvider{key: key}, defaultGenericHeaders, defaultBodyHeaders).(ociRequestSigner)
+		u, _ := url.Parse(testURL)
+		req := &http.Request{Method: http.MethodGet, Header: make(http.Header), URL: u}
+		req.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+		assert.NoError(t, s.Sign(req))
+
+		auth := req.Header.Get(requestHeaderAuthorization)
+		assert.Containsf(t, auth, `algorithm="`+r.algorithm+`"`, "unexpected Authorization header %s", auth)
+		i := strings.Index(auth, `signature="`)
+		if !assert.Truef(t, i >= 0, "no signature in Authorization header %s", auth) {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(auth[i+len(`signature="`):], `"`))
+		assert.NoError(t, err)
+		h := r.hash.New()
+		io.WriteString(h, s.getSigningString(req))
+		assert.Truef(t, ecdsa.VerifyASN1(&key.PublicKey, h.Sum(nil), sig), "invalid %s signature", r.algorithm)
+	}
+
+	// Curves other than P-256 and P-384 are rejected.
+	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	assert.NoError(t, err)
+	sec1, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	_, err = PrivateKeySignerFromBytes(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}), nil)
+	assert.Error(t, err)
+
+	// The RSA key provider can not return an ECDSA key.
+	_, err = PrivateKeyFromBytesWithPassword(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}), nil)
+	assert.Error(t, err)
+}
+
+func TestOCIRequestSigner_RSAPSS(t *testing.T) {
+	kp := testKeyProvider{}
+	key, err := kp.PrivateRSAKey()
+	assert.NoError(t, err)
+
+	s := RequestSignerWithAlgorithm(kp, defaultGenericHeaders, defaultBodyHeaders, RSAPSSSHA256).(ociRequestSigner)
+	u, _ := url.Parse(testURL)
+	req := &http.Request{Method: http.MethodGet, Header: make(http.Header), URL: u}
+	req.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+	signature, algorithm, err := s.computeSignature(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "rsa-pss-sha256", algorithm)
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	assert.NoError(t, err)
+	hashed := sha256.Sum256([]byte(s.getSigningString(req)))
+	assert.NoError(t, rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, hashed[:], sig,
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}))
+
+	// PSS can not be used with ECDSA keys, and unknown algorithms are rejected.
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	s.KeyProvider = testSignerKeyProvider{key: ecKey}
+	_, _, err = s.computeSignature(req)
+	assert.Error(t, err)
+	s.KeyProvider, s.Algorithm = kp, "rsa-sha512"
+	_, _, err = s.computeSignature(req)
+	assert.Error(t, err)
+
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", testFingerprint, testPrivateKey, nil), "")
+	assert.NoError(t, err)
+	_, err = p.SetSigningAlgorithm("rsa-sha512")
+	assert.Error(t, err)
+	_, err = p.SetSigningAlgorithm(RSAPSSSHA256)
+	assert.NoError(t, err)
+	req = &http.Request{Method: http.MethodGet, Header: make(http.Header), URL: u}
+	assert.NoError(t, p.SignHTTPRequest(req))
+	assert.Contains(t, req.Header.Get(requestHeaderAuthorization), `algorithm="rsa-pss-sha256"`)
+}
+
+func TestSignWithBody(t *testing.T) {
+	signer := DefaultRequestSigner(testKeyProvider{})
+	r, err := http.NewRequest(http.MethodPost, testURL2, nil)
+	assert.NoError(t, err)
+	r.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+	r.Header.Set(requestHeaderContentType, "application/json")
+
+	err = SignWithBody(signer, r, []byte(testBody))
+	assert.NoError(t, err)
+	assert.Equal(t, "316", r.Header.Get(requestHeaderContentLength))
+	assert.Equal(t, "V9Z20UJTvkvpJ50flBzKE32+6m2zJjweHpDMX/U4Uy0=", r.Header.Get(requestHeaderXContentSHA256))
+	assert.Contains(t, r.Header.Get(requestHeaderAuthorization), `signature="`+expectedSignature2+`"`)
+
+	// The body can be read again, such as when the request is retried.
+	body, err := r.GetBody()
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	assert.Equal(t, testBody, string(data))
+
+	assert.Error(t, SignWithBody(nil, r, nil))
+}
+
+func TestStringToSign(t *testing.T) {
+	signer := DefaultRequestSigner(testKeyProvider{})
+	r, err := http.NewRequest(http.MethodPost, testURL2, bytes.NewBufferString(testBody))
+	assert.NoError(t, err)
+	r.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+	r.Header.Set(requestHeaderContentType, "application/json")
+
+	s, err := StringToSign(signer, r)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSigningString2, s)
+
+	r, err = http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, err)
+	r.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+	s, err = StringToSign(signer, r)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSigningString, s)
+
+	_, err = StringToSign(nil, r)
+	assert.Error(t, err)
+}
+
+func TestCrossTenancy(t *testing.T) {
+	otherTenancy := "ocid1.tenancy.oc1..aaaaaaaaotherotherotherotherotherotherotherotherotherother"
+	targetTenancy := "ocid1.tenancy.oc1..aaaaaaaatargettargettargettargettargettargettargettarget"
+	assert.Equal(t, otherTenancy+"/"+testUserOCID+"/"+testFingerprint,
+		overrideKeyIDTenancy(testTenancyOCID+"/"+testUserOCID+"/"+testFingerprint, otherTenancy))
+	assert.Equal(t, "ST$token", overrideKeyIDTenancy("ST$token", otherTenancy))
+	assert.Equal(t, "a/b/c", overrideKeyIDTenancy("a/b/c", ""))
+
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", testFingerprint, testPrivateKey, nil), "")
+	assert.NoError(t, err)
+	_, err = p.SetCrossTenancy("ocid1.compartment.oc1..aaaa")
+	assert.Error(t, err)
+	_, err = p.SetSigningAlgorithm(RSAPSSSHA256)
+	assert.NoError(t, err)
+	_, err = p.SetCrossTenancy(otherTenancy, targetTenancy)
+	assert.NoError(t, err)
+
+	u, _ := url.Parse(testURL)
+	req := &http.Request{Method: http.MethodGet, Header: make(http.Header), URL: u}
+	assert.NoError(t, p.SignHTTPRequest(req))
+	assert.Contains(t, req.Header.Get(requestHeaderAuthorization),
+		`keyId="`+otherTenancy+"/"+testUserOCID+"/"+testFingerprint+`"`)
+	assert.Contains(t, req.Header.Get(requestHeaderAuthorization), `algorithm="rsa-pss-sha256"`)
+	assert.Equal(t, targetTenancy, req.Header.Get(requestHeaderCrossTenancyRequest))
+
+	// The configuration is removed, and the cached signature is not used.
+	_, err = p.SetCrossTenancy("")
+	assert.NoError(t, err)
+	req = &http.Request{Method: http.MethodGet, Header: make(http.Header), URL: u}
+	assert.NoError(t, p.SignHTTPRequest(req))
+	assert.Contains(t, req.Header.Get(requestHeaderAuthorization), `keyId="`+testTenancyOCID+"/")
+	assert.Empty(t, req.Header.Get(requestHeaderCrossTenancyRequest))
+
+	// The configuration can be changed while requests are signed.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				req := &http.Request{Method: http.MethodGet, Header: make(http.Header), URL: u}
+				assert.NoError(t, p.SignHTTPRequest(req))
+			}
+		}()
+	}
+	for j := 0; j < 50; j++ {
+		_, err = p.SetCrossTenancy(otherTenancy, targetTenancy)
+		assert.NoError(t, err)
+		_, err = p.SetCrossTenancy("")
+		assert.NoError(t, err)
+	}
+	wg.Wait()
+
+	ext, err := NewSignatureProviderWithAuthorizationStringProvider(AuthorizationStringProviderFunc(
+		func(req *http.Request) (string, error) { return "", nil }), "ocid1.compartment.oc1..aaaa")
+	assert.NoError(t, err)
+	_, err = ext.SetCrossTenancy(otherTenancy)
+	assert.Error(t, err)
+}
+
+func TestSignRequest(t *testing.T) {
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", testFingerprint, testPrivateKey, nil), "")
+	assert.NoError(t, err)
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	p.SetClock(func() time.Time { return date })
+
+	// The Date header is set from the clock of the provider.
+	r, err := http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, SignRequest(p, r))
+	assert.Equal(t, "Tue, 02 Jan 2024 03:04:05 GMT", r.Header.Get(requestHeaderDate))
+
+	// The body of a POST request is hashed, and the NoSQL headers are not set.
+	r, err = http.NewRequest(http.MethodPost, testURL2, bytes.NewBufferString(testBody))
+	assert.NoError(t, err)
+	r.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+	r.Header.Set(requestHeaderContentType, "application/json")
+	assert.NoError(t, SignRequest(p, r))
+	assert.Contains(t, r.Header.Get(requestHeaderAuthorization), `signature="`+expectedSignature2+`"`)
+	assert.Empty(t, r.Header.Get(requestHeaderXNoSQLCompartmentID))
+
+	// Signatures are not reused for other targets.
+	r, err = http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, err)
+	r.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+	assert.NoError(t, SignRequest(p, r))
+	assert.Contains(t, r.Header.Get(requestHeaderAuthorization), `signature="`+expectedSignature+`"`)
+
+	// The delegation token is sent and signed.
+	token := validJwtTokenString
+	_, err = p.SetDelegationToken(token)
+	assert.NoError(t, err)
+	r, err = http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, err)
+	r.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+	assert.NoError(t, SignRequest(p, r))
+	assert.Equal(t, token, r.Header.Get(requestHeaderDelegationToken))
+	assert.Contains(t, r.Header.Get(requestHeaderAuthorization), `headers="date (request-target) host opc-obo-token"`)
+	s, err := StringToSign(p.RequestSigner(), r)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSigningString+"\nopc-obo-token: "+token, s)
+
+	assert.Error(t, SignRequest(nil, r))
+}
+
+func TestOCIRequestSigner_SigningHost(t *testing.T) {
+	testCases := []struct {
+		url           string
+		host          string
+		want          string
+		wantNoDefault string
+	}{
+		{"https://[::1]:8089/V2/nosql/data", "", "[::1]:8089", "[::1]:8089"},
+		{"https://[::1]:443/V2/nosql/data", "", "[::1]:443", "[::1]"},
+		{"http://[fe80::1%25eth0]:8080/", "", "[fe80::1]:8080", "[fe80::1]:8080"},
+		{"https://localhost/", "::1", "[::1]", "[::1]"},
+		{"https://localhost/", "[::1]", "[::1]", "[::1]"},
+		{"https://localhost:8089/", "nosql.example.com", "nosql.example.com", "nosql.example.com"},
+		{"https://nosql.example.com:443/", "", "nosql.example.com:443", "nosql.example.com"},
+		{"http://nosql.example.com:80/", "", "nosql.example.com:80", "nosql.example.com"},
+		{"http://nosql.example.com:443/", "", "nosql.example.com:443", "nosql.example.com:443"},
+		{"wss://nosql.example.com:443/", "", "nosql.example.com:443", "nosql.example.com"},
+	}
+
+	for _, r := range testCases {
+		req, err := http.NewRequest(http.MethodGet, r.url, nil)
+		if !assert.NoError(t, err, r.url) {
+			continue
+		}
+		if r.host != "" {
+			req.Host = r.host
+		}
+		s := ociRequestSigner{KeyProvider: testKeyProvider{}, GenericHeaders: defaultGenericHeaders, ShouldHashBody: defaultBodyHashPredicate}
+		assert.Equal(t, r.want, s.signingHost(req), "host of %s (Host=%q)", r.url, r.host)
+		s.HostNormalization = HostWithoutDefaultPort
+		assert.Equal(t, r.wantNoDefault, s.signingHost(req), "host without default port of %s (Host=%q)", r.url, r.host)
+
+		// The signed host is sent.
+		req.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+		assert.NoError(t, s.Sign(req))
+		assert.Equal(t, r.wantNoDefault, requestHost(req))
+	}
+
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", testFingerprint, testPrivateKey, nil), "")
+	assert.NoError(t, err)
+	_, err = p.SetHostNormalization(HostNormalization(5))
+	assert.Error(t, err)
+	_, err = p.SetHostNormalization(HostWithoutDefaultPort)
+	assert.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "https://nosql.us-ashburn-1.oci.oraclecloud.com:443/V2/nosql/data", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, p.SignHTTPRequest(req))
+	assert.Equal(t, "nosql.us-ashburn-1.oci.oraclecloud.com", req.Host)
+}
+
+func TestClockSkewCorrection(t *testing.T) {
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", testFingerprint, testPrivateKey, nil), "")
+	assert.NoError(t, err)
+
+	// The skew is not corrected unless it is enabled.
+	p.ObserveServerDate(time.Now().Add(-time.Hour))
+	assert.Equal(t, time.Duration(0), p.ClockSkew())
+
+	p.SetClockSkewCorrection(true)
+	p.ObserveServerDate(time.Now().Add(2 * time.Second))
+	assert.Equal(t, time.Duration(0), p.ClockSkew())
+	p.ObserveServerDate(time.Now().Add(-time.Hour))
+	assert.InDelta(t, float64(-time.Hour), float64(p.ClockSkew()), float64(2*time.Second))
+
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	p.SetClock(func() time.Time { return date })
+	req, err := http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, p.SignHTTPRequest(req))
+	signed, err := http.ParseTime(req.Header.Get(requestHeaderDate))
+	assert.NoError(t, err)
+	assert.InDelta(t, float64(-time.Hour), float64(signed.Sub(date)), float64(2*time.Second))
+
+	p.SetClockSkewCorrection(false)
+	assert.Equal(t, time.Duration(0), p.ClockSkew())
+	req, err = http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, p.SignHTTPRequest(req))
+	assert.Equal(t, "Tue, 02 Jan 2024 03:04:05 GMT", req.Header.Get(requestHeaderDate))
+}
+
+func TestSignatureProviderRotateKey(t *testing.T) {
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", "previous", testPrivateKey, nil), "")
+	assert.NoError(t, err)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	p.SetClock(func() time.Time { return now })
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	newKey := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	_, err = p.RotateKey(NewRawConfigurationProvider(testTenancyOCID, testUserOCID, "us-ashburn-1", "new", newKey, nil),
+		10*time.Minute)
+	assert.NoError(t, err)
+
+	sign := func(r *http.Request) string {
+		assert.NoError(t, p.SignHTTPRequest(r))
+		return r.Header.Get(requestHeaderAuthorization)
+	}
+	get := func() *http.Request {
+		r, _ := http.NewRequest(http.MethodGet, testURL, nil)
+		return r
+	}
+
+	// The previous key signs requests during the grace period.
+	assert.Contains(t, sign(get()), `/previous",`)
+	r := get()
+	assert.NoError(t, SignRequest(p, r))
+	assert.Contains(t, r.Header.Get(requestHeaderAuthorization), `/previous",`)
+
+	// The cached signature of the previous key expires with the grace period.
+	now = now.Add(10 * time.Minute)
+	assert.Contains(t, sign(get()), `/new",`)
+	r = get()
+	r.Header.Set("X-Nosql-Hash-Body", "true")
+	assert.Contains(t, sign(r), `/new",`)
+
+	// Without grace period, the new key is used at once.
+	_, err = p.RotateKey(NewRawConfigurationProvider(testTenancyOCID, testUserOCID, "us-ashburn-1", "newer", testPrivateKey, nil), 0)
+	assert.NoError(t, err)
+	assert.Contains(t, sign(get()), `/newer",`)
+	_, err = p.RotateKey(nil, 0)
+	assert.Error(t, err)
+}
+
+func TestSignatureProviderAlgorithmIdentifier(t *testing.T) {
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", testFingerprint, testPrivateKey, nil), "")
+	assert.NoError(t, err)
+	_, err = p.SetAlgorithmIdentifier(`rsa"`)
+	assert.Error(t, err)
+	_, err = p.SetAlgorithmIdentifier("hs2019")
+	assert.NoError(t, err)
+
+	r, _ := http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, p.SignHTTPRequest(r))
+	auth := r.Header.Get(requestHeaderAuthorization)
+	assert.Contains(t, auth, `algorithm="hs2019"`)
+
+	// The signature is still computed with RSA-SHA256.
+	verifyAuthorization(t, r.Header, "date: "+r.Header.Get(requestHeaderDate)+"\n"+
+		"(request-target): get "+r.URL.RequestURI()+"\nhost: "+r.URL.Host)
+
+	_, err = p.SetAlgorithmIdentifier("")
+	assert.NoError(t, err)
+	r, _ = http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, p.SignHTTPRequest(r))
+	assert.Contains(t, r.Header.Get(requestHeaderAuthorization), `algorithm="rsa-sha256"`)
+}
+
+func TestSignatureProviderCachePerHost(t *testing.T) {
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", testFingerprint, testPrivateKey, nil), "")
+	assert.NoError(t, err)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	p.SetClock(func() time.Time { return now })
+
+	// Requests to different hosts, such as the additional endpoints of a
+	// client, are signed with the host they are sent to, and the signature of
+	// each host is reused.
+	hosts := []string{"proxy1.example.com:8080", "proxy2.example.com:8080"}
+	auths := make(map[string]string)
+	for i := 0; i < 2; i++ {
+		for _, host := range hosts {
+			r, _ := http.NewRequest(http.MethodGet, "https://"+host+"/20190828/tables", nil)
+			assert.NoError(t, p.SignHTTPRequest(r))
+			verifyAuthorization(t, r.Header, "date: "+r.Header.Get(requestHeaderDate)+"\n"+
+				"(request-target): get /20190828/tables\nhost: "+host)
+			if i == 0 {
+				auths[host] = r.Header.Get(requestHeaderAuthorization)
+			} else {
+				assert.Equal(t, auths[host], r.Header.Get(requestHeaderAuthorization))
+			}
+		}
+	}
+	assert.NotEqual(t, auths[hosts[0]], auths[hosts[1]])
+
+	// Expired signatures are dropped from the cache.
+	now = now.Add(10 * time.Minute)
+	r, _ := http.NewRequest(http.MethodGet, "https://"+hosts[0]+"/20190828/tables", nil)
+	assert.NoError(t, p.SignHTTPRequest(r))
+	assert.Len(t, p.signatures, 1)
+}
+
+func TestSignContextCancelledBodyHash(t *testing.T) {
+	s := ociRequestSigner{
+		KeyProvider:    testKeyProvider{},
+		GenericHeaders: defaultGenericHeaders,
+		ShouldHashBody: defaultBodyHashPredicate,
+		BodyHeaders:    defaultBodyHeaders}
+	body := bytes.Repeat([]byte("0123456789abcdef"), 3*bodyHashChunkSize/16+1)
+
+	newRequest := func() *http.Request {
+		r, err := http.NewRequest(http.MethodPost, testURL2, bytes.NewReader(body))
+		assert.NoError(t, err)
+		r.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+		r.Header.Set(requestHeaderContentType, "application/octet-stream")
+		return r
+	}
+
+	// The body is hashed in chunks to the same hash.
+	r := newRequest()
+	hash, err := getBodyHash(context.Background(), r)
+	if assert.NoError(t, err) {
+		assert.Equal(t, hashAndEncode(body), hash)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r = newRequest()
+	err = s.SignContext(ctx, r)
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, r.Header.Get(requestHeaderAuthorization))
+	assert.Empty(t, r.Header.Get(requestHeaderXContentSHA256))
+
+	r = newRequest()
+	if assert.NoError(t, s.SignContext(context.Background(), r)) {
+		assert.Equal(t, hash, r.Header.Get(requestHeaderXContentSHA256))
+		assert.NotEmpty(t, r.Header.Get(requestHeaderAuthorization))
+	}
+}
+
+func TestSignatureProviderSigningTrace(t *testing.T) {
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", testFingerprint, testPrivateKey, nil), "")
+	assert.NoError(t, err)
+	_, err = p.SetDelegationToken(validJwtTokenString)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = p.SetSigningTrace(logger.New(&buf, logger.Debug, false))
+	assert.NoError(t, err)
+
+	r, _ := http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, p.SignHTTPRequest(r))
+	trace := buf.String()
+	assert.Contains(t, trace, `keyId="`+testTenancyOCID+"/"+testUserOCID+"/"+testFingerprint+`"`)
+	assert.Contains(t, trace, `headers="date (request-target) host opc-obo-token"`)
+	assert.Contains(t, trace, "date: "+r.Header.Get(requestHeaderDate)+"\n(request-target): get "+r.URL.RequestURI())
+	assert.Contains(t, trace, "opc-obo-token: <redacted>")
+	assert.NotContains(t, trace, validJwtTokenString)
+	auth := r.Header.Get(requestHeaderAuthorization)
+	signature := auth[strings.Index(auth, `signature="`)+len(`signature="`) : len(auth)-1]
+	assert.NotContains(t, trace, signature)
+
+	// The requests that reuse the signature only log its date.
+	buf.Reset()
+	r, _ = http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, p.SignHTTPRequest(r))
+	assert.Contains(t, buf.String(), "Reusing the signature of "+r.Header.Get(requestHeaderDate))
+
+	// The security tokens of key ids are not logged.
+	buf.Reset()
+	signer := ociRequestSigner{
+		KeyProvider:    testKeyProvider{},
+		GenericHeaders: defaultGenericHeaders,
+		ShouldHashBody: defaultBodyHashPredicate,
+		Trace:          logger.New(&buf, logger.Debug, false),
+	}
+	signer.traceSigning(r, "ST$"+validJwtTokenString, "date (request-target) host", "rsa-sha256")
+	assert.Contains(t, buf.String(), `keyId="ST$<redacted>"`)
+	assert.NotContains(t, buf.String(), validJwtTokenString)
+
+	// Nothing is logged above Debug level.
+	buf.Reset()
+	_, err = p.SetSigningTrace(logger.New(&buf, logger.Info, false))
+	assert.NoError(t, err)
+	r, _ = http.NewRequest(http.MethodGet, testURL, nil)
+	assert.NoError(t, p.SignHTTPRequest(r))
+	assert.Empty(t, buf.String())
+}
+
+func TestSignatureProviderConcurrentSetters(t *testing.T) {
+	p, err := NewSignatureProviderWithConfiguration(NewRawConfigurationProvider(testTenancyOCID, testUserOCID,
+		"us-ashburn-1", testFingerprint, testPrivateKey, nil), "")
+	assert.NoError(t, err)
+	trace := logger.New(io.Discard, logger.Debug, false)
+
+	setters := []struct {
+		name string
+		set  func(i int) error
+	}{
+		{"SetSigningAlgorithm", func(i int) error {
+			_, err := p.SetSigningAlgorithm([]SigningAlgorithm{RSAPSSSHA256, DefaultSigningAlgorithm}[i%2])
+			return err
+		}},
+		{"SetHostNormalization", func(i int) error {
+			_, err := p.SetHostNormalization([]HostNormalization{HostWithoutDefaultPort, HostAsSent}[i%2])
+			return err
+		}},
+		{"SetAlgorithmIdentifier", func(i int) error {
+			_, err := p.SetAlgorithmIdentifier([]string{"hs2019", ""}[i%2])
+			return err
+		}},
+		{"SetSigningTrace", func(i int) error {
+			_, err := p.SetSigningTrace([]*logger.Logger{trace, nil}[i%2])
+			return err
+		}},
+		{"SetCrossTenancy", func(i int) error {
+			_, err := p.SetCrossTenancy([]string{testTenancyOCID, ""}[i%2])
+			return err
+		}},
+	}
+
+	// The setters can be called while requests are signed, which is checked
+	// by the race detector.
+	u, _ := url.Parse(testURL)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(hashBody bool) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				req := &http.Request{Method: http.MethodPost, Header: make(http.Header), URL: u,
+					Body: io.NopCloser(strings.NewReader(testBody))}
+				if hashBody {
+					req.Header.Set("X-Nosql-Hash-Body", "true")
+				}
+				assert.NoError(t, p.SignHTTPRequest(req))
+			}
+		}(i%2 == 0)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for i := 0; ; i++ {
+		for _, s := range setters {
+			assert.NoErrorf(t, s.set(i), "%s", s.name)
+		}
+		select {
+		case <-done:
+			return
+		default:
+		}
+	}
+}
@@ -7,14 +7,33 @@
 
 // Package iam provides authorization provider implementations for clients
 // that connect to cloud via IAM (Oracle Identity and Access Management).
+//
+// The request signers and key providers of the package do not depend on the
+// NoSQL client, and can be used to sign requests to other OCI services:
+//
+//	provider, err := iam.NewSignatureProviderFromFile("~/.oci/config", "", "", "")
+//	...
+//	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+//	req.Header.Set("Content-Type", "application/json")
+//	err = iam.SignRequest(provider, req)
+//
+// SignRequest signs a request with the credentials of a SignatureProvider,
+// and SignatureProvider.RequestSigner returns a reusable signer for them.
+// SignWithBody signs a request with a body given as bytes.
+//
+// StringToSign returns the string that is signed for a request, to diagnose
+// signatures that a service rejects.
 package iam
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/oracle/nosql-go-sdk/nosqldb/auth"
@@ -27,6 +46,17 @@ const (
 	requestHeaderDelegationToken     = "opc-obo-token"
 	requestHeaderAuthorization       = "Authorization"
 	requestHeaderXNoSQLCompartmentID = "X-Nosql-Compartment-Id"
+	requestHeaderCrossTenancyRequest = "X-Cross-Tenancy-Request"
+)
+
+// Environment variables of the OCI CLI that specify API key credentials.
+const (
+	cliTenancyEnvVar     = "OCI_CLI_TENANCY"
+	cliUserEnvVar        = "OCI_CLI_USER"
+	cliFingerprintEnvVar = "OCI_CLI_FINGERPRINT"
+	cliKeyFileEnvVar     = "OCI_CLI_KEY_FILE"
+	cliKeyContentEnvVar  = "OCI_CLI_KEY_CONTENT"
+	cliRegionEnvVar      = "OCI_CLI_REGION"
 )
 
 // SignatureProvider is an signature provider for use with cloud IAM.
@@ -46,22 +76,69 @@ type SignatureProvider struct {
 	// delegation token - optional
 	delegationToken string
 
-	// cached signature string
-	signature string
+	// the provider of the delegation token, if it can change - optional
+	delegationTokenProvider DelegationTokenProvider
+
+	// the algorithm used to sign requests - optional
+	algorithm SigningAlgorithm
+
+	// the tenancy that replaces the tenancy of the key id - optional
+	keyIDTenancy string
 
-	// date of last signature string generation, in RFC1123 format
-	signatureFormattedDate string
+	// the tenancies accessed by cross-tenancy requests - optional
+	crossTenancies string
+
+	// how the host of requests is normalized - optional
+	hostNormalization HostNormalization
+
+	// the algorithm written into the Authorization header - optional
+	algorithmIdentifier string
+
+	// the logger of the signing traces - optional
+	trace *logger.Logger
+
+	// cached signatures, by host of the signed requests
+	signatures map[string]cachedSignature
 
 	// interval for new signature generations
 	expiryInterval time.Duration
 
-	// next time the signature expires
-	signatureExpiresAt time.Time
+	// the clock used to date signatures - optional, time.Now if nil
+	clock func() time.Time
+
+	// whether the date of signatures is corrected by the clock skew
+	clockSkewCorrection bool
+
+	// the difference between the clock of the server and the local clock, in
+	// nanoseconds, accessed atomically
+	clockSkew int64
+
+	// the hooks called on events of the lifecycle of the credentials - optional
+	hooks *AuthHooks
+
+	// the configuration provider of the previous key, and its signer, which
+	// sign requests until previousKeyUntil during a key rotation - optional
+	previousConfigProvider ConfigurationProvider
+	previousSigner         HTTPRequestSigner
+	previousKeyUntil       time.Time
 
 	// lock for updating cached signatures
 	mutex sync.RWMutex
 }
 
+// cachedSignature is a signature reused by the requests to a host until it
+// expires.
+type cachedSignature struct {
+	// the Authorization header
+	authorization string
+
+	// the date of the signature, in RFC1123 format
+	formattedDate string
+
+	// the time the signature expires
+	expiresAt time.Time
+}
+
 // NewSignatureProvider creates a signature provider using the "DEFAULT"
 // profile specified in the default OCI configuration file ~/.oci/config.
 // See [SDK Configuration File] for details of the configuration file's contents and format.
@@ -144,6 +221,66 @@ func NewRawSignatureProvider(tenancy, user, region, fingerprint, compartmentID,
 	return NewSignatureProviderWithConfiguration(configProvider, compartmentID)
 }
 
+// NewSignatureProviderFromEnvironment creates a signature provider with the
+// API key credentials specified by the environment variables of the OCI CLI:
+//
+//	OCI_CLI_TENANCY
+//	OCI_CLI_USER
+//	OCI_CLI_FINGERPRINT
+//	OCI_CLI_KEY_FILE or OCI_CLI_KEY_CONTENT
+//	OCI_CLI_REGION
+//
+// OCI_CLI_KEY_CONTENT specifies the private key itself in PEM format, so that
+// no file is needed, such as in containers whose secrets are injected as
+// environment variables. Its line breaks may be escaped as "\n". It takes
+// precedence over OCI_CLI_KEY_FILE if both are set.
+//
+// An error is returned if any of these variables is not set.
+//
+// compartmentID is optional; if empty, the tenancyOCID is used in its place.
+func NewSignatureProviderFromEnvironment(compartmentID string) (*SignatureProvider, error) {
+	keyVar := cliKeyFileEnvVar
+	if os.Getenv(cliKeyContentEnvVar) != "" {
+		keyVar = cliKeyContentEnvVar
+	}
+
+	var values []string
+	for _, key := range []string{cliTenancyEnvVar, cliUserEnvVar, cliFingerprintEnvVar, keyVar, cliRegionEnvVar} {
+		val, ok := os.LookupEnv(key)
+		if !ok || val == "" {
+			if key == cliKeyFileEnvVar {
+				key += " or " + cliKeyContentEnvVar
+			}
+			return nil, fmt.Errorf("can not create signature provider from environment, environment variable: %s, not present", key)
+		}
+		values = append(values, val)
+	}
+
+	var privateKey string
+	if keyVar == cliKeyContentEnvVar {
+		privateKey = values[3]
+		if !strings.Contains(privateKey, "\n") {
+			privateKey = strings.Replace(privateKey, `\n`, "\n", -1)
+		}
+	} else {
+		keyFile, err := sdkutil.ExpandPath(values[3])
+		if err != nil {
+			return nil, err
+		}
+		pemData, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read private key file %s: %v", keyFile, err)
+		}
+		privateKey = string(pemData)
+	}
+
+	configProvider := NewRawConfigurationProvider(values[0], values[1], values[4], values[2], privateKey, nil)
+	if ok, err := IsConfigurationProviderValid(configProvider); !ok {
+		return nil, err
+	}
+	return NewSignatureProviderWithConfiguration(configProvider, compartmentID)
+}
+
 // NewSignatureProviderWithResourcePrincipal creates a signature provider with
 // resource principal. This can be used for applications that access NoSQL cloud
 // service from within a function that executes on Oracle Functions.
@@ -159,8 +296,8 @@ func NewRawSignatureProvider(tenancy, user, region, fingerprint, compartmentID,
 //	OCI_RESOURCE_PRINCIPAL_PRIVATE_PEM_PASSPHRASE
 //	OCI_RESOURCE_PRINCIPAL_REGION
 //
-// Where OCI_RESOURCE_PRINCIPAL_VERSION specifies a resource principal version.
-// Current version is 2.2.
+// Where OCI_RESOURCE_PRINCIPAL_VERSION specifies a resource principal version,
+// 2.2 or 3.0.
 //
 // OCI_RESOURCE_PRINCIPAL_RPST specifies a resource principal session token or
 // a path to the file that stores the token.
@@ -174,11 +311,37 @@ func NewRawSignatureProvider(tenancy, user, region, fingerprint, compartmentID,
 //
 // OCI_RESOURCE_PRINCIPAL_REGION specifies an OCI region identifier.
 //
+// Version 3.0 supports nested resource principals, where the session token of
+// a parent resource is obtained on behalf of a leaf resource. It is configured
+// using the following environment variables:
+//
+//	OCI_RESOURCE_PRINCIPAL_RPT_URL_FOR_PARENT_RESOURCE
+//	OCI_RESOURCE_PRINCIPAL_RPST_ENDPOINT_FOR_PARENT_RESOURCE
+//
+// which specify the endpoints that issue the resource principal token and the
+// session token of the parent resource, along with the variables of version
+// 2.2 suffixed with _FOR_LEAF_RESOURCE, such as
+// OCI_RESOURCE_PRINCIPAL_RPST_FOR_LEAF_RESOURCE, which specify the leaf
+// resource principal used to sign the requests to these endpoints.
+// OCI_RESOURCE_PRINCIPAL_REGION is optional with version 3.0, the region of
+// the leaf resource is used if it is not set.
+//
 // Note that if your application is deployed to Oracle Functions, these
 // environment variables are already set inside the container in which the
 // function executes.
 func NewSignatureProviderWithResourcePrincipal(compartmentID string) (*SignatureProvider, error) {
-	configProvider, err := newResourcePrincipalConfigurationProvider()
+	return NewSignatureProviderWithResourcePrincipalOptions(compartmentID, ResourcePrincipalOptions{})
+}
+
+// NewSignatureProviderWithResourcePrincipalOptions creates a signature provider
+// with resource principal, using the specified options. The resource principal
+// is configured with the environment variables described for
+// NewSignatureProviderWithResourcePrincipal.
+//
+// The compartmentID specifies the OCID of compartment to which the Oracle
+// NoSQL tables belong. If empty, the tenancy OCID is used.
+func NewSignatureProviderWithResourcePrincipalOptions(compartmentID string, options ResourcePrincipalOptions) (*SignatureProvider, error) {
+	configProvider, err := newResourcePrincipalConfigurationProvider(options)
 	if err != nil {
 		return nil, err
 	}
@@ -190,10 +353,25 @@ func NewSignatureProviderWithResourcePrincipal(compartmentID string) (*Signature
 // instance principal. This can be used for applications that access NoSQL cloud
 // service from within an Oracle Compute Instance.
 //
+// The region and the certificates of the instance are retrieved from the
+// instance metadata service at http://169.254.169.254/opc/v2, unless the
+// OCI_METADATA_BASE_URL environment variable specifies another base URL.
+//
 // The compartmentID specifies the OCID of compartment to which the Oracle
 // NoSQL tables belong. If empty, the tenancy OCID is used.
 func NewSignatureProviderWithInstancePrincipal(compartmentID string) (*SignatureProvider, error) {
-	configProvider, err := newInstancePrincipalConfigurationProvider()
+	return NewSignatureProviderWithInstancePrincipalOptions(compartmentID, InstancePrincipalOptions{})
+}
+
+// NewSignatureProviderWithInstancePrincipalOptions creates a signature provider
+// with instance principal, using the specified options to access the instance
+// metadata service. This can be used for applications that run behind a proxy
+// of the instance metadata service, or in environments that emulate it.
+//
+// The compartmentID specifies the OCID of compartment to which the Oracle
+// NoSQL tables belong. If empty, the tenancy OCID is used.
+func NewSignatureProviderWithInstancePrincipalOptions(compartmentID string, options InstancePrincipalOptions) (*SignatureProvider, error) {
+	configProvider, err := newInstancePrincipalConfigurationProvider(options)
 	if err != nil {
 		return nil, err
 	}
@@ -234,6 +412,52 @@ func NewSignatureProviderWithInstancePrincipalDelegationFromFile(compartmentID s
 	return sp.SetDelegationTokenFromFile(delegationTokenFile)
 }
 
+// NewSignatureProviderWithOKEWorkloadIdentity creates a signature provider with
+// OKE Workload Identity. This can be used for applications that access NoSQL
+// cloud service from within a pod of an Oracle Container Engine for Kubernetes
+// (OKE) enhanced cluster, without mounting API keys in the pod.
+//
+// The Kubernetes service account token of the pod is read from
+// /var/run/secrets/kubernetes.io/serviceaccount/token, and is exchanged at the
+// OKE proxymux endpoint for a resource principal session token. The session
+// token is refreshed automatically before it expires, and the service account
+// token is read again for each refresh, as it is rotated by Kubernetes.
+//
+// The compartmentID specifies the OCID of compartment to which the Oracle
+// NoSQL tables belong. If empty, the tenancy OCID is used.
+//
+// OKE Workload Identity is configured using the following environment variables:
+//
+//	KUBERNETES_SERVICE_HOST
+//	OCI_RESOURCE_PRINCIPAL_REGION
+//	OCI_KUBERNETES_SERVICE_ACCOUNT_CERT_PATH
+//
+// Where KUBERNETES_SERVICE_HOST specifies the host of the proxymux endpoint,
+// and is set by Kubernetes in all pods.
+//
+// OCI_RESOURCE_PRINCIPAL_REGION specifies an OCI region identifier.
+//
+// OCI_KUBERNETES_SERVICE_ACCOUNT_CERT_PATH specifies the path of the CA
+// certificate used to verify the proxymux endpoint. This is optional, if not
+// set /var/run/secrets/kubernetes.io/serviceaccount/ca.crt is used.
+func NewSignatureProviderWithOKEWorkloadIdentity(compartmentID string) (*SignatureProvider, error) {
+	return NewSignatureProviderWithOKEWorkloadIdentityFromFile(compartmentID, okeServiceAccountTokenPath)
+}
+
+// NewSignatureProviderWithOKEWorkloadIdentityFromFile creates a signature
+// provider with OKE Workload Identity that reads the Kubernetes service account
+// token from the specified file, for pods that mount the token from a projected
+// volume at a custom path. See NewSignatureProviderWithOKEWorkloadIdentity for
+// details.
+func NewSignatureProviderWithOKEWorkloadIdentityFromFile(compartmentID, serviceAccountTokenFile string) (*SignatureProvider, error) {
+	configProvider, err := newOKEWorkloadIdentityConfigurationProvider(serviceAccountTokenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSignatureProviderWithConfiguration(configProvider, compartmentID)
+}
+
 // NewSessionTokenSignatureProvider Creates a SignatureProvider using a
 // temporary session token read from a token file.
 //
@@ -262,6 +486,12 @@ func NewSessionTokenSignatureProvider() (*SignatureProvider, error) {
 // for details of the file's contents and format.
 //
 // The path of token file is read from the configuration, using the value of field 'security_token_file'.
+// The token is read from the file again when it is about to expire, and if it
+// has not been refreshed, for example by "oci session refresh", it is refreshed
+// with the authentication service, signed with the key of the 'key_file' field,
+// and the new token is written to the file. A session can be refreshed until
+// it reaches its maximum lifetime, after which a new session must be created
+// with "oci session authenticate".
 //
 // See [Session Token-Based Authentication] for more details of session-token-based authentication.
 //
@@ -333,12 +563,9 @@ func NewSignatureProviderWithConfiguration(configProvider ConfigurationProvider,
 	expiryInterval, _ := time.ParseDuration("5m")
 
 	p := &SignatureProvider{
-		signature:              "",
-		signatureExpiresAt:     time.Now(),
-		signatureFormattedDate: "",
-		expiryInterval:         expiryInterval,
-		compartmentID:          compartmentID,
-		configProvider:         configProvider,
+		expiryInterval: expiryInterval,
+		compartmentID:  compartmentID,
+		configProvider: configProvider,
 	}
 
 	// this will also set the signer
@@ -367,6 +594,49 @@ func (p *SignatureProvider) Profile() ConfigurationProvider {
 	return p.configProvider
 }
 
+// UsesInstancePrincipal reports whether the signature provider authenticates
+// with an instance principal. In that case, the Region method of its Profile
+// returns the region of the compute instance, which is retrieved from the
+// instance metadata service.
+func (p *SignatureProvider) UsesInstancePrincipal() bool {
+	_, ok := p.configProvider.(*instancePrincipalConfigurationProvider)
+	return ok
+}
+
+// CompartmentID returns the OCID or name of the compartment that is sent with
+// requests signed by the signature provider.
+func (p *SignatureProvider) CompartmentID() string {
+	return p.compartmentID
+}
+
+// PrincipalType returns a description of the kind of principal the signature
+// provider authenticates as, such as "user principal" or "instance principal".
+// It is intended for diagnostic messages.
+func (p *SignatureProvider) PrincipalType() string {
+	var s string
+	switch cp := p.configProvider.(type) {
+	case nil:
+		s = "external signer"
+	case *instancePrincipalConfigurationProvider:
+		s = "instance principal"
+	case *resourcePrincipalKeyProvider:
+		s = "resource principal"
+	case *okeWorkloadIdentityConfigurationProvider:
+		s = "OKE workload identity"
+	default:
+		if _, err := cp.SecurityTokenFile(); err == nil {
+			s = "session token"
+		} else {
+			s = "user principal"
+		}
+	}
+
+	if p.delegationToken != "" {
+		s += " with delegation token"
+	}
+	return s
+}
+
 // AuthorizationScheme returns "Signature" for this provider which means the requests
 // must be signed before sending out
 func (p *SignatureProvider) AuthorizationScheme() string {
@@ -375,38 +645,435 @@ func (p *SignatureProvider) AuthorizationScheme() string {
 
 // SetDelegationToken is used to set a delegation token for the signature provider.
 // Passing an empty string will configure the provider to not use delegation.
+// It replaces the delegation token provider set by SetDelegationTokenProvider,
+// if any.
 func (p *SignatureProvider) SetDelegationToken(delegationToken string) (*SignatureProvider, error) {
-	if delegationToken == "" {
-		p.delegationToken = delegationToken
-		// we currently don't sign the -body- of the requests
-		p.signer = RequestSignerExcludeBody(p.configProvider)
-		return p, nil
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.delegationTokenProvider = nil
+	p.signatures = nil
+	return p.setDelegationToken(delegationToken)
+}
+
+// setDelegationToken sets the delegation token and the signer that uses it.
+func (p *SignatureProvider) setDelegationToken(delegationToken string) (*SignatureProvider, error) {
+	if _, ok := p.signer.(externalRequestSigner); ok {
+		return p.setExternalDelegationToken(delegationToken)
 	}
-	// check token format
-	parts := strings.Split(delegationToken, ".")
-	if len(parts) != 3 {
-		return nil, fmt.Errorf("given delegation token \"%s\" is not in valid JWT format", delegationToken)
+
+	// we currently don't sign the -body- of the requests
+	newSigner := RequestSignerExcludeBody
+	if delegationToken != "" {
+		// check token format
+		parts := strings.Split(delegationToken, ".")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("given delegation token \"%s\" is not in valid JWT format", delegationToken)
+		}
+		newSigner = DelegationRequestSignerExcludeBody
 	}
 	p.delegationToken = delegationToken
-	// we currently don't sign the -body- of the requests
-	p.signer = DelegationRequestSignerExcludeBody(p.configProvider)
+	p.signer = p.withSignerOptions(newSigner(p.configProvider))
+	p.previousSigner = nil
+	if p.previousConfigProvider != nil {
+		p.previousSigner = p.withSignerOptions(newSigner(p.previousConfigProvider))
+	}
 	return p, nil
 }
 
+// SetSigningAlgorithm sets the algorithm used to sign requests, such as
+// RSAPSSSHA256 for gateways that require RSASSA-PSS signatures. Passing
+// DefaultSigningAlgorithm selects the algorithm from the type of the private
+// key.
+//
+// An error is returned if the algorithm is not supported, or if the provider
+// uses an external signer, see NewSignatureProviderWithAuthorizationStringProvider.
+func (p *SignatureProvider) SetSigningAlgorithm(algorithm SigningAlgorithm) (*SignatureProvider, error) {
+	if err := algorithm.validate(); err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.signer.(externalRequestSigner); ok {
+		return nil, fmt.Errorf("the signing algorithm can not be set for an external signer")
+	}
+
+	p.algorithm = algorithm
+	p.signatures = nil
+	return p.setDelegationToken(p.delegationToken)
+}
+
+// SetCrossTenancy configures the provider to sign requests that access tables
+// in other tenancies, where the policies of the tenancies allow it.
+//
+// If keyIDTenancy is not empty, it replaces the tenancy of the key id of the
+// API key of the provider, which has the format "tenancy/user/fingerprint".
+// The key ids of instance principals, resource principals and session tokens
+// are not changed. The OCIDs of the tenancies in targetTenancies, if any, are
+// sent in the X-Cross-Tenancy-Request header of each request. Passing an empty
+// keyIDTenancy and no targetTenancies removes the configuration.
+//
+// An error is returned if an OCID is not the OCID of a tenancy, or if the
+// provider uses an external signer, see NewSignatureProviderWithAuthorizationStringProvider.
+func (p *SignatureProvider) SetCrossTenancy(keyIDTenancy string, targetTenancies ...string) (*SignatureProvider, error) {
+	for _, ocid := range append([]string{keyIDTenancy}, targetTenancies...) {
+		if ocid != "" && !strings.HasPrefix(ocid, "ocid1.tenancy.") {
+			return nil, fmt.Errorf("invalid tenancy OCID %q", ocid)
+		}
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.signer.(externalRequestSigner); ok {
+		return nil, fmt.Errorf("cross-tenancy requests can not be configured for an external signer")
+	}
+
+	p.keyIDTenancy = keyIDTenancy
+	p.crossTenancies = strings.Join(targetTenancies, ",")
+	p.signatures = nil
+	return p.setDelegationToken(p.delegationToken)
+}
+
+// SetHostNormalization sets how the host of requests is normalized before
+// they are signed, such as HostWithoutDefaultPort for servers behind proxies
+// that remove default ports from the Host header. The default is HostAsSent.
+//
+// An error is returned if the normalization is not supported, or if the
+// provider uses an external signer, see NewSignatureProviderWithAuthorizationStringProvider.
+func (p *SignatureProvider) SetHostNormalization(normalization HostNormalization) (*SignatureProvider, error) {
+	if normalization != HostAsSent && normalization != HostWithoutDefaultPort {
+		return nil, fmt.Errorf("unsupported host normalization %d", normalization)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.signer.(externalRequestSigner); ok {
+		return nil, fmt.Errorf("the host normalization can not be set for an external signer")
+	}
+
+	p.hostNormalization = normalization
+	p.signatures = nil
+	return p.setDelegationToken(p.delegationToken)
+}
+
+// SetAlgorithmIdentifier sets the name of the algorithm written into the
+// algorithm field of the Authorization header, for gateways that expect
+// another name than the one of the signing algorithm, such as "hs2019".
+// Passing an empty string restores the name of the signing algorithm. It does
+// not change how requests are signed, see SetSigningAlgorithm.
+//
+// An error is returned if the name contains a double quote, or if the provider
+// uses an external signer, see NewSignatureProviderWithAuthorizationStringProvider.
+func (p *SignatureProvider) SetAlgorithmIdentifier(identifier string) (*SignatureProvider, error) {
+	if strings.ContainsAny(identifier, "\"\r\n") {
+		return nil, fmt.Errorf("invalid algorithm identifier %q", identifier)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.signer.(externalRequestSigner); ok {
+		return nil, fmt.Errorf("the algorithm identifier can not be set for an external signer")
+	}
+
+	p.algorithmIdentifier = identifier
+	p.signatures = nil
+	return p.setDelegationToken(p.delegationToken)
+}
+
+// SetSigningTrace sets the logger of the signing traces of the provider,
+// which are logged at Debug level to help diagnose requests rejected because
+// of a signature mismatch. Each trace includes the string to sign, the signed
+// headers and the key id of a request, so that they can be compared with the
+// ones the service expects. The signatures, the private keys and the security
+// tokens are never logged. Passing nil disables the traces.
+//
+// A signature is reused by the requests signed within its expiry interval,
+// which only log the date of the signature they reuse.
+//
+// An error is returned if the provider uses an external signer, see
+// NewSignatureProviderWithAuthorizationStringProvider.
+func (p *SignatureProvider) SetSigningTrace(trace *logger.Logger) (*SignatureProvider, error) {
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.signer.(externalRequestSigner); ok {
+		return nil, fmt.Errorf("signing traces can not be enabled for an external signer")
+	}
+
+	p.trace = trace
+	p.signatures = nil
+	return p.setDelegationToken(p.delegationToken)
+}
+
+// RotateKey replaces the configuration provider of the key that signs
+// requests with the provider of a new key, such as when an API key is rotated.
+//
+// The previous key continues to sign requests during the grace period, as IAM
+// may take some time to accept a newly uploaded key, and the new key signs
+// requests afterwards. The previous key must therefore remain valid until the
+// grace period ends. If grace is not positive, the new key is used at once.
+//
+// An error is returned if the provider uses an external signer, see
+// NewSignatureProviderWithAuthorizationStringProvider.
+func (p *SignatureProvider) RotateKey(configProvider ConfigurationProvider, grace time.Duration) (*SignatureProvider, error) {
+	if configProvider == nil {
+		return nil, fmt.Errorf("the configuration provider of the new key must be non-nil")
+	}
+	if _, ok := p.signer.(externalRequestSigner); ok {
+		return nil, fmt.Errorf("the key can not be rotated for an external signer")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.previousConfigProvider = nil
+	if grace > 0 {
+		p.previousConfigProvider = p.configProvider
+		p.previousKeyUntil = p.now().Add(grace)
+	}
+	p.configProvider = configProvider
+	setAuthHooks(configProvider, p.hooks)
+	p.signatures = nil
+	return p.setDelegationToken(p.delegationToken)
+}
+
+// WatchKeyFile configures the provider to read the private key again when the
+// private key file changes, such as when the key is rotated on disk by a
+// secrets agent, so that the new key is used without restarting the
+// application. The fingerprint of the new key, which is part of the key id of
+// requests, is computed from the key.
+//
+// If keyFile is empty, the file specified by the "key_file" field of the OCI
+// configuration file of the provider is watched, which requires the provider
+// to be created from a configuration file. The modification time and size of
+// the file are checked at most once per checkInterval, when a request is
+// signed. If checkInterval is not positive, the file is checked at most once
+// per 10 seconds. If the file cannot be read or does not contain a valid key,
+// such as while it is being replaced, the key read previously is used.
+//
+// The file is no longer watched after the key is rotated with RotateKey.
+//
+// An error is returned if the file cannot be read, or if the provider uses an
+// external signer, see NewSignatureProviderWithAuthorizationStringProvider.
+func (p *SignatureProvider) WatchKeyFile(keyFile string, checkInterval time.Duration) (*SignatureProvider, error) {
+	if _, ok := p.signer.(externalRequestSigner); ok {
+		return nil, fmt.Errorf("the key file can not be watched for an external signer")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	configProvider := p.configProvider
+	if w, ok := configProvider.(*keyFileWatcher); ok {
+		configProvider = w.ConfigurationProvider
+	}
+	w, err := newKeyFileWatcher(configProvider, keyFile, checkInterval)
+	if err != nil {
+		return nil, err
+	}
+	w.setAuthHooks(p.hooks)
+	p.configProvider = w
+	p.signatures = nil
+	return p.setDelegationToken(p.delegationToken)
+}
+
+// signerAt returns the signer of the requests signed at now, which is the
+// signer of the previous key during the grace period of a key rotation.
+func (p *SignatureProvider) signerAt(now time.Time) HTTPRequestSigner {
+	if p.usesPreviousKey(now) {
+		return p.previousSigner
+	}
+	return p.signer
+}
+
+// usesPreviousKey reports whether now is in the grace period of a key
+// rotation, during which the previous key signs requests.
+func (p *SignatureProvider) usesPreviousKey(now time.Time) bool {
+	return p.previousSigner != nil && now.Before(p.previousKeyUntil)
+}
+
+// configProviderAt returns the configuration provider of the key that signs
+// requests at now, see signerAt.
+func (p *SignatureProvider) configProviderAt(now time.Time) ConfigurationProvider {
+	if p.usesPreviousKey(now) {
+		return p.previousConfigProvider
+	}
+	return p.configProvider
+}
+
+// SetAuthHooks sets the hooks called on events of the lifecycle of the
+// credentials of the provider, such as the renewal of the security token of
+// an instance or resource principal. Passing nil removes the hooks.
+//
+// It should be called before the provider is used by a client.
+func (p *SignatureProvider) SetAuthHooks(hooks *AuthHooks) *SignatureProvider {
+	p.mutex.Lock()
+	p.hooks = hooks
+	p.mutex.Unlock()
+	setAuthHooks(p.configProvider, hooks)
+	return p
+}
+
+// SetClock sets the function that returns the current time used for the Date
+// header of signed requests, so that tests can produce reproducible
+// signatures. Passing nil restores the system clock.
+func (p *SignatureProvider) SetClock(now func() time.Time) *SignatureProvider {
+	p.mutex.Lock()
+	p.clock = now
+	p.signatures = nil
+	p.mutex.Unlock()
+	return p
+}
+
+// clockSkewThreshold is the smallest difference between the clock of the
+// server and the local clock that is corrected. The Date header has a
+// resolution of one second, and responses take time to arrive, so that smaller
+// differences can not be measured.
+const clockSkewThreshold = 5 * time.Second
+
+// SetClockSkewCorrection enables or disables the correction of the date of
+// signatures by the difference between the clock of the server and the local
+// clock, so that requests are not rejected when the local clock drifts.
+//
+// When the correction is enabled, the difference is computed from the Date
+// header of the responses passed to ObserveServerDate, which the Client calls
+// for each response. Differences smaller than 5 seconds are not corrected.
+func (p *SignatureProvider) SetClockSkewCorrection(enabled bool) *SignatureProvider {
+	p.mutex.Lock()
+	p.clockSkewCorrection = enabled
+	if !enabled {
+		atomic.StoreInt64(&p.clockSkew, 0)
+	}
+	p.signatures = nil
+	p.mutex.Unlock()
+	return p
+}
+
+// ObserveServerDate records the date of a response of the server, and updates
+// the clock skew if clock skew correction is enabled and the skew has
+// changed, see SetClockSkewCorrection.
+func (p *SignatureProvider) ObserveServerDate(serverDate time.Time) {
+	if serverDate.IsZero() {
+		return
+	}
+	p.mutex.RLock()
+	enabled := p.clockSkewCorrection
+	p.mutex.RUnlock()
+	if !enabled {
+		return
+	}
+
+	// The Date header is truncated to the second.
+	skew := serverDate.Add(time.Second / 2).Sub(time.Now())
+	if skew > -clockSkewThreshold && skew < clockSkewThreshold {
+		skew = 0
+	}
+	previous := time.Duration(atomic.LoadInt64(&p.clockSkew))
+	if diff := skew - previous; diff > -clockSkewThreshold && diff < clockSkewThreshold {
+		return
+	}
+
+	p.mutex.Lock()
+	atomic.StoreInt64(&p.clockSkew, int64(skew))
+	p.signatures = nil
+	p.mutex.Unlock()
+}
+
+// ClockSkew returns the difference between the clock of the server and the
+// local clock that is used to correct the date of signatures. It is zero if
+// clock skew correction is not enabled.
+func (p *SignatureProvider) ClockSkew() time.Duration {
+	return time.Duration(atomic.LoadInt64(&p.clockSkew))
+}
+
+// now returns the time of the clock of the provider.
+func (p *SignatureProvider) now() time.Time {
+	if p.clock != nil {
+		return p.clock()
+	}
+	return time.Now()
+}
+
+// signatureDate returns the value of the Date header of a request signed at
+// now, corrected by the clock skew.
+func (p *SignatureProvider) signatureDate(now time.Time) string {
+	return now.Add(p.ClockSkew()).UTC().Format(http.TimeFormat)
+}
+
+// withSignerOptions returns a copy of the signer that uses the signing
+// algorithm, the algorithm identifier, the key id tenancy and the host
+// normalization of the provider.
+func (p *SignatureProvider) withSignerOptions(signer HTTPRequestSigner) HTTPRequestSigner {
+	if s, ok := signer.(ociRequestSigner); ok {
+		s.Algorithm = p.algorithm
+		s.KeyIDTenancy = p.keyIDTenancy
+		s.HostNormalization = p.hostNormalization
+		s.AlgorithmIdentifier = p.algorithmIdentifier
+		s.Trace = p.trace
+		return s
+	}
+	return signer
+}
+
 // SetDelegationTokenFromFile is used to set a delegation token for the signature provider based
 // on the string contents of a file.
 // The file must have the token istelf and nothing else.
+//
+// The file is read again when it changes, so that a token renewed by another
+// process is used without restarting the application, see FileDelegationTokenProvider.
 func (p *SignatureProvider) SetDelegationTokenFromFile(delegationTokenFile string) (*SignatureProvider, error) {
-	file, ok := fileExists(delegationTokenFile)
-	if !ok {
-		return nil, fmt.Errorf("delegation token file \"%s\" does not exist", delegationTokenFile)
+	provider, err := NewFileDelegationTokenProvider(delegationTokenFile, 0)
+	if err != nil {
+		return nil, err
+	}
+	return p.SetDelegationTokenProvider(provider)
+}
+
+// SetDelegationTokenProvider sets the provider of the delegation token of the
+// signature provider. The token is obtained from the provider for each
+// request, and the signature is generated again when the token changes.
+func (p *SignatureProvider) SetDelegationTokenProvider(provider DelegationTokenProvider) (*SignatureProvider, error) {
+	token, err := provider.DelegationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, err = p.setDelegationToken(token); err != nil {
+		return nil, err
 	}
-	tokenData, err := os.ReadFile(file)
+	p.delegationTokenProvider = provider
+	p.signatures = nil
+	return p, nil
+}
+
+// reloadDelegationToken gets the delegation token from the delegation token
+// provider, if any, and updates the signer if the token has changed.
+func (p *SignatureProvider) reloadDelegationToken() error {
+	p.mutex.RLock()
+	provider := p.delegationTokenProvider
+	p.mutex.RUnlock()
+	if provider == nil {
+		return nil
+	}
+
+	token, err := provider.DelegationToken()
 	if err != nil {
-		return nil, fmt.Errorf("cannot read delegation token file %s: %v", file, err)
+		return fmt.Errorf("cannot get delegation token: %v", err)
 	}
-	tokenLines := strings.Split(string(tokenData), "\n")
-	return p.SetDelegationToken(tokenLines[0])
+
+	p.mutex.RLock()
+	changed := token != p.delegationToken
+	p.mutex.RUnlock()
+	if !changed {
+		return nil
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, err = p.setDelegationToken(token); err != nil {
+		return err
+	}
+	p.signatures = nil
+	return nil
 }
 
 // AuthorizationString isn't used for IAM; instead, each individual request is
@@ -422,64 +1089,195 @@ func (p *SignatureProvider) AuthorizationString(req auth.Request) (auth string,
 //
 //	Signature version=n,headers=<>,keyId=<>,algorithm="rsa-sha256",signature="..."
 //
-// This method uses the cached signature if it was generated within the expiry time
-// specified in signatureExpiry. Else it gets the current date/time and uses that to
-// generate a new signature.
+// This method uses the cached signature of the host of the request if it was
+// generated within the expiry time specified in signatureExpiry. Else it gets
+// the current date/time and uses that to generate a new signature.
+//
+// The deadline and cancellation of the context of req are honored while a
+// security token is renewed, see SignHTTPRequestContext.
 func (p *SignatureProvider) SignHTTPRequest(req *http.Request) error {
+	return p.SignHTTPRequestContext(req.Context(), req)
+}
+
+// SignHTTPRequestContext is like SignHTTPRequest, but stops waiting for the
+// renewal of a security token, such as the token of an instance or resource
+// principal, when ctx is done, so that a hung federation endpoint does not
+// block the request beyond its timeout. The renewal continues, and its token
+// is used by the next requests.
+func (p *SignatureProvider) SignHTTPRequestContext(ctx context.Context, req *http.Request) error {
 
 	// no matter what, we set the compartmentID in the header
 	req.Header.Set(requestHeaderXNoSQLCompartmentID, p.compartmentID)
 
-	// if used, set the delegation token
-	if p.delegationToken != "" {
-		req.Header.Set(requestHeaderDelegationToken, p.delegationToken)
+	// if used, reload the delegation token, which is set in the header
+	// along with the signature that uses it
+	if err := p.reloadDelegationToken(); err != nil {
+		return err
 	}
 
-	now := time.Now()
+	now := p.now()
 
 	mustHashBody := req.Header.Get("X-Nosql-Hash-Body") == "true"
-	if mustHashBody {
-		// If hashing body, skip all caching below
-		signatureFormattedDate := now.UTC().Format(http.TimeFormat)
+	if mustHashBody || isUpgradeRequest(req) {
+		// If hashing body, skip all caching below. The cached signature is
+		// also not used for upgrade requests, whose targets differ from those
+		// of the requests of the client.
+		signatureFormattedDate := p.signatureDate(now)
 		req.Header.Set(requestHeaderDate, signatureFormattedDate)
-		return p.signer.Sign(req)
+		p.mutex.RLock()
+		p.setProviderHeaders(req)
+		signer := p.signerAt(now)
+		p.mutex.RUnlock()
+		start := time.Now()
+		err := signContext(ctx, signer, req)
+		p.hooks.signatureComputed(start, err)
+		return err
 	}
 
-	// use cached signature and date, if not expired and not including body hash
-	if p.signature != "" && p.signatureExpiresAt.After(now) {
-		p.mutex.RLock()
+	// use the cached signature of the host and its date, if not expired and
+	// not including body hash, as the host is part of the signing string
+	host := requestHost(req)
+	p.mutex.RLock()
+	if s, ok := p.signatures[host]; ok && s.expiresAt.After(now) {
 		defer p.mutex.RUnlock()
-		req.Header.Set(requestHeaderDate, p.signatureFormattedDate)
-		req.Header.Set(requestHeaderAuthorization, p.signature)
+		p.setProviderHeaders(req)
+		req.Header.Set(requestHeaderDate, s.formattedDate)
+		req.Header.Set(requestHeaderAuthorization, s.authorization)
+		p.trace.Debug("Reusing the signature of %s", s.formattedDate)
 		return nil
 	}
+	p.mutex.RUnlock()
 
 	// calculate new signature
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
-	signatureFormattedDate := now.UTC().Format(http.TimeFormat)
+	p.setProviderHeaders(req)
+	signatureFormattedDate := p.signatureDate(now)
 	req.Header.Set(requestHeaderDate, signatureFormattedDate)
-	err := p.signer.Sign(req)
+	signer := p.signerAt(now)
+	start := time.Now()
+	err := signContext(ctx, signer, req)
+	p.hooks.signatureComputed(start, err)
 	if err != nil {
 		return err
 	}
 
-	p.signatureFormattedDate = signatureFormattedDate
-	p.signature = req.Header.Get(requestHeaderAuthorization)
-	p.signatureExpiresAt = now.Add(p.expiryInterval)
+	s := cachedSignature{
+		authorization: req.Header.Get(requestHeaderAuthorization),
+		formattedDate: signatureFormattedDate,
+		expiresAt:     now.Add(p.expiryInterval),
+	}
 
 	// need to use min(expiryInterval, tokenExpiration)
-	exp := p.signer.ExpirationTime()
-	if p.signatureExpiresAt.After(exp) {
-		p.signatureExpiresAt = exp
+	exp := signer.ExpirationTime()
+	if s.expiresAt.After(exp) {
+		s.expiresAt = exp
+	}
+	// the signature of the previous key expires with its grace period
+	if p.usesPreviousKey(now) && s.expiresAt.After(p.previousKeyUntil) {
+		s.expiresAt = p.previousKeyUntil
 	}
 
+	if p.signatures == nil {
+		p.signatures = make(map[string]cachedSignature)
+	}
+	for h, c := range p.signatures {
+		if !c.expiresAt.After(now) {
+			delete(p.signatures, h)
+		}
+	}
+	p.signatures[host] = s
+
 	return nil
 }
 
+// setProviderHeaders sets the delegation token and cross-tenancy headers of
+// req, if the provider uses them. It must be called with p.mutex held, so
+// that the headers match the configuration used by the signer.
+func (p *SignatureProvider) setProviderHeaders(req *http.Request) {
+	if p.delegationToken != "" {
+		req.Header.Set(requestHeaderDelegationToken, p.delegationToken)
+	}
+	if p.crossTenancies != "" {
+		req.Header.Set(requestHeaderCrossTenancyRequest, p.crossTenancies)
+	}
+}
+
+// RequestSigner returns a signer that signs requests to any OCI service with
+// the credentials, delegation token, signing algorithm and cross-tenancy
+// configuration of the provider.
+//
+// Unlike SignHTTPRequest, the signer hashes the body of POST, PUT and PATCH
+// requests as OCI services require, does not set the NoSQL compartment header,
+// and does not reuse signatures, which are only valid for the target of the
+// signed request. The signer does not follow later changes of the delegation
+// token of the provider, see SignRequest.
+func (p *SignatureProvider) RequestSigner() HTTPRequestSigner {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if _, ok := p.signer.(externalRequestSigner); ok {
+		return p.signer
+	}
+	configProvider := p.configProviderAt(p.now())
+	if p.delegationToken != "" {
+		return delegationRequestSigner{
+			HTTPRequestSigner: p.withSignerOptions(DelegationRequestSigner(configProvider)),
+			delegationToken:   p.delegationToken,
+		}
+	}
+	return p.withSignerOptions(DefaultRequestSigner(configProvider))
+}
+
+// delegationRequestSigner is an HTTPRequestSigner that sets the delegation
+// token header of the requests it signs.
+type delegationRequestSigner struct {
+	HTTPRequestSigner
+	delegationToken string
+}
+
+func (s delegationRequestSigner) Sign(r *http.Request) error {
+	return s.SignContext(r.Context(), r)
+}
+
+func (s delegationRequestSigner) SignContext(ctx context.Context, r *http.Request) error {
+	r.Header.Set(requestHeaderDelegationToken, s.delegationToken)
+	return signContext(ctx, s.HTTPRequestSigner, r)
+}
+
+// SignRequest signs a request to any OCI service with the credentials of the
+// provider, which can be the provider given to the NoSQL client, so that
+// applications can call other OCI REST APIs without another SDK. The Date
+// header is set if the request does not have one.
+//
+// See SignatureProvider.RequestSigner for the differences with the signatures
+// of the requests of the NoSQL client. The delegation token of the provider
+// is reloaded first if it is read from a file or a DelegationTokenProvider.
+func SignRequest(provider *SignatureProvider, request *http.Request) error {
+	if provider == nil || request == nil {
+		return fmt.Errorf("can not sign the request, provider and request must be non-nil")
+	}
+	if err := provider.reloadDelegationToken(); err != nil {
+		return err
+	}
+	if request.Header == nil {
+		request.Header = make(http.Header)
+	}
+	if request.Header.Get(requestHeaderDate) == "" {
+		request.Header.Set(requestHeaderDate, provider.signatureDate(provider.now()))
+	}
+	start := time.Now()
+	err := signContext(request.Context(), provider.RequestSigner(), request)
+	provider.hooks.signatureComputed(start, err)
+	return err
+}
+
 // Close releases resources allocated by the provider and sets closed state for the provider.
-// Currently nothing to release
+// It stops the background renewals of security tokens, if the configuration
+// provider performs them, see FederationClientOptions.RefreshAhead.
 func (p *SignatureProvider) Close() error {
+	if c, ok := p.configProvider.(io.Closer); ok {
+		return c.Close()
+	}
 	return nil
 }
 
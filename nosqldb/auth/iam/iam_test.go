@@ -260,6 +260,35 @@ func (suite *iamTestSuite) TestNewRawSignatureProvider() {
 	}
 }
 
+func (suite *iamTestSuite) TestPrincipalType() {
+	passphrase := ""
+	p, err := NewRawSignatureProvider(testTenancyOCID, testUserOCID, testRegion, testFingerprint,
+		"", testPrivateKeyConf, &passphrase)
+	suite.Require().NoErrorf(err, "NewRawSignatureProvider() got error: %v", err)
+	suite.Equalf(testTenancyOCID, p.CompartmentID(), "CompartmentID() should default to the tenancy")
+	suite.Equalf("user principal", p.PrincipalType(), "unexpected PrincipalType()")
+	suite.Falsef(p.UsesInstancePrincipal(), "UsesInstancePrincipal() should be false for user principal")
+
+	p, err = NewSignatureProviderWithAuthorizationStringProvider(AuthorizationStringProviderFunc(
+		func(req *http.Request) (string, error) { return "Signature version=\"1\"", nil }), "myCompartment")
+	suite.Require().NoErrorf(err, "NewSignatureProviderWithAuthorizationStringProvider() got error: %v", err)
+	suite.Equalf("myCompartment", p.CompartmentID(), "unexpected CompartmentID()")
+	suite.Equalf("external signer", p.PrincipalType(), "unexpected PrincipalType()")
+
+	p.delegationToken = "a.b.c"
+	suite.Equalf("external signer with delegation token", p.PrincipalType(), "unexpected PrincipalType()")
+
+	p = &SignatureProvider{configProvider: &instancePrincipalConfigurationProvider{}}
+	suite.Equalf("instance principal", p.PrincipalType(), "unexpected PrincipalType()")
+	suite.Truef(p.UsesInstancePrincipal(), "UsesInstancePrincipal() should be true for instance principal")
+
+	p = &SignatureProvider{configProvider: &resourcePrincipalKeyProvider{}}
+	suite.Equalf("resource principal", p.PrincipalType(), "unexpected PrincipalType()")
+
+	p = &SignatureProvider{configProvider: &okeWorkloadIdentityConfigurationProvider{}}
+	suite.Equalf("OKE workload identity", p.PrincipalType(), "unexpected PrincipalType()")
+}
+
 func (suite *iamTestSuite) TestFileExists() {
 	tests := []struct {
 		shortDesc string
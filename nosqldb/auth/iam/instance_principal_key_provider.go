@@ -5,6 +5,7 @@ package iam
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"fmt"
 	"net/http"
@@ -26,12 +27,43 @@ const (
 	leafCertificateKeyPassphrase         = `` // No passphrase for the private key for Compute instances
 	intermediateCertificateKeyURL        = ``
 	intermediateCertificateKeyPassphrase = `` // No passphrase for the private key for Compute instances
+
+	// environment variable that overrides the base URL of the instance
+	// metadata service, for use behind a metadata proxy or in emulated
+	// environments
+	metadataBaseURLEnvVar = "OCI_METADATA_BASE_URL"
 )
 
 var (
 	regionURL, leafCertificateURL, leafCertificateKeyURL, intermediateCertificateURL string
 )
 
+// InstancePrincipalOptions represents options for an instance principal
+// signature provider.
+type InstancePrincipalOptions struct {
+	// MetadataBaseURL specifies the base URL of the instance metadata service
+	// from which the region and the instance certificates are retrieved, such
+	// as "http://169.254.169.254/opc/v2".
+	// If not set, the value of the OCI_METADATA_BASE_URL environment variable
+	// is used, or http://169.254.169.254/opc/v2 if that is not set either.
+	MetadataBaseURL string
+
+	// HTTPClient specifies the HTTP client used for requests to the instance
+	// metadata service. It is optional. If not set, a client with a timeout of
+	// 60 seconds is used.
+	HTTPClient *http.Client
+
+	// FederationClient specifies the retries and timeout of the requests to
+	// the federation endpoint of the Auth service that get security tokens.
+	FederationClient FederationClientOptions
+
+	// CertificateRefresh specifies when the instance certificates are
+	// refreshed in the background. If not set, they are refreshed when the
+	// security token is renewed. The background refreshes stop when the
+	// signature provider is closed.
+	CertificateRefresh CertificateRefreshOptions
+}
+
 // instancePrincipalKeyProvider implements KeyProvider to provide a key ID and
 // its corresponding private key for an instance principal by getting a security
 // token via x509FederationClient.
@@ -55,11 +87,23 @@ type instancePrincipalKeyProvider struct {
 // Thus, even if a client obtains a KeyID that is not expired at the moment,
 // the PrivateRSAKey that the client acquires at a next moment could be
 // invalid because the KeyID could be already expired.
-func newInstancePrincipalKeyProvider() (provider *instancePrincipalKeyProvider, err error) {
+func newInstancePrincipalKeyProvider(options InstancePrincipalOptions) (provider *instancePrincipalKeyProvider, err error) {
+
+	baseURL := options.MetadataBaseURL
+	if baseURL == "" {
+		if url := requireEnv(metadataBaseURLEnvVar); url != nil {
+			baseURL = *url
+		} else {
+			baseURL = metadataBaseURL
+		}
+	}
+	updateX509CertRetrieverURLParas(strings.TrimSuffix(baseURL, "/"))
 
-	updateX509CertRetrieverURLParas(metadataBaseURL)
-	client := &http.Client{
-		Timeout: 60 * time.Second,
+	client := options.HTTPClient
+	if client == nil {
+		client = &http.Client{
+			Timeout: 60 * time.Second,
+		}
 	}
 
 	var region common.Region
@@ -82,8 +126,20 @@ func newInstancePrincipalKeyProvider() (provider *instancePrincipalKeyProvider,
 	}
 	tenancyID := extractTenancyIDFromCertificate(leafCertificateRetriever.Certificate())
 
-	federationClient, err := newX509FederationClient(region, tenancyID, leafCertificateRetriever, intermediateCertificateRetrievers)
+	for _, r := range append([]x509CertificateRetriever{leafCertificateRetriever}, intermediateCertificateRetrievers...) {
+		if u, ok := r.(*urlBasedX509CertificateRetriever); ok {
+			u.startBackgroundRefresh(options.CertificateRefresh)
+		}
+	}
+
+	federationClient, err := newX509FederationClient(region, tenancyID, leafCertificateRetriever, intermediateCertificateRetrievers,
+		options.FederationClient)
 	if err != nil {
+		for _, r := range append([]x509CertificateRetriever{leafCertificateRetriever}, intermediateCertificateRetrievers...) {
+			if u, ok := r.(*urlBasedX509CertificateRetriever); ok {
+				u.Stop()
+			}
+		}
 		err = fmt.Errorf("failed to create federation client: %s", err.Error())
 		return nil, err
 	}
@@ -140,10 +196,30 @@ func (p *instancePrincipalKeyProvider) KeyID() (string, error) {
 	return fmt.Sprintf("ST$%s", securityToken), nil
 }
 
+// KeyIDContext is like KeyID, but stops waiting for the renewal of the
+// security token when ctx is done.
+func (p *instancePrincipalKeyProvider) KeyIDContext(ctx context.Context) (string, error) {
+	var securityToken string
+	var err error
+	if securityToken, err = p.FederationClient.SecurityTokenContext(ctx); err != nil {
+		return "", fmt.Errorf("failed to get security token: %s", err.Error())
+	}
+	return fmt.Sprintf("ST$%s", securityToken), nil
+}
+
 func (p *instancePrincipalKeyProvider) ExpirationTime() time.Time {
 	return p.FederationClient.ExpirationTime()
 }
 
+// Close stops the background renewals of the security token.
+func (p *instancePrincipalKeyProvider) Close() error {
+	return closeFederationClient(p.FederationClient)
+}
+
+func (p *instancePrincipalKeyProvider) setAuthHooks(hooks *AuthHooks) {
+	setAuthHooks(p.FederationClient, hooks)
+}
+
 func (p *instancePrincipalKeyProvider) TenancyOCID() (string, error) {
 	return p.TenancyID, nil
 }
@@ -153,10 +229,10 @@ type instancePrincipalConfigurationProvider struct {
 	region      *common.Region
 }
 
-func newInstancePrincipalConfigurationProvider() (ConfigurationProvider, error) {
+func newInstancePrincipalConfigurationProvider(options InstancePrincipalOptions) (ConfigurationProvider, error) {
 	var err error
 	var keyProvider *instancePrincipalKeyProvider
-	if keyProvider, err = newInstancePrincipalKeyProvider(); err != nil {
+	if keyProvider, err = newInstancePrincipalKeyProvider(options); err != nil {
 		return nil, fmt.Errorf("failed to create a new key provider for instance principal: %s", err.Error())
 	}
 
@@ -174,6 +250,10 @@ func (p *instancePrincipalConfigurationProvider) KeyID() (string, error) {
 	return p.keyProvider.KeyID()
 }
 
+func (p *instancePrincipalConfigurationProvider) KeyIDContext(ctx context.Context) (string, error) {
+	return p.keyProvider.KeyIDContext(ctx)
+}
+
 func (p *instancePrincipalConfigurationProvider) ExpirationTime() time.Time {
 	return p.keyProvider.ExpirationTime()
 }
@@ -194,6 +274,15 @@ func (p *instancePrincipalConfigurationProvider) KeyFingerprint() (string, error
 	return "", nil
 }
 
+// Close stops the background renewals of the security token.
+func (p *instancePrincipalConfigurationProvider) Close() error {
+	return p.keyProvider.Close()
+}
+
+func (p *instancePrincipalConfigurationProvider) setAuthHooks(hooks *AuthHooks) {
+	p.keyProvider.setAuthHooks(hooks)
+}
+
 func (p *instancePrincipalConfigurationProvider) Region() (string, error) {
 	if p.region == nil {
 		region := p.keyProvider.RegionForFederationClient()
@@ -4,6 +4,7 @@
 package iam
 
 import (
+	"context"
 	"crypto/rsa"
 	"fmt"
 	"net/http"
@@ -94,6 +95,20 @@ func TestInstancePrincipalKeyProvider_KeyID(t *testing.T) {
 	assert.Equal(t, "ST$TestSecurityTokenString", actualKeyID)
 }
 
+func TestInstancePrincipalKeyProvider_KeyIDContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockFederationClient := new(mockFederationClient)
+	mockFederationClient.On("SecurityTokenContext", ctx).Return("", context.Canceled).Once()
+
+	keyProvider := &instancePrincipalKeyProvider{FederationClient: mockFederationClient}
+
+	_, err := keyProvider.KeyIDContext(ctx)
+
+	assert.EqualError(t, err, "failed to get security token: context canceled")
+	mockFederationClient.AssertExpectations(t)
+}
+
 func TestInstancePrincipalKeyProvider_KeyIDError(t *testing.T) {
 	mockFederationClient := new(mockFederationClient)
 	expectedErrorMessage := "TestSecurityTokenError"
@@ -108,6 +123,66 @@ func TestInstancePrincipalKeyProvider_KeyIDError(t *testing.T) {
 	mockFederationClient.AssertExpectations(t)
 }
 
+// countingTransport counts the requests sent through it.
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newMetadataServer(t *testing.T, basePath string) *httptest.Server {
+	keyPem, certPem := generateRandomCertificate()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case basePath + regionPath:
+			fmt.Fprint(w, "phx")
+		case basePath + leafCertificatePath, basePath + intermediateCertificatePath:
+			w.Write(certPem)
+		case basePath + leafCertificateKeyPath:
+			w.Write(keyPem)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestInstancePrincipalKeyProvider_MetadataBaseURL(t *testing.T) {
+	server := newMetadataServer(t, "/proxy/opc/v2")
+	defer server.Close()
+	defer updateX509CertRetrieverURLParas(metadataBaseURL)
+
+	transport := &countingTransport{}
+	keyProvider, err := newInstancePrincipalKeyProvider(InstancePrincipalOptions{
+		MetadataBaseURL: server.URL + "/proxy/opc/v2/",
+		HTTPClient:      &http.Client{Transport: transport},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, common.RegionUsPhoenix1, keyProvider.RegionForFederationClient())
+		assert.Equal(t, 3, transport.requests, "requests should be sent with the specified HTTP client")
+	}
+}
+
+func TestInstancePrincipalKeyProvider_MetadataBaseURLEnvVar(t *testing.T) {
+	server := newMetadataServer(t, "/opc/v2")
+	defer server.Close()
+	defer updateX509CertRetrieverURLParas(metadataBaseURL)
+
+	t.Setenv(metadataBaseURLEnvVar, server.URL+"/opc/v2")
+	keyProvider, err := newInstancePrincipalKeyProvider(InstancePrincipalOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, common.RegionUsPhoenix1, keyProvider.RegionForFederationClient())
+	}
+
+	// The option takes precedence over the environment variable.
+	t.Setenv(metadataBaseURLEnvVar, "http://127.0.0.1:0/opc/v2")
+	_, err = newInstancePrincipalKeyProvider(InstancePrincipalOptions{MetadataBaseURL: server.URL + "/opc/v2"})
+	assert.NoError(t, err)
+}
+
 type mockFederationClient struct {
 	mock.Mock
 }
@@ -122,6 +197,11 @@ func (m *mockFederationClient) SecurityToken() (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockFederationClient) SecurityTokenContext(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
 func (m *mockFederationClient) ExpirationTime() time.Time {
 	// TODO: more expiry tests
 	return time.Now().Add(24 * time.Hour)
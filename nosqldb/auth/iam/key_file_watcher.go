@@ -0,0 +1,215 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+// This software is dual-licensed to you under the Universal Permissive License (UPL) 1.0 as shown at https://oss.oracle.com/licenses/upl or Apache License 2.0 as shown at http://www.apache.org/licenses/LICENSE-2.0. You may choose either license.
+
+package iam
+
+import (
+	"crypto"
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultKeyFileCheckInterval is the default interval at which a watched
+// private key file is checked for changes.
+const defaultKeyFileCheckInterval = 10 * time.Second
+
+// keyFileWatcher is a ConfigurationProvider that reads the private key of
+// another ConfigurationProvider from a file, and reads it again when the file
+// changes. See SignatureProvider.WatchKeyFile.
+type keyFileWatcher struct {
+	ConfigurationProvider
+	file          string
+	passphrase    *string
+	checkInterval time.Duration
+	hooks         *AuthHooks
+
+	mux         sync.Mutex
+	key         crypto.Signer
+	fingerprint string
+	modTime     time.Time
+	size        int64
+	lastCheck   time.Time
+}
+
+// newKeyFileWatcher creates a keyFileWatcher for the private key of
+// configProvider. If keyFile is empty, the key file specified in the OCI
+// configuration file of configProvider is watched.
+func newKeyFileWatcher(configProvider ConfigurationProvider, keyFile string, checkInterval time.Duration) (*keyFileWatcher, error) {
+	var passphrase *string
+	switch p := configProvider.(type) {
+	case fileConfigurationProvider:
+		file, password, err := p.keyFile()
+		if err != nil {
+			return nil, err
+		}
+		if keyFile == "" {
+			keyFile = file
+		}
+		passphrase = &password
+	case rawConfigurationProvider:
+		passphrase = p.privateKeyPassphrase
+	}
+	if keyFile == "" {
+		return nil, fmt.Errorf("the private key file must be specified for %T", configProvider)
+	}
+
+	file, ok := fileExists(keyFile)
+	if !ok {
+		return nil, fmt.Errorf("private key file \"%s\" does not exist", keyFile)
+	}
+	if checkInterval <= 0 {
+		checkInterval = defaultKeyFileCheckInterval
+	}
+
+	fingerprint, err := configProvider.KeyFingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &keyFileWatcher{
+		ConfigurationProvider: configProvider,
+		file:                  file,
+		passphrase:            passphrase,
+		checkInterval:         checkInterval,
+		fingerprint:           fingerprint,
+	}
+	if err := w.reload(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// KeyFingerprint returns the fingerprint of the private key, which is
+// computed from the key after it is read again.
+func (w *keyFileWatcher) KeyFingerprint() (string, error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.check()
+	return w.fingerprint, nil
+}
+
+// KeyID returns the key id of the provider, with the fingerprint of the
+// private key. The key ids of security tokens are returned unchanged.
+func (w *keyFileWatcher) KeyID() (string, error) {
+	keyID, err := w.ConfigurationProvider.KeyID()
+	if err != nil {
+		return "", err
+	}
+	parts := strings.SplitN(keyID, "/", 3)
+	if len(parts) != 3 || strings.HasPrefix(keyID, "ST$") {
+		return keyID, nil
+	}
+
+	fingerprint, err := w.KeyFingerprint()
+	if err != nil {
+		return "", err
+	}
+	return parts[0] + "/" + parts[1] + "/" + fingerprint, nil
+}
+
+// PrivateRSAKey returns the private key if it is an RSA key.
+func (w *keyFileWatcher) PrivateRSAKey() (*rsa.PrivateKey, error) {
+	key, err := w.PrivateKeySigner()
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key file %s does not contain an RSA key", w.file)
+	}
+	return rsaKey, nil
+}
+
+// PrivateKeySigner returns the private key read from the file, reading the
+// file again if it has changed since it was last read.
+func (w *keyFileWatcher) PrivateKeySigner() (crypto.Signer, error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.check()
+	return w.key, nil
+}
+
+// ExpirationTime returns the time of the next check of the file, so that the
+// signatures computed with the key are not reused after the key changes.
+func (w *keyFileWatcher) ExpirationTime() time.Time {
+	w.mux.Lock()
+	next := w.lastCheck.Add(w.checkInterval)
+	w.mux.Unlock()
+	if exp := w.ConfigurationProvider.ExpirationTime(); exp.Before(next) {
+		return exp
+	}
+	return next
+}
+
+func (w *keyFileWatcher) setAuthHooks(hooks *AuthHooks) {
+	w.mux.Lock()
+	w.hooks = hooks
+	w.mux.Unlock()
+	setAuthHooks(w.ConfigurationProvider, hooks)
+}
+
+// check reads the key again if the check interval has elapsed since the file
+// was last checked. It must be called with w.mux held.
+func (w *keyFileWatcher) check() {
+	if time.Since(w.lastCheck) >= w.checkInterval {
+		// Errors are ignored, the previous key is used until the file
+		// contains a valid key again, such as while it is being replaced.
+		w.reload()
+	}
+}
+
+// reload reads the key from the file if its modification time or size has
+// changed. It must be called with w.mux held.
+func (w *keyFileWatcher) reload() error {
+	w.lastCheck = time.Now()
+	info, err := os.Stat(w.file)
+	if err != nil {
+		return fmt.Errorf("cannot read private key file %s: %v", w.file, err)
+	}
+	if w.key != nil && info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return nil
+	}
+
+	data, err := os.ReadFile(w.file)
+	if err != nil {
+		return fmt.Errorf("cannot read private key file %s: %v", w.file, err)
+	}
+	key, err := PrivateKeySignerFromBytes(data, w.passphrase)
+	if err != nil {
+		return fmt.Errorf("cannot parse private key file %s: %v", w.file, err)
+	}
+
+	if w.key == nil {
+		// The fingerprint of the first key is the configured one.
+		w.key, w.modTime, w.size = key, info.ModTime(), info.Size()
+		return nil
+	}
+
+	fingerprint, err := keyFingerprint(key)
+	if err != nil {
+		return err
+	}
+	w.key, w.modTime, w.size = key, info.ModTime(), info.Size()
+	if fingerprint != w.fingerprint {
+		w.fingerprint = fingerprint
+		w.hooks.keyReloaded(fingerprint)
+	}
+	return nil
+}
+
+// keyFingerprint returns the fingerprint of an API signing key, which is the
+// MD5 hash of the DER encoding of its public key, in colon separated hex.
+func keyFingerprint(key crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return "", fmt.Errorf("cannot compute the fingerprint of the private key: %v", err)
+	}
+	sum := md5.Sum(der)
+	return strings.Replace(fmt.Sprintf("% x", sum), " ", ":", -1), nil
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+// This software is dual-licensed to you under the Universal Permissive License (UPL) 1.0 as shown at https://oss.oracle.com/licenses/upl or Apache License 2.0 as shown at http://www.apache.org/licenses/LICENSE-2.0. You may choose either license.
+
+package iam
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+)
+
+const kmsSignPath = "/20180608/sign"
+
+// KMSKeyOptions specifies an asymmetric key of OCI Vault that is used to sign
+// requests, so that the private key never leaves the vault.
+type KMSKeyOptions struct {
+	// CryptoEndpoint specifies the cryptographic endpoint of the vault that
+	// holds the key, such as
+	// "https://<vault-id>-crypto.kms.us-ashburn-1.oraclecloud.com".
+	// It is required.
+	CryptoEndpoint string
+
+	// KeyID specifies the OCID of the key. It is required.
+	KeyID string
+
+	// KeyVersionID specifies the OCID of the key version. It is optional.
+	// If not set, the current version of the key is used.
+	KeyVersionID string
+
+	// PublicKey specifies the public key of the key version in PEM format,
+	// which must be uploaded as an API key of the user. It determines the
+	// algorithm of the signatures. RSA, ECDSA P-256 and P-384 keys are
+	// supported. It is required.
+	PublicKey []byte
+
+	// RequestKeyProvider specifies the key provider used to sign the requests
+	// to the vault, such as the ConfigurationProvider of an instance
+	// principal. It is required.
+	RequestKeyProvider KeyProvider
+
+	// HTTPClient specifies the HTTP client used for requests to the vault.
+	// It is optional. If not set, a client with a timeout of 60 seconds is
+	// used.
+	HTTPClient *http.Client
+
+	// Retry specifies the retries of the requests to the vault that fail
+	// with a transient error.
+	Retry FederationClientOptions
+}
+
+// kmsConfigurationProvider is a ConfigurationProvider whose private key is
+// held in OCI Vault.
+type kmsConfigurationProvider struct {
+	rawConfigurationProvider
+	signer *kmsSigner
+}
+
+// NewKMSConfigurationProvider creates a ConfigurationProvider for the API key
+// of a user whose private key is held in OCI Vault. Requests are signed with
+// the sign operation of the vault, as specified by options.
+//
+// The returned provider can be used with NewSignatureProviderWithConfiguration.
+// As a SignatureProvider caches signatures for several minutes, the vault is
+// not called for each request.
+func NewKMSConfigurationProvider(tenancy, user, region, fingerprint string, options KMSKeyOptions) (ConfigurationProvider, error) {
+	signer, err := newKMSSigner(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return kmsConfigurationProvider{
+		rawConfigurationProvider: rawConfigurationProvider{
+			tenancy:     tenancy,
+			user:        user,
+			region:      region,
+			fingerprint: fingerprint,
+		},
+		signer: signer,
+	}, nil
+}
+
+// PrivateRSAKey returns an error, as the private key can not be exported from
+// the vault.
+func (p kmsConfigurationProvider) PrivateRSAKey() (*rsa.PrivateKey, error) {
+	return nil, fmt.Errorf("the private key of %s is held in OCI Vault and can not be exported", p.signer.keyID)
+}
+
+// PrivateKeySigner returns a signer that signs with the key of the vault. It
+// implements the SignerKeyProvider interface.
+func (p kmsConfigurationProvider) PrivateKeySigner() (crypto.Signer, error) {
+	return p.signer, nil
+}
+
+// kmsSigner is a crypto.Signer that signs digests with the sign operation of
+// OCI Vault.
+type kmsSigner struct {
+	url          string
+	keyID        string
+	keyVersionID string
+	publicKey    crypto.PublicKey
+	requestSign  HTTPRequestSigner
+	httpClient   *http.Client
+	retry        FederationClientOptions
+}
+
+func newKMSSigner(options KMSKeyOptions) (*kmsSigner, error) {
+	switch {
+	case options.CryptoEndpoint == "":
+		return nil, fmt.Errorf("the crypto endpoint of the vault must be specified")
+	case options.KeyID == "":
+		return nil, fmt.Errorf("the OCID of the key must be specified")
+	case options.RequestKeyProvider == nil:
+		return nil, fmt.Errorf("the key provider used to sign requests to the vault must be specified")
+	}
+
+	block, _ := pem.Decode(options.PublicKey)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode the public key of %s", options.KeyID)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the public key of %s: %s", options.KeyID, err.Error())
+	}
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+	case *ecdsa.PublicKey:
+		if _, _, err = ecdsaHash(pub); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	return &kmsSigner{
+		url:          strings.TrimSuffix(options.CryptoEndpoint, "/") + kmsSignPath,
+		keyID:        options.KeyID,
+		keyVersionID: options.KeyVersionID,
+		publicKey:    pub,
+		requestSign:  DefaultRequestSigner(options.RequestKeyProvider),
+		httpClient:   httpClient,
+		retry:        options.Retry,
+	}, nil
+}
+
+// Public returns the public key of the key version.
+func (s *kmsSigner) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+// kmsSignRequest is the body of a sign request of OCI Vault.
+type kmsSignRequest struct {
+	KeyID            string `json:"keyId"`
+	KeyVersionID     string `json:"keyVersionId,omitempty"`
+	Message          string `json:"message"`
+	MessageType      string `json:"messageType"`
+	SigningAlgorithm string `json:"signingAlgorithm"`
+}
+
+// Sign signs digest with the key of the vault. The signature is in the same
+// format as the signatures of rsa.PrivateKey and ecdsa.PrivateKey.
+func (s *kmsSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	algorithm, err := s.signingAlgorithm(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(kmsSignRequest{
+		KeyID:            s.keyID,
+		KeyVersionID:     s.keyVersionID,
+		Message:          base64.StdEncoding.EncodeToString(digest),
+		MessageType:      "DIGEST",
+		SigningAlgorithm: algorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.retry.do(s.httpClient, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+		req.Header.Set("User-Agent", sdkutil.UserAgent())
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		if err = s.requestSign.Sign(req); err != nil {
+			return nil, err
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with %s: %s", s.keyID, err.Error())
+	}
+	defer closeBodyIfValid(resp)
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("failed to sign with %s: error status code: %d, message: %s",
+			s.keyID, resp.StatusCode, string(content))
+	}
+
+	var signed struct {
+		Signature string `json:"signature"`
+	}
+	if err = json.Unmarshal(content, &signed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the response: %s", err.Error())
+	}
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil || len(signature) == 0 {
+		return nil, fmt.Errorf("the response does not contain a valid signature")
+	}
+	return signature, nil
+}
+
+// signingAlgorithm returns the name of the signing algorithm of the vault
+// that corresponds to the type of the key and opts.
+func (s *kmsSigner) signingAlgorithm(opts crypto.SignerOpts) (string, error) {
+	h := opts.HashFunc()
+	switch s.publicKey.(type) {
+	case *rsa.PublicKey:
+		if h != crypto.SHA256 {
+			break
+		}
+		if _, ok := opts.(*rsa.PSSOptions); ok {
+			return "SHA_256_RSA_PKCS_PSS", nil
+		}
+		return "SHA_256_RSA_PKCS1_V1_5", nil
+	case *ecdsa.PublicKey:
+		switch h {
+		case crypto.SHA256:
+			return "ECDSA_SHA_256", nil
+		case crypto.SHA384:
+			return "ECDSA_SHA_384", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported hash function %v for a key of type %T", h, s.publicKey)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+
+package iam
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newVaultServer returns a server that emulates the sign operation of OCI
+// Vault with key, and records the signing algorithms it is called with.
+func newVaultServer(t *testing.T, key crypto.Signer, algorithms *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, kmsSignPath, r.URL.Path)
+		assert.Contains(t, r.Header.Get(requestHeaderAuthorization), testFingerprint)
+
+		var req kmsSignRequest
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, "ocid1.key.oc1..test", req.KeyID)
+		assert.Equal(t, "DIGEST", req.MessageType)
+		*algorithms = append(*algorithms, req.SigningAlgorithm)
+
+		digest, _ := base64.StdEncoding.DecodeString(req.Message)
+		var opts crypto.SignerOpts = crypto.SHA256
+		switch req.SigningAlgorithm {
+		case "SHA_256_RSA_PKCS_PSS":
+			opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+		case "ECDSA_SHA_384":
+			opts = crypto.SHA384
+		}
+		sig, err := key.Sign(rand.Reader, digest, opts)
+		if !assert.NoError(t, err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"signature": "%s", "keyId": "%s"}`, base64.StdEncoding.EncodeToString(sig), req.KeyID)
+	}))
+}
+
+func publicKeyPem(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestKMSConfigurationProvider_RSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	var algorithms []string
+	vault := newVaultServer(t, key, &algorithms)
+	defer vault.Close()
+
+	provider, err := NewKMSConfigurationProvider(testTenancyOCID, testUserOCID, "us-ashburn-1", testFingerprint, KMSKeyOptions{
+		CryptoEndpoint:     vault.URL + "/",
+		KeyID:              "ocid1.key.oc1..test",
+		PublicKey:          publicKeyPem(t, key),
+		RequestKeyProvider: testKeyProvider{},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	ok, err := IsConfigurationProviderValid(provider)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	_, err = provider.PrivateRSAKey()
+	assert.Error(t, err)
+
+	for _, algorithm := range []SigningAlgorithm{RSASHA256, RSAPSSSHA256} {
+		s := RequestSignerWithAlgorithm(provider, defaultGenericHeaders, defaultBodyHeaders, algorithm).(ociRequestSigner)
+		u, _ := url.Parse(testURL)
+		req := &http.Request{Method: http.MethodGet, Header: make(http.Header), URL: u}
+		req.Header.Set(requestHeaderDate, "Thu, 05 Jan 2014 21:31:40 GMT")
+		if !assert.NoError(t, s.Sign(req)) {
+			continue
+		}
+
+		auth := req.Header.Get(requestHeaderAuthorization)
+		assert.Containsf(t, auth, `algorithm="`+string(algorithm)+`"`, "unexpected Authorization header %s", auth)
+		i := strings.Index(auth, `signature="`)
+		sig, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(auth[i+len(`signature="`):], `"`))
+		assert.NoError(t, err)
+		hashed := sha256.Sum256([]byte(s.getSigningString(req)))
+		if algorithm == RSAPSSSHA256 {
+			assert.NoError(t, rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, hashed[:], sig, nil))
+		} else {
+			assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], sig))
+		}
+	}
+	assert.Equal(t, []string{"SHA_256_RSA_PKCS1_V1_5", "SHA_256_RSA_PKCS_PSS"}, algorithms)
+}
+
+func TestKMSConfigurationProvider_ECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	var algorithms []string
+	vault := newVaultServer(t, key, &algorithms)
+	defer vault.Close()
+
+	provider, err := NewKMSConfigurationProvider(testTenancyOCID, testUserOCID, "us-ashburn-1", testFingerprint, KMSKeyOptions{
+		CryptoEndpoint:     vault.URL,
+		KeyID:              "ocid1.key.oc1..test",
+		PublicKey:          publicKeyPem(t, key),
+		RequestKeyProvider: testKeyProvider{},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	signer, err := provider.(SignerKeyProvider).PrivateKeySigner()
+	assert.NoError(t, err)
+	digest := crypto.SHA384.New()
+	io.WriteString(digest, "signing string")
+	sig, err := signer.Sign(rand.Reader, digest.Sum(nil), crypto.SHA384)
+	if assert.NoError(t, err) {
+		assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest.Sum(nil), sig))
+	}
+	assert.Equal(t, []string{"ECDSA_SHA_384"}, algorithms)
+
+	// A hash that does not match the curve is rejected without calling the vault.
+	_, err = signer.Sign(rand.Reader, make([]byte, 64), crypto.SHA512)
+	assert.Error(t, err)
+	assert.Len(t, algorithms, 1)
+}
+
+func TestKMSConfigurationProvider_InvalidOptions(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	assert.NoError(t, err)
+
+	valid := KMSKeyOptions{
+		CryptoEndpoint:     "https://vault-crypto.kms.us-ashburn-1.oraclecloud.com",
+		KeyID:              "ocid1.key.oc1..test",
+		PublicKey:          publicKeyPem(t, key),
+		RequestKeyProvider: testKeyProvider{},
+	}
+	tests := []func(o *KMSKeyOptions){
+		func(o *KMSKeyOptions) { o.CryptoEndpoint = "" },
+		func(o *KMSKeyOptions) { o.KeyID = "" },
+		func(o *KMSKeyOptions) { o.RequestKeyProvider = nil },
+		func(o *KMSKeyOptions) { o.PublicKey = []byte("not a key") },
+		// P-224 keys are not supported.
+		func(o *KMSKeyOptions) {},
+	}
+	for i, update := range tests {
+		options := valid
+		update(&options)
+		_, err = NewKMSConfigurationProvider(testTenancyOCID, testUserOCID, "us-ashburn-1", testFingerprint, options)
+		assert.Errorf(t, err, "test %d: expected an error", i)
+	}
+}
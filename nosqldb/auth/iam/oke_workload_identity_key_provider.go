@@ -0,0 +1,179 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+// This software is dual-licensed to you under the Universal Permissive License (UPL) 1.0 as shown at https://oss.oracle.com/licenses/upl or Apache License 2.0 as shown at http://www.apache.org/licenses/LICENSE-2.0. You may choose either license.
+
+package iam
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+)
+
+const (
+	// default path of the Kubernetes service account token, which is mounted
+	// in the pods from a projected volume
+	okeServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// default path of the certificate of the Kubernetes cluster CA
+	okeServiceAccountCertPath = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
+	// environment variable that overrides the path of the service account CA certificate
+	okeServiceAccountCertPathEnvVar = "OCI_KUBERNETES_SERVICE_ACCOUNT_CERT_PATH"
+
+	// environment variable that specifies the host of the Kubernetes API
+	// server, which is set by Kubernetes in all pods
+	kubernetesServiceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+
+	// port and path of the OKE proxymux endpoint that issues resource
+	// principal session tokens in exchange for service account tokens
+	okeProxymuxPort = "12250"
+	okeProxymuxPath = "/resourcePrincipalSessionTokens"
+
+	okeRequestTimeout = 30 * time.Second
+)
+
+// okeWorkloadIdentityConfigurationProvider is a configuration provider for
+// OKE Workload Identity. It is a resource principal whose session tokens are
+// obtained from the OKE proxymux endpoint.
+type okeWorkloadIdentityConfigurationProvider struct {
+	*resourcePrincipalKeyProvider
+}
+
+// newOKEWorkloadIdentityConfigurationProvider creates a configuration provider
+// for OKE Workload Identity that reads the service account token from
+// saTokenPath. The region is read from the OCI_RESOURCE_PRINCIPAL_REGION
+// environment variable, and the proxymux endpoint is determined from the
+// KUBERNETES_SERVICE_HOST environment variable.
+func newOKEWorkloadIdentityConfigurationProvider(saTokenPath string) (ConfigurationProvider, error) {
+	host := requireEnv(kubernetesServiceHostEnvVar)
+	if host == nil {
+		return nil, fmt.Errorf("can not create OKE workload identity, environment variable: %s, not present. "+
+			"The application must run in a pod of an OKE cluster", kubernetesServiceHostEnvVar)
+	}
+	region := requireEnv(resourcePrincipalRegionEnvVar)
+	if region == nil {
+		return nil, fmt.Errorf("can not create OKE workload identity, environment variable: %s, not present",
+			resourcePrincipalRegionEnvVar)
+	}
+
+	certPath := okeServiceAccountCertPath
+	if p := requireEnv(okeServiceAccountCertPathEnvVar); p != nil {
+		certPath = *p
+	}
+	caCert, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("can not create OKE workload identity, failed to read the service account "+
+			"CA certificate from %s: %s", certPath, err.Error())
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("can not create OKE workload identity, invalid service account "+
+			"CA certificate in %s", certPath)
+	}
+
+	httpClient := &http.Client{
+		Timeout: okeRequestTimeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+		},
+	}
+	endpoint := "https://" + net.JoinHostPort(*host, okeProxymuxPort) + okeProxymuxPath
+	return newOKEWorkloadIdentityProvider(endpoint, saTokenPath, *region, httpClient), nil
+}
+
+func newOKEWorkloadIdentityProvider(endpoint, saTokenPath, region string, httpClient *http.Client) *okeWorkloadIdentityConfigurationProvider {
+	supplier := newSessionKeySupplier()
+	fd := &genericFederationClient{
+		SessionKeySupplier: supplier,
+		RefreshSecurityToken: func() (securityToken, error) {
+			return getOKEResourcePrincipalToken(httpClient, endpoint, saTokenPath, supplier)
+		},
+	}
+	return &okeWorkloadIdentityConfigurationProvider{
+		&resourcePrincipalKeyProvider{
+			FederationClient:  fd,
+			KeyProviderRegion: region,
+		},
+	}
+}
+
+type okeTokenRequest struct {
+	PodKey string `json:"podKey"`
+}
+
+type okeTokenResponse struct {
+	Token string `json:"token"`
+}
+
+// getOKEResourcePrincipalToken exchanges the service account token read from
+// saTokenPath for a resource principal session token bound to the public key
+// of the session key supplier. The service account token is read for every
+// exchange, as it is rotated by Kubernetes.
+func getOKEResourcePrincipalToken(httpClient *http.Client, endpoint, saTokenPath string,
+	supplier sessionKeySupplier) (securityToken, error) {
+
+	saToken, err := os.ReadFile(saTokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the service account token from %s: %s", saTokenPath, err.Error())
+	}
+
+	block, _ := pem.Decode(supplier.PublicKeyPemRaw())
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode the session public key")
+	}
+	body, err := json.Marshal(okeTokenRequest{PodKey: base64.StdEncoding.EncodeToString(block.Bytes)})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(saToken)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", sdkutil.UserAgent())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a resource principal session token from %s: %s", endpoint, err.Error())
+	}
+	defer closeBodyIfValid(resp)
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("failed to get a resource principal session token from %s, "+
+			"error status code: %d, message: %s", endpoint, resp.StatusCode, string(content))
+	}
+
+	// The response is a base64 encoded JSON object.
+	decoded, err := base64.StdEncoding.DecodeString(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the resource principal session token response: %s", err.Error())
+	}
+	var tokenResp okeTokenResponse
+	if err = json.Unmarshal(decoded, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the resource principal session token response: %s", err.Error())
+	}
+	return newInstancePrincipalToken(strings.TrimPrefix(tokenResp.Token, "ST$"))
+}
+
+func (p *okeWorkloadIdentityConfigurationProvider) SecurityTokenFile() (string, error) {
+	return "", fmt.Errorf("OKE workload identity does not support SecurityTokenFile")
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+// This software is dual-licensed to you under the Universal Permissive License (UPL) 1.0 as shown at https://oss.oracle.com/licenses/upl or Apache License 2.0 as shown at http://www.apache.org/licenses/LICENSE-2.0. You may choose either license.
+
+package iam
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOKEWorkloadIdentity(t *testing.T) {
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != okeProxymuxPath ||
+			r.Header.Get("Authorization") != "Bearer sa-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req okeTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		der, err := base64.StdEncoding.DecodeString(req.PodKey)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if _, err = x509.ParsePKIXPublicKey(der); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, _ := json.Marshal(okeTokenResponse{Token: "ST$" + rpst})
+		w.Write([]byte(base64.StdEncoding.EncodeToString(resp)))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("sa-token\n"), 0600))
+	certFile := filepath.Join(dir, "ca.crt")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(certFile, cert, 0600))
+
+	// The environment of the pod is required.
+	t.Setenv(kubernetesServiceHostEnvVar, "")
+	os.Unsetenv(kubernetesServiceHostEnvVar)
+	_, err := NewSignatureProviderWithOKEWorkloadIdentityFromFile("", tokenFile)
+	assert.Error(t, err)
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	t.Setenv(kubernetesServiceHostEnvVar, host[:strings.LastIndex(host, ":")])
+	t.Setenv(resourcePrincipalRegionEnvVar, regionPHX)
+	t.Setenv(okeServiceAccountCertPathEnvVar, certFile)
+	cp, err := newOKEWorkloadIdentityConfigurationProvider(tokenFile)
+	require.NoError(t, err)
+	region, _ := cp.Region()
+	assert.Equal(t, regionPHX, region)
+
+	// The session token is obtained from the proxymux endpoint, which is
+	// started on a random port by the test.
+	provider := newOKEWorkloadIdentityProvider(server.URL+okeProxymuxPath, tokenFile, regionPHX, server.Client())
+	p, err := NewSignatureProviderWithConfiguration(provider, "")
+	require.NoError(t, err)
+	assert.Equal(t, "customer-tenant-1", p.CompartmentID())
+	assert.Equal(t, "OKE workload identity", p.PrincipalType())
+	keyID, err := provider.KeyID()
+	require.NoError(t, err)
+	assert.Equal(t, "ST$"+rpst, keyID)
+	key, err := provider.PrivateRSAKey()
+	require.NoError(t, err)
+	assert.NotNil(t, key)
+	assert.Equal(t, 1, requests, "the session token should be cached until it expires")
+
+	// Errors of the proxymux endpoint are returned.
+	require.NoError(t, os.WriteFile(tokenFile, []byte("expired"), 0600))
+	provider = newOKEWorkloadIdentityProvider(server.URL+okeProxymuxPath, tokenFile, regionPHX, server.Client())
+	_, err = provider.KeyID()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "401")
+	}
+}
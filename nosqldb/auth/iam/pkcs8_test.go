@@ -0,0 +1,73 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package iam
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPBKDF2(t *testing.T) {
+	// Test vectors of RFC 6070.
+	tests := []struct {
+		password, salt string
+		iter, keyLen   int
+		want           string
+	}{
+		{"password", "salt", 1, 20, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+		{"password", "salt", 2, 20, "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"},
+		{"password", "salt", 4096, 20, "4b007901b765489abead49d926f721d065a429c1"},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 25,
+			"3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038"},
+	}
+	for _, tt := range tests {
+		got := pbkdf2(sha1.New, []byte(tt.password), []byte(tt.salt), tt.iter, tt.keyLen)
+		assert.Equalf(t, tt.want, hex.EncodeToString(got), "pbkdf2(%q, %q, %d, %d)", tt.password, tt.salt, tt.iter, tt.keyLen)
+	}
+}
+
+func TestEncryptedPKCS8PrivateKey(t *testing.T) {
+	password := "secret"
+	// The keys are generated with "openssl genpkey" and "openssl pkcs8 -topk8".
+	tests := []struct {
+		file string
+		rsa  bool
+	}{
+		{"pkcs8_rsa_aes256.pem", true},
+		{"pkcs8_rsa_des3.pem", true},
+		{"pkcs8_ec_aes128.pem", false},
+	}
+	for _, tt := range tests {
+		pemData, err := os.ReadFile(filepath.Join("testdata", tt.file))
+		require.NoError(t, err)
+
+		signer, err := PrivateKeySignerFromBytes(pemData, &password)
+		require.NoErrorf(t, err, "PrivateKeySignerFromBytes(%s) got error %v", tt.file, err)
+		if tt.rsa {
+			assert.IsType(t, &rsa.PrivateKey{}, signer)
+			_, err = PrivateKeyFromBytes(pemData, &password)
+			assert.NoErrorf(t, err, "PrivateKeyFromBytes(%s) got error %v", tt.file, err)
+		} else {
+			assert.IsType(t, &ecdsa.PrivateKey{}, signer)
+		}
+
+		wrong := "wrong"
+		_, err = PrivateKeySignerFromBytes(pemData, &wrong)
+		assert.Errorf(t, err, "PrivateKeySignerFromBytes(%s) with a wrong password should fail", tt.file)
+		_, err = PrivateKeySignerFromBytes(pemData, nil)
+		assert.Errorf(t, err, "PrivateKeySignerFromBytes(%s) without a password should fail", tt.file)
+	}
+}
@@ -4,6 +4,7 @@
 package iam
 
 import (
+	"context"
 	"crypto/rsa"
 	"errors"
 	"fmt"
@@ -37,12 +38,21 @@ const (
 	errMsgEnvVarNotPresent = "can not create resource principal, environment variable: %s, not present"
 )
 
+// ResourcePrincipalOptions represents options for a resource principal
+// signature provider.
+type ResourcePrincipalOptions struct {
+	// FederationClient specifies the retries and timeout of the requests to
+	// the endpoints of the parent resource that get security tokens for
+	// nested resource principals of version 3.0.
+	FederationClient FederationClientOptions
+}
+
 // newResourcePrincipalConfigurationProvider creates a resource principal
 // configuration provider using well known environment variables to look up
 // token information. The environment variables can either paths or contain the
 // material value of the keys. However in the case of the keys and tokens paths
 // and values can not be mixed.
-func newResourcePrincipalConfigurationProvider() (ConfigurationProvider, error) {
+func newResourcePrincipalConfigurationProvider(options ResourcePrincipalOptions) (ConfigurationProvider, error) {
 	var version string
 	var ok bool
 	if version, ok = os.LookupEnv(resourcePrincipalVersionEnvVar); !ok {
@@ -66,6 +76,8 @@ func newResourcePrincipalConfigurationProvider() (ConfigurationProvider, error)
 			return nil, fmt.Errorf(errMsgEnvVarNotPresent, resourcePrincipalRegionEnvVar)
 		}
 		return newResourcePrincipalKeyProvider22(*rpst, *private, passphrase, *region)
+	case resourcePrincipalVersion3_0:
+		return newResourcePrincipalKeyProviderV30(options)
 	default:
 		return nil, fmt.Errorf("can not create resource principal, environment variable: %s, must be valid",
 			resourcePrincipalVersionEnvVar)
@@ -151,10 +163,30 @@ func (p *resourcePrincipalKeyProvider) KeyID() (string, error) {
 	return fmt.Sprintf("ST$%s", securityToken), nil
 }
 
+// KeyIDContext is like KeyID, but stops waiting for the renewal of the
+// security token when ctx is done.
+func (p *resourcePrincipalKeyProvider) KeyIDContext(ctx context.Context) (string, error) {
+	var securityToken string
+	var err error
+	if securityToken, err = p.FederationClient.SecurityTokenContext(ctx); err != nil {
+		return "", fmt.Errorf("failed to get security token: %s", err.Error())
+	}
+	return fmt.Sprintf("ST$%s", securityToken), nil
+}
+
 func (p *resourcePrincipalKeyProvider) ExpirationTime() time.Time {
 	return p.FederationClient.ExpirationTime()
 }
 
+// Close stops the background renewals of the security token.
+func (p *resourcePrincipalKeyProvider) Close() error {
+	return closeFederationClient(p.FederationClient)
+}
+
+func (p *resourcePrincipalKeyProvider) setAuthHooks(hooks *AuthHooks) {
+	setAuthHooks(p.FederationClient, hooks)
+}
+
 func (p *resourcePrincipalKeyProvider) Region() (string, error) {
 	return p.KeyProviderRegion, nil
 }
@@ -4,7 +4,11 @@
 package iam
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -131,7 +135,7 @@ func setupResourcePrincipalsEnvsWithPaths(enabledVars ...string) (tempFiles []st
 func TestResourcePrincipalKeyProvider(t *testing.T) {
 	unsetAllVars()
 	setupResourcePrincipalsEnvsWithValues(ppEnvVars)
-	provider, e := newResourcePrincipalConfigurationProvider()
+	provider, e := newResourcePrincipalConfigurationProvider(ResourcePrincipalOptions{})
 
 	assert.NoError(t, e)
 	assert.NotNil(t, provider)
@@ -149,7 +153,7 @@ func TestResourcePrincipalKeyProvider_MissingEnvVars(t *testing.T) {
 	for _, v := range testVars {
 		unsetAllVars()
 		setupResourcePrincipalsEnvsWithValues(ppEnvVars, v...)
-		_, e := newResourcePrincipalConfigurationProvider()
+		_, e := newResourcePrincipalConfigurationProvider(ResourcePrincipalOptions{})
 		assert.Error(t, e, "should have failed with %s", v)
 	}
 }
@@ -160,7 +164,7 @@ func TestResourcePrincipalKeyProvider_DifferentEnvVarContent(t *testing.T) {
 	tempFiles := setupResourcePrincipalsEnvsWithPaths(resourcePrincipalRPSTEnvVar)
 	defer removeFile(tempFiles...)
 
-	_, e := newResourcePrincipalConfigurationProvider()
+	_, e := newResourcePrincipalConfigurationProvider(ResourcePrincipalOptions{})
 	assert.NoError(t, e, "should have not failed")
 }
 
@@ -171,7 +175,7 @@ func TestResourcePrincipalConfigurationProvider(t *testing.T) {
 	tempFiles := setupResourcePrincipalsEnvsWithPaths(resourcePrincipalRPSTEnvVar, resourcePrincipalPrivatePEMEnvVar)
 	defer removeFile(tempFiles...)
 
-	provider, e := newResourcePrincipalConfigurationProvider()
+	provider, e := newResourcePrincipalConfigurationProvider(ResourcePrincipalOptions{})
 	assert.NoError(t, e)
 
 	tenancyOCID, e := provider.TenancyOCID()
@@ -199,3 +203,69 @@ func TestResourcePrincipalConfigurationProvider(t *testing.T) {
 	assert.NotNil(t, privateKey)
 
 }
+
+func TestResourcePrincipalKeyProviderV30(t *testing.T) {
+	var parentURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The requests must be signed with the leaf resource principal.
+		if !strings.Contains(r.Header.Get("Authorization"), `keyId="ST$`+rpst+`"`) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rpt":
+			json.NewEncoder(w).Encode(resourcePrincipalTokenResponse{
+				ResourcePrincipalToken:       "parent-rpt",
+				ServicePrincipalSessionToken: "parent-spst",
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/resourcePrincipalSessionToken":
+			var req resourcePrincipalSessionTokenRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ResourcePrincipalToken != "parent-rpt" ||
+				req.SessionPublicKey == "" || r.Header.Get(opcParentRPTURLHeader) != parentURL {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"token": "ST$" + rpst})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	parentURL = server.URL + "/rpt"
+
+	unsetAllVars()
+	leafVars := []string{resourcePrincipalVersionEnvVar, resourcePrincipalRegionEnvVar,
+		resourcePrincipalRPSTEnvVar, resourcePrincipalPrivatePEMEnvVar}
+	for _, k := range leafVars {
+		t.Setenv(k+leafResourceEnvVarSuffix, envVars[k])
+	}
+	t.Setenv(resourcePrincipalVersionEnvVar, resourcePrincipalVersion3_0)
+
+	// The endpoints of the parent resource are required.
+	_, e := newResourcePrincipalConfigurationProvider(ResourcePrincipalOptions{})
+	assert.Error(t, e)
+	t.Setenv(resourcePrincipalRPTURLForParentEnvVar, parentURL)
+	t.Setenv(resourcePrincipalRPSTEndpointForParentEnvVar, server.URL)
+
+	provider, e := newResourcePrincipalConfigurationProvider(ResourcePrincipalOptions{})
+	if !assert.NoError(t, e) {
+		return
+	}
+
+	region, e := provider.Region()
+	assert.NoError(t, e)
+	assert.Equal(t, regionPHX, region)
+
+	keyID, e := provider.KeyID()
+	assert.NoError(t, e)
+	assert.Equal(t, "ST$"+rpst, keyID)
+
+	tenancyOCID, e := provider.TenancyOCID()
+	assert.NoError(t, e)
+	assert.Equal(t, "customer-tenant-1", tenancyOCID)
+
+	// The leaf resource principal is required.
+	os.Unsetenv(resourcePrincipalRPSTEnvVar + leafResourceEnvVarSuffix)
+	_, e = newResourcePrincipalConfigurationProvider(ResourcePrincipalOptions{})
+	assert.Error(t, e)
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+// This software is dual-licensed to you under the Universal Permissive License (UPL) 1.0 as shown at https://oss.oracle.com/licenses/upl or Apache License 2.0 as shown at http://www.apache.org/licenses/LICENSE-2.0. You may choose either license.
+
+package iam
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+)
+
+const (
+	// supported version for nested resource principal
+	resourcePrincipalVersion3_0 = "3.0"
+
+	// environment variable that specifies the URL of the resource principal
+	// token (RPT) endpoint of the parent resource
+	resourcePrincipalRPTURLForParentEnvVar = "OCI_RESOURCE_PRINCIPAL_RPT_URL_FOR_PARENT_RESOURCE"
+
+	// environment variable that specifies the resource principal session token
+	// (RPST) endpoint of the parent resource
+	resourcePrincipalRPSTEndpointForParentEnvVar = "OCI_RESOURCE_PRINCIPAL_RPST_ENDPOINT_FOR_PARENT_RESOURCE"
+
+	// suffix of the environment variables that specify the leaf resource
+	// principal, such as OCI_RESOURCE_PRINCIPAL_RPST_FOR_LEAF_RESOURCE
+	leafResourceEnvVarSuffix = "_FOR_LEAF_RESOURCE"
+
+	// header that specifies the RPT URL of the parent in the RPST request
+	opcParentRPTURLHeader = "opc-parent-rpt-url"
+)
+
+// newResourcePrincipalKeyProviderV30 creates a key provider for a nested
+// resource principal, using the well known environment variables of version
+// 3.0. The leaf resource principal is configured with the environment
+// variables of version 2.2 suffixed with _FOR_LEAF_RESOURCE, and is used to
+// sign the requests that exchange the resource principal token of the parent
+// resource for a session token.
+func newResourcePrincipalKeyProviderV30(options ResourcePrincipalOptions) (*resourcePrincipalKeyProvider, error) {
+	rptURL := requireEnv(resourcePrincipalRPTURLForParentEnvVar)
+	if rptURL == nil {
+		return nil, fmt.Errorf(errMsgEnvVarNotPresent, resourcePrincipalRPTURLForParentEnvVar)
+	}
+	rpstEndpoint := requireEnv(resourcePrincipalRPSTEndpointForParentEnvVar)
+	if rpstEndpoint == nil {
+		return nil, fmt.Errorf(errMsgEnvVarNotPresent, resourcePrincipalRPSTEndpointForParentEnvVar)
+	}
+
+	leaf, err := newLeafResourcePrincipalKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	// The parent resource is in the region of the leaf, unless specified.
+	region := leaf.KeyProviderRegion
+	if r := requireEnv(resourcePrincipalRegionEnvVar); r != nil {
+		region = *r
+	}
+
+	httpClient := &http.Client{Timeout: options.FederationClient.timeout()}
+	return newNestedResourcePrincipalKeyProvider(leaf, *rptURL, *rpstEndpoint, region, httpClient,
+		options.FederationClient), nil
+}
+
+// newLeafResourcePrincipalKeyProvider creates the key provider of the leaf
+// resource principal of version 3.0.
+func newLeafResourcePrincipalKeyProvider() (*resourcePrincipalKeyProvider, error) {
+	leafEnv := func(key string) (*string, error) {
+		val := requireEnv(key + leafResourceEnvVarSuffix)
+		if val == nil {
+			return nil, fmt.Errorf(errMsgEnvVarNotPresent, key+leafResourceEnvVarSuffix)
+		}
+		return val, nil
+	}
+
+	version, err := leafEnv(resourcePrincipalVersionEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	if *version != resourcePrincipalVersion2_2 {
+		return nil, fmt.Errorf("can not create resource principal, environment variable: %s, must be valid",
+			resourcePrincipalVersionEnvVar+leafResourceEnvVarSuffix)
+	}
+
+	rpst, err := leafEnv(resourcePrincipalRPSTEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	private, err := leafEnv(resourcePrincipalPrivatePEMEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	region, err := leafEnv(resourcePrincipalRegionEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	// passphrase is optional
+	passphrase := requireEnv(resourcePrincipalPrivatePEMPassphraseEnvVar + leafResourceEnvVarSuffix)
+	return newResourcePrincipalKeyProvider22(*rpst, *private, passphrase, *region)
+}
+
+// newNestedResourcePrincipalKeyProvider creates a key provider whose session
+// tokens are obtained from the parent resource, with requests signed by leaf.
+func newNestedResourcePrincipalKeyProvider(leaf KeyProvider, rptURL, rpstEndpoint, region string,
+	httpClient *http.Client, options FederationClientOptions) *resourcePrincipalKeyProvider {
+
+	supplier := newSessionKeySupplier()
+	signer := DefaultRequestSigner(leaf)
+	fd := &genericFederationClient{
+		SessionKeySupplier: supplier,
+		RefreshSecurityToken: func() (securityToken, error) {
+			return getNestedResourcePrincipalToken(httpClient, options, signer, rptURL, rpstEndpoint, supplier)
+		},
+	}
+	fd.refresher.options = options
+	return &resourcePrincipalKeyProvider{
+		FederationClient:  fd,
+		KeyProviderRegion: region,
+	}
+}
+
+type resourcePrincipalTokenResponse struct {
+	ResourcePrincipalToken       string `json:"resourcePrincipalToken"`
+	ServicePrincipalSessionToken string `json:"servicePrincipalSessionToken"`
+}
+
+type resourcePrincipalSessionTokenRequest struct {
+	resourcePrincipalTokenResponse
+	SessionPublicKey string `json:"sessionPublicKey"`
+}
+
+// getNestedResourcePrincipalToken retrieves the resource principal token of
+// the parent resource from rptURL, and exchanges it at rpstEndpoint for a
+// session token bound to the public key of the session key supplier.
+func getNestedResourcePrincipalToken(httpClient *http.Client, options FederationClientOptions, signer HTTPRequestSigner,
+	rptURL, rpstEndpoint string, supplier sessionKeySupplier) (securityToken, error) {
+
+	var rpt resourcePrincipalTokenResponse
+	if err := callResourcePrincipalEndpoint(httpClient, options, signer, http.MethodGet, rptURL, nil, nil, &rpt); err != nil {
+		return nil, fmt.Errorf("failed to get the resource principal token of the parent resource: %s", err.Error())
+	}
+
+	block, _ := pem.Decode(supplier.PublicKeyPemRaw())
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode the session public key")
+	}
+	body, err := json.Marshal(resourcePrincipalSessionTokenRequest{
+		resourcePrincipalTokenResponse: rpt,
+		SessionPublicKey:               base64.StdEncoding.EncodeToString(block.Bytes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rpst struct {
+		Token string `json:"token"`
+	}
+	url := strings.TrimSuffix(rpstEndpoint, "/") + "/v1/resourcePrincipalSessionToken"
+	headers := map[string]string{opcParentRPTURLHeader: rptURL}
+	if err = callResourcePrincipalEndpoint(httpClient, options, signer, http.MethodPost, url, body, headers, &rpst); err != nil {
+		return nil, fmt.Errorf("failed to get the resource principal session token of the parent resource: %s", err.Error())
+	}
+	return newInstancePrincipalToken(strings.TrimPrefix(rpst.Token, "ST$"))
+}
+
+// callResourcePrincipalEndpoint sends a request signed by signer to url, and
+// unmarshals the JSON response into out. The request is retried on transient
+// errors as specified by options.
+func callResourcePrincipalEndpoint(httpClient *http.Client, options FederationClientOptions, signer HTTPRequestSigner,
+	method, url string, body []byte, headers map[string]string, out interface{}) error {
+
+	resp, err := options.do(httpClient, func() (*http.Request, error) {
+		req, err := http.NewRequest(method, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+		req.Header.Set("User-Agent", sdkutil.UserAgent())
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if err = signer.Sign(req); err != nil {
+			return nil, err
+		}
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer closeBodyIfValid(resp)
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("error status code: %d, message: %s", resp.StatusCode, string(content))
+	}
+	if err = json.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("failed to unmarshal the response: %s", err.Error())
+	}
+	return nil
+}
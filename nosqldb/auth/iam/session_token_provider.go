@@ -0,0 +1,248 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+// This software is dual-licensed to you under the Universal Permissive License (UPL) 1.0 as shown at https://oss.oracle.com/licenses/upl or Apache License 2.0 as shown at http://www.apache.org/licenses/LICENSE-2.0. You may choose either license.
+
+package iam
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/common"
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+)
+
+const (
+	// sessionTokenRefreshWindow is the time before the expiration of a session
+	// token at which it is refreshed. It is greater than the time signatures
+	// are cached by SignatureProvider, so that the token is refreshed before
+	// it expires if requests are sent continuously.
+	sessionTokenRefreshWindow = 10 * time.Minute
+
+	// sessionTokenRetryInterval is the minimum interval between attempts to
+	// refresh a session token that has not expired yet.
+	sessionTokenRetryInterval = time.Minute
+
+	sessionTokenRefreshPath = "/v1/authentication/refresh"
+)
+
+// sessionTokenConfigurationProvider is a configuration provider for a profile
+// of an OCI configuration file created by "oci session authenticate". It
+// reads the session token from the security_token_file of the profile, and
+// refreshes it with the authentication service before it expires, as
+// "oci session refresh" does. The refreshed token is written back to the
+// security_token_file, so that it is also used by other tools.
+type sessionTokenConfigurationProvider struct {
+	ConfigurationProvider
+
+	// refreshURL is the URL of the refresh endpoint. If empty, it is
+	// determined from the region of the profile.
+	refreshURL string
+	httpClient *http.Client
+
+	mu sync.Mutex
+	// token is the current token, and expiration its expiration time. The
+	// expiration is zero if the token is not a JWT, in which case it is never
+	// refreshed.
+	token      string
+	expiration time.Time
+
+	// lastAttempt is the time of the last failed attempt to refresh the token.
+	lastAttempt time.Time
+}
+
+func newSessionTokenConfigurationProvider(provider ConfigurationProvider) *sessionTokenConfigurationProvider {
+	return &sessionTokenConfigurationProvider{
+		ConfigurationProvider: provider,
+		httpClient:            &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// KeyID returns "ST$" followed by the session token, which is refreshed if it
+// is about to expire.
+func (p *sessionTokenConfigurationProvider) KeyID() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.refreshIfNeeded(); err != nil {
+		return "", err
+	}
+	return "ST$" + p.token, nil
+}
+
+// ExpirationTime returns the expiration time of the session token.
+func (p *sessionTokenConfigurationProvider) ExpirationTime() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.refreshIfNeeded(); err != nil {
+		return time.Now().Add(-time.Second)
+	}
+	if p.expiration.IsZero() {
+		return p.ConfigurationProvider.ExpirationTime()
+	}
+	return p.expiration
+}
+
+// PrivateKeySigner returns the private key of the profile. It implements the
+// SignerKeyProvider interface.
+func (p *sessionTokenConfigurationProvider) PrivateKeySigner() (crypto.Signer, error) {
+	if sp, ok := p.ConfigurationProvider.(SignerKeyProvider); ok {
+		return sp.PrivateKeySigner()
+	}
+	return p.ConfigurationProvider.PrivateRSAKey()
+}
+
+// refreshIfNeeded reads the session token from the security_token_file, and
+// refreshes it if it expires within sessionTokenRefreshWindow. The file is
+// read every time, so that tokens refreshed by other tools are used.
+//
+// An error is returned only if the token cannot be read, or it has expired
+// and cannot be refreshed.
+func (p *sessionTokenConfigurationProvider) refreshIfNeeded() error {
+	tokenFile, err := p.SecurityTokenFile()
+	if err != nil {
+		return err
+	}
+	token, err := readTokenFromFile(tokenFile)
+	if err != nil {
+		return err
+	}
+
+	p.token, p.expiration = token, time.Time{}
+	jwt, err := parseJwt(token)
+	if err != nil {
+		// Not a JWT: the token is used as is.
+		return nil
+	}
+	if _, ok := jwt.payload["exp"].(float64); !ok {
+		return nil
+	}
+	p.expiration = jwt.expirationTime()
+	if time.Until(p.expiration) > sessionTokenRefreshWindow {
+		return nil
+	}
+	expired := !time.Now().Before(p.expiration)
+	if !expired && time.Since(p.lastAttempt) < sessionTokenRetryInterval {
+		return nil
+	}
+
+	newToken, err := p.refresh(token)
+	if err != nil {
+		p.lastAttempt = time.Now()
+		if !expired {
+			// Use the current token until it expires, the refresh is
+			// retried later.
+			return nil
+		}
+		return fmt.Errorf("the session token in %s has expired and could not be refreshed: %s. "+
+			"Run \"oci session authenticate\" to create a new session", tokenFile, err.Error())
+	}
+
+	if jwt, err = parseJwt(newToken); err != nil {
+		return fmt.Errorf("failed to parse the refreshed session token: %s", err.Error())
+	}
+	if _, ok := jwt.payload["exp"].(float64); !ok {
+		return fmt.Errorf("the refreshed session token has no expiration time")
+	}
+	p.token, p.expiration = newToken, jwt.expirationTime()
+
+	// The refreshed token is written back to the file; failing to do so only
+	// means that it will be refreshed again.
+	if path, err := sdkutil.ExpandPath(tokenFile); err == nil {
+		os.WriteFile(path, []byte(newToken), 0600)
+	}
+	return nil
+}
+
+// sessionKeyProvider is the key provider used to sign refresh requests, with
+// the session token being refreshed.
+type sessionKeyProvider struct {
+	p     *sessionTokenConfigurationProvider
+	token string
+}
+
+func (k sessionKeyProvider) KeyID() (string, error) {
+	return "ST$" + k.token, nil
+}
+
+func (k sessionKeyProvider) ExpirationTime() time.Time {
+	return time.Now().Add(sessionTokenRefreshWindow)
+}
+
+func (k sessionKeyProvider) PrivateRSAKey() (*rsa.PrivateKey, error) {
+	return k.p.PrivateRSAKey()
+}
+
+func (k sessionKeyProvider) PrivateKeySigner() (crypto.Signer, error) {
+	return k.p.PrivateKeySigner()
+}
+
+// refresh exchanges the current session token for a new one at the
+// authentication service.
+func (p *sessionTokenConfigurationProvider) refresh(token string) (string, error) {
+	url := p.refreshURL
+	if url == "" {
+		region, err := p.Region()
+		if err != nil {
+			return "", err
+		}
+		host, err := common.Region(region).EndpointForService("auth")
+		if err != nil {
+			return "", err
+		}
+		url = "https://" + host + sessionTokenRefreshPath
+	}
+
+	body, err := json.Marshal(map[string]string{"currentToken": token})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("User-Agent", sdkutil.UserAgent())
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	signer := RequestSigner(sessionKeyProvider{p, token}, genericHeaders, bodyHeaders)
+	if err = signer.Sign(req); err != nil {
+		return "", err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer closeBodyIfValid(resp)
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("error status code: %d, message: %s", resp.StatusCode, string(content))
+	}
+
+	var refreshed struct {
+		Token string `json:"token"`
+	}
+	if err = json.Unmarshal(content, &refreshed); err != nil {
+		return "", fmt.Errorf("failed to unmarshal the response: %s", err.Error())
+	}
+	if refreshed.Token == "" {
+		return "", fmt.Errorf("the response does not contain a token")
+	}
+	return strings.TrimPrefix(refreshed.Token, "ST$"), nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2016, 2025 Oracle and/or its affiliates. All rights reserved.
+// This software is dual-licensed to you under the Universal Permissive License (UPL) 1.0 as shown at https://oss.oracle.com/licenses/upl or Apache License 2.0 as shown at http://www.apache.org/licenses/LICENSE-2.0. You may choose either license.
+
+package iam
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// isUpgradeRequest reports whether the request asks to upgrade the connection
+// to another protocol, such as a WebSocket upgrade request.
+func isUpgradeRequest(request *http.Request) bool {
+	if request.Header.Get("Upgrade") == "" {
+		return false
+	}
+	for _, v := range request.Header.Values("Connection") {
+		for _, token := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SignUpgradeRequest signs a GET request that upgrades the connection to
+// another protocol, such as a WebSocket upgrade request, with signer.
+//
+// The request must not have a body. The Date header is set if the request
+// does not have one. The host and the request target are signed as they are
+// sent, with the port of the URL if it specifies one, and with the query
+// string as it is encoded in the URL.
+func SignUpgradeRequest(signer HTTPRequestSigner, request *http.Request) error {
+	if signer == nil || request == nil {
+		return fmt.Errorf("can not sign the upgrade request, signer and request must be non-nil")
+	}
+	if request.Method != http.MethodGet {
+		return fmt.Errorf("can not sign the upgrade request, method must be GET, got %s", request.Method)
+	}
+	if request.Body != nil && request.Body != http.NoBody {
+		return fmt.Errorf("can not sign the upgrade request, request must not have a body")
+	}
+	if request.Header.Get(requestHeaderDate) == "" {
+		request.Header.Set(requestHeaderDate, time.Now().UTC().Format(http.TimeFormat))
+	}
+	return signContext(request.Context(), signer, request)
+}
+
+// UpgradeRequestHeader returns the header of a signed WebSocket upgrade
+// request to the specified URL, for WebSocket clients that create the request
+// from a URL and a header. The scheme of the URL is ws, wss, http or https.
+//
+// The returned header contains the entries of header, and the Date and
+// Authorization headers of the signature.
+func UpgradeRequestHeader(signer HTTPRequestSigner, rawURL string, header http.Header) (http.Header, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL of the upgrade request: %v", err)
+	}
+	switch u.Scheme {
+	case "ws", "wss", "http", "https":
+	default:
+		return nil, fmt.Errorf("invalid scheme %q of the upgrade request URL, expect ws, wss, http or https", u.Scheme)
+	}
+
+	request := &http.Request{
+		Method: http.MethodGet,
+		URL:    u,
+		Host:   u.Host,
+		Header: header.Clone(),
+	}
+	if request.Header == nil {
+		request.Header = make(http.Header)
+	}
+	if err = SignUpgradeRequest(signer, request); err != nil {
+		return nil, err
+	}
+	return request.Header, nil
+}
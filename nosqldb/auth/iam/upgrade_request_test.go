@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package iam
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyAuthorization verifies the signature of the Authorization header of
+// header for the signing string, with the key of testKeyProvider.
+func verifyAuthorization(t *testing.T, header http.Header, signingString string) {
+	m := regexp.MustCompile(`signature="([^"]+)"`).FindStringSubmatch(header.Get(requestHeaderAuthorization))
+	require.Len(t, m, 2)
+	sig, err := base64.StdEncoding.DecodeString(m[1])
+	require.NoError(t, err)
+	key, err := testKeyProvider{}.PrivateRSAKey()
+	require.NoError(t, err)
+	hashed := sha256.Sum256([]byte(signingString))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], sig))
+}
+
+func TestSignUpgradeRequest(t *testing.T) {
+	signer := DefaultRequestSigner(testKeyProvider{})
+	r, err := http.NewRequest(http.MethodGet, "wss://stream.us-ashburn-1.oci.oraclecloud.com:8443/20180418/feed?cursor=a%2Fb%3D%3D&name=x%20y", nil)
+	require.NoError(t, err)
+	r.Header.Set("Connection", "keep-alive, Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	assert.True(t, isUpgradeRequest(r))
+
+	require.NoError(t, SignUpgradeRequest(signer, r))
+	date := r.Header.Get(requestHeaderDate)
+	assert.NotEmpty(t, date)
+	assert.Contains(t, r.Header.Get(requestHeaderAuthorization), `headers="date (request-target) host"`)
+	verifyAuthorization(t, r.Header, "date: "+date+"\n"+
+		"(request-target): get /20180418/feed?cursor=a%2Fb%3D%3D&name=x%20y\n"+
+		"host: stream.us-ashburn-1.oci.oraclecloud.com:8443")
+
+	// The Host field overrides the host of the URL.
+	r.Host = "proxy.example.com"
+	s, err := StringToSign(signer, r)
+	require.NoError(t, err)
+	assert.Contains(t, s, "host: proxy.example.com")
+
+	r, err = http.NewRequest(http.MethodPost, "wss://localhost/feed", bytes.NewBufferString(testBody))
+	require.NoError(t, err)
+	assert.Error(t, SignUpgradeRequest(signer, r))
+	r.Method = http.MethodGet
+	assert.Error(t, SignUpgradeRequest(signer, r))
+}
+
+func TestUpgradeRequestHeader(t *testing.T) {
+	signer := DefaultRequestSigner(testKeyProvider{})
+	header := http.Header{}
+	header.Set("Sec-WebSocket-Protocol", "nosql")
+
+	h, err := UpgradeRequestHeader(signer, "ws://[::1]:8080/changes?from=2024-01-01T00%3A00%3A00Z", header)
+	require.NoError(t, err)
+	assert.Equal(t, "nosql", h.Get("Sec-WebSocket-Protocol"))
+	assert.Empty(t, header.Get(requestHeaderAuthorization))
+	verifyAuthorization(t, h, "date: "+h.Get(requestHeaderDate)+"\n"+
+		"(request-target): get /changes?from=2024-01-01T00%3A00%3A00Z\n"+
+		"host: [::1]:8080")
+
+	_, err = UpgradeRequestHeader(signer, "ftp://localhost/changes", nil)
+	assert.Error(t, err)
+	_, err = UpgradeRequestHeader(signer, "ws://localhost/changes", nil)
+	assert.NoError(t, err)
+}
+
+func TestSignatureProviderUpgradeRequest(t *testing.T) {
+	p, err := NewSignatureProviderWithConfiguration(
+		NewRawConfigurationProvider(testTenancyOCID, testUserOCID, testRegion, testFingerprint, testPrivateKey, nil), "")
+	require.NoError(t, err)
+
+	sign := func(path string) *http.Request {
+		r, err := http.NewRequest(http.MethodGet, "wss://localhost:8443"+path, nil)
+		require.NoError(t, err)
+		r.Header.Set("Connection", "Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		require.NoError(t, p.SignHTTPRequest(r))
+		return r
+	}
+
+	// The signatures of upgrade requests are not cached, as their targets
+	// differ.
+	r1, r2 := sign("/feed/1"), sign("/feed/2")
+	assert.NotEqual(t, r1.Header.Get(requestHeaderAuthorization), r2.Header.Get(requestHeaderAuthorization))
+	verifyAuthorization(t, r2.Header, "date: "+r2.Header.Get(requestHeaderDate)+"\n"+
+		"(request-target): get /feed/2\nhost: localhost:8443")
+}
@@ -0,0 +1,203 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package kvstore
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth"
+	"github.com/oracle/nosql-go-sdk/nosqldb/logger"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+)
+
+// KerberosCredentials specifies the Kerberos credentials that a
+// KerberosProvider uses to authenticate with a kerberized NoSQL server.
+type KerberosCredentials struct {
+	// Principal is the name of the client principal, such as
+	// "nosqluser@EXAMPLE.COM". It is required with Keytab.
+	Principal string
+
+	// Keytab is the path of the keytab file that holds the keys of
+	// Principal. If neither Keytab nor CCache is set, the value of the
+	// KRB5_KTNAME environment variable is used.
+	Keytab string
+
+	// CCache is the path of the credential cache that holds the tickets of
+	// the client, such as the cache that kinit writes. If neither Keytab nor
+	// CCache is set, the value of the KRB5CCNAME environment variable is used.
+	CCache string
+
+	// ServicePrincipal is the name of the service principal of the server,
+	// such as "HTTP/proxy.example.com". If not set, "HTTP/" followed by the
+	// host name of the request is used.
+	ServicePrincipal string
+}
+
+// NegotiateTokenSource creates the SPNEGO tokens that a KerberosProvider
+// sends to the server.
+//
+// The SDK does not include a Kerberos implementation. Applications provide
+// one that is backed by a GSSAPI library, which loads the keys or tickets of
+// the client from the keytab or credential cache of the credentials.
+//
+// Implementations of this interface must be safe for concurrent use by
+// multiple goroutines.
+type NegotiateTokenSource interface {
+	// NegotiateToken returns the SPNEGO token of the initial security context
+	// of the client with the service principal of creds. The ServicePrincipal
+	// of creds is always set.
+	NegotiateToken(ctx context.Context, creds KerberosCredentials) ([]byte, error)
+}
+
+// KerberosProvider is an authorization provider used for on-premise NoSQL
+// servers that authenticate clients with Kerberos. It sets the Authorization
+// header of each request to a SPNEGO token in the form of:
+//
+//   Negotiate <base64 encoded token>
+//
+// This implements the nosqldb.AuthorizationProvider interface.
+type KerberosProvider struct {
+	creds   KerberosCredentials
+	source  NegotiateTokenSource
+	timeout time.Duration
+	logger  *logger.Logger
+
+	mutex    sync.RWMutex
+	isClosed bool
+}
+
+// NewKerberosProvider creates a Kerberos authorization provider with the
+// specified credentials, token source and options.
+//
+// This is a variadic function that may be invoked with zero or more arguments
+// for the options parameter, but only the first argument for the options
+// parameter, if specified, is used, others are ignored. The Timeout of the
+// options limits the time to create a token, ExpiryWindow and HTTPClient are
+// not used.
+func NewKerberosProvider(creds KerberosCredentials, source NegotiateTokenSource, options ...auth.ProviderOptions) (*KerberosProvider, error) {
+	if source == nil {
+		return nil, nosqlerr.NewIllegalArgument("NegotiateTokenSource must be non-nil")
+	}
+
+	if creds.Keytab == "" && creds.CCache == "" {
+		creds.Keytab = os.Getenv("KRB5_KTNAME")
+		creds.CCache = os.Getenv("KRB5CCNAME")
+	}
+
+	if creds.Keytab == "" && creds.CCache == "" {
+		return nil, nosqlerr.NewIllegalArgument("either a keytab or a credential cache must be specified")
+	}
+
+	if creds.Keytab != "" {
+		if creds.Principal == "" {
+			return nil, nosqlerr.NewIllegalArgument("principal must be specified with keytab")
+		}
+
+		// Only a keytab in a file can be checked.
+		path := strings.TrimPrefix(creds.Keytab, "FILE:")
+		if !strings.Contains(path, ":") {
+			if _, err := os.Stat(path); err != nil {
+				return nil, nosqlerr.NewIllegalArgument("cannot access keytab %s: %v", path, err)
+			}
+		}
+	}
+
+	opt := defaultOptions
+	if len(options) > 0 {
+		v := &options[0]
+		if v.Timeout >= time.Millisecond {
+			opt.Timeout = v.Timeout
+		}
+
+		if v.Logger != nil {
+			opt.Logger = v.Logger
+		}
+	}
+
+	return &KerberosProvider{
+		creds:   creds,
+		source:  source,
+		timeout: opt.Timeout,
+		logger:  opt.Logger,
+	}, nil
+}
+
+// AuthorizationScheme returns "Negotiate" for this provider.
+func (p *KerberosProvider) AuthorizationScheme() string {
+	return auth.Negotiate
+}
+
+// AuthorizationString returns an empty string, the authorization of a
+// request is set by SignHTTPRequest, which depends on the host of the request.
+func (p *KerberosProvider) AuthorizationString(req auth.Request) (string, error) {
+	return "", nil
+}
+
+// SignHTTPRequest sets the Authorization header of the request to a new
+// SPNEGO token.
+func (p *KerberosProvider) SignHTTPRequest(req *http.Request) error {
+	return p.SignHTTPRequestContext(req.Context(), req)
+}
+
+// SignHTTPRequestContext is like SignHTTPRequest, but returns the error of ctx
+// if ctx is done before the token is created.
+func (p *KerberosProvider) SignHTTPRequestContext(ctx context.Context, req *http.Request) error {
+	if p.checkClosed() {
+		return nosqlerr.NewIllegalState("the Kerberos authorization provider is closed")
+	}
+
+	creds := p.creds
+	if creds.ServicePrincipal == "" {
+		host := req.Host
+		if host == "" {
+			host = req.URL.Host
+		}
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		creds.ServicePrincipal = "HTTP/" + host
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	token, err := p.source.NegotiateToken(ctx, creds)
+	if err != nil {
+		return nosqlerr.NewWithCause(nosqlerr.InvalidAuthorization, err,
+			"cannot create a Kerberos token for %s", creds.ServicePrincipal)
+	}
+
+	req.Header.Set("Authorization", auth.Negotiate+" "+base64.StdEncoding.EncodeToString(token))
+	return nil
+}
+
+// GetLogger returns the logger to use.
+func (p *KerberosProvider) GetLogger() *logger.Logger {
+	return p.logger
+}
+
+// Close sets closed state for the provider.
+func (p *KerberosProvider) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.isClosed = true
+	return nil
+}
+
+// checkClosed checks if the provider is closed.
+func (p *KerberosProvider) checkClosed() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.isClosed
+}
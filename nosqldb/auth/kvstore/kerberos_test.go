@@ -0,0 +1,94 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+)
+
+// testTokenSource returns the service principal of the credentials as the
+// token, or err if it is set.
+type testTokenSource struct {
+	err error
+}
+
+func (s testTokenSource) NegotiateToken(ctx context.Context, creds KerberosCredentials) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []byte(creds.ServicePrincipal), nil
+}
+
+func (suite *AuthTestSuite) TestNewKerberosProvider() {
+	keytab := filepath.Join(suite.T().TempDir(), "client.keytab")
+	suite.Require().NoError(os.WriteFile(keytab, []byte{5, 2}, 0600))
+	suite.T().Setenv("KRB5_KTNAME", "")
+	suite.T().Setenv("KRB5CCNAME", "")
+
+	tests := []struct {
+		desc   string
+		creds  KerberosCredentials
+		source NegotiateTokenSource
+		ok     bool
+	}{
+		{"nil token source", KerberosCredentials{CCache: "/tmp/krb5cc_0"}, nil, false},
+		{"no keytab or credential cache", KerberosCredentials{}, testTokenSource{}, false},
+		{"keytab without principal", KerberosCredentials{Keytab: keytab}, testTokenSource{}, false},
+		{"missing keytab", KerberosCredentials{Principal: "user@EXAMPLE.COM", Keytab: keytab + ".missing"}, testTokenSource{}, false},
+		{"keytab", KerberosCredentials{Principal: "user@EXAMPLE.COM", Keytab: "FILE:" + keytab}, testTokenSource{}, true},
+		{"credential cache", KerberosCredentials{CCache: "KEYRING:persistent:1000"}, testTokenSource{}, true},
+	}
+	for _, r := range tests {
+		p, err := NewKerberosProvider(r.creds, r.source)
+		if r.ok {
+			suite.NoErrorf(err, "%s: NewKerberosProvider() got error %v", r.desc, err)
+			suite.Equalf(auth.Negotiate, p.AuthorizationScheme(), "%s: unexpected authorization scheme", r.desc)
+		} else {
+			suite.Truef(nosqlerr.Is(err, nosqlerr.IllegalArgument), "%s: NewKerberosProvider() got error %v, "+
+				"want IllegalArgument", r.desc, err)
+		}
+	}
+
+	suite.T().Setenv("KRB5CCNAME", "FILE:/tmp/krb5cc_1000")
+	p, err := NewKerberosProvider(KerberosCredentials{}, testTokenSource{})
+	if suite.NoError(err) {
+		suite.Equal("FILE:/tmp/krb5cc_1000", p.creds.CCache)
+	}
+}
+
+func (suite *AuthTestSuite) TestKerberosProviderSignHTTPRequest() {
+	p, err := NewKerberosProvider(KerberosCredentials{CCache: "/tmp/krb5cc_0"}, testTokenSource{})
+	suite.Require().NoError(err)
+
+	// The service principal is derived from the host of the request.
+	req, err := http.NewRequest(http.MethodPost, "https://proxy.example.com:8080/V2/nosql/data", nil)
+	suite.Require().NoError(err)
+	suite.NoError(p.SignHTTPRequest(req))
+	suite.Equal("Negotiate SFRUUC9wcm94eS5leGFtcGxlLmNvbQ==", req.Header.Get("Authorization"))
+
+	p.creds.ServicePrincipal = "HTTP/nosql.example.com"
+	suite.NoError(p.SignHTTPRequestContext(context.Background(), req))
+	suite.Equal("Negotiate SFRUUC9ub3NxbC5leGFtcGxlLmNvbQ==", req.Header.Get("Authorization"))
+
+	p.source = testTokenSource{err: errors.New("no credentials")}
+	err = p.SignHTTPRequest(req)
+	suite.Truef(nosqlerr.Is(err, nosqlerr.InvalidAuthorization), "SignHTTPRequest() got error %v, "+
+		"want InvalidAuthorization", err)
+
+	suite.NoError(p.Close())
+	err = p.SignHTTPRequest(req)
+	suite.Truef(nosqlerr.Is(err, nosqlerr.IllegalState), "SignHTTPRequest() got error %v, "+
+		"want IllegalState", err)
+}
@@ -35,6 +35,13 @@ const (
 	renewService = "/renew"
 )
 
+const (
+	// The delay before the first retry of a failed renewal of the token.
+	// The delay doubles after each failure, up to maxRenewalBackoff.
+	initialRenewalBackoff = time.Second
+	maxRenewalBackoff     = time.Minute
+)
+
 // Default options for the provider.
 var defaultOptions = auth.ProviderOptions{
 	Timeout:      10 * time.Second,
@@ -87,6 +94,22 @@ type AccessTokenProvider struct {
 	// the provider is allowed to renew the token.
 	expiryWindow time.Duration
 
+	// logins and renewals deduplicate the concurrent logins and renewals of
+	// the token, so that goroutines that find the token expired or about to
+	// expire at the same time share a single request to the server.
+	logins   sdkutil.SingleFlight
+	renewals sdkutil.SingleFlight
+
+	// renewalTimer renews the cached token when it enters the expiry window,
+	// and retries the failed renewals with exponential backoff.
+	renewalTimer *time.Timer
+
+	// renewalBackoff is the delay before the first retry of a failed renewal.
+	renewalBackoff time.Duration
+
+	// renewalFailed is called when the renewal of the token permanently fails.
+	renewalFailed func(err error)
+
 	mutex sync.RWMutex
 	wg    sync.WaitGroup
 }
@@ -165,12 +188,13 @@ func NewAccessTokenProvider(username string, password []byte, options ...auth.Pr
 	}
 
 	p := &AccessTokenProvider{
-		username:     username,
-		isSecure:     true,
-		timeout:      opt.Timeout,
-		expiryWindow: opt.ExpiryWindow,
-		logger:       opt.Logger,
-		httpClient:   opt.HTTPClient,
+		username:       username,
+		isSecure:       true,
+		timeout:        opt.Timeout,
+		expiryWindow:   opt.ExpiryWindow,
+		logger:         opt.Logger,
+		httpClient:     opt.HTTPClient,
+		renewalBackoff: initialRenewalBackoff,
 		reqHeaders: map[string]string{
 			"Accept":     "application/json",
 			"Connection": "keep-alive",
@@ -200,6 +224,45 @@ func (p *AccessTokenProvider) SetHTTPClient(httpClient *httputil.HTTPClient) *Ac
 	return p
 }
 
+// SetRenewalFailedCallback sets the function that is called when the renewal
+// of the access token permanently fails, which is when the token expires
+// before a renewal succeeds. The provider renews the token when it enters the
+// expiry window, and retries the failed renewals with exponential backoff.
+//
+// The callback is called with the error of the last renewal, after the token
+// is dropped, so that the next request logs in again. Applications can use it
+// to alert, or to update the credentials with UpdateCredentials.
+func (p *AccessTokenProvider) SetRenewalFailedCallback(callback func(err error)) *AccessTokenProvider {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.renewalFailed = callback
+	return p
+}
+
+// UpdateCredentials replaces the username and password of the provider, and
+// drops the cached access token, so that the next request logs in with the
+// new credentials.
+func (p *AccessTokenProvider) UpdateCredentials(username string, password []byte) error {
+	if username == "" {
+		return nosqlerr.NewIllegalArgument("username must be non-empty")
+	}
+
+	if len(password) == 0 {
+		return nosqlerr.NewIllegalArgument("password must be non-nil and non-empty")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.username = username
+	p.password = make([]byte, len(password))
+	copy(p.password, password)
+	p.basicAuth = httputil.BasicAuth(p.username, p.password)
+	p.cachedToken = nil
+	p.stopRenewal()
+	return nil
+}
+
 // GetHTTPClient returns the http client associated with the provider.
 func (p *AccessTokenProvider) GetHTTPClient() *httputil.HTTPClient {
 	return p.httpClient
@@ -237,14 +300,14 @@ func (p *AccessTokenProvider) AuthorizationString(req auth.Request) (auth string
 
 	// Cached token is nil or expired.
 	if !ok {
-		return p.login()
+		return p.loginOnce()
 	}
 
 	if needRenew {
 		p.wg.Add(1)
 		go func() {
 			defer p.wg.Done()
-			p.renewToken()
+			p.renewTokenOnce()
 		}()
 	}
 
@@ -279,6 +342,7 @@ func (p *AccessTokenProvider) Close() error {
 
 	p.isClosed = true
 	p.cachedToken = nil
+	p.stopRenewal()
 	return nil
 }
 
@@ -315,9 +379,108 @@ func (p *AccessTokenProvider) login() (auth string, err error) {
 	}
 
 	p.cachedToken = token
+	p.scheduleRenewal(token, p.renewalBackoff)
 	return token.AuthString(), nil
 }
 
+// loginOnce logs into the server unless a valid token is cached, and returns
+// an authorization string that contains the token. Concurrent callers share a
+// single login and its error.
+func (p *AccessTokenProvider) loginOnce() (string, error) {
+	v, err, _ := p.logins.Do(func() (interface{}, error) {
+		p.mutex.RLock()
+		token, ok, _ := p.getCachedToken()
+		p.mutex.RUnlock()
+		if ok {
+			return token.AuthString(), nil
+		}
+		return p.login()
+	})
+	authStr, _ := v.(string)
+	return authStr, err
+}
+
+// renewTokenOnce renews the cached token if it still needs to be renewed.
+// Concurrent callers share a single renewal and its error.
+func (p *AccessTokenProvider) renewTokenOnce() error {
+	_, err, _ := p.renewals.Do(func() (interface{}, error) {
+		p.mutex.RLock()
+		_, ok, needRenew := p.getCachedToken()
+		p.mutex.RUnlock()
+		if !ok || !needRenew {
+			return nil, nil
+		}
+		return nil, p.renewToken()
+	})
+	return err
+}
+
+// scheduleRenewal schedules the renewal of token when it enters the expiry
+// window, with backoff as the delay before the retry of a failed renewal.
+// The caller must hold p.mutex.
+func (p *AccessTokenProvider) scheduleRenewal(token *auth.Token, backoff time.Duration) {
+	p.stopRenewal()
+	if token.Expiry.IsZero() || p.expiryWindow <= 0 || p.expiryWindow > token.ExpiresIn {
+		return
+	}
+
+	delay := time.Until(token.Expiry.Add(-p.expiryWindow))
+	p.renewalTimer = time.AfterFunc(delay, func() {
+		p.renewInBackground(token, backoff)
+	})
+}
+
+// stopRenewal stops the scheduled renewal, if any.
+// The caller must hold p.mutex.
+func (p *AccessTokenProvider) stopRenewal() {
+	if p.renewalTimer != nil {
+		p.renewalTimer.Stop()
+		p.renewalTimer = nil
+	}
+}
+
+// renewInBackground renews token if it is still cached. If the renewal
+// fails, it is retried after backoff, which doubles after each failure, until
+// the token expires. The RenewalFailed callback is called if the token expires
+// before the renewal succeeds.
+func (p *AccessTokenProvider) renewInBackground(token *auth.Token, backoff time.Duration) {
+	err := p.renewTokenOnce()
+	if err == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	if p.isClosed || p.cachedToken != token {
+		// The token was renewed or dropped meanwhile.
+		p.mutex.Unlock()
+		return
+	}
+
+	if time.Now().Add(backoff).Before(token.Expiry) {
+		p.logger.Info("retry the renewal of access token in %v", backoff)
+		next := 2 * backoff
+		if next > maxRenewalBackoff {
+			next = maxRenewalBackoff
+		}
+		p.renewalTimer = time.AfterFunc(backoff, func() {
+			p.renewInBackground(token, next)
+		})
+		p.mutex.Unlock()
+		return
+	}
+
+	// The token expires before the next retry, drop it so that the next
+	// request logs in again.
+	p.logger.Error("failed to renew access token before it expires: %v", err)
+	p.cachedToken = nil
+	callback := p.renewalFailed
+	p.mutex.Unlock()
+
+	if callback != nil {
+		callback(err)
+	}
+}
+
 // renewToken attempts to renew the token that currently in use.
 func (p *AccessTokenProvider) renewToken() error {
 	p.mutex.Lock()
@@ -335,6 +498,7 @@ func (p *AccessTokenProvider) renewToken() error {
 	}
 
 	p.cachedToken = token
+	p.scheduleRenewal(token, p.renewalBackoff)
 	return nil
 }
 
@@ -17,6 +17,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -381,6 +382,144 @@ func (suite *AuthTestSuite) TestAuthorizationString() {
 	wg.Wait()
 }
 
+func (suite *AuthTestSuite) TestConcurrentLogin() {
+	username := "TestUser01"
+	password := []byte("NoSql00__123456")
+	mockServer := &mockAuthServer{
+		tokenLifetime: time.Minute,
+		username:      username,
+		password:      password,
+	}
+	mockServer.Server = httptest.NewTLSServer(mockServer)
+	defer mockServer.Server.Close()
+
+	option := auth.ProviderOptions{
+		HTTPClient: testHTTPClient,
+		Logger:     testLogger,
+	}
+
+	for _, r := range []struct {
+		user       string
+		wantErr    bool
+		wantLogins int32
+	}{
+		{user: username, wantLogins: 1},
+		// Goroutines that wait for a failed login share its error.
+		{user: "NotExistsUser", wantErr: true},
+	} {
+		p, err := NewAccessTokenProvider(r.user, password, option)
+		if !suite.NoErrorf(err, "NewAccessTokenProvider() got error %v", err) {
+			continue
+		}
+		p.SetEndpoint(mockServer.Server.URL)
+		atomic.StoreInt32(&mockServer.logins, 0)
+
+		var wg sync.WaitGroup
+		cnt := 50
+		for i := 0; i < cnt; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := p.AuthorizationString(nil)
+				suite.Equalf(r.wantErr, err != nil, "AuthorizationString() got error %v", err)
+			}()
+		}
+		wg.Wait()
+
+		logins := atomic.LoadInt32(&mockServer.logins)
+		if r.wantLogins > 0 {
+			suite.Equalf(r.wantLogins, logins, "got unexpected number of logins")
+		} else {
+			suite.Lessf(logins, int32(cnt), "concurrent failed logins were not shared")
+		}
+		p.Close()
+	}
+}
+
+func (suite *AuthTestSuite) TestRenewalBackoff() {
+	username := "TestUser01"
+	password := []byte("NoSql00__123456")
+	mockServer := &mockAuthServer{
+		tokenLifetime: 3 * time.Second,
+		username:      username,
+		password:      password,
+	}
+	mockServer.Server = httptest.NewTLSServer(mockServer)
+	defer mockServer.Server.Close()
+
+	option := auth.ProviderOptions{
+		HTTPClient:   testHTTPClient,
+		Logger:       testLogger,
+		ExpiryWindow: 1500 * time.Millisecond,
+	}
+
+	var failures int32
+	var failErr atomic.Value
+	newProvider := func() *AccessTokenProvider {
+		p, err := NewAccessTokenProvider(username, password, option)
+		suite.Require().NoErrorf(err, "NewAccessTokenProvider() got error %v", err)
+		p.SetEndpoint(mockServer.Server.URL)
+		p.renewalBackoff = 100 * time.Millisecond
+		p.SetRenewalFailedCallback(func(err error) {
+			atomic.AddInt32(&failures, 1)
+			failErr.Store(err)
+		})
+		return p
+	}
+
+	// The token is renewed without requests, and the failed renewals are
+	// retried until one succeeds.
+	atomic.StoreInt32(&mockServer.failRenewals, 2)
+	p := newProvider()
+	authStr, err := p.AuthorizationString(nil)
+	suite.Require().NoErrorf(err, "AuthorizationString() got error %v", err)
+	suite.Truef(waitFor(func() bool {
+		return atomic.LoadInt32(&mockServer.renewals) == 3
+	}, 3*time.Second), "the token was not renewed")
+	suite.Truef(waitFor(func() bool {
+		s, err := p.AuthorizationString(nil)
+		return err == nil && s != authStr
+	}, time.Second), "the renewed token is not used")
+	suite.Equal(int32(0), atomic.LoadInt32(&failures))
+	p.Close()
+
+	// The callback is called when the token expires before a renewal succeeds.
+	atomic.StoreInt32(&mockServer.failRenewals, 1000)
+	p = newProvider()
+	_, err = p.AuthorizationString(nil)
+	suite.Require().NoErrorf(err, "AuthorizationString() got error %v", err)
+	suite.Truef(waitFor(func() bool {
+		return atomic.LoadInt32(&failures) == 1
+	}, 4*time.Second), "the renewal failed callback was not called")
+	if err, ok := failErr.Load().(error); suite.True(ok) {
+		suite.Contains(err.Error(), "renew access token failed")
+	}
+	p.mutex.RLock()
+	suite.Nil(p.cachedToken, "the token was not dropped")
+	p.mutex.RUnlock()
+
+	// The next request logs in with the updated credentials.
+	suite.Error(p.UpdateCredentials("", password))
+	suite.NoError(p.UpdateCredentials(username, password))
+	atomic.StoreInt32(&mockServer.logins, 0)
+	_, err = p.AuthorizationString(nil)
+	suite.NoErrorf(err, "AuthorizationString() got error %v", err)
+	suite.Equal(int32(1), atomic.LoadInt32(&mockServer.logins))
+	p.Close()
+}
+
+// waitFor polls cond until it returns true or timeout elapses, and returns
+// the last result of cond.
+func waitFor(cond func() bool, timeout time.Duration) bool {
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); {
+		if cond() {
+			return true
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return cond()
+}
+
 func (suite *AuthTestSuite) getAuthStringTest(testName string,
 	server *mockAuthServer, p *AccessTokenProvider) {
 
@@ -472,6 +611,12 @@ type mockAuthServer struct {
 	// A mutex used to guard the read/write of issuedToken.
 	mutex       sync.RWMutex
 	issuedToken string
+	// The number of login requests received.
+	logins int32
+	// The number of renew requests received.
+	renewals int32
+	// The number of renew requests to fail with 401 Unauthorized.
+	failRenewals int32
 }
 
 // ServeHTTP handles login, logout and renew requests for clients.
@@ -485,6 +630,7 @@ func (m *mockAuthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		atomic.AddInt32(&m.logins, 1)
 		if usr != m.username || pwd != string(m.password) {
 			w.WriteHeader(http.StatusUnauthorized)
 			fmt.Fprintln(w, "Invalid username or password.")
@@ -524,6 +670,12 @@ func (m *mockAuthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 		// Issue a new access token for the client.
 		if strings.HasSuffix(r.URL.Path, renewService) {
+			atomic.AddInt32(&m.renewals, 1)
+			if atomic.AddInt32(&m.failRenewals, -1) >= 0 {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprintln(w, "Renewal rejected.")
+				return
+			}
 			expireAt := time.Now().Add(m.tokenLifetime).Unix() * 1000
 			token := m.generateAccessToken()
 			fmt.Fprintf(w, `{"token": "%s", "expireAt": %d}`, token, expireAt)
@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+)
+
+// authProviderKey is the context key for AuthorizationProvider values.
+type authProviderKey struct{}
+
+// WithAuthorizationProvider returns a copy of ctx that carries the specified
+// AuthorizationProvider. Requests executed by a Client with the returned
+// context, using methods such as Client.GetWithContext() and
+// Client.PutWithContext(), are authorized by that provider instead of the
+// provider configured for the Client.
+//
+// This allows a single Client to issue requests on behalf of different
+// principals, for example the user of each incoming request of a service
+// that uses delegation tokens, while sharing the connections of the Client.
+//
+// The Client does not close the provider, which remains the responsibility
+// of the caller.
+func WithAuthorizationProvider(ctx context.Context, p AuthorizationProvider) context.Context {
+	return context.WithValue(ctx, authProviderKey{}, p)
+}
+
+// AuthorizationProviderFromContext returns the AuthorizationProvider carried
+// by ctx, if any.
+func AuthorizationProviderFromContext(ctx context.Context) (p AuthorizationProvider, ok bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	p, ok = ctx.Value(authProviderKey{}).(AuthorizationProvider)
+	return p, ok && p != nil
+}
+
+// setQueryAuthProvider saves the AuthorizationProvider carried by ctx in a
+// query request, so that the internal requests created for the query use it.
+func setQueryAuthProvider(ctx context.Context, req Request) {
+	qr, ok := req.(*QueryRequest)
+	if !ok || qr.isInternal {
+		return
+	}
+
+	if p, ok := AuthorizationProviderFromContext(ctx); ok {
+		qr.authProvider = p
+	}
+}
+
+// authProviderFor returns the AuthorizationProvider used for the request.
+func (c *Client) authProviderFor(ctx context.Context, req Request) AuthorizationProvider {
+	if p, ok := AuthorizationProviderFromContext(ctx); ok {
+		return p
+	}
+
+	if qr, ok := req.(*QueryRequest); ok && qr.authProvider != nil {
+		return qr.authProvider
+	}
+
+	return c.AuthorizationProvider
+}
@@ -0,0 +1,44 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextAuthorizationProvider(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+
+	user := &DummyAccessTokenProvider{TenantID: "user1"}
+	ctx := WithAuthorizationProvider(context.Background(), user)
+	p, ok := AuthorizationProviderFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, user, p)
+	_, ok = AuthorizationProviderFromContext(context.Background())
+	assert.False(t, ok)
+
+	getReq := &GetRequest{TableName: "T1"}
+	assert.Equal(t, client.AuthorizationProvider, client.authProviderFor(context.Background(), getReq))
+	assert.Equal(t, user, client.authProviderFor(ctx, getReq))
+
+	authStr, err := client.getAuthString(client.authProviderFor(ctx, getReq), getReq)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer user1", authStr)
+
+	// The internal requests of a query use the provider of the context used
+	// to execute the query.
+	queryReq := &QueryRequest{Statement: "select * from T1"}
+	setQueryAuthProvider(ctx, queryReq)
+	internalReq := queryReq.copyInternal()
+	assert.Equal(t, user, client.authProviderFor(context.Background(), internalReq))
+}
@@ -0,0 +1,63 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+)
+
+// enrichAuthError adds a hint to the message of an error returned from the
+// cloud service that may be caused by missing IAM policies rather than by the
+// reason its code suggests. The service reports a table the principal is not
+// authorized to see the same way as a table that does not exist, so the hint
+// describes what the request was checked against: the compartment, the kind
+// of principal and the host of the endpoint that returned the error.
+//
+// Other errors, and errors of requests not signed by an iam.SignatureProvider,
+// are returned unchanged.
+func (c *Client) enrichAuthError(ctx context.Context, req Request, err error, host string) error {
+	if err == nil || !c.IsCloud() {
+		return err
+	}
+
+	e, ok := err.(*nosqlerr.Error)
+	if !ok {
+		return err
+	}
+
+	p, ok := asSignatureProvider(c.authProviderFor(ctx, req))
+	if !ok {
+		return err
+	}
+	principal, compartment := p.PrincipalType(), p.CompartmentID()
+
+	var hint string
+	switch e.Code {
+	case nosqlerr.TableNotFound:
+		hint = fmt.Sprintf("the table was not found in compartment %s; if the table exists, "+
+			"it may be in another compartment, or the %s may not be authorized to access it", compartment, principal)
+	case nosqlerr.InsufficientPermission:
+		hint = fmt.Sprintf("the %s is not authorized to perform the operation in compartment %s; "+
+			"check the IAM policies that apply to it", principal, compartment)
+	case nosqlerr.InvalidAuthorization:
+		hint = fmt.Sprintf("the request signed by the %s was not authenticated; check that its credentials "+
+			"are valid, that the clock of this host is accurate, and that the endpoint is in a region "+
+			"the tenancy is subscribed to", principal)
+	default:
+		return err
+	}
+
+	return &nosqlerr.Error{
+		Code:    e.Code,
+		Message: fmt.Sprintf("%s (hint: %s; endpoint: %s)", e.Message, hint, host),
+		Cause:   e.Cause,
+	}
+}
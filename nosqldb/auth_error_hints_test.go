@@ -0,0 +1,106 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/oracle/nosql-go-sdk/nosqldb/httputil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichAuthError(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	client.SetSerialVersion(3)
+
+	mockExec := &mockExecutor{
+		errChan: make(chan error),
+	}
+	client.executor = mockExec
+	injected := make(chan error)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case e := <-injected:
+				mockExec.errChan <- e
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	signer, err := iam.NewSignatureProviderWithAuthorizationStringProvider(iam.AuthorizationStringProviderFunc(
+		func(req *http.Request) (string, error) { return `Signature version="1"`, nil }), "ocid1.compartment.oc1..test")
+	require.NoError(t, err)
+	ctx := WithAuthorizationProvider(context.Background(), signer)
+	req := &GetTableRequest{TableName: "orders"}
+
+	tests := []struct {
+		code nosqlerr.ErrorCode
+		want string
+	}{
+		{nosqlerr.TableNotFound, "may be in another compartment"},
+		{nosqlerr.InsufficientPermission, "check the IAM policies"},
+		{nosqlerr.InvalidAuthorization, "was not authenticated"},
+	}
+	for _, r := range tests {
+		go func(code nosqlerr.ErrorCode) {
+			injected <- nosqlerr.New(code, "mock error")
+		}(r.code)
+		_, err = client.getTableWithContext(ctx, req)
+		require.Truef(t, nosqlerr.Is(err, r.code), "%v: got error %v", r.code, err)
+		msg := err.Error()
+		assert.Containsf(t, msg, "mock error (hint: ", "%v: unexpected message", r.code)
+		assert.Containsf(t, msg, r.want, "%v: unexpected message", r.code)
+		assert.Containsf(t, msg, "external signer", "%v: message should describe the principal", r.code)
+		assert.Containsf(t, msg, "endpoint: mockHost", "%v: message should include the endpoint", r.code)
+		if r.code != nosqlerr.InvalidAuthorization {
+			assert.Containsf(t, msg, "compartment ocid1.compartment.oc1..test", "%v: message should include the compartment", r.code)
+		}
+	}
+
+	// The endpoint is the host the error was returned by.
+	client.executor = hostExecutor{RequestExecutor: mockExec, host: "replica.example.com:443"}
+	go func() {
+		injected <- nosqlerr.New(nosqlerr.TableNotFound, "mock error")
+	}()
+	_, err = client.getTableWithContext(ctx, req)
+	assert.Contains(t, err.Error(), "endpoint: replica.example.com:443")
+	client.executor = mockExec
+
+	// Errors of requests that are not signed with IAM are unchanged.
+	go func() {
+		injected <- nosqlerr.New(nosqlerr.TableNotFound, "mock error")
+	}()
+	_, err = client.getTableWithContext(context.Background(), req)
+	assert.Equal(t, nosqlerr.New(nosqlerr.TableNotFound, "mock error"), err)
+
+	err = client.processNotOKResponse(nil, http.StatusForbidden)
+	assert.True(t, nosqlerr.Is(err, nosqlerr.InsufficientPermission), "got error %v", err)
+	err = client.processNotOKResponse(nil, http.StatusUnauthorized)
+	assert.True(t, nosqlerr.Is(err, nosqlerr.InvalidAuthorization), "got error %v", err)
+}
+
+// hostExecutor sends the requests to the specified host.
+type hostExecutor struct {
+	httputil.RequestExecutor
+	host string
+}
+
+func (e hostExecutor) Do(req *http.Request) (*http.Response, error) {
+	req.URL.Host = e.host
+	return e.RequestExecutor.Do(req)
+}
@@ -8,7 +8,10 @@
 package nosqldb
 
 import (
+	"context"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/oracle/nosql-go-sdk/nosqldb/auth"
 	"github.com/oracle/nosql-go-sdk/nosqldb/logger"
@@ -25,6 +28,7 @@ import (
 //
 //   iam.SignatureProvider        cloud IAM
 //   kvstore.AccessTokenProvider  on-premise
+//   kvstore.KerberosProvider     on-premise with Kerberos
 //
 // Applications do not need to provide an implementation
 // for this interface unless there is a special requirement.
@@ -38,6 +42,12 @@ type AuthorizationProvider interface {
 	//
 	//   Bearer    : supported for the on-premise Oracle NoSQL server and the Oracle NoSQL cloud simulator
 	//   Signature : supported for the Oracle NoSQL cloud service that uses OCI IAM for request authorization
+	//   Negotiate : supported for the on-premise Oracle NoSQL server that uses Kerberos
+	//
+	// Other schemes are used for proxies with their own authorization: the
+	// string returned by AuthorizationString, if not empty, is set as the
+	// Authorization header of requests, which SignHTTPRequest can then
+	// modify.
 	//
 	AuthorizationScheme() string
 
@@ -58,6 +68,107 @@ type AuthorizationProvider interface {
 	GetLogger() *logger.Logger
 }
 
+// ContextAuthorizationProvider is an AuthorizationProvider that honors the
+// deadline and cancellation of a context while it signs a request, such as
+// while it waits for a security token to be renewed.
+//
+// If the AuthorizationProvider of a Client implements this interface, the
+// Client calls SignHTTPRequestContext rather than SignHTTPRequest, with a
+// context that is done when the request times out or the context of the
+// request is done. iam.SignatureProvider implements this interface.
+type ContextAuthorizationProvider interface {
+	AuthorizationProvider
+
+	// SignHTTPRequestContext is like SignHTTPRequest, but returns the error
+	// of ctx if ctx is done before the request is signed.
+	SignHTTPRequestContext(ctx context.Context, httpReq *http.Request) error
+}
+
+// ServerDateObserver is an AuthorizationProvider that uses the dates of the
+// responses of the server, such as to correct the difference between the
+// clock of the server and the local clock when it dates signatures.
+//
+// If the AuthorizationProvider of a Client implements this interface, the
+// Client calls ObserveServerDate with the Date header of each response,
+// whether the request succeeded or not. iam.SignatureProvider implements this
+// interface, see iam.SignatureProvider.SetClockSkewCorrection.
+type ServerDateObserver interface {
+	AuthorizationProvider
+
+	// ObserveServerDate is called with the date of a response of the server.
+	ObserveServerDate(serverDate time.Time)
+}
+
+// RequestSigner signs the HTTP requests that a Client sends, for proxies and
+// gateways that authenticate requests with their own schemes, such as single
+// sign-on gateways. It is set by Config.RequestSigner, as a simpler
+// alternative to implementing AuthorizationProvider.
+//
+// Sign may set any header of the request, such as the Authorization header,
+// but must not read or modify the body of the request. If the RequestSigner
+// also implements the SignContext method of iam.ContextHTTPRequestSigner,
+// the Client calls SignContext with a context that is done when the request
+// times out. If it implements io.Closer, it is closed with the Client.
+// The signers returned by iam.SignatureProvider.RequestSigner implement this
+// interface.
+//
+// Implementations of this interface must be safe for concurrent use by
+// multiple goroutines.
+type RequestSigner interface {
+	Sign(httpReq *http.Request) error
+}
+
+// RequestSignerFunc is an adapter to use a function as a RequestSigner.
+type RequestSignerFunc func(httpReq *http.Request) error
+
+// Sign calls f(httpReq).
+func (f RequestSignerFunc) Sign(httpReq *http.Request) error {
+	return f(httpReq)
+}
+
+// requestSignerScheme is the authorization scheme of the
+// AuthorizationProvider created for a RequestSigner.
+const requestSignerScheme = "RequestSigner"
+
+// requestSignerProvider is the AuthorizationProvider that signs requests with
+// a RequestSigner.
+type requestSignerProvider struct {
+	signer RequestSigner
+	logger *logger.Logger
+}
+
+func (p *requestSignerProvider) AuthorizationScheme() string {
+	return requestSignerScheme
+}
+
+func (p *requestSignerProvider) AuthorizationString(req auth.Request) (string, error) {
+	return "", nil
+}
+
+func (p *requestSignerProvider) SignHTTPRequest(httpReq *http.Request) error {
+	return p.signer.Sign(httpReq)
+}
+
+func (p *requestSignerProvider) SignHTTPRequestContext(ctx context.Context, httpReq *http.Request) error {
+	if s, ok := p.signer.(interface {
+		SignContext(ctx context.Context, r *http.Request) error
+	}); ok {
+		return s.SignContext(ctx, httpReq)
+	}
+	return p.signer.Sign(httpReq)
+}
+
+func (p *requestSignerProvider) Close() error {
+	if c, ok := p.signer.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (p *requestSignerProvider) GetLogger() *logger.Logger {
+	return p.logger
+}
+
 // accessTokenRequest represents a request for access token from authorization server.
 //
 // This implements the auth.Request interface.
@@ -0,0 +1,213 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// writeOpOverhead is an estimate of the size of a write operation in a
+// WriteMultiple request, excluding the table name and the row.
+const writeOpOverhead = 32
+
+// BatchPlanOptions specifies the limits used by PlanWriteBatches.
+type BatchPlanOptions struct {
+	// MaxOperations specifies the maximum number of operations in a batch.
+	// If set to 0, the maximum number of operations in a WriteMultiple
+	// request on the cloud service, which is 50, is used.
+	MaxOperations int
+
+	// MaxBytes specifies the maximum estimated size of a batch in bytes.
+	// If set to 0, the size limit of a WriteMultiple request, which is 25 MB,
+	// is used.
+	//
+	// The size of an operation is estimated from the serialized size of its
+	// row or key. An operation larger than MaxBytes is planned in a batch of
+	// its own.
+	MaxBytes int
+}
+
+// WriteBatch represents a group of write operations planned by
+// PlanWriteBatches to be executed in one WriteMultipleRequest.
+type WriteBatch struct {
+	// ShardKey represents the values of the shard key fields shared by the
+	// operations, encoded with types.Key.Encode().
+	ShardKey string
+
+	// Operations represents the operations of the batch, in the order they
+	// were specified.
+	Operations []*WriteOperation
+
+	// Indexes represents the index of each operation in the slice of
+	// operations passed to PlanWriteBatches.
+	Indexes []int
+
+	// Size represents the estimated size of the batch in bytes.
+	Size int
+}
+
+// PlanWriteBatches groups write operations into batches that can each be
+// executed in one WriteMultipleRequest. It is intended for applications that
+// load or modify many rows and need control over how the operations are
+// batched, for example to execute batches concurrently.
+//
+// The operations of a WriteMultipleRequest must share the same shard key, so
+// the operations are grouped by the values of the shardKey fields, which can
+// be obtained with Client.TableShardKey. The operations must write to the same
+// table, or to tables of the same parent-child hierarchy, which share the shard
+// key of the top-level table. The operations of each group are
+// split into batches that do not exceed the limits of opts, which may be nil
+// to use the default limits. The order of the operations is preserved within
+// each group.
+//
+// The batches of the groups are interleaved: the first batch of each group is
+// returned, in the order the groups first appear, then the second batch of
+// each group, and so on. Executing the batches in this order distributes the
+// load across shards rather than writing to one shard at a time.
+func PlanWriteBatches(ops []*WriteOperation, shardKey []string, opts *BatchPlanOptions) ([]*WriteBatch, error) {
+	if len(shardKey) == 0 {
+		return nil, nosqlerr.NewIllegalArgument("PlanWriteBatches: shardKey must be non-empty")
+	}
+
+	maxOps, maxBytes := maxCloudBatchOps, proto.BatchRequestSizeLimit
+	if opts != nil {
+		if opts.MaxOperations < 0 || opts.MaxBytes < 0 {
+			return nil, nosqlerr.NewIllegalArgument("PlanWriteBatches: the limits of opts must not be negative")
+		}
+		if opts.MaxOperations > 0 {
+			maxOps = opts.MaxOperations
+		}
+		if opts.MaxBytes > 0 {
+			maxBytes = opts.MaxBytes
+		}
+	}
+
+	// The batches of each shard key, with the shard keys in order of first
+	// appearance.
+	var keys []string
+	groups := make(map[string][]*WriteBatch)
+	for i, op := range ops {
+		row, tableName, err := writeOpRow(op)
+		if err != nil {
+			return nil, nosqlerr.NewIllegalArgument("PlanWriteBatches: operation %d: %v", i, err)
+		}
+
+		k, err := types.KeyFromMapValue(row, shardKey...)
+		if err != nil {
+			return nil, nosqlerr.NewIllegalArgument("PlanWriteBatches: operation %d: %v", i, err)
+		}
+		key, err := k.Encode()
+		if err != nil {
+			return nil, nosqlerr.NewIllegalArgument("PlanWriteBatches: operation %d: %v", i, err)
+		}
+
+		w := binary.NewWriter()
+		if _, err = w.WriteMap(row); err != nil {
+			return nil, nosqlerr.NewIllegalArgument("PlanWriteBatches: operation %d: %v", i, err)
+		}
+		size := w.Size() + len(tableName) + writeOpOverhead
+
+		batches, ok := groups[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		var b *WriteBatch
+		if n := len(batches); n > 0 {
+			b = batches[n-1]
+		}
+		if b == nil || len(b.Operations) == maxOps || (len(b.Operations) > 0 && b.Size+size > maxBytes) {
+			b = &WriteBatch{ShardKey: key}
+			groups[key] = append(batches, b)
+		}
+		b.Operations = append(b.Operations, op)
+		b.Indexes = append(b.Indexes, i)
+		b.Size += size
+	}
+
+	plan := make([]*WriteBatch, 0, countBatches(groups))
+	for round := 0; len(plan) < cap(plan); round++ {
+		for _, key := range keys {
+			if batches := groups[key]; round < len(batches) {
+				plan = append(plan, batches[round])
+			}
+		}
+	}
+	return plan, nil
+}
+
+func countBatches(groups map[string][]*WriteBatch) (n int) {
+	for _, batches := range groups {
+		n += len(batches)
+	}
+	return n
+}
+
+// writeOpRow returns the row or key the operation writes, and the name of the
+// table it writes to.
+func writeOpRow(op *WriteOperation) (*types.MapValue, string, error) {
+	switch {
+	case op == nil:
+		return nil, "", errors.New("WriteOperation must be non-nil")
+	case (op.DeleteRequest == nil) == (op.PutRequest == nil):
+		return nil, "", errors.New("exactly one of PutRequest or DeleteRequest must be specified")
+	case op.DeleteRequest != nil:
+		if op.DeleteRequest.Key == nil {
+			return nil, "", errors.New("DeleteRequest: Key must be non-nil")
+		}
+		return op.DeleteRequest.Key, op.DeleteRequest.TableName, nil
+	}
+
+	req := op.PutRequest
+	value, err := req.rowValue()
+	if err != nil {
+		return nil, "", err
+	}
+	if value == nil {
+		return nil, "", errors.New("PutRequest: Value must be non-nil")
+	}
+	return value, req.TableName, nil
+}
+
+// TableShardKey returns the names of the shard key fields of the specified
+// table, as needed by PlanWriteBatches. If the table does not declare a shard
+// key, its primary key is the shard key. The table metadata is retrieved with
+// GetTableCached.
+func (c *Client) TableShardKey(namespace, tableName string) ([]string, error) {
+	_, shardKey, err := c.tableKeys(context.Background(), namespace, tableName)
+	return shardKey, err
+}
+
+// tableKeys returns the names of the primary key and shard key fields of the
+// specified table, as declared in its schema.
+func (c *Client) tableKeys(ctx context.Context, namespace, tableName string) (primaryKey, shardKey []string, err error) {
+	table, err := c.getTableCachedWithContext(ctx, namespace, tableName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var schema struct {
+		PrimaryKey []string `json:"primaryKey"`
+		ShardKey   []string `json:"shardKey"`
+	}
+	if err = json.Unmarshal([]byte(table.Schema), &schema); err != nil || len(schema.PrimaryKey) == 0 {
+		return nil, nil, nosqlerr.New(nosqlerr.IllegalState,
+			"cannot determine the primary key of table %q from its schema", tableName)
+	}
+
+	if len(schema.ShardKey) == 0 {
+		schema.ShardKey = schema.PrimaryKey
+	}
+	return schema.PrimaryKey, schema.ShardKey, nil
+}
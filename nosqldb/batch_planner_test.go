@@ -0,0 +1,71 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanWriteBatches(t *testing.T) {
+	put := func(shard string, id int) *WriteOperation {
+		return &WriteOperation{PutRequest: &PutRequest{
+			TableName: "orders",
+			Value:     types.NewMapValue(map[string]interface{}{"shard": shard, "id": id}),
+		}}
+	}
+	del := func(shard string, id int) *WriteOperation {
+		return &WriteOperation{DeleteRequest: &DeleteRequest{
+			TableName: "orders",
+			Key:       types.NewMapValue(map[string]interface{}{"shard": shard, "id": id}),
+		}}
+	}
+
+	ops := []*WriteOperation{
+		put("a", 1), put("a", 2), put("b", 1), del("a", 3), put("c", 1), put("b", 2), put("b", 3),
+	}
+	plan, err := PlanWriteBatches(ops, []string{"shard"}, &BatchPlanOptions{MaxOperations: 2})
+	require.NoError(t, err)
+
+	// The first batch of each shard, then the second batch of each shard.
+	var indexes [][]int
+	for _, b := range plan {
+		indexes = append(indexes, b.Indexes)
+		assert.Len(t, b.Operations, len(b.Indexes))
+		for i, op := range b.Operations {
+			assert.Same(t, ops[b.Indexes[i]], op)
+		}
+		assert.Greater(t, b.Size, 0)
+	}
+	assert.Equal(t, [][]int{{0, 1}, {2, 5}, {4}, {3}, {6}}, indexes)
+	assert.Equal(t, plan[0].ShardKey, plan[3].ShardKey)
+	assert.NotEqual(t, plan[0].ShardKey, plan[1].ShardKey)
+
+	// A batch is limited by its estimated size.
+	plan, err = PlanWriteBatches(ops[:2], []string{"shard"}, &BatchPlanOptions{MaxBytes: 1})
+	require.NoError(t, err)
+	assert.Len(t, plan, 2)
+
+	// The default limits.
+	plan, err = PlanWriteBatches(ops, []string{"shard"}, nil)
+	require.NoError(t, err)
+	assert.Len(t, plan, 3)
+
+	_, err = PlanWriteBatches(ops, nil, nil)
+	assert.True(t, nosqlerr.IsIllegalArgument(err), "got error %v", err)
+	_, err = PlanWriteBatches(ops, []string{"region"}, nil)
+	assert.True(t, nosqlerr.IsIllegalArgument(err), "got error %v", err)
+	_, err = PlanWriteBatches([]*WriteOperation{{}}, []string{"shard"}, nil)
+	assert.True(t, nosqlerr.IsIllegalArgument(err), "got error %v", err)
+	_, err = PlanWriteBatches(ops, []string{"shard"}, &BatchPlanOptions{MaxOperations: -1})
+	assert.True(t, nosqlerr.IsIllegalArgument(err), "got error %v", err)
+}
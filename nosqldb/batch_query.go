@@ -0,0 +1,149 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"sync"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// defaultBatchConcurrency is the number of statements ExecuteBatch executes
+// concurrently if Config.MaxConcurrentRequests is not set.
+const defaultBatchConcurrency = 8
+
+// Statement represents a read-only query executed by Client.ExecuteBatch.
+// Either Query or PreparedStatement must be specified.
+type Statement struct {
+	// Query specifies the SQL statement.
+	Query string
+
+	// PreparedStatement specifies a prepared statement, with its variables
+	// bound, to execute instead of Query.
+	PreparedStatement *PreparedStatement
+
+	// Consistency specifies the consistency of the query. It is optional.
+	// If not set, the default consistency of the Client is used.
+	Consistency types.Consistency
+
+	// Limit specifies the maximum number of rows returned by each request of
+	// the query. It is optional. All results of the query are returned by
+	// ExecuteBatch regardless of Limit.
+	Limit uint
+}
+
+// StatementResult represents the results of a Statement executed by
+// Client.ExecuteBatch.
+type StatementResult struct {
+	// Rows represents all rows returned by the query.
+	Rows []*types.MapValue
+
+	// ReadKB represents the read throughput consumed by the query, in KB.
+	ReadKB int
+
+	// ReadUnits represents the read units consumed by the query.
+	ReadUnits int
+
+	// Err represents the error that occurred executing the query, if any, in
+	// which case Rows is not valid.
+	Err error
+}
+
+// ExecuteBatch executes independent read-only statements concurrently, and
+// returns their results in the order of the statements. This reduces the
+// latency of applications that issue many small queries at once, such as the
+// backends of dashboards.
+//
+// The number of statements executed concurrently is Config.MaxConcurrentRequests
+// if it is set, or 8 otherwise. Each statement is executed until all of its
+// results are returned, and its requests are subject to the concurrency limits
+// of the Client as any other request.
+//
+// An error executing a statement is reported in the Err field of the
+// corresponding StatementResult rather than returned, and does not stop the
+// other statements. If ctx is done, the statements that are not complete fail
+// with the error of ctx.
+func (c *Client) ExecuteBatch(ctx context.Context, statements []Statement) ([]StatementResult, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	for i, stmt := range statements {
+		if (stmt.Query == "") == (stmt.PreparedStatement == nil) {
+			return nil, nosqlerr.NewIllegalArgument("ExecuteBatch: statement %d must specify "+
+				"either a Query or a PreparedStatement", i)
+		}
+	}
+
+	concurrency := c.MaxConcurrentRequests
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	if concurrency > len(statements) {
+		concurrency = len(statements)
+	}
+
+	results := make([]StatementResult, len(statements))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for n := 0; n < concurrency; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = c.executeStatement(ctx, &statements[i])
+			}
+		}()
+	}
+	for i := range statements {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results, nil
+}
+
+// executeStatement executes a statement of ExecuteBatch until all its results
+// are returned.
+func (c *Client) executeStatement(ctx context.Context, stmt *Statement) (res StatementResult) {
+	req := &QueryRequest{
+		Statement:         stmt.Query,
+		PreparedStatement: stmt.PreparedStatement,
+		Consistency:       stmt.Consistency,
+		Limit:             stmt.Limit,
+	}
+	defer req.Close()
+
+	for {
+		if res.Err = ctx.Err(); res.Err != nil {
+			return
+		}
+
+		var qres *QueryResult
+		if qres, res.Err = c.QueryWithContext(ctx, req); res.Err != nil {
+			return
+		}
+
+		var rows []*types.MapValue
+		if rows, res.Err = qres.GetResults(); res.Err != nil {
+			return
+		}
+		res.Rows = append(res.Rows, rows...)
+
+		if capacity, err := qres.ConsumedCapacity(); err == nil {
+			res.ReadKB += capacity.ReadKB
+			res.ReadUnits += capacity.ReadUnits
+		}
+
+		if req.IsDone() {
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteBatch(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	client.SetSerialVersion(3)
+
+	invalid := []struct {
+		desc string
+		stmt Statement
+	}{
+		{"empty statement", Statement{}},
+		{"Query and PreparedStatement", Statement{Query: "SELECT * FROM t", PreparedStatement: &PreparedStatement{}}},
+	}
+	for _, r := range invalid {
+		_, err = client.ExecuteBatch(context.Background(), []Statement{r.stmt})
+		assert.Truef(t, nosqlerr.IsIllegalArgument(err), "%s: unexpected error %v", r.desc, err)
+	}
+
+	// The errors of the statements are reported in their results.
+	mockExec := &mockExecutor{
+		errChan: make(chan error),
+	}
+	client.executor = mockExec
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case mockExec.errChan <- nosqlerr.New(nosqlerr.TableNotFound, "table not found"):
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	statements := make([]Statement, 20)
+	for i := range statements {
+		statements[i].Query = fmt.Sprintf("SELECT * FROM t%d", i)
+	}
+	results, err := client.ExecuteBatch(context.Background(), statements)
+	require.NoError(t, err)
+	require.Len(t, results, len(statements))
+	for i, res := range results {
+		assert.Truef(t, nosqlerr.Is(res.Err, nosqlerr.TableNotFound), "statement %d: unexpected error %v", i, res.Err)
+	}
+
+	// The statements that are not complete fail when the context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = client.ExecuteBatch(ctx, statements[:2])
+	require.NoError(t, err)
+	for i, res := range results {
+		assert.Equalf(t, context.Canceled, res.Err, "statement %d: unexpected error", i)
+	}
+}
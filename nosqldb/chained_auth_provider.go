@@ -0,0 +1,163 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+)
+
+// defaultMetadataTimeout is the timeout of the requests to the instance
+// metadata service of the instance principal source of
+// DefaultAuthorizationSources, so that the chain fails quickly outside of OCI.
+const defaultMetadataTimeout = 10 * time.Second
+
+// AuthorizationSource represents a source of credentials that a
+// ChainedAuthorizationProvider tries.
+type AuthorizationSource struct {
+	// Name describes the source, such as "environment" or "instance principal".
+	Name string
+
+	// New creates an AuthorizationProvider from the source. It returns an
+	// error if the source does not provide valid credentials.
+	New func() (AuthorizationProvider, error)
+}
+
+// ChainedAuthorizationProvider is an AuthorizationProvider that uses the
+// credentials of the first of a list of sources that provides them, so that
+// the same code can run in different deployment targets, such as a
+// developer machine with an OCI configuration file, an Oracle Function or an
+// OCI compute instance.
+//
+// The source is selected once, when the provider is created.
+type ChainedAuthorizationProvider struct {
+	AuthorizationProvider
+
+	source string
+}
+
+// NewChainedAuthorizationProvider creates a ChainedAuthorizationProvider that
+// tries the specified sources in order, and uses the provider created by the
+// first source that succeeds. If all sources fail, the returned error lists
+// the error of each source.
+func NewChainedAuthorizationProvider(sources ...AuthorizationSource) (*ChainedAuthorizationProvider, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("no authorization sources specified")
+	}
+
+	var msgs []string
+	for _, s := range sources {
+		p, err := s.New()
+		if err == nil && p != nil {
+			return &ChainedAuthorizationProvider{AuthorizationProvider: p, source: s.Name}, nil
+		}
+		if err == nil {
+			err = errors.New("no provider returned")
+		}
+		msgs = append(msgs, s.Name+": "+err.Error())
+	}
+	return nil, errors.New("no valid credentials found in any authorization source: " + strings.Join(msgs, "; "))
+}
+
+// NewDefaultChainedAuthorizationProvider creates a ChainedAuthorizationProvider
+// that tries the sources returned by DefaultAuthorizationSources.
+func NewDefaultChainedAuthorizationProvider(compartmentID string) (*ChainedAuthorizationProvider, error) {
+	return NewChainedAuthorizationProvider(DefaultAuthorizationSources(compartmentID)...)
+}
+
+// Source returns the name of the source whose credentials are used.
+func (p *ChainedAuthorizationProvider) Source() string {
+	return p.source
+}
+
+// DefaultAuthorizationSources returns the sources of credentials for the
+// cloud service, in the order they are tried:
+//
+//	environment         the OCI_CLI_* environment variables, see iam.NewSignatureProviderFromEnvironment
+//	config file         the DEFAULT profile of ~/.oci/config, see iam.NewSignatureProvider
+//	resource principal  if OCI_RESOURCE_PRINCIPAL_VERSION is set, see iam.NewSignatureProviderWithResourcePrincipal
+//	instance principal  see iam.NewSignatureProviderWithInstancePrincipal
+//
+// Sources of explicit credentials can be prepended to the returned sources,
+// so that they take precedence.
+//
+// The compartmentID specifies the OCID of compartment to which the Oracle
+// NoSQL tables belong. If empty, the tenancy OCID is used.
+func DefaultAuthorizationSources(compartmentID string) []AuthorizationSource {
+	return []AuthorizationSource{
+		{
+			Name: "environment",
+			New: func() (AuthorizationProvider, error) {
+				return signatureProvider(iam.NewSignatureProviderFromEnvironment(compartmentID))
+			},
+		},
+		{
+			Name: "config file",
+			New: func() (AuthorizationProvider, error) {
+				return signatureProvider(iam.NewSignatureProviderFromFile("~/.oci/config", "", "", compartmentID))
+			},
+		},
+		{
+			Name: "resource principal",
+			New: func() (AuthorizationProvider, error) {
+				if _, ok := os.LookupEnv("OCI_RESOURCE_PRINCIPAL_VERSION"); !ok {
+					return nil, errors.New("OCI_RESOURCE_PRINCIPAL_VERSION is not set")
+				}
+				return signatureProvider(iam.NewSignatureProviderWithResourcePrincipal(compartmentID))
+			},
+		},
+		{
+			Name: "instance principal",
+			New: func() (AuthorizationProvider, error) {
+				options := iam.InstancePrincipalOptions{
+					HTTPClient: &http.Client{Timeout: defaultMetadataTimeout},
+				}
+				return signatureProvider(iam.NewSignatureProviderWithInstancePrincipalOptions(compartmentID, options))
+			},
+		},
+	}
+}
+
+// ProviderAuthorizationSource returns a source that always provides p, which
+// can be used to place explicitly configured credentials in a chain. If p is
+// nil, the source fails.
+func ProviderAuthorizationSource(name string, p AuthorizationProvider) AuthorizationSource {
+	return AuthorizationSource{
+		Name: name,
+		New: func() (AuthorizationProvider, error) {
+			if p == nil {
+				return nil, errors.New("no provider configured")
+			}
+			return p, nil
+		},
+	}
+}
+
+// signatureProvider converts the results of the iam constructors, so that a
+// nil *iam.SignatureProvider is not returned as a non-nil interface.
+func signatureProvider(p *iam.SignatureProvider, err error) (AuthorizationProvider, error) {
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// asSignatureProvider returns p as an *iam.SignatureProvider, if it is one or
+// it is a ChainedAuthorizationProvider that uses one.
+func asSignatureProvider(p AuthorizationProvider) (*iam.SignatureProvider, bool) {
+	if cp, ok := p.(*ChainedAuthorizationProvider); ok && cp != nil {
+		p = cp.AuthorizationProvider
+	}
+	sp, ok := p.(*iam.SignatureProvider)
+	return sp, ok
+}
@@ -0,0 +1,71 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainedAuthorizationProvider(t *testing.T) {
+	_, err := NewChainedAuthorizationProvider()
+	assert.Error(t, err)
+
+	var tried []string
+	failing := func(name string) AuthorizationSource {
+		return AuthorizationSource{Name: name, New: func() (AuthorizationProvider, error) {
+			tried = append(tried, name)
+			return nil, errors.New("not configured")
+		}}
+	}
+
+	explicit := &DummyAccessTokenProvider{TenantID: "explicit"}
+	p, err := NewChainedAuthorizationProvider(failing("first"), ProviderAuthorizationSource("explicit", explicit),
+		failing("last"))
+	require.NoError(t, err)
+	assert.Equal(t, "explicit", p.Source())
+	assert.Equal(t, []string{"first"}, tried)
+	authStr, err := p.AuthorizationString(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer explicit", authStr)
+	_, ok := asSignatureProvider(p)
+	assert.False(t, ok)
+
+	_, err = NewChainedAuthorizationProvider(failing("first"), ProviderAuthorizationSource("explicit", nil))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "first: not configured")
+		assert.Contains(t, err.Error(), "explicit: no provider configured")
+	}
+
+	// A chained signature provider is used as an iam.SignatureProvider.
+	privateKeyFile := "testdata/chained_key.pem"
+	require.NoError(t, generatePrivateKeyPEM(privateKeyFile))
+	defer os.Remove(privateKeyFile)
+	sp, err := iam.NewRawSignatureProvider("ocid1.tenancy.oc1..test", "ocid1.user.oc1..test", "us-ashburn-1",
+		"fingerprint", "", privateKeyFile, nil)
+	require.NoError(t, err)
+	p, err = NewChainedAuthorizationProvider(ProviderAuthorizationSource("explicit", sp))
+	require.NoError(t, err)
+	unwrapped, ok := asSignatureProvider(p)
+	assert.True(t, ok)
+	assert.Equal(t, sp, unwrapped)
+
+	cfg := Config{AuthorizationProvider: p}
+	assert.NoError(t, cfg.Validate())
+
+	names := make([]string, 0, 4)
+	for _, s := range DefaultAuthorizationSources("") {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"environment", "config file", "resource principal", "instance principal"}, names)
+}
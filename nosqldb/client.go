@@ -105,6 +105,57 @@ type Client struct {
 
 	// Internal: used by tests. This is _not_ the wire protocol version.
 	serverSerialVersion int
+
+	// schemaCache caches table metadata, if enabled by Config.SchemaCacheTTL.
+	schemaCache *schemaCache
+
+	// cookieJar stores cookies set by the server, if enabled by
+	// Config.SessionAffinity.
+	cookieJar http.CookieJar
+
+	// affinityKey is the value of the affinity header for requests other
+	// than queries.
+	affinityKey string
+
+	// tableHooks maps lower case table names to the hooks registered with
+	// SetTableHooks.
+	tableHooks map[string]*TableHooks
+	hooksMux   sync.RWMutex
+
+	// limiter limits the number of concurrent requests. It is nil if the
+	// number of concurrent requests is not limited.
+	limiter *concurrencyLimiter
+
+	// endpoints selects the endpoint of each request. It is nil if a single
+	// endpoint is configured.
+	endpoints *endpointSelector
+
+	// sizeStats aggregates the sizes of the messages exchanged with the
+	// server, see MessageSizeStats.
+	sizeStats messageSizeStats
+
+	// retryStats aggregates the errors returned to requests by class, see
+	// RetryStats.
+	retryStats retryStats
+
+	// activeTables records the tables known to be active, see
+	// EnsureTableActive.
+	activeTables activeTables
+
+	// restEndpoints records the endpoints on which the REST API is used, if
+	// enabled by Config.RESTFallback.
+	restEndpoints restEndpoints
+
+	// compatibility records the server versions observed on the first
+	// response, see CompatibilityInfo.
+	compatibility compatibility
+
+	// recentErrors keeps the most recent request errors, see SupportBundle.
+	recentErrors recentErrors
+
+	// putCoalescer groups puts into WriteMultiple requests. It is nil unless
+	// enabled by Config.PutCoalescingWindow.
+	putCoalescer *putCoalescer
 }
 
 var (
@@ -132,6 +183,10 @@ func NewClient(cfg Config) (*Client, error) {
 		return nil, err
 	}
 
+	if err = cfg.checkAllowedEndpoints(); err != nil {
+		return nil, err
+	}
+
 	if cfg.httpClient == nil {
 		cfg.httpClient, err = httputil.NewHTTPClient(cfg.HTTPConfig)
 		if err != nil {
@@ -153,6 +208,7 @@ func NewClient(cfg Config) (*Client, error) {
 		topology:      nil,
 	}
 	c.handleResponse = c.processResponse
+	c.loadProtocolVersions()
 	c.queryLogger, err = newQueryLogger()
 	if err != nil {
 		c.logger.Warn("cannot create a query logger: %v", err)
@@ -165,8 +221,31 @@ func NewClient(cfg Config) (*Client, error) {
 
 	c.oneTimeMessages = make(map[string]struct{})
 
+	if err = c.initAffinity(); err != nil {
+		return nil, err
+	}
+
+	if cfg.SchemaCacheTTL > 0 {
+		c.schemaCache = newSchemaCache(cfg.SchemaCacheTTL)
+	}
+	c.limiter = newConcurrencyLimiter(&cfg)
+	c.putCoalescer = newPutCoalescer(&cfg)
+
+	if c.endpoints, err = newEndpointSelector(&cfg); err != nil {
+		return nil, err
+	}
+
+	c.initDeterministic()
 	c.warmupClientAuth()
 
+	if len(cfg.PreflightTables) > 0 {
+		report, _ := c.CheckPermissions(context.Background(), cfg.PreflightTables...)
+		if err = report.Err(); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
 	return c, nil
 }
 
@@ -202,11 +281,16 @@ func (c *Client) Close() error {
 // Use of types.Absolute consistency may affect latency of the operation and may
 // result in additional cost for the operation.
 func (c *Client) Get(req *GetRequest) (*GetResult, error) {
+	return c.GetWithContext(context.Background(), req)
+}
+
+// GetWithContext is like Get, but uses the specified context.
+func (c *Client) GetWithContext(ctx context.Context, req *GetRequest) (*GetResult, error) {
 	if req == nil {
 		return nil, errNilRequest
 	}
 
-	res, err := c.execute(req)
+	res, err := c.executeWithContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -257,7 +341,9 @@ func (c *Client) getTableWithContext(ctx context.Context, req *GetTableRequest)
 // IllegalArgument error is returned.
 //
 // On success the returned GetIndexesResult is non-nil and contains desired
-// index information.
+// index information. The indexes can be paged by specifying the StartIndex
+// and Limit fields of the request. The paging is done by the client: each
+// request retrieves the information of all the indexes of the table.
 func (c *Client) GetIndexes(req *GetIndexesRequest) (*GetIndexesResult, error) {
 	if req == nil {
 		return nil, errNilRequest
@@ -269,12 +355,27 @@ func (c *Client) GetIndexes(req *GetIndexesRequest) (*GetIndexesResult, error) {
 	}
 
 	if res, ok := res.(*GetIndexesResult); ok {
+		res.Indexes, res.LastIndexReturned = pageIndexes(res.Indexes, req.StartIndex, req.Limit)
 		return res, nil
 	}
 
 	return nil, errUnexpectedResult
 }
 
+// pageIndexes returns up to limit indexes starting at index start, and the
+// index that follows them. If limit is 0, all the indexes from start are
+// returned.
+func pageIndexes(indexes []IndexInfo, start, limit uint) ([]IndexInfo, uint) {
+	if start > uint(len(indexes)) {
+		start = uint(len(indexes))
+	}
+	end := uint(len(indexes))
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+	return indexes[start:end], end
+}
+
 // DoTableRequest performs an operation that manages table schema or changes
 // table limits.
 //
@@ -626,16 +727,44 @@ func (c *Client) ListUsers() ([]UserInfo, error) {
 // If the operation is successful there will be no information returned about
 // the previous row.
 func (c *Client) Put(req *PutRequest) (*PutResult, error) {
+	return c.PutWithContext(context.Background(), req)
+}
+
+// PutWithContext is like Put, but uses the specified context. If the Client is
+// configured with a ProvenanceConfig, the provenance values associated with
+// ctx are stamped on the row, see WithProvenance().
+func (c *Client) PutWithContext(ctx context.Context, req *PutRequest) (*PutResult, error) {
 	if req == nil {
 		return nil, errNilRequest
 	}
 
-	res, err := c.execute(req)
+	if c.ValidateRowsOnPut && req.Value != nil {
+		if err := c.ValidateRow(req.Namespace, req.TableName, req.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Value == nil && req.StructValue != nil {
+		req.recordLayout = c.recordLayout(req.Namespace, req.TableName)
+	}
+
+	if c.putCoalescer != nil {
+		if res, ok, err := c.coalescePut(ctx, req); ok {
+			if err != nil {
+				return nil, err
+			}
+			c.afterPut(ctx, req, res.Version)
+			return res, nil
+		}
+	}
+
+	res, err := c.executeWithContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
 	if res, ok := res.(*PutResult); ok {
+		c.afterPut(ctx, req, res.Version)
 		return res, nil
 	}
 
@@ -662,16 +791,23 @@ func (c *Client) Put(req *PutRequest) (*PutResult, error) {
 // capacity. If the operation is successful there will be no information
 // returned about the previous row.
 func (c *Client) Delete(req *DeleteRequest) (*DeleteResult, error) {
+	return c.DeleteWithContext(context.Background(), req)
+}
+
+// DeleteWithContext is like Delete, but uses the specified context, which is
+// passed to the TableHooks registered for the table.
+func (c *Client) DeleteWithContext(ctx context.Context, req *DeleteRequest) (*DeleteResult, error) {
 	if req == nil {
 		return nil, errNilRequest
 	}
 
-	res, err := c.execute(req)
+	res, err := c.executeWithContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
 	if res, ok := res.(*DeleteResult); ok {
+		c.afterDelete(ctx, req, res.Success)
 		return res, nil
 	}
 
@@ -773,18 +909,45 @@ func (c *Client) GetTableUsage(req *TableUsageRequest) (*TableUsageResult, error
 //
 //  1. The max number of individual operations (put, delete) in a single WriteMultiple request is 50.
 //  2. The total request size is limited to 25MB.
+//
+// Requests that exceed these limits fail, unless WriteMultipleRequest.AllowSplit
+// is set, in which case the request is split into multiple requests.
 func (c *Client) WriteMultiple(req *WriteMultipleRequest) (*WriteMultipleResult, error) {
+	return c.WriteMultipleWithContext(context.Background(), req)
+}
+
+// WriteMultipleWithContext is like WriteMultiple, but uses the specified
+// context. If the Client is configured with a ProvenanceConfig, the provenance
+// values associated with ctx are stamped on the rows of put operations, see
+// WithProvenance().
+func (c *Client) WriteMultipleWithContext(ctx context.Context, req *WriteMultipleRequest) (*WriteMultipleResult, error) {
 	if req == nil {
 		return nil, errNilRequest
 	}
 
+	for _, op := range req.Operations {
+		if r := op.PutRequest; r != nil && r.Value == nil && r.StructValue != nil {
+			r.recordLayout = c.recordLayout(req.Namespace, r.TableName)
+		}
+	}
+
+	if req.AllowSplit {
+		return c.writeMultipleSplit(ctx, req, req.Operations)
+	}
+
+	return c.writeMultiple(ctx, req)
+}
+
+// writeMultiple executes a WriteMultipleRequest as a single request.
+func (c *Client) writeMultiple(ctx context.Context, req *WriteMultipleRequest) (*WriteMultipleResult, error) {
 	req.checkSubReqSize = c.isCloud
-	res, err := c.execute(req)
+	res, err := c.executeWithContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
 	if res, ok := res.(*WriteMultipleResult); ok {
+		c.runAfterWriteMultipleHooks(ctx, req, res)
 		return res, nil
 	}
 
@@ -856,16 +1019,28 @@ func (c *Client) Prepare(req *PrepareRequest) (*PrepareResult, error) {
 // in a loop, acquiring more results, until QueryRequest.IsDone() returns true,
 // indicating that the query is done.
 func (c *Client) Query(req *QueryRequest) (*QueryResult, error) {
+	return c.QueryWithContext(context.Background(), req)
+}
+
+// QueryWithContext is like Query, but uses the specified context. If ctx
+// carries an AuthorizationProvider, see WithAuthorizationProvider(), it is
+// also used for the requests that retrieve further batches of results of
+// the query.
+func (c *Client) QueryWithContext(ctx context.Context, req *QueryRequest) (*QueryResult, error) {
 	if req == nil {
 		return nil, errNilRequest
 	}
 
-	res, err := c.execute(req)
+	numTraces := len(req.traces)
+	res, err := c.executeWithContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
 	if res, ok := res.(*QueryResult); ok {
+		if len(req.traces) > numTraces {
+			res.traces = req.traces[numTraces:len(req.traces):len(req.traces)]
+		}
 		return res, nil
 	}
 
@@ -881,7 +1056,7 @@ func (c *Client) nextRequestID() int32 {
 // processRequest processes the specified request before it is sent to server.
 // This method applies default configurations such as timeout and consistency
 // values for the request if they are not specified for the request.
-func (c *Client) processRequest(req Request) (data []byte, serialVerUsed int16, queryVerUsed int16, err error) {
+func (c *Client) processRequest(ctx context.Context, req Request) (data []byte, serialVerUsed int16, queryVerUsed int16, err error) {
 	if req == nil {
 		return nil, 0, 0, errNilRequest
 	}
@@ -896,7 +1071,13 @@ func (c *Client) processRequest(req Request) (data []byte, serialVerUsed int16,
 		return nil, 0, 0, err
 	}
 
-	data, serialVerUsed, queryVerUsed, err = c.serializeRequest(req)
+	// The batch of a traced query is numbered once, as the request may be
+	// serialized again when it is retried with an older protocol version.
+	if qr, ok := req.(*QueryRequest); ok && qr.TraceLevel > 0 {
+		qr.batchNumber = qr.nextBatch()
+	}
+
+	data, serialVerUsed, queryVerUsed, err = c.serializeRequest(ctx, req)
 	if err != nil || !c.isCloud {
 		return
 	}
@@ -918,12 +1099,30 @@ func (c *Client) execute(req Request) (Result, error) {
 }
 
 func (c *Client) executeWithContext(ctx context.Context, req Request) (Result, error) {
-	data, serialVerUsed, queryVerUsed, err := c.processRequest(req)
-	if err != nil {
+	if err := c.runBeforeHooks(ctx, req); err != nil {
 		return nil, err
 	}
 
-	return c.doExecute(ctx, req, data, serialVerUsed, queryVerUsed)
+	if err := c.Provenance.stamp(ctx, req); err != nil {
+		return nil, err
+	}
+	c.setAffinityKey(req)
+	setQueryAuthProvider(ctx, req)
+
+	data, serialVerUsed, queryVerUsed, err := c.processRequest(ctx, req)
+	if err != nil {
+		return nil, c.Redaction.redactError(err, req)
+	}
+
+	release, err := c.limiter.acquire(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.executeWithREST(ctx, req, data, serialVerUsed, queryVerUsed)
+	release()
+	c.schemaCache.onRequestDone(req, err)
+	c.activeTables.onRequestDone(req, err)
+	return res, c.Redaction.redactError(err, req)
 }
 
 func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serialVerUsed int16, queryVerUsed int16) (result Result, err error) {
@@ -973,9 +1172,10 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 
 	// Include the content body hash in the request signature if this is a
 	// Global Active Tables resuest, or a table DDL request.
+	authProvider := c.authProviderFor(ctx, req)
 	mustHashBody := false
-	if c.AuthorizationProvider != nil &&
-		c.AuthorizationProvider.AuthorizationScheme() == auth.Signature {
+	if authProvider != nil &&
+		authProvider.AuthorizationScheme() == auth.Signature {
 		if _, ok := req.(*AddReplicaRequest); ok {
 			mustHashBody = true
 		} else if _, ok := req.(*DropReplicaRequest); ok {
@@ -989,13 +1189,31 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 	var authStr string
 	var httpReq *http.Request
 	var httpResp *http.Response
+	// statusCode is the HTTP status code of the last response, or 0 if no
+	// response was received.
+	var statusCode int
+
+	// The hint of an authorization error names the endpoint that returned
+	// it, which differs from that of the client when it has additional
+	// endpoints.
+	defer func() {
+		if httpResp != nil && httpResp.Request != nil {
+			err = c.enrichAuthError(ctx, req, err, httpResp.Request.URL.Host)
+		} else if httpReq != nil {
+			err = c.enrichAuthError(ctx, req, err, httpReq.URL.Host)
+		}
+	}()
 
 	reqTimeout := req.timeout()
+	// retryDuration is the time the request can be retried for, while
+	// reqTimeout limits each attempt.
+	retryDuration := c.retryDuration(reqTimeout)
 	secInfoTimeout := c.DefaultSecurityInfoTimeout()
 	numRetries := 0
 	numThrottleRetries := 0
 
 	req.SetRetryTime(0)
+	req.SetRetryDuration(retryDuration)
 	var rateDelayedTime time.Duration = 0
 	checkReadUnits := false
 	checkWriteUnits := false
@@ -1037,10 +1255,16 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 			if isSecErr {
 				timeout = secInfoTimeout
 			} else {
-				timeout = reqTimeout
+				timeout = retryDuration
 			}
 
 			if time.Since(startTime) > timeout {
+				c.recordRequestError(req, err, statusCode, false)
+				if !isSecErr && retryDuration > reqTimeout {
+					return nil, nosqlerr.NewWithCause(nosqlerr.RequestTimeout, err,
+						"request timed out after %d attempt(s) in %v. Timeout: %v, MaxRetryDuration: %v",
+						numRetries+1, time.Since(startTime).Round(time.Millisecond), reqTimeout, retryDuration)
+				}
 				return nil, nosqlerr.NewWithCause(nosqlerr.RequestTimeout, err,
 					"request timed out after %d attempt(s). Timeout: %v", numRetries+1, timeout)
 			}
@@ -1068,7 +1292,7 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 					return nil, err
 				}
 				// if serial version mismatch, we must re-serialize the request
-				data, serialVerUsed, queryVerUsed, err = c.serializeRequest(req)
+				data, serialVerUsed, queryVerUsed, err = c.serializeRequest(ctx, req)
 				if err != nil {
 					return nil, err
 				}
@@ -1077,12 +1301,15 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 					return nil, err
 				}
 				// if query version mismatch, we must re-serialize the request
-				data, serialVerUsed, queryVerUsed, err = c.serializeRequest(req)
+				data, serialVerUsed, queryVerUsed, err = c.serializeRequest(ctx, req)
 				if err != nil {
 					return nil, err
 				}
 			} else if !c.handleError(err, req, numThrottleRetries) {
+				c.recordRequestError(req, err, statusCode, false)
 				return nil, err
+			} else {
+				c.recordRequestError(req, err, statusCode, true)
 			}
 
 			if isSecErr {
@@ -1101,7 +1328,7 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 		// Before executing request: wait for rate limiter(s) to go below limit
 		if readLimiter != nil && checkReadUnits {
 			// wait for read limiter to come below the limit
-			timeout = reqTimeout - time.Since(startTime)
+			timeout = retryDuration - time.Since(startTime)
 			if timeout <= 0 {
 				if !readLimiter.TryConsumeUnits(0) {
 					return nil, nosqlerr.New(nosqlerr.RequestTimeout, "Could not execute request due to read rate limiting")
@@ -1118,7 +1345,7 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 		if writeLimiter != nil && checkWriteUnits {
 			// wait for write limiter to come below the limit
 			// note this may sleep for a while
-			timeout = reqTimeout - time.Since(startTime)
+			timeout = retryDuration - time.Since(startTime)
 			if timeout <= 0 {
 				if !writeLimiter.TryConsumeUnits(0) {
 					return nil, nosqlerr.New(nosqlerr.RequestTimeout, "Could not execute request due to write rate limiting")
@@ -1139,19 +1366,24 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 		}
 
 		// Handle errors that may occur when retrieving authorization string.
-		authStr, err = c.getAuthString(req)
+		authStr, err = c.getAuthString(authProvider, req)
 		if err != nil {
 			continue
 		}
 
-		httpReq, err = httputil.NewPostRequest(c.requestURL, data)
+		requestURL, serverHost := c.requestURL, c.serverHost
+		ep := c.endpoints.pick()
+		if ep != nil {
+			requestURL, serverHost = ep.requestURL, ep.host
+		}
+
+		httpReq, err = httputil.NewPostRequest(requestURL, data)
 		if err != nil {
 			return nil, err
 		}
 
-		reqID := int(c.nextRequestID())
-		httpReq.Header.Add("x-nosql-request-id", strconv.Itoa(reqID))
-		httpReq.Header.Add("Host", c.serverHost)
+		httpReq.Header.Add("x-nosql-request-id", c.requestIDHeader())
+		httpReq.Header.Add("Host", serverHost)
 		httpReq.Header.Set("Content-Length", strconv.Itoa(len(data)))
 		httpReq.Header.Set("Content-Type", "application/octet-stream")
 		httpReq.Header.Set("Accept", "application/octet-stream")
@@ -1172,11 +1404,13 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 		}
 
 		// Allow for session persistence, if available
-		if c.sessionStr != "" {
+		if c.sessionStr != "" && c.cookieJar == nil {
 			httpReq.Header.Set("Cookie", c.sessionStr)
 		}
+		c.addAffinityHeaders(httpReq, req)
+		c.addTraceHeaders(ctx, httpReq)
 
-		err = c.signHTTPRequest(httpReq)
+		err = c.signHTTPRequestWithTimeout(ctx, authProvider, httpReq, retryDuration-time.Since(startTime))
 		if err != nil {
 			return nil, err
 		}
@@ -1212,11 +1446,16 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 
 		reqCtx, reqCancel := context.WithTimeout(ctx, reqTimeout)
 		httpReq = httpReq.WithContext(reqCtx)
+		sendTime := time.Now()
 		httpResp, err = c.executor.Do(httpReq)
+		c.endpoints.record(ep, time.Since(sendTime), httpResp, err)
 		if err != nil {
+			statusCode = 0
 			reqCancel()
 			continue
 		}
+		statusCode = httpResp.StatusCode
+		c.saveCookies(httpReq.URL, httpResp)
 
 		result, err = c.handleResponse(httpResp, req, serialVerUsed, queryVerUsed)
 		// Cancel request context after response body has been read.
@@ -1240,15 +1479,17 @@ func (c *Client) doExecute(ctx context.Context, req Request, data []byte, serial
 		// limiters, possibly delaying return
 		used, _ := result.ConsumedCapacity()
 		if used.ReadUnits > 0 && readLimiter != nil {
-			timeout = reqTimeout - time.Since(startTime)
+			timeout = retryDuration - time.Since(startTime)
 			rateDelayedTime += c.consumeLimiterUnits(readLimiter, int64(used.ReadUnits), timeout)
 		}
 		if used.WriteKB > 0 && writeLimiter != nil {
-			timeout = reqTimeout - time.Since(startTime)
+			timeout = retryDuration - time.Since(startTime)
 			rateDelayedTime += c.consumeLimiterUnits(writeLimiter, int64(used.WriteKB), timeout)
 		}
 		result.Delayed().setRateLimitTime(rateDelayedTime)
 		result.Delayed().setRetryTime(req.GetRetryTime())
+		result.Sizes().RequestSize = len(data)
+		c.sizeStats.record(req, result.Sizes())
 
 		return result, nil
 	}
@@ -1274,7 +1515,7 @@ func (c *Client) warmupClientAuth() {
 		return
 	}
 	httpReq.Header.Add("Host", c.serverHost)
-	err = c.signHTTPRequest(httpReq)
+	err = c.signHTTPRequest(context.Background(), c.AuthorizationProvider, httpReq)
 	if err != nil {
 		c.logger.Fine("Got error signing warmup request: %v", err)
 		return
@@ -1454,48 +1695,69 @@ func (c *Client) handleRetry(err error, req Request, numRetries uint) bool {
 }
 
 // getAuthString returns an authorization string for the specified request.
-func (c *Client) getAuthString(opReq Request) (string, error) {
-	if c.AuthorizationProvider == nil {
+func (c *Client) getAuthString(ap AuthorizationProvider, opReq Request) (string, error) {
+	if ap == nil {
 		return "", nil
 	}
 
-	switch scheme := c.AuthorizationProvider.AuthorizationScheme(); scheme {
+	switch scheme := ap.AuthorizationScheme(); scheme {
 	case auth.BearerToken:
 		req := &accessTokenRequest{opReq}
-		return c.AuthorizationProvider.AuthorizationString(req)
-	case auth.Signature:
-		// signature method requires an http.Request - auth is added in the Sign() method later
+		return ap.AuthorizationString(req)
+	case auth.Signature, auth.Negotiate, requestSignerScheme:
+		// these methods require an http.Request - auth is added in the Sign() method later
 		return "", nil
-	default:
+	case "":
 		return "", nosqlerr.NewIllegalArgument("unsupported authorization scheme: %s", scheme)
+	default:
+		// custom schemes may use both the authorization string and Sign()
+		req := &accessTokenRequest{opReq}
+		return ap.AuthorizationString(req)
 	}
 }
 
-func (c *Client) signHTTPRequest(httpReq *http.Request) error {
-	if c.AuthorizationProvider == nil {
+// signHTTPRequestWithTimeout signs httpReq, waiting at most timeout for the
+// authorization provider if it is a ContextAuthorizationProvider.
+func (c *Client) signHTTPRequestWithTimeout(ctx context.Context, ap AuthorizationProvider,
+	httpReq *http.Request, timeout time.Duration) error {
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.signHTTPRequest(ctx, ap, httpReq)
+}
+
+func (c *Client) signHTTPRequest(ctx context.Context, ap AuthorizationProvider, httpReq *http.Request) error {
+	if ap == nil {
 		return nil
 	}
 
-	switch c.AuthorizationProvider.AuthorizationScheme() {
-	case auth.Signature:
-		// currently this is the only provider that uses an actual http.Request
-		return c.AuthorizationProvider.SignHTTPRequest(httpReq)
+	switch ap.AuthorizationScheme() {
 	case auth.BearerToken:
 		// no changes to http req for this method
 		return nil
+	case "":
+		return nosqlerr.NewIllegalArgument("unsupported authorization scheme for http request signing")
 	default:
+		// the other providers use an actual http.Request
+		if cap, ok := ap.(ContextAuthorizationProvider); ok {
+			return cap.SignHTTPRequestContext(ctx, httpReq)
+		}
+		return ap.SignHTTPRequest(httpReq)
 	}
-
-	return nosqlerr.NewIllegalArgument("unsupported authorization scheme for http request signing")
 }
 
 // serializeRequest serializes the specified request into a slice of bytes that
 // will be sent to the server. The serial version is always written followed by
 // the actual request payload.
-func (c *Client) serializeRequest(req Request) (data []byte, serialVerUsed int16, queryVerUsed int16, err error) {
+//
+// The serialization of large values stops with the error of ctx when ctx is
+// done, so that cancelled requests do not use CPU to serialize their values.
+func (c *Client) serializeRequest(ctx context.Context, req Request) (data []byte, serialVerUsed int16, queryVerUsed int16, err error) {
 	serialVerUsed = c.serialVersion
 	queryVerUsed = c.queryVersion
 	wr := binary.NewWriter()
+	wr.SetSortMapKeys(c.Deterministic != nil)
+	wr.SetContext(ctx)
 	if _, err = wr.WriteSerialVersion(serialVerUsed); err != nil {
 		return nil, 0, 0, err
 	}
@@ -1525,10 +1787,22 @@ func (c *Client) processResponse(httpResp *http.Response, req Request, serialVer
 		return nil, err
 	}
 
+	if err = c.checkBinaryBlocked(httpResp); err != nil {
+		return nil, err
+	}
+
+	c.observeServerDate(httpResp, req)
 	if httpResp.StatusCode == http.StatusOK {
 		c.setSessionCookie(httpResp.Header)
 		c.setServerSerialVersion(httpResp.Header)
-		return c.processOKResponse(data, req, serialVerUsed, queryVerUsed)
+		res, err := c.processOKResponse(data, req, serialVerUsed, queryVerUsed)
+		if err == nil && res != nil {
+			res.Sizes().ResponseSize = len(data)
+		}
+		if err == nil {
+			c.checkCompatibility(httpResp.Header)
+		}
+		return res, err
 	}
 
 	return nil, c.processNotOKResponse(data, httpResp.StatusCode)
@@ -1537,6 +1811,7 @@ func (c *Client) processResponse(httpResp *http.Response, req Request, serialVer
 func (c *Client) processOKResponse(data []byte, req Request, serialVerUsed int16, queryVerUsed int16) (res Result, err error) {
 	buf := bytes.NewBuffer(data)
 	rd := binary.NewReader(buf)
+	rd.SetMapValuePool(c.MapValuePool)
 
 	var code int
 	if serialVerUsed >= 4 {
@@ -1546,6 +1821,9 @@ func (c *Client) processOKResponse(data []byte, req Request, serialVerUsed int16
 		if queryReq, ok := req.(*QueryRequest); ok && !queryReq.isSimpleQuery() {
 			queryReq.driver.client = c
 		}
+		if ps, ok := res.(mapValuePoolSetter); ok && c.MapValuePool != nil {
+			ps.setMapValuePool(c.MapValuePool)
+		}
 		return res, nil
 	}
 
@@ -1563,6 +1841,9 @@ func (c *Client) processOKResponse(data []byte, req Request, serialVerUsed int16
 		if queryReq, ok := req.(*QueryRequest); ok && !queryReq.isSimpleQuery() {
 			queryReq.driver.client = c
 		}
+		if ps, ok := res.(mapValuePoolSetter); ok && c.MapValuePool != nil {
+			ps.setMapValuePool(c.MapValuePool)
+		}
 		return res, nil
 	}
 
@@ -1580,6 +1861,12 @@ func (c *Client) processOKResponse(data []byte, req Request, serialVerUsed int16
 	return nil, wrapResponseErrors(int(code), msg)
 }
 
+// mapValuePoolSetter is implemented by the results that can release their
+// rows to a MapValuePool.
+type mapValuePoolSetter interface {
+	setMapValuePool(pool *types.MapValuePool)
+}
+
 // setSessionCookie sets a persistent session cookie value to use for
 // following requests, if present in the response header.
 func (c *Client) setSessionCookie(header http.Header) {
@@ -1603,6 +1890,22 @@ func (c *Client) setSessionCookie(header http.Header) {
 	})
 }
 
+// observeServerDate passes the Date header of a response to the authorization
+// provider used for req, if it is a ServerDateObserver.
+func (c *Client) observeServerDate(httpResp *http.Response, req Request) {
+	ctx := context.Background()
+	if httpResp.Request != nil {
+		ctx = httpResp.Request.Context()
+	}
+	o, ok := c.authProviderFor(ctx, req).(ServerDateObserver)
+	if !ok {
+		return
+	}
+	if date, err := http.ParseTime(httpResp.Header.Get("Date")); err == nil {
+		o.ObserveServerDate(date)
+	}
+}
+
 // setServerSerialVersion sets the server serial version (not the protocol version)
 // in the client, if not already set.
 // Note that if the client is connected to multiple proxies via a load balancer, this
@@ -1621,6 +1924,7 @@ func (c *Client) setServerSerialVersion(header http.Header) {
 	i, err := strconv.Atoi(v)
 	if err == nil {
 		c.serverSerialVersion = i
+		c.storeProtocolVersions()
 		c.logger.LogWithFn(logger.Fine, func() string {
 			return fmt.Sprintf("Set server serial version to %d", c.serverSerialVersion)
 		})
@@ -1642,6 +1946,15 @@ func (c *Client) processNotOKResponse(data []byte, statusCode int) error {
 		return fmt.Errorf("error response: %s", string(data))
 	}
 
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return nosqlerr.New(nosqlerr.InvalidAuthorization, "error response: %d %s",
+			statusCode, http.StatusText(statusCode))
+	case http.StatusForbidden:
+		return nosqlerr.New(nosqlerr.InsufficientPermission, "error response: %d %s",
+			statusCode, http.StatusText(statusCode))
+	}
+
 	return fmt.Errorf("error response: %d %s", statusCode, http.StatusText(statusCode))
 }
 
@@ -1748,6 +2061,7 @@ func (c *Client) decrementSerialVersion(serialVerUsed int16) bool {
 	if c.serialVersion > 2 {
 		c.serialVersion--
 		c.logger.Fine("Decremented serial version to %d\n", c.serialVersion)
+		c.storeProtocolVersions()
 		return true
 	}
 	return false
@@ -1775,6 +2089,7 @@ func (c *Client) decrementQueryVersion(queryVerUsed int16) bool {
 	if c.queryVersion > 3 {
 		c.queryVersion--
 		c.logger.Fine("Decremented query version to %d\n", c.queryVersion)
+		c.storeProtocolVersions()
 		return true
 	}
 	return false
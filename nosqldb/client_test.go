@@ -290,6 +290,31 @@ func TestExecuteErrorHandling(t *testing.T) {
 	}
 }
 
+// indexesExecutor responds to GetIndexes requests with the specified indexes.
+type indexesExecutor struct {
+	indexes  []string
+	requests int
+}
+
+func (e *indexesExecutor) Do(req *http.Request) (*http.Response, error) {
+	e.requests++
+	w := binary.NewWriter()
+	w.WriteByte(0)
+	w.WritePackedInt(len(e.indexes))
+	for i := range e.indexes {
+		w.WriteString(&e.indexes[i])
+		w.WritePackedInt(0)
+	}
+	body := w.Bytes()
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        make(http.Header),
+		Request:       req,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}, nil
+}
+
 func newMockClient() (*Client, error) {
 	authProvider := &DummyAccessTokenProvider{
 		TenantID: "TestTenantId",
@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkewCorrection(t *testing.T) {
+	client, err := newMockClient()
+	require.NoError(t, err)
+	privateKeyFile := "testdata/clock_skew_key.pem"
+	require.NoError(t, generatePrivateKeyPEM(privateKeyFile))
+	defer os.Remove(privateKeyFile)
+	newProvider := func() *iam.SignatureProvider {
+		sp, err := iam.NewRawSignatureProvider("ocid1.tenancy.oc1..test", "ocid1.user.oc1..test", "us-ashburn-1",
+			"fingerprint", "", privateKeyFile, nil)
+		require.NoError(t, err)
+		sp.SetClockSkewCorrection(true)
+		return sp
+	}
+	sp := newProvider()
+	client.AuthorizationProvider = sp
+	user := newProvider()
+
+	// The date of a rejected request is used to correct the skew of the
+	// provider of the request, not that of the client.
+	tests := []struct {
+		desc     string
+		ctx      context.Context
+		provider *iam.SignatureProvider
+		skew     time.Duration
+	}{
+		{"provider of the client", context.Background(), sp, 10 * time.Minute},
+		{"provider of the request", WithAuthorizationProvider(context.Background(), user), user, -time.Hour},
+	}
+	for _, r := range tests {
+		httpReq, err := http.NewRequestWithContext(r.ctx, http.MethodPost, client.requestURL, nil)
+		require.NoError(t, err)
+		httpResp := &http.Response{
+			StatusCode: http.StatusUnauthorized,
+			Header:     http.Header{"Date": []string{time.Now().Add(r.skew).UTC().Format(http.TimeFormat)}},
+			Body:       io.NopCloser(strings.NewReader("invalid signature")),
+			Request:    httpReq,
+		}
+		_, err = client.processResponse(httpResp, &GetRequest{TableName: "T1"}, 4, 4)
+		assert.Errorf(t, err, "%s: processResponse() should have failed", r.desc)
+		assert.InDeltaf(t, float64(r.skew), float64(r.provider.ClockSkew()), float64(2*time.Second),
+			"%s: unexpected clock skew", r.desc)
+	}
+	assert.InDelta(t, float64(10*time.Minute), float64(sp.ClockSkew()), float64(2*time.Second))
+
+	// The corrected date is used to sign requests.
+	httpReq, err := http.NewRequest(http.MethodPost, client.requestURL, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.signHTTPRequest(context.Background(), sp, httpReq))
+	date, err := http.ParseTime(httpReq.Header.Get("Date"))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), date, 2*time.Second)
+}
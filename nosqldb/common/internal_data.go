@@ -19,6 +19,8 @@ type InternalRequestDataInt interface {
 	RateLimiterPairInt
 	GetRetryTime() time.Duration
 	SetRetryTime(d time.Duration)
+	GetRetryDuration() time.Duration
+	SetRetryDuration(d time.Duration)
 	SetTopology(ti *TopologyInfo)
 	GetTopoSeqNum() int
 }
@@ -27,8 +29,9 @@ type InternalRequestDataInt interface {
 // in every Request type
 type InternalRequestData struct {
 	RateLimiterPair
-	retryTime time.Duration
-	topology  *TopologyInfo
+	retryTime     time.Duration
+	retryDuration time.Duration
+	topology      *TopologyInfo
 }
 
 // GetRetryTime returns the current time spent in the client in retries
@@ -41,6 +44,17 @@ func (ird *InternalRequestData) SetRetryTime(d time.Duration) {
 	ird.retryTime = d
 }
 
+// GetRetryDuration returns the maximum time the request can be retried for,
+// or 0 if it is limited by the timeout of the request only.
+func (ird *InternalRequestData) GetRetryDuration() time.Duration {
+	return ird.retryDuration
+}
+
+// SetRetryDuration sets the maximum time the request can be retried for
+func (ird *InternalRequestData) SetRetryDuration(d time.Duration) {
+	ird.retryDuration = d
+}
+
 // SetTopologyInfo sets the topology info used for the query
 func (ird *InternalRequestData) SetTopology(ti *TopologyInfo) {
 	ird.topology = ti
@@ -0,0 +1,170 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+)
+
+// CompatibilityInfo represents the versions of the SDK, the protocol and the
+// server used by a Client, and the known problems of their combination.
+// It is returned by Client.CompatibilityInfo.
+type CompatibilityInfo struct {
+	// SDKVersion specifies the version of the SDK.
+	SDKVersion string `json:"sdkVersion"`
+
+	// GoVersion specifies the version of Go the application is built with.
+	GoVersion string `json:"goVersion"`
+
+	// SerialVersion specifies the version of the protocol used by the client,
+	// which is reduced from the default version when connected to older
+	// servers.
+	SerialVersion int16 `json:"serialVersion"`
+
+	// QueryVersion specifies the version of the query protocol used by the
+	// client, which is reduced like SerialVersion.
+	QueryVersion int16 `json:"queryVersion"`
+
+	// Connected specifies whether the client has received a response from
+	// the server. The server versions are unknown until it has.
+	Connected bool `json:"connected"`
+
+	// ServerSerialVersion specifies the serial version reported by the
+	// server in the "x-nosql-serial-version" header of its responses, or 0 if
+	// it does not report one. This is not the protocol version.
+	ServerSerialVersion int `json:"serverSerialVersion,omitempty"`
+
+	// Server specifies the "Server" header of the responses of the proxy or
+	// the service, if any.
+	Server string `json:"server,omitempty"`
+
+	// Warnings describes the known problems of the combination of the
+	// versions, if any.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// String returns a JSON string representation of the CompatibilityInfo.
+func (info CompatibilityInfo) String() string {
+	return jsonutil.AsPrettyJSON(info)
+}
+
+// compatibilityRule represents a combination of the versions of the client
+// and the server that is known to be problematic.
+type compatibilityRule struct {
+	// matches reports whether the versions are problematic.
+	matches func(info *CompatibilityInfo) bool
+
+	// warning describes the problem.
+	warning string
+}
+
+// compatibilityRules is the compatibility matrix of the SDK, which lists the
+// known-problematic combinations of versions. The versions of the client
+// are negotiated with the server, so that they only match older servers.
+var compatibilityRules = []compatibilityRule{
+	{
+		matches: func(info *CompatibilityInfo) bool {
+			return info.SerialVersion < proto.DefaultSerialVersion
+		},
+		warning: "the server does not support protocol version 4, the features " +
+			"that require it are unavailable; upgrade the proxy to a version " +
+			"that supports it",
+	},
+	{
+		matches: func(info *CompatibilityInfo) bool {
+			return info.QueryVersion < proto.DefaultQueryVersion
+		},
+		warning: "the server does not support query version 4, queries with " +
+			"features that require it may fail; upgrade the proxy to a version " +
+			"that supports it",
+	},
+}
+
+// compatibility records the server versions observed by a client.
+type compatibility struct {
+	once   sync.Once
+	mu     sync.Mutex
+	seen   bool
+	server string
+}
+
+// CompatibilityInfo returns the versions of the SDK, the protocol and the
+// server used by the client, and the known problems of their combination.
+// It is intended to be included in support requests.
+//
+// The server versions are observed on the responses to requests, so they are
+// unknown until the client has executed a request. The versions of the
+// protocol may be reduced by later requests, such as the first query.
+func (c *Client) CompatibilityInfo() CompatibilityInfo {
+	c.compatibility.mu.Lock()
+	seen, server := c.compatibility.seen, c.compatibility.server
+	c.compatibility.mu.Unlock()
+
+	info := CompatibilityInfo{
+		SDKVersion:          sdkutil.SDKVersion(),
+		GoVersion:           runtime.Version(),
+		SerialVersion:       c.GetSerialVersion(),
+		QueryVersion:        c.GetQueryVersion(),
+		Connected:           seen,
+		ServerSerialVersion: c.GetServerSerialVersion(),
+		Server:              server,
+	}
+	if !seen {
+		return info
+	}
+
+	for _, r := range compatibilityRules {
+		if r.matches(&info) {
+			info.Warnings = append(info.Warnings, r.warning)
+		}
+	}
+	return info
+}
+
+// checkCompatibility records the server versions from the header of the
+// first successful response and logs them, along with a warning for each
+// known problem of their combination with the versions of the client.
+func (c *Client) checkCompatibility(header http.Header) {
+	c.compatibility.once.Do(func() {
+		c.compatibility.mu.Lock()
+		c.compatibility.seen = true
+		c.compatibility.server = header.Get("Server")
+		c.compatibility.mu.Unlock()
+
+		info := c.CompatibilityInfo()
+		fields := compatibilityFields(info)
+		c.logger.Info("NoSQL Go SDK %s connected: %s", info.SDKVersion, fields)
+		for _, w := range info.Warnings {
+			c.logger.Warn("Compatibility problem: %s: %s", w, fields)
+		}
+	})
+}
+
+// compatibilityFields returns the versions of info as key=value pairs for
+// log messages.
+func compatibilityFields(info CompatibilityInfo) string {
+	fields := []string{
+		"sdkVersion=" + info.SDKVersion,
+		"goVersion=" + info.GoVersion,
+		fmt.Sprintf("serialVersion=%d", info.SerialVersion),
+		fmt.Sprintf("queryVersion=%d", info.QueryVersion),
+		fmt.Sprintf("serverSerialVersion=%d", info.ServerSerialVersion),
+	}
+	if info.Server != "" {
+		fields = append(fields, fmt.Sprintf("server=%q", info.Server))
+	}
+	return strings.Join(fields, " ")
+}
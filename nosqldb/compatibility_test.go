@@ -0,0 +1,57 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompatibilityInfo(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	var buf bytes.Buffer
+	client.logger = logger.New(&buf, logger.Info, false)
+
+	info := client.CompatibilityInfo()
+	assert.Equal(t, sdkutil.SDKVersion(), info.SDKVersion)
+	assert.False(t, info.Connected)
+	assert.Empty(t, info.Warnings, "the server versions are unknown before the first response")
+
+	// The server supports protocol version 3 only.
+	client.SetSerialVersion(3)
+	header := http.Header{}
+	header.Set("Server", "NoSQL-Proxy/5.4")
+	header.Set("x-nosql-serial-version", "2")
+	client.setServerSerialVersion(header)
+	client.checkCompatibility(header)
+	info = client.CompatibilityInfo()
+	assert.True(t, info.Connected)
+	assert.Equal(t, int16(3), info.SerialVersion)
+	assert.Equal(t, 2, info.ServerSerialVersion)
+	assert.Equal(t, "NoSQL-Proxy/5.4", info.Server)
+	if assert.Len(t, info.Warnings, 1) {
+		assert.Contains(t, info.Warnings[0], "protocol version 4")
+	}
+	logged := buf.String()
+	assert.Contains(t, logged, "serialVersion=3 queryVersion=4 serverSerialVersion=2 server=\"NoSQL-Proxy/5.4\"")
+	assert.Contains(t, logged, "Compatibility problem: the server does not support protocol version 4")
+
+	// The banner is only logged for the first response.
+	buf.Reset()
+	client.SetQueryVersion(3)
+	client.checkCompatibility(header)
+	assert.Empty(t, buf.String())
+	assert.Len(t, client.CompatibilityInfo().Warnings, 2)
+}
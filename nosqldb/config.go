@@ -69,10 +69,28 @@ type Config struct {
 	// http in all other cases.
 	Endpoint string `json:"endpoint"`
 
+	// AdditionalEndpoints specifies the endpoints of other proxies that serve
+	// the same NoSQL database as Endpoint, using the same syntax and protocol
+	// as Endpoint. This is typically used with multiple proxies of an
+	// on-premise NoSQL database.
+	//
+	// If specified, each request is sent to the endpoint with the lowest
+	// latency among the endpoints that are healthy. The latency of each
+	// endpoint is tracked with an exponentially weighted moving average, and
+	// a small fraction of requests is sent to other endpoints to keep their
+	// latency up to date. An endpoint that fails to respond is considered
+	// unhealthy for a period that increases with consecutive failures.
+	AdditionalEndpoints []string `json:"additionalEndpoints,omitempty"`
+
 	// Region specifies the region for the Oracle NoSQL cloud service that clients connect to.
 	// Region takes precedence over the "region" property that may be specified
 	// in the OCI configuration file which is ~/.oci/config by default.
 	//
+	// If neither Region nor Endpoint is specified, and the AuthorizationProvider
+	// uses instance principals, the region of the compute instance is
+	// retrieved from the instance metadata service, so applications running
+	// in OCI do not need to configure it.
+	//
 	// This is used for cloud service only.
 	Region common.Region `json:"region"`
 
@@ -111,6 +129,13 @@ type Config struct {
 	//
 	AuthorizationProvider
 
+	// RequestSigner specifies a signer of the HTTP requests of the client,
+	// for proxies and gateways with their own authentication schemes. It
+	// cannot be specified with AuthorizationProvider, or with Username and
+	// Password. See RequestSigner for details.
+	// It is optional.
+	RequestSigner RequestSigner `json:"-"`
+
 	// RetryHandler specifies a handler used to handle operation retries.
 	RetryHandler
 
@@ -125,6 +150,210 @@ type Config struct {
 	// The default for this value is 100.0 (full table limits).
 	RateLimiterPercentage float64
 
+	// Provenance specifies the provenance columns that are stamped on every
+	// row written by put operations. It is optional.
+	// See ProvenanceConfig for details.
+	Provenance *ProvenanceConfig `json:"provenance,omitempty"`
+
+	// Redaction specifies a policy used to keep sensitive values out of query
+	// traces and error messages. It is optional.
+	// See RedactionPolicy for details.
+	Redaction *RedactionPolicy `json:"redaction,omitempty"`
+
+	// SchemaCacheTTL specifies how long the table metadata retrieved by
+	// Client.GetTableCached() is cached by the client.
+	// If set to 0, which is the default, table metadata is not cached.
+	SchemaCacheTTL time.Duration `json:"schemaCacheTTL,omitempty"`
+
+	// ValidateRowsOnPut specifies whether Client.Put validates the values of
+	// PutRequest.Value against the schema of the table before the request is
+	// sent, using Client.ValidateRow, so that values of the wrong type in MAP,
+	// ARRAY and RECORD columns are reported with the path of the element.
+	// This requires the table metadata, and should be used along with
+	// SchemaCacheTTL to avoid a GetTable request for each Put.
+	//
+	// It is optional. If set to false, which is the default, rows are
+	// validated by the server.
+	ValidateRowsOnPut bool `json:"validateRowsOnPut,omitempty"`
+
+	// PutCoalescingWindow specifies how long Client.Put waits for other puts
+	// to the same table and with the same shard key values, which are
+	// executed along with it in a WriteMultiple request. This raises the
+	// throughput of applications that issue many concurrent puts, without
+	// changing their calls, at the cost of up to this delay for each put.
+	// A window of a few milliseconds, such as 2ms, is recommended.
+	//
+	// Each put receives its own result. The puts of a WriteMultiple request
+	// are not atomic, a put that fails its condition does not affect the
+	// others, but they all fail if the request fails. The consumed capacity
+	// of coalesced puts is not reported in their PutResult. The hooks and
+	// the provenance of each put use its own context. Puts are grouped by
+	// Durability and by the AuthorizationProvider of their context, see
+	// WithAuthorizationProvider. A put whose context is canceled before its
+	// WriteMultiple request is sent is not written. The table metadata is
+	// retrieved once per table to determine its shard key; puts to tables
+	// whose metadata cannot be retrieved are executed on their own.
+	//
+	// It is optional. If set to 0, which is the default, puts are not
+	// coalesced.
+	PutCoalescingWindow time.Duration `json:"putCoalescingWindow,omitempty"`
+
+	// CacheProtocolVersions specifies whether the protocol versions
+	// negotiated with the server are cached in a registry shared by all the
+	// clients of the process that also enable it, keyed by endpoint. New
+	// clients for the same endpoint start with the cached versions, so that
+	// short-lived clients, such as clients created for each request, do not
+	// negotiate them again. See ResetProtocolVersionCache.
+	//
+	// It is optional. If set to false, which is the default, each client
+	// negotiates the protocol versions with the server.
+	CacheProtocolVersions bool `json:"cacheProtocolVersions,omitempty"`
+
+	// SessionAffinity specifies how the client keeps consecutive requests on
+	// the same proxy node behind a load balancer. It is optional.
+	// See SessionAffinityConfig for details.
+	SessionAffinity SessionAffinityConfig `json:"sessionAffinity,omitempty"`
+
+	// MaxConcurrentRequests specifies the maximum number of requests the
+	// client executes concurrently. This protects the server from an unbounded
+	// number of requests issued by goroutines of the application.
+	// If set to 0, which is the default, the number of requests is not limited.
+	//
+	// The GetTable requests issued by the client to fill the cache of table
+	// metadata, see GetTableCached, are not subject to the limits, as they
+	// may be needed by requests that already hold their permits.
+	MaxConcurrentRequests int `json:"maxConcurrentRequests,omitempty"`
+
+	// MaxConcurrentRequestsPerTable specifies the maximum number of requests
+	// on a single table the client executes concurrently, so that requests
+	// on a busy table do not prevent requests on other tables from executing.
+	// If set to 0, which is the default, the number of requests per table is
+	// not limited.
+	MaxConcurrentRequestsPerTable int `json:"maxConcurrentRequestsPerTable,omitempty"`
+
+	// QueueWhenBusy specifies whether a request that exceeds the concurrency
+	// limits waits until it can be executed. The request waits until the
+	// context of the request is done or, if the context has no deadline, until
+	// the timeout of the request elapses, in which case a RequestTimeout error
+	// is returned.
+	// If set to false, which is the default, such requests fail immediately
+	// with ErrClientBusy.
+	QueueWhenBusy bool `json:"queueWhenBusy,omitempty"`
+
+	// RequestWeight is an optional function that returns the weight of a
+	// request with respect to the concurrency limits, for example to let a
+	// WriteMultipleRequest or a QueryRequest count as several requests.
+	// Weights less than 1 are treated as 1, weights greater than the limits
+	// are treated as the limits.
+	// If not set, each request has a weight of 1.
+	RequestWeight func(req Request) int `json:"-"`
+
+	// PreflightTables specifies the tables on which NewClient checks that the
+	// client has the permissions to read table metadata and rows, using
+	// Client.CheckPermissions(). If a check fails, NewClient returns an error
+	// that describes the missing permissions.
+	// It is optional. If not set, no checks are performed.
+	PreflightTables []string `json:"preflightTables,omitempty"`
+
+	// AllowedEndpoints specifies the patterns of the endpoints the client is
+	// allowed to connect to, to prevent an application configured for one
+	// environment from accessing the data of another, such as a production
+	// build that points at a cloud simulator or a development proxy.
+	// A pattern may contain "*" wildcards that match any sequence of
+	// characters, and is matched against the host, the host and port, and
+	// the complete endpoint, for example:
+	//
+	//   nosql.*.oci.oraclecloud.com
+	//   proxy-*.prod.example.com:443
+	//   https://*
+	//
+	// NewClient returns an error if Endpoint, the endpoint of Region, or one
+	// of AdditionalEndpoints does not match any of the patterns.
+	//
+	// A list of patterns can also be set when the application is built, which
+	// applies in addition to AllowedEndpoints, with the linker flag:
+	//
+	//   -X github.com/oracle/nosql-go-sdk/nosqldb.buildAllowedEndpoints=pattern1,pattern2
+	//
+	// It is optional. If not set, all endpoints are allowed.
+	AllowedEndpoints []string `json:"allowedEndpoints,omitempty"`
+
+	// RESTFallback specifies whether the client uses the REST API of the
+	// cloud service for an endpoint on which the binary protocol is found to
+	// be blocked, for example by a proxy or firewall that rejects its content
+	// type. The binary protocol is considered blocked if a request receives an
+	// HTML page, or a 406 or 415 response.
+	//
+	// Only Get, Put and Delete requests that are signed with an
+	// iam.SignatureProvider are executed with the REST API, except for
+	// PutIfVersion, conditional deletes and TTLs specified in hours. Other
+	// requests, and requests on other endpoints, use the binary protocol.
+	// Requests executed with the REST API are not retried, and their results
+	// do not include row versions for Get or modification times.
+	//
+	// The fallback only applies to a client with a single endpoint; it is not
+	// used if AdditionalEndpoints are specified.
+	//
+	// It is optional and only applies to the cloud service. If set to false,
+	// which is the default, the binary protocol is always used.
+	RESTFallback bool `json:"restFallback,omitempty"`
+
+	// SigningAlgorithm specifies the algorithm used by an iam.SignatureProvider
+	// to sign requests, such as iam.RSAPSSSHA256 for gateways that require
+	// RSASSA-PSS signatures. See SignatureProvider.SetSigningAlgorithm.
+	//
+	// It is optional and only applies to the cloud service. If not set, the
+	// algorithm is selected from the type of the private key, which is
+	// "rsa-sha256" for RSA keys.
+	SigningAlgorithm iam.SigningAlgorithm `json:"signingAlgorithm,omitempty"`
+
+	// TraceSigning specifies whether an iam.SignatureProvider logs the string
+	// to sign, the signed headers and the key id of requests to Logger at
+	// Debug level, to help diagnose requests rejected because of a signature
+	// mismatch. The signatures, the private keys and the security tokens are
+	// never logged. See SignatureProvider.SetSigningTrace.
+	//
+	// It is optional and only applies to the cloud service. The traces are
+	// only written if the level of Logger is Debug or lower.
+	TraceSigning bool `json:"traceSigning,omitempty"`
+
+	// PropagateTraceContext specifies whether the client propagates the trace
+	// context of each operation to the server with the W3C "traceparent" and
+	// "tracestate" headers, so that the logs of proxies and of the service
+	// can be correlated with the traces of the application.
+	//
+	// The trace context is added by TracePropagator if it is set, or taken
+	// from the context of the operation as set by WithTraceContext otherwise.
+	// If set to false, which is the default, no trace headers are sent.
+	PropagateTraceContext bool `json:"propagateTraceContext,omitempty"`
+
+	// TracePropagator specifies the function that adds the trace headers to
+	// requests, typically from the spans of a tracing library such as
+	// OpenTelemetry. See TracePropagator for an example.
+	// It is optional and only used if PropagateTraceContext is set.
+	TracePropagator TracePropagator `json:"-"`
+
+	// MetricsSink specifies where the client reports its metrics, such as
+	// the errors returned to requests by class and by whether they were
+	// retried. See MetricsSink for the metrics that are reported.
+	// It is optional. The same metrics are available from Client.RetryStats.
+	MetricsSink MetricsSink `json:"-"`
+
+	// MapValuePool specifies the pool from which the client takes the
+	// MapValues of the rows that it reads, for applications that read many
+	// small rows and want to reduce their allocations. The rows of a
+	// GetResult or a QueryResult are returned to the pool by their Release
+	// method. See types.MapValuePool for the detection of the uses of rows
+	// after they are released.
+	// It is optional. If not set, new MapValues are allocated for the rows.
+	MapValuePool *types.MapValuePool `json:"-"`
+
+	// Deterministic specifies options that make the requests serialized and
+	// signed by the client reproducible, for golden-file tests of requests
+	// and signatures. See DeterministicOptions for details.
+	// It is optional and should only be set in tests.
+	Deterministic *DeterministicOptions `json:"-"`
+
 	host     string
 	port     string
 	protocol string
@@ -132,23 +361,10 @@ type Config struct {
 	httpClient *httputil.HTTPClient
 }
 
+// validate normalizes the configuration mode and validates the Config.
 func (c *Config) validate() error {
 	c.Mode = strings.ToLower(c.Mode)
-	switch c.Mode {
-	case "", "cloud":
-	case "cloudsim", "onprem":
-		if len(c.Endpoint) == 0 {
-			return fmt.Errorf("endpoint must be specified")
-		}
-	default:
-		return fmt.Errorf("the specified configuration mode %q is not supported", c.Mode)
-	}
-
-	if len(c.Endpoint) > 0 && len(c.Region) > 0 {
-		return fmt.Errorf("cannot have both Endpoint and Region specified")
-	}
-
-	return nil
+	return c.Validate()
 }
 
 func (c *Config) setDefaults() (err error) {
@@ -162,6 +378,14 @@ func (c *Config) setDefaults() (err error) {
 		c.Logger = logger.DefaultLogger
 	}
 
+	// Parameters that are ignored do not prevent the client from being
+	// created, as they did not in earlier releases.
+	if c.Logger != nil {
+		for _, w := range c.Warnings() {
+			c.Logger.Warn("configuration: %s", w)
+		}
+	}
+
 	// Set a default RetryHandler if not specified.
 	if c.RetryHandler == nil {
 		c.RetryHandler, err = NewDefaultRetryHandler(5, 0)
@@ -170,6 +394,10 @@ func (c *Config) setDefaults() (err error) {
 		}
 	}
 
+	if c.RequestSigner != nil && c.AuthorizationProvider == nil {
+		c.AuthorizationProvider = &requestSignerProvider{signer: c.RequestSigner, logger: c.Logger}
+	}
+
 	// Check the specified endpoint and set default authorization provider
 	// when connect to cloud simulator or on-premise server.
 	if c.Mode == "cloudsim" || c.Mode == "onprem" {
@@ -240,17 +468,46 @@ func (c *Config) setDefaults() (err error) {
 		}
 	}
 
+	if c.SigningAlgorithm != iam.DefaultSigningAlgorithm {
+		if sp, ok := asSignatureProvider(c.AuthorizationProvider); ok {
+			if _, err = sp.SetSigningAlgorithm(c.SigningAlgorithm); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.TraceSigning {
+		if sp, ok := asSignatureProvider(c.AuthorizationProvider); ok {
+			if _, err = sp.SetSigningTrace(c.Logger); err != nil {
+				return err
+			}
+		}
+	}
+
 	// When connect to cloud service, look for Region or Endpoint in order:
 	//
 	//   1. use Config.Region if it is specified
 	//   2. use the "region" field from OCI configuration file if it is specified
 	//   3. use Config.Endpoint if it is specified
+	//   4. use the region of the compute instance, retrieved from the instance
+	//      metadata service, for instance principals
 	//
 	if len(c.Region) == 0 {
 		var regionID string
-		if sp, ok := c.AuthorizationProvider.(*iam.SignatureProvider); ok {
-			if profile := sp.Profile(); profile != nil {
-				regionID, _ = profile.Region()
+		var regionErr error
+		if sp, ok := asSignatureProvider(c.AuthorizationProvider); ok {
+			profile := sp.Profile()
+			switch {
+			case profile == nil:
+			case sp.UsesInstancePrincipal():
+				if len(c.Endpoint) == 0 {
+					regionID, regionErr = profile.Region()
+					if len(regionID) > 0 {
+						c.Logger.Info("using region %s of the compute instance from the instance metadata service", regionID)
+					}
+				}
+			default:
+				regionID, regionErr = profile.Region()
 			}
 		}
 
@@ -260,6 +517,9 @@ func (c *Config) setDefaults() (err error) {
 			c.Region = common.Region(regionID)
 		// neither region nor endpoint is specified
 		case len(c.Endpoint) == 0:
+			if regionErr != nil {
+				return fmt.Errorf("region must be specified: %v", regionErr)
+			}
 			return fmt.Errorf("region must be specified")
 		}
 	}
@@ -393,6 +653,15 @@ type RequestConfig struct {
 	// If set, it must be greater than or equal to 1 millisecond.
 	TableRequestTimeout time.Duration `json:"tableRequestTimeout,omitempty"`
 
+	// MaxRetryDuration specifies the maximum time spent to execute a request,
+	// including its retries. The timeout of the request then only limits each
+	// attempt, so that a request with a timeout of 2 seconds can be retried
+	// for up to 30 seconds with a MaxRetryDuration of 30 seconds. The
+	// RequestTimeout error returned when it elapses reports it.
+	// If not set, or set to a value that is less than the timeout of a
+	// request, the request is retried until its timeout elapses.
+	MaxRetryDuration time.Duration `json:"maxRetryDuration,omitempty"`
+
 	// SecurityInfoTimeout specifies a timeout value for retrieving security
 	// information such as access tokens from authorization service.
 	// This specifies a period of time waiting for security information to be available.
@@ -410,6 +679,11 @@ type RequestConfig struct {
 	// This is only available with on-premises installations using NoSQL
 	// Server versions 23.3 and above.
 	Namespace string `json:"namespace,omitempty"`
+
+	// DecodeMode specifies how rows are decoded into native structs by
+	// GetRequest and QueryRequest, unless specified in the request.
+	// If not set, DecodeLenient is used.
+	DecodeMode DecodeMode `json:"decodeMode,omitempty"`
 }
 
 // DefaultRequestTimeout returns the default timeout value for requests.
@@ -422,6 +696,15 @@ func (r *RequestConfig) DefaultRequestTimeout() time.Duration {
 	return r.RequestTimeout
 }
 
+// retryDuration returns the maximum time spent to execute a request with the
+// specified timeout, including its retries.
+func (r *RequestConfig) retryDuration(timeout time.Duration) time.Duration {
+	if r == nil || r.MaxRetryDuration < timeout {
+		return timeout
+	}
+	return r.MaxRetryDuration
+}
+
 // DefaultTableRequestTimeout returns the default timeout value for table
 // requests. If there is no configured timeout or it is configured as 0, a
 // default value (defaultTableRequestTimeout) of 10 seconds is used.
@@ -465,6 +748,38 @@ func (r *RequestConfig) DefaultNamespace() string {
 	return r.Namespace
 }
 
+// DefaultDecodeMode returns the default DecodeMode value. If there is a
+// configured DecodeMode it is returned. Otherwise DecodeLenient is used.
+func (r *RequestConfig) DefaultDecodeMode() DecodeMode {
+	if r == nil || r.DecodeMode == DecodeDefault {
+		return DecodeLenient
+	}
+	return r.DecodeMode
+}
+
+// DecodeMode specifies how the rows returned by the server are decoded into
+// native structs, see GetRequest.StructType and QueryRequest.StructType.
+type DecodeMode int
+
+const (
+	// DecodeDefault means the DecodeMode of the RequestConfig of the client
+	// is used.
+	DecodeDefault DecodeMode = iota // 0
+
+	// DecodeLenient means the fields of a row that have no corresponding
+	// struct field are ignored, and values are converted to the type of their
+	// struct field even if they are truncated or lose precision, for example
+	// a LONG decoded into an int32 field.
+	DecodeLenient // 1
+
+	// DecodeStrict means decoding a row fails with an error if it has a field
+	// that has no corresponding struct field, or a value that cannot be
+	// represented exactly by its struct field, such as a LONG that overflows
+	// an int32 field, or a NUMBER or DOUBLE that is not exactly a float32 or
+	// float64. Use it to detect schema changes and silent data truncation.
+	DecodeStrict // 2
+)
+
 // LoggingConfig represents logging configurations.
 type LoggingConfig struct {
 
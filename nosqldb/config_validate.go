@@ -0,0 +1,319 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"os"
+	"strings"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/kvstore"
+)
+
+// ConfigProblem describes a problem found in a Config.
+type ConfigProblem struct {
+	// Field represents the name of the configuration parameter the problem
+	// relates to, such as "Endpoint" or "HTTPConfig.CertPath".
+	Field string `json:"field"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+
+	// Hint suggests how to fix the problem. It may be empty.
+	Hint string `json:"hint,omitempty"`
+}
+
+// String returns a string representation of the ConfigProblem.
+func (p ConfigProblem) String() string {
+	s := p.Field + ": " + p.Message
+	if p.Hint != "" {
+		s += " (" + p.Hint + ")"
+	}
+	return s
+}
+
+// ConfigError is the error returned by Config.Validate. It contains all the
+// problems found in the Config.
+type ConfigError struct {
+	Problems []ConfigProblem `json:"problems"`
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string {
+	if len(e.Problems) == 1 {
+		return "invalid configuration: " + e.Problems[0].String()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("invalid configuration:")
+	for _, p := range e.Problems {
+		sb.WriteString("\n  - ")
+		sb.WriteString(p.String())
+	}
+	return sb.String()
+}
+
+// Validate checks the Config for problems that prevent a Client from being
+// created, including combinations of parameters that conflict with each
+// other, such as an Endpoint specified along with a Region, invalid values,
+// and certificate files that cannot be accessed.
+//
+// If problems are found, Validate returns a *ConfigError that contains all of
+// them, with hints on how to fix them. Validate does not modify the Config.
+//
+// Validate is called by NewClient, so it is not necessary to call it before
+// creating a Client. Parameters that are ignored, such as credentials that do
+// not match the configuration mode, are not errors, see Warnings.
+func (c *Config) Validate() error {
+	chk := c.check()
+	if len(chk.errors) > 0 {
+		return &ConfigError{Problems: chk.errors}
+	}
+	return nil
+}
+
+// Warnings returns the problems of the Config that do not prevent a Client
+// from being created, such as parameters that are ignored because they do
+// not apply to the configuration mode, the Endpoint or the
+// AuthorizationProvider. NewClient logs them at Warn level.
+func (c *Config) Warnings() []ConfigProblem {
+	return c.check().warnings
+}
+
+// configCheck collects the problems found in a Config.
+type configCheck struct {
+	// errors represents the problems that prevent a Client from being
+	// created.
+	errors []ConfigProblem
+
+	// warnings represents the parameters that are ignored.
+	warnings []ConfigProblem
+}
+
+func (chk *configCheck) fail(field, message, hint string) {
+	chk.errors = append(chk.errors, ConfigProblem{Field: field, Message: message, Hint: hint})
+}
+
+func (chk *configCheck) warn(field, message, hint string) {
+	chk.warnings = append(chk.warnings, ConfigProblem{Field: field, Message: message, Hint: hint})
+}
+
+// check checks the Config for errors and ignored parameters.
+func (c *Config) check() *configCheck {
+	chk := &configCheck{}
+
+	mode := strings.ToLower(c.Mode)
+	switch mode {
+	case "", "cloud":
+	case "cloudsim", "onprem":
+		if len(c.Endpoint) == 0 {
+			chk.fail("Endpoint", "endpoint must be specified for mode "+mode,
+				"set Endpoint to the address of the "+modeServer(mode)+", such as http://localhost:8080")
+		}
+		if len(c.Region) > 0 {
+			chk.warn("Region", "region is not used for mode "+mode, "remove Region and set Endpoint")
+		}
+	default:
+		chk.fail("Mode", "the specified configuration mode \""+c.Mode+"\" is not supported",
+			"use \"cloud\", \"cloudsim\" or \"onprem\"")
+	}
+
+	if len(c.Endpoint) > 0 && len(c.Region) > 0 {
+		chk.fail("Endpoint", "cannot have both Endpoint and Region specified",
+			"specify either Region or Endpoint, Region is preferred for the cloud service")
+	}
+
+	var protocol string
+	if len(c.Endpoint) > 0 {
+		var err error
+		if protocol, _, _, err = parseEndpoint(c.Endpoint); err != nil {
+			chk.fail("Endpoint", err.Error(), "use the syntax [http[s]://]host[:port]")
+		}
+	}
+
+	if len(c.AdditionalEndpoints) > 0 {
+		if len(c.Endpoint) == 0 {
+			chk.fail("AdditionalEndpoints", "AdditionalEndpoints can only be specified with Endpoint",
+				"set Endpoint to one of the endpoints")
+		}
+		for _, e := range c.AdditionalEndpoints {
+			p, _, _, err := parseEndpoint(e)
+			switch {
+			case err != nil:
+				chk.fail("AdditionalEndpoints", err.Error(), "use the syntax [http[s]://]host[:port]")
+			case protocol != "" && p != protocol:
+				chk.fail("AdditionalEndpoints", "endpoint \""+e+"\" does not use the same protocol as Endpoint",
+					"use "+protocol+" for all endpoints")
+			}
+		}
+	}
+
+	c.checkCredentials(mode, chk)
+	if c.RESTFallback && (mode == "cloudsim" || mode == "onprem") {
+		chk.warn("RESTFallback", "the REST API is only available on the cloud service",
+			"remove RESTFallback, or set Mode to \"cloud\"")
+	}
+	c.checkTLS(protocol, chk)
+
+	if c.MaxConcurrentRequests < 0 {
+		chk.fail("MaxConcurrentRequests", "must not be negative", "use 0 to not limit the number of requests")
+	}
+	if c.MaxConcurrentRequestsPerTable < 0 {
+		chk.fail("MaxConcurrentRequestsPerTable", "must not be negative", "use 0 to not limit the number of requests")
+	}
+	if c.SchemaCacheTTL < 0 {
+		chk.fail("SchemaCacheTTL", "must not be negative", "use 0 to disable the schema cache")
+	}
+	if c.PutCoalescingWindow < 0 {
+		chk.fail("PutCoalescingWindow", "must not be negative", "use 0 to not coalesce puts")
+	}
+	if c.MaxRetryDuration < 0 {
+		chk.fail("MaxRetryDuration", "must not be negative", "use 0 to retry requests until their timeout elapses")
+	}
+	if c.RateLimiterPercentage < 0 || c.RateLimiterPercentage > 100 {
+		chk.fail("RateLimiterPercentage", "must be between 0 and 100", "use 0 for the default of 100")
+	}
+
+	return chk
+}
+
+// checkCredentials checks that the credentials and AuthorizationProvider
+// match the configuration mode.
+func (c *Config) checkCredentials(mode string, chk *configCheck) {
+	hasUser, hasPassword := len(c.Username) > 0, len(c.Password) > 0
+	if hasUser || hasPassword {
+		switch {
+		case mode != "onprem":
+			chk.warn("Username", "Username and Password are only used for mode onprem",
+				"remove Username and Password, or set Mode to \"onprem\"")
+		case c.AuthorizationProvider != nil:
+			chk.warn("Username", "Username and Password are ignored when an AuthorizationProvider is specified",
+				"remove either Username and Password, or AuthorizationProvider")
+		case c.RequestSigner != nil:
+			chk.warn("Username", "Username and Password are ignored when a RequestSigner is specified",
+				"remove either Username and Password, or RequestSigner")
+		case !hasUser:
+			chk.warn("Password", "Password is ignored as Username is not specified", "set Username")
+		case !hasPassword:
+			chk.warn("Username", "Username is ignored as Password is not specified", "set Password")
+		}
+	}
+
+	if c.RequestSigner != nil && c.AuthorizationProvider != nil {
+		if _, ok := c.AuthorizationProvider.(*requestSignerProvider); !ok {
+			chk.fail("RequestSigner", "cannot have both RequestSigner and AuthorizationProvider specified",
+				"remove either RequestSigner, or AuthorizationProvider")
+		}
+	}
+
+	ap := c.AuthorizationProvider
+	if sp, ok := asSignatureProvider(ap); ok {
+		ap = sp
+	}
+	switch ap.(type) {
+	case *kvstore.AccessTokenProvider:
+		if mode != "onprem" {
+			chk.warn("AuthorizationProvider", "kvstore.AccessTokenProvider is only used for mode onprem",
+				"use an iam.SignatureProvider for the cloud service")
+		}
+	case *kvstore.KerberosProvider:
+		if mode != "onprem" {
+			chk.warn("AuthorizationProvider", "kvstore.KerberosProvider is only used for mode onprem",
+				"use an iam.SignatureProvider for the cloud service")
+		}
+	case *iam.SignatureProvider:
+		if mode == "cloudsim" || mode == "onprem" {
+			chk.warn("AuthorizationProvider", "iam.SignatureProvider is only used for the cloud service",
+				"remove AuthorizationProvider, or set Mode to \"cloud\"")
+		}
+	}
+
+	if c.SigningAlgorithm != iam.DefaultSigningAlgorithm {
+		_, isSignatureProvider := asSignatureProvider(c.AuthorizationProvider)
+		switch {
+		case c.SigningAlgorithm != iam.RSASHA256 && c.SigningAlgorithm != iam.RSAPSSSHA256:
+			chk.fail("SigningAlgorithm", "the signing algorithm \""+string(c.SigningAlgorithm)+"\" is not supported",
+				"use iam.RSASHA256 or iam.RSAPSSSHA256")
+		case mode == "cloudsim" || mode == "onprem":
+			chk.warn("SigningAlgorithm", "SigningAlgorithm is only used for the cloud service",
+				"remove SigningAlgorithm, or set Mode to \"cloud\"")
+		case (c.AuthorizationProvider != nil && !isSignatureProvider) || c.RequestSigner != nil:
+			chk.warn("SigningAlgorithm", "SigningAlgorithm is only used with an iam.SignatureProvider",
+				"remove SigningAlgorithm, or use an iam.SignatureProvider")
+		}
+	}
+
+	if c.TraceSigning {
+		_, isSignatureProvider := asSignatureProvider(c.AuthorizationProvider)
+		switch {
+		case mode == "cloudsim" || mode == "onprem":
+			chk.warn("TraceSigning", "TraceSigning is only used for the cloud service",
+				"remove TraceSigning, or set Mode to \"cloud\"")
+		case (c.AuthorizationProvider != nil && !isSignatureProvider) || c.RequestSigner != nil:
+			chk.warn("TraceSigning", "TraceSigning is only used with an iam.SignatureProvider",
+				"remove TraceSigning, or use an iam.SignatureProvider")
+		}
+	}
+}
+
+// checkTLS checks the TLS and proxy settings of the HTTPConfig.
+func (c *Config) checkTLS(protocol string, chk *configCheck) {
+	h := &c.HTTPConfig
+	hasClientCert := h.ClientCertPath != "" || h.ClientKeyPath != "" || h.ClientCertificate != nil ||
+		h.GetClientCertificate != nil
+	if protocol == "http" && (h.CertPath != "" || h.ServerName != "" || h.InsecureSkipVerify || hasClientCert) {
+		chk.warn("HTTPConfig", "TLS settings are ignored as Endpoint uses http",
+			"use an https Endpoint, or remove CertPath, ServerName, InsecureSkipVerify and the client certificate")
+	}
+
+	if (h.ClientCertPath == "") != (h.ClientKeyPath == "") {
+		chk.fail("HTTPConfig.ClientCertPath", "ClientCertPath and ClientKeyPath must be specified together",
+			"set both to the paths of the PEM-encoded certificate and private key files")
+	}
+
+	for _, f := range []struct{ field, path string }{
+		{"HTTPConfig.ClientCertPath", h.ClientCertPath},
+		{"HTTPConfig.ClientKeyPath", h.ClientKeyPath},
+	} {
+		if f.path == "" {
+			continue
+		}
+		if _, err := os.Stat(f.path); err != nil {
+			chk.fail(f.field, "cannot access the file: "+err.Error(), "")
+		}
+	}
+
+	if h.InsecureSkipVerify && (h.CertPath != "" || h.ServerName != "") {
+		chk.warn("HTTPConfig.InsecureSkipVerify", "CertPath and ServerName are ignored when InsecureSkipVerify is true",
+			"remove InsecureSkipVerify to verify the server certificate with CertPath")
+	}
+
+	if h.ServerName != "" && h.CertPath == "" {
+		chk.warn("HTTPConfig.ServerName", "ServerName is only used with CertPath",
+			"set CertPath to the certificate of the server")
+	}
+
+	if h.CertPath != "" {
+		if _, err := os.Stat(h.CertPath); err != nil {
+			chk.fail("HTTPConfig.CertPath", "cannot access the certificate file: "+err.Error(),
+				"set CertPath to the path of a PEM-encoded certificate file")
+		}
+	}
+
+	if (h.ProxyUsername != "" || h.ProxyPassword != "") && h.ProxyURL == "" && !h.UseProxyFromEnv {
+		chk.warn("HTTPConfig.ProxyUsername", "proxy credentials are specified without a proxy",
+			"set ProxyURL, or set UseProxyFromEnv to true")
+	}
+}
+
+func modeServer(mode string) string {
+	if mode == "cloudsim" {
+		return "cloud simulator"
+	}
+	return "proxy"
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/oracle/nosql-go-sdk/nosqldb/httputil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidateProblems(t *testing.T) {
+	c := &Config{
+		Mode:                  "cloudsim",
+		Endpoint:              "http://localhost:8080",
+		AdditionalEndpoints:   []string{"https://localhost:8081"},
+		Username:              "admin",
+		MaxConcurrentRequests: -1,
+	}
+	c.InsecureSkipVerify = true
+	c.CertPath = "testdata/no-such-cert.pem"
+
+	err := c.Validate()
+	var cfgErr *ConfigError
+	require.True(t, errors.As(err, &cfgErr), "unexpected error %v", err)
+
+	fields := func(problems []ConfigProblem) []string {
+		var fields []string
+		for _, p := range problems {
+			fields = append(fields, p.Field)
+		}
+		return fields
+	}
+	assert.Equal(t, []string{
+		"AdditionalEndpoints",
+		"HTTPConfig.CertPath",
+		"MaxConcurrentRequests",
+	}, fields(cfgErr.Problems))
+	assert.Contains(t, err.Error(), "\n  - MaxConcurrentRequests: must not be negative")
+	assert.Equal(t, []string{
+		"Username",
+		"HTTPConfig",
+		"HTTPConfig.InsecureSkipVerify",
+	}, fields(c.Warnings()))
+	assert.Equal(t, "cloudsim", c.Mode, "Validate must not modify the Config")
+
+	tests := []struct {
+		desc    string
+		cfg     *Config
+		problem string
+		// warning specifies whether the problem is a warning rather than an
+		// error.
+		warning bool
+	}{
+		{"unsupported signing algorithm", &Config{Region: "us-ashburn-1", SigningAlgorithm: "rsa-sha512"},
+			"SigningAlgorithm: the signing algorithm \"rsa-sha512\" is not supported", false},
+		{"negative retry duration", func() *Config {
+			c := &Config{Mode: "cloudsim", Endpoint: "localhost:8080"}
+			c.MaxRetryDuration = -time.Second
+			return c
+		}(), "MaxRetryDuration: must not be negative", false},
+		{"endpoint and region", &Config{Mode: "onprem", Endpoint: "localhost:8080", Region: "us-ashburn-1"},
+			"Endpoint: cannot have both Endpoint and Region specified", false},
+		{"signing algorithm for cloudsim", &Config{Mode: "cloudsim", Endpoint: "localhost:8080", SigningAlgorithm: iam.RSAPSSSHA256},
+			"SigningAlgorithm: SigningAlgorithm is only used for the cloud service", true},
+		{"signing traces for onprem", &Config{Mode: "onprem", Endpoint: "localhost:8080", TraceSigning: true},
+			"TraceSigning: TraceSigning is only used for the cloud service", true},
+		{"password without username", &Config{Mode: "onprem", Endpoint: "localhost:8080", Password: []byte("pwd")},
+			"Password: Password is ignored as Username is not specified", true},
+		{"REST fallback for onprem", &Config{Mode: "onprem", Endpoint: "localhost:8080", RESTFallback: true},
+			"RESTFallback: the REST API is only available on the cloud service", true},
+		{"proxy credentials without proxy", &Config{Mode: "onprem", Endpoint: "localhost:8080",
+			HTTPConfig: httputil.HTTPConfig{ProxyUsername: "user"}},
+			"HTTPConfig.ProxyUsername: proxy credentials are specified without a proxy", true},
+	}
+	for _, r := range tests {
+		err := r.cfg.Validate()
+		var warnings []string
+		for _, w := range r.cfg.Warnings() {
+			warnings = append(warnings, w.Field+": "+w.Message)
+		}
+		if r.warning {
+			assert.NoErrorf(t, err, "%s", r.desc)
+			assert.Containsf(t, warnings, r.problem, "%s", r.desc)
+		} else {
+			require.Errorf(t, err, "%s", r.desc)
+			assert.Containsf(t, err.Error(), r.problem, "%s", r.desc)
+		}
+	}
+
+	c = &Config{Mode: "ONPREM", Endpoint: "localhost:8080", Username: "admin", Password: []byte("pwd")}
+	assert.NoError(t, c.Validate())
+	assert.Empty(t, c.Warnings())
+
+	// The warnings are logged by NewClient, which does not fail.
+	var buf bytes.Buffer
+	cfg := Config{Mode: "onprem", Endpoint: "localhost:8080", Password: []byte("pwd")}
+	cfg.Logger = logger.New(&buf, logger.Warn, false)
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+	client.Close()
+	assert.Contains(t, buf.String(), "configuration: Password: Password is ignored as Username is not specified")
+}
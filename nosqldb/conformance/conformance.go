@@ -0,0 +1,163 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+// Package conformance provides test vectors for the wire format of the binary
+// protocol used between the SDK and the Oracle NoSQL Database proxy, and a
+// test entry point that verifies them, so that forks and extensions of the
+// SDK can check that their changes keep the wire format compatible with the
+// proxy and the other SDKs.
+//
+// The vectors consist of the encodings of field values, which are shared by
+// all versions of the protocol, and of exchanges of serialized requests and
+// responses in version 4 of the protocol. The encodings are stored in
+// hexadecimal, so that vectors recorded from other SDKs can be added.
+//
+// To verify the SDK, call Run from a test:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t)
+//	}
+package conformance
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb"
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// ValueVector is the encoding of a field value.
+type ValueVector struct {
+	// Name describes the value.
+	Name string
+
+	// Value is the field value.
+	Value types.FieldValue
+
+	// Hex is the hexadecimal encoding of Value, which starts with its type.
+	Hex string
+}
+
+// Exchange is a request sent by a Client and the response of the server to
+// it, in version 4 of the protocol.
+type Exchange struct {
+	// Name describes the exchange.
+	Name string
+
+	// Do sends the request with the client, and returns its result.
+	Do func(c *nosqldb.Client) (interface{}, error)
+
+	// Request is the hexadecimal encoding of the body of the request, with
+	// the entries of maps sorted by key, see nosqldb.DeterministicOptions.
+	Request string
+
+	// Response is the hexadecimal encoding of the body of the response.
+	Response string
+
+	// Check verifies the result and error returned by Do for Response.
+	Check func(t testing.TB, res interface{}, err error)
+}
+
+// Run verifies the value vectors and the exchanges of the package.
+func Run(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		RunValues(t, ValueVectors)
+	})
+	t.Run("Exchanges", func(t *testing.T) {
+		RunExchanges(t, Exchanges)
+	})
+}
+
+// RunValues verifies that each value is encoded as the vector specifies, and
+// that the encoding is decoded to a value with the same encoding.
+func RunValues(t *testing.T, vectors []ValueVector) {
+	for _, v := range vectors {
+		v := v
+		t.Run(v.Name, func(t *testing.T) {
+			want, err := hex.DecodeString(v.Hex)
+			if err != nil {
+				t.Fatalf("invalid vector: %v", err)
+			}
+
+			got, err := encodeValue(v.Value)
+			if err != nil {
+				t.Fatalf("cannot encode %v: %v", v.Value, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("encoding of %v:\n got: %x\nwant: %x", v.Value, got, want)
+			}
+
+			decoded, err := binary.NewReader(bytes.NewBuffer(want)).ReadFieldValue()
+			if err != nil {
+				t.Fatalf("cannot decode %s: %v", v.Hex, err)
+			}
+			if got, err = encodeValue(decoded); err != nil || !bytes.Equal(got, want) {
+				t.Errorf("decoded value %v is encoded as %x, want %x (error %v)", decoded, got, want, err)
+			}
+		})
+	}
+}
+
+// RunExchanges sends the request of each exchange to a server that verifies
+// it and replies with the response of the exchange, and verifies the result.
+func RunExchanges(t *testing.T, exchanges []Exchange) {
+	for _, e := range exchanges {
+		e := e
+		t.Run(e.Name, func(t *testing.T) {
+			wantReq, err := hex.DecodeString(e.Request)
+			if err != nil {
+				t.Fatalf("invalid request: %v", err)
+			}
+			resp, err := hex.DecodeString(e.Response)
+			if err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ := io.ReadAll(r.Body)
+				if !bytes.Equal(got, wantReq) {
+					t.Errorf("request:\n got: %x\nwant: %x", got, wantReq)
+				}
+				w.Header().Set("Content-Type", "application/octet-stream")
+				w.Write(resp)
+			}))
+			defer server.Close()
+
+			client, err := nosqldb.NewClient(nosqldb.Config{
+				Mode:     "onprem",
+				Endpoint: server.URL,
+				Deterministic: &nosqldb.DeterministicOptions{
+					RequestID: func() int { return 1 },
+				},
+			})
+			if err != nil {
+				t.Fatalf("cannot create client: %v", err)
+			}
+			defer client.Close()
+
+			res, err := e.Do(client)
+			e.Check(t, res, err)
+		})
+	}
+}
+
+// encodeValue returns the encoding of a field value, with the entries of maps
+// sorted by key.
+func encodeValue(v types.FieldValue) ([]byte, error) {
+	w := binary.NewWriter()
+	w.SetSortMapKeys(true)
+	if _, err := w.WriteFieldValue(v); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
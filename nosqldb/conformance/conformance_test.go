@@ -0,0 +1,14 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package conformance
+
+import "testing"
+
+func TestConformance(t *testing.T) {
+	Run(t)
+}
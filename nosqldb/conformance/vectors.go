@@ -0,0 +1,143 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package conformance
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// ValueVectors are the encodings of field values of each type, including the
+// boundaries of the packed integer encodings, which are big-endian.
+var ValueVectors = []ValueVector{
+	{Name: "json null", Value: types.JSONNullValueInstance, Hex: "0a"},
+	{Name: "sql null", Value: types.NullValueInstance, Hex: "0b"},
+	{Name: "empty", Value: types.EmptyValueInstance, Hex: "0c"},
+	{Name: "true", Value: true, Hex: "0201"},
+	{Name: "false", Value: false, Hex: "0200"},
+	{Name: "integer 0", Value: 0, Hex: "047f"},
+	{Name: "integer 119", Value: 119, Hex: "04f6"},
+	{Name: "integer 120", Value: 120, Hex: "04f7"},
+	{Name: "integer -119", Value: -119, Hex: "0408"},
+	{Name: "integer -120", Value: -120, Hex: "0407ff"},
+	{Name: "integer 65535", Value: 65535, Hex: "04f9ff86"},
+	{Name: "integer max", Value: math.MaxInt32, Hex: "04fb7fffff86"},
+	{Name: "integer min", Value: math.MinInt32, Hex: "040480000077"},
+	{Name: "long 0", Value: int64(0), Hex: "057f"},
+	{Name: "long max", Value: int64(math.MaxInt64), Hex: "05ff7fffffffffffff86"},
+	{Name: "long min", Value: int64(math.MinInt64), Hex: "05008000000000000077"},
+	{Name: "double 0", Value: 0.0, Hex: "030000000000000000"},
+	{Name: "double 1.5", Value: 1.5, Hex: "033ff8000000000000"},
+	{Name: "double -1e300", Value: -1e300, Hex: "03fe37e43c8800759c"},
+	{Name: "double max", Value: math.MaxFloat64, Hex: "037fefffffffffffff"},
+	{Name: "string empty", Value: "", Hex: "077f"},
+	{Name: "string ascii", Value: "Oracle NoSQL", Hex: "078b4f7261636c65204e6f53514c"},
+	{Name: "string utf-8", Value: "héllo 世界", Hex: "078c68c3a96c6c6f20e4b896e7958c"},
+	{Name: "binary", Value: []byte{0, 1, 0xfe, 0xff}, Hex: "01830001feff"},
+	{Name: "binary empty", Value: []byte{}, Hex: "017f"},
+	{Name: "timestamp", Value: time.Date(2024, time.February, 29, 23, 59, 59, 123000000, time.UTC), Hex: "0897323032342d30322d32395432333a35393a35392e3132335a"},
+	{Name: "number", Value: big.NewRat(123456789012345678, 1), Hex: "0991313233343536373839303132333435363738"},
+	{Name: "array", Value: []types.FieldValue{1, "a", nil}, Hex: "000000000a0000000304800780610a"},
+	{Name: "array empty", Value: []types.FieldValue{}, Hex: "000000000400000000"},
+	{Name: "map", Value: map[string]interface{}{"b": 2, "a": []byte{1}, "c": map[string]interface{}{"d": true}}, Hex: "060000001c00000003806101800180620481806306000000080000000180640201"},
+	{Name: "map empty", Value: map[string]interface{}{}, Hex: "060000000400000000"},
+}
+
+// Exchanges are requests of each kind of data operation and the responses of
+// the server to them, including an error response.
+var Exchanges = []Exchange{
+	{
+		Name: "get",
+		Do: func(c *nosqldb.Client) (interface{}, error) {
+			return c.Get(&nosqldb.GetRequest{
+				TableName: "users",
+				Key:       types.NewMapValue(map[string]interface{}{"id": 1}),
+			})
+		},
+		Request:  "0004060000005600000002806806000000200000000580760483806f0481807404f9130f806e07847573657273817473047e807006000000240000000281636f06000000080000000180790480806b0600000009000000018169640480",
+		Response: "060000006200000003806306000000130000000381726b0480817275048081776b047f8065047f8072060000003900000004806c0600000015000000028169640480836e616d650784416c696365816d6405fd018bcfe56787817276018301020304817870057f",
+		Check: func(t testing.TB, res interface{}, err error) {
+			if err != nil {
+				t.Fatalf("Get() got error %v", err)
+			}
+			r := res.(*nosqldb.GetResult)
+			if name, _ := r.Value.GetString("name"); name != "Alice" {
+				t.Errorf("Get() got row %v, want name Alice", r.Value)
+			}
+			if r.ReadUnits != 1 || r.ModificationTime != 1700000000000 || len(r.Version) != 4 {
+				t.Errorf("Get() got read units %d, modification time %d, version %x",
+					r.ReadUnits, r.ModificationTime, r.Version)
+			}
+		},
+	},
+	{
+		Name: "put",
+		Do: func(c *nosqldb.Client) (interface{}, error) {
+			return c.Put(&nosqldb.PutRequest{
+				TableName: "users",
+				Value: types.NewMapValue(map[string]interface{}{
+					"id":   1,
+					"name": "Alice",
+					"tags": []interface{}{"a", "b"},
+				}),
+			})
+		},
+		Request:  "0004060000006b00000002806806000000200000000580760483806f0482807404f9130f806e07847573657273817473047e8070060000003900000002816963047f806c0600000029000000038169640480836e616d650784416c6963658374616773000000000a00000002078061078062",
+		Response: "060000002b00000003806306000000130000000381726b047f817275047f81776b04808065047f817276018305060708",
+		Check: func(t testing.TB, res interface{}, err error) {
+			if err != nil {
+				t.Fatalf("Put() got error %v", err)
+			}
+			r := res.(*nosqldb.PutResult)
+			if r.WriteKB != 1 || len(r.Version) != 4 {
+				t.Errorf("Put() got write KB %d, version %x", r.WriteKB, r.Version)
+			}
+		},
+	},
+	{
+		Name: "delete",
+		Do: func(c *nosqldb.Client) (interface{}, error) {
+			return c.Delete(&nosqldb.DeleteRequest{
+				TableName: "users",
+				Key:       types.NewMapValue(map[string]interface{}{"id": 1}),
+			})
+		},
+		Request:  "0004060000004600000002806806000000200000000580760483806f047f807404f9130f806e07847573657273817473047e8070060000001400000001806b0600000009000000018169640480",
+		Response: "060000002700000003806306000000130000000381726b047f817275047f81776b04808065047f8173730201",
+		Check: func(t testing.TB, res interface{}, err error) {
+			if err != nil {
+				t.Fatalf("Delete() got error %v", err)
+			}
+			if r := res.(*nosqldb.DeleteResult); !r.Success {
+				t.Errorf("Delete() got Success false")
+			}
+		},
+	},
+	{
+		Name: "table not found",
+		Do: func(c *nosqldb.Client) (interface{}, error) {
+			return c.Get(&nosqldb.GetRequest{
+				TableName: "missing",
+				Key:       types.NewMapValue(map[string]interface{}{"id": 1}),
+			})
+		},
+		Request:  "0004060000005800000002806806000000220000000580760483806f0481807404f9130f806e07866d697373696e67817473047e807006000000240000000281636f06000000080000000180790480806b0600000009000000018169640480",
+		Response: "06000000240000000280650481807807975461626c65206e6f7420666f756e643a206d697373696e67",
+		Check: func(t testing.TB, res interface{}, err error) {
+			if !nosqlerr.IsTableNotFound(err) {
+				t.Errorf("Get() got error %v, want TableNotFound", err)
+			}
+		},
+	},
+}
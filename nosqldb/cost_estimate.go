@@ -0,0 +1,294 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+const (
+	// costUnitSize is the number of bytes metered by one read or write unit.
+	costUnitSize = 1024
+
+	// defaultVariableFieldSize is the size in bytes assumed for the values
+	// of the columns of variable size, such as STRING and JSON columns, when
+	// the row size is estimated from the schema of a table.
+	defaultVariableFieldSize = 64
+)
+
+// EstimateOptions specifies options for Client.Estimate.
+type EstimateOptions struct {
+	// RowSize specifies the estimated size of the rows in bytes.
+	//
+	// It is optional. If not set, the size of the row of a PutRequest is
+	// computed from its value, and the size of the other rows is estimated
+	// from the schema of the table.
+	RowSize int
+
+	// Rows specifies the number of rows read by a QueryRequest.
+	//
+	// It is optional. If not set, the query is assumed to read a single row.
+	Rows int
+}
+
+// CostEstimate represents the read and write units that an operation is
+// predicted to consume, as returned by Client.Estimate.
+type CostEstimate struct {
+	// Request specifies the kind of request, such as "Get" or "Put".
+	Request string `json:"request"`
+
+	// TableName specifies the name of the table.
+	TableName string `json:"tableName,omitempty"`
+
+	// RowSize specifies the size in bytes of the rows used for the estimate.
+	RowSize int `json:"rowSize"`
+
+	// ReadUnits specifies the predicted read units.
+	ReadUnits int `json:"readUnits"`
+
+	// WriteUnits specifies the predicted write units.
+	WriteUnits int `json:"writeUnits"`
+
+	// OperationsPerSecond specifies the number of operations per second that
+	// the throughput limits of the table allow, or 0 if the table has no
+	// limits, such as on-premises.
+	OperationsPerSecond float64 `json:"operationsPerSecond,omitempty"`
+}
+
+// String returns a JSON string representation of the CostEstimate.
+func (e CostEstimate) String() string {
+	return jsonutil.AsJSON(e)
+}
+
+// Estimate predicts the read and write units that the specified GetRequest,
+// PutRequest, DeleteRequest or QueryRequest would consume in the Cloud
+// Service, without executing it, so that capacity planning tools can model
+// workloads. The request is neither validated nor modified.
+//
+// The estimate follows the metering rules of the service:
+//
+//   - A read unit is consumed for each 1 KB read, rounded up, with a minimum
+//     of 1. Reads with Absolute consistency consume twice as many units.
+//   - A write unit is consumed for each 1 KB written, rounded up, with a
+//     minimum of 1. A write unit is consumed for each index entry written or
+//     deleted.
+//   - Conditional puts, and deletes, read the existing row with Absolute
+//     consistency.
+//
+// The estimate is an upper bound for puts, which are assumed to replace an
+// existing row and its index entries, unless PutIfAbsent is used. The rows
+// read by a query must be specified with EstimateOptions.Rows, as queries are
+// neither prepared nor executed; the read units consumed to scan indexes are
+// not included.
+//
+// The schema, limits and indexes of the table are retrieved from the server,
+// using GetTableCached for the schema and limits. opts may be nil.
+func (c *Client) Estimate(ctx context.Context, req Request, opts *EstimateOptions) (*CostEstimate, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+	if req == nil {
+		return nil, errNilRequest
+	}
+	if opts == nil {
+		opts = &EstimateOptions{}
+	}
+	if opts.RowSize < 0 || opts.Rows < 0 {
+		return nil, nosqlerr.NewIllegalArgument("Estimate: RowSize and Rows must be non-negative")
+	}
+
+	var (
+		tableName, namespace string
+		consistency          types.Consistency
+		row                  *types.MapValue
+	)
+	switch r := req.(type) {
+	case *GetRequest:
+		tableName, namespace, consistency = r.TableName, r.Namespace, r.Consistency
+	case *PutRequest:
+		tableName, namespace = r.TableName, r.Namespace
+		v, err := r.rowValue()
+		if err != nil {
+			return nil, err
+		}
+		row = v
+	case *DeleteRequest:
+		tableName, namespace = r.TableName, r.Namespace
+	case *QueryRequest:
+		tableName, namespace, consistency = r.TableName, r.Namespace, r.Consistency
+	default:
+		return nil, nosqlerr.NewIllegalArgument("Estimate: unsupported request %T", req)
+	}
+	if namespace == "" {
+		namespace = c.DefaultNamespace()
+	}
+	if consistency == 0 {
+		consistency = c.DefaultConsistency()
+	}
+
+	e := &CostEstimate{
+		Request:   requestKind(req),
+		TableName: tableName,
+		RowSize:   opts.RowSize,
+	}
+
+	var table *TableResult
+	if tableName != "" {
+		var err error
+		if table, err = c.getTableCachedWithContext(ctx, namespace, tableName); err != nil {
+			return nil, err
+		}
+	}
+
+	if e.RowSize == 0 {
+		size, err := estimateRowSize(table, row)
+		if err != nil {
+			return nil, err
+		}
+		e.RowSize = size
+	}
+
+	readUnits := costUnits(e.RowSize)
+	if consistency == types.Absolute {
+		readUnits *= 2
+	}
+
+	switch r := req.(type) {
+	case *GetRequest:
+		e.ReadUnits = readUnits
+
+	case *QueryRequest:
+		rows := opts.Rows
+		if rows == 0 {
+			rows = 1
+		}
+		e.ReadUnits = rows * readUnits
+
+	case *PutRequest, *DeleteRequest:
+		indexes, err := c.countIndexes(ctx, namespace, tableName)
+		if err != nil {
+			return nil, err
+		}
+		// Replacing a row deletes its index entries and writes new ones.
+		if p, ok := r.(*PutRequest); ok && p.PutOption != types.PutIfAbsent {
+			indexes *= 2
+		}
+		e.WriteUnits = costUnits(e.RowSize) + indexes
+		if r.doesReads() {
+			e.ReadUnits = 2 * costUnits(e.RowSize)
+		}
+	}
+
+	if table != nil {
+		e.OperationsPerSecond = operationsPerSecond(table.Limits, e.ReadUnits, e.WriteUnits)
+	}
+	return e, nil
+}
+
+// countIndexes returns the number of indexes on the specified table.
+func (c *Client) countIndexes(ctx context.Context, namespace, tableName string) (int, error) {
+	res, err := c.executeWithContext(ctx, &GetIndexesRequest{
+		TableName: tableName,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if res, ok := res.(*GetIndexesResult); ok {
+		return len(res.Indexes), nil
+	}
+	return 0, errUnexpectedResult
+}
+
+// costUnits returns the read or write units metered for size bytes.
+func costUnits(size int) int {
+	if size <= costUnitSize {
+		return 1
+	}
+	return (size + costUnitSize - 1) / costUnitSize
+}
+
+// operationsPerSecond returns the number of operations per second that
+// consume the specified units that the limits of a table allow, or 0 if the
+// table has no limits.
+func operationsPerSecond(limits TableLimits, readUnits, writeUnits int) float64 {
+	ops := math.Inf(1)
+	if limits.ReadUnits > 0 && readUnits > 0 {
+		ops = math.Min(ops, float64(limits.ReadUnits)/float64(readUnits))
+	}
+	if limits.WriteUnits > 0 && writeUnits > 0 {
+		ops = math.Min(ops, float64(limits.WriteUnits)/float64(writeUnits))
+	}
+	if math.IsInf(ops, 1) {
+		return 0
+	}
+	return ops
+}
+
+// estimateRowSize returns the size in bytes of row as serialized by the
+// client, or, if row is nil, the size of a row of table estimated from its
+// schema.
+func estimateRowSize(table *TableResult, row *types.MapValue) (int, error) {
+	if row != nil {
+		w := binary.NewWriter()
+		if _, err := w.WriteFieldValue(row); err != nil {
+			return 0, err
+		}
+		return w.Size(), nil
+	}
+
+	if table == nil {
+		return 0, nosqlerr.NewIllegalArgument("Estimate: RowSize must be specified " +
+			"for requests without a table name")
+	}
+	var schema struct {
+		Fields []schemaField `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(table.Schema), &schema); err != nil || len(schema.Fields) == 0 {
+		return 0, nosqlerr.New(nosqlerr.IllegalState,
+			"cannot estimate the row size of table %q from its schema, specify RowSize", table.TableName)
+	}
+	return fieldsSize(schema.Fields), nil
+}
+
+// fieldsSize returns the estimated size in bytes of the values of fields.
+func fieldsSize(fields []schemaField) int {
+	size := 0
+	for _, f := range fields {
+		size += fieldSize(f)
+	}
+	return size
+}
+
+// fieldSize returns the estimated size in bytes of a value of the type of f.
+func fieldSize(f schemaField) int {
+	switch f.Type {
+	case "BOOLEAN", "ENUM":
+		return 1
+	case "INTEGER", "FLOAT":
+		return 5
+	case "LONG", "DOUBLE", "TIMESTAMP":
+		return 9
+	case "NUMBER":
+		return 12
+	case "FIXED_BINARY":
+		return f.Size
+	case "RECORD":
+		return fieldsSize(f.Fields)
+	default:
+		return defaultVariableFieldSize
+	}
+}
@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimate(t *testing.T) {
+	client, err := newMockClient()
+	require.NoError(t, err)
+	client.SetSerialVersion(3)
+	client.executor = &indexesExecutor{indexes: []string{"idx_name", "idx_created"}}
+	client.schemaCache = newSchemaCache(time.Minute)
+	client.schemaCache.put("", "users", &TableResult{
+		TableName: "users",
+		State:     types.Active,
+		Limits:    TableLimits{ReadUnits: 100, WriteUnits: 60},
+		Schema: `{"name":"users","fields":[
+			{"name":"id","type":"INTEGER"},
+			{"name":"name","type":"STRING"},
+			{"name":"created","type":"TIMESTAMP"}]}`,
+	})
+	ctx := context.Background()
+
+	// The row size of gets is estimated from the schema.
+	e, err := client.Estimate(ctx, &GetRequest{TableName: "users"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, CostEstimate{Request: "Get", TableName: "users", RowSize: 5 + defaultVariableFieldSize + 9,
+		ReadUnits: 1, OperationsPerSecond: 100}, *e)
+	e, err = client.Estimate(ctx, &GetRequest{TableName: "users", Consistency: types.Absolute}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, e.ReadUnits, "Absolute consistency")
+	assert.Equal(t, float64(50), e.OperationsPerSecond)
+
+	// The row size of puts is computed from the value.
+	row := types.NewMapValue(map[string]interface{}{"id": 1, "name": strings.Repeat("n", 2000)})
+	e, err = client.Estimate(ctx, &PutRequest{TableName: "users", Value: row}, nil)
+	require.NoError(t, err)
+	assert.Greater(t, e.RowSize, 2000)
+	assert.Equal(t, 0, e.ReadUnits)
+	assert.Equal(t, 2+2*2, e.WriteUnits, "row and replaced index entries")
+	assert.Equal(t, float64(10), e.OperationsPerSecond)
+	e, err = client.Estimate(ctx, &PutRequest{TableName: "users", Value: row, PutOption: types.PutIfAbsent}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2*2, e.ReadUnits, "conditional put")
+	assert.Equal(t, 2+2, e.WriteUnits)
+
+	e, err = client.Estimate(ctx, &DeleteRequest{TableName: "users"}, &EstimateOptions{RowSize: 100})
+	require.NoError(t, err)
+	assert.Equal(t, CostEstimate{Request: "Delete", TableName: "users", RowSize: 100,
+		ReadUnits: 2, WriteUnits: 1 + 2, OperationsPerSecond: 20}, *e)
+
+	e, err = client.Estimate(ctx, &QueryRequest{Statement: "select * from users"},
+		&EstimateOptions{RowSize: 3000, Rows: 10})
+	require.NoError(t, err)
+	assert.Equal(t, CostEstimate{Request: "Query", RowSize: 3000, ReadUnits: 30}, *e)
+
+	invalid := []struct {
+		desc string
+		req  Request
+		opts *EstimateOptions
+	}{
+		{"query without RowSize", &QueryRequest{Statement: "select * from users"}, &EstimateOptions{Rows: 10}},
+		{"unsupported request", &GetTableRequest{TableName: "users"}, nil},
+		{"negative Rows", &GetRequest{TableName: "users"}, &EstimateOptions{Rows: -1}},
+	}
+	for _, r := range invalid {
+		_, err = client.Estimate(ctx, r.req, r.opts)
+		assert.Truef(t, nosqlerr.IsIllegalArgument(err), "%s: got error %v, want IllegalArgument", r.desc, err)
+	}
+	_, err = client.Estimate(nil, &GetRequest{TableName: "users"}, nil)
+	assert.Equal(t, errNilContext, err)
+}
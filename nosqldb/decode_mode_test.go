@@ -0,0 +1,53 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeModeDefaults(t *testing.T) {
+	cfg := &RequestConfig{}
+	assert.Equal(t, DecodeLenient, cfg.DefaultDecodeMode())
+
+	cfg.DecodeMode = DecodeStrict
+	getReq := &GetRequest{TableName: "t", Key: types.NewMapValue(map[string]interface{}{"id": 1})}
+	getReq.setDefaults(cfg)
+	assert.Equal(t, DecodeStrict, getReq.DecodeMode)
+	assert.NoError(t, getReq.validate())
+
+	queryReq := &QueryRequest{Statement: "SELECT * FROM t", DecodeMode: DecodeLenient}
+	queryReq.setDefaults(cfg)
+	assert.Equal(t, DecodeLenient, queryReq.DecodeMode)
+	assert.NoError(t, queryReq.validate())
+
+	queryReq.DecodeMode = 5
+	assert.True(t, nosqlerr.IsIllegalArgument(queryReq.validate()))
+
+	// A query result is decoded in the mode of its request.
+	type row struct{ ID int32 }
+	res := &QueryResult{
+		request:    queryReq,
+		results:    []*types.MapValue{types.NewMapValue(map[string]interface{}{"id": 1, "name": "n"})},
+		isComputed: true,
+	}
+	queryReq.StructType = reflect.TypeOf(row{})
+	queryReq.DecodeMode = DecodeLenient
+	rows, err := res.GetStructResults()
+	if assert.NoError(t, err) {
+		assert.Equal(t, []any{&row{ID: 1}}, rows)
+	}
+	queryReq.DecodeMode = DecodeStrict
+	_, err = res.GetStructResults()
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"strconv"
+	"time"
+)
+
+// DeterministicOptions makes the requests serialized and signed by a Client
+// reproducible, so that tests can compare them with golden files.
+//
+// When set in Config.Deterministic:
+//
+//   - the entries of map values are serialized in the sorted order of their
+//     keys, rather than in the random iteration order of Go maps
+//   - the "x-nosql-request-id" header is taken from RequestID, if set
+//   - requests signed by an *iam.SignatureProvider are dated with Now, if set
+//
+// These options are intended for tests and should not be used in production,
+// where request ids must be unique and signatures must use the current time.
+type DeterministicOptions struct {
+	// Now returns the time used for the Date header of signed requests.
+	// If nil, the system clock is used.
+	//
+	// It is applied to the *iam.SignatureProvider of Config.AuthorizationProvider
+	// when the Client is created, see iam.SignatureProvider.SetClock.
+	Now func() time.Time
+
+	// RequestID returns the id sent in the "x-nosql-request-id" header of
+	// each request. If nil, the ids of the client are used, which start at 1
+	// and are incremented for each request.
+	RequestID func() int
+}
+
+// FixedClock returns a function for DeterministicOptions.Now that always
+// returns t.
+func FixedClock(t time.Time) func() time.Time {
+	return func() time.Time {
+		return t
+	}
+}
+
+// initDeterministic applies the DeterministicOptions of the configuration, if
+// any, to the authorization provider of the client.
+func (c *Client) initDeterministic() {
+	if c.Deterministic == nil || c.Deterministic.Now == nil {
+		return
+	}
+	if sp, ok := asSignatureProvider(c.AuthorizationProvider); ok {
+		sp.SetClock(c.Deterministic.Now)
+	}
+}
+
+// requestIDHeader returns the value of the "x-nosql-request-id" header for
+// the next request.
+func (c *Client) requestIDHeader() string {
+	reqID := int(c.nextRequestID())
+	if c.Deterministic != nil && c.Deterministic.RequestID != nil {
+		reqID = c.Deterministic.RequestID()
+	}
+	return strconv.Itoa(reqID)
+}
@@ -0,0 +1,74 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeterministicOptions(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+
+	value := &types.MapValue{}
+	for i := 0; i < 32; i++ {
+		value.Put(fmt.Sprintf("field%02d", i), i)
+	}
+	req := &PutRequest{TableName: "T", Value: value}
+	req.setDefaults(&client.RequestConfig)
+
+	client.Deterministic = &DeterministicOptions{
+		RequestID: func() int { return 42 },
+	}
+	want, _, _, err := client.serializeRequest(context.Background(), req)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		data, _, _, err := client.serializeRequest(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equalf(t, want, data, "serialization %d is different", i)
+	}
+	assert.Equal(t, "42", client.requestIDHeader())
+	client.Deterministic.RequestID = nil
+	assert.NotEqual(t, "42", client.requestIDHeader())
+
+	// Signature providers created from the same key sign identical requests
+	// identically.
+	privateKeyFile := "testdata/deterministic_key.pem"
+	require.NoError(t, generatePrivateKeyPEM(privateKeyFile))
+	defer os.Remove(privateKeyFile)
+	date := time.Date(2014, time.January, 5, 21, 31, 40, 0, time.UTC)
+	client.Deterministic.Now = FixedClock(date)
+
+	var auths []string
+	for i := 0; i < 2; i++ {
+		sp, err := iam.NewRawSignatureProvider("ocid1.tenancy.oc1..test", "ocid1.user.oc1..test", "us-ashburn-1",
+			"fingerprint", "", privateKeyFile, nil)
+		require.NoError(t, err)
+		client.AuthorizationProvider = sp
+		client.initDeterministic()
+
+		httpReq, err := http.NewRequest(http.MethodPost, client.requestURL, bytes.NewReader(want))
+		require.NoError(t, err)
+		require.NoError(t, client.signHTTPRequest(context.Background(), sp, httpReq))
+		assert.Equal(t, "Sun, 05 Jan 2014 21:31:40 GMT", httpReq.Header.Get("Date"))
+		auths = append(auths, httpReq.Header.Get("Authorization"))
+	}
+	assert.NotEmpty(t, auths[0])
+	assert.Equal(t, auths[0], auths[1])
+}
@@ -0,0 +1,363 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/httputil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+)
+
+// DiagnosticStatus represents the outcome of a diagnostic check.
+type DiagnosticStatus string
+
+const (
+	// DiagnosticOK represents a check that passed.
+	DiagnosticOK DiagnosticStatus = "OK"
+
+	// DiagnosticWarn represents a check that passed with a potential problem.
+	DiagnosticWarn DiagnosticStatus = "WARN"
+
+	// DiagnosticFail represents a check that failed.
+	DiagnosticFail DiagnosticStatus = "FAIL"
+
+	// DiagnosticSkip represents a check that was not performed.
+	DiagnosticSkip DiagnosticStatus = "SKIP"
+)
+
+const (
+	// maxClockSkewWarn is the clock skew above which a warning is reported.
+	maxClockSkewWarn = time.Minute
+
+	// maxClockSkew is the clock skew above which request signatures are
+	// rejected by the cloud service.
+	maxClockSkew = 5 * time.Minute
+
+	// certExpiryWarn is the time before expiry of the server certificate
+	// within which a warning is reported.
+	certExpiryWarn = 30 * 24 * time.Hour
+)
+
+// DiagnosticCheck represents the result of a single diagnostic check.
+type DiagnosticCheck struct {
+	// Name specifies the name of check, which is one of "dns", "tls", "auth",
+	// "clock", "protocol" and "read".
+	Name string `json:"name"`
+
+	// Status specifies the outcome of the check.
+	Status DiagnosticStatus `json:"status"`
+
+	// Detail describes what was checked, or the problem found.
+	Detail string `json:"detail,omitempty"`
+
+	// Elapsed specifies the time spent on the check.
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// DiagnosticReport represents the result of Client.Diagnose().
+type DiagnosticReport struct {
+	// Endpoint specifies the service endpoint that was checked.
+	Endpoint string `json:"endpoint"`
+
+	// Time specifies when the checks started.
+	Time time.Time `json:"time"`
+
+	// Checks specifies the results of the checks, in the order performed.
+	Checks []DiagnosticCheck `json:"checks"`
+}
+
+// OK reports whether none of the checks failed.
+func (r *DiagnosticReport) OK() bool {
+	for _, c := range r.Checks {
+		if c.Status == DiagnosticFail {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a JSON string representation of the DiagnosticReport.
+func (r DiagnosticReport) String() string {
+	return jsonutil.AsPrettyJSON(r)
+}
+
+// Diagnose runs a series of checks that exercise each step required to talk
+// to the service and returns a report of the results. It is intended to help
+// troubleshoot connection problems, and can be called at any time after the
+// Client is created.
+//
+// The checks performed are:
+//
+//	dns:      resolve the host name of the endpoint
+//	tls:      establish a TLS connection and verify the server certificate
+//	auth:     obtain authorization information for a request
+//	clock:    compare the local clock with the Date header of the server
+//	protocol: negotiate the protocol version with the server
+//	read:     perform a minimal read operation
+//
+// A check that depends on a previous check that failed is skipped. Failures
+// of checks are reported in the returned DiagnosticReport rather than as an
+// error. The returned error is non-nil only if ctx is nil.
+func (c *Client) Diagnose(ctx context.Context) (*DiagnosticReport, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	report := &DiagnosticReport{
+		Endpoint: c.Endpoint,
+		Time:     time.Now(),
+	}
+
+	run := func(name string, fn func() (DiagnosticStatus, string)) DiagnosticStatus {
+		start := time.Now()
+		status, detail := fn()
+		report.Checks = append(report.Checks, DiagnosticCheck{
+			Name:    name,
+			Status:  status,
+			Detail:  detail,
+			Elapsed: time.Since(start),
+		})
+		return status
+	}
+
+	skip := func(name, reason string) {
+		report.Checks = append(report.Checks, DiagnosticCheck{
+			Name:   name,
+			Status: DiagnosticSkip,
+			Detail: reason,
+		})
+	}
+
+	u, err := url.Parse(c.requestURL)
+	if err != nil {
+		run("dns", func() (DiagnosticStatus, string) {
+			return DiagnosticFail, fmt.Sprintf("invalid endpoint %q: %v", c.Endpoint, err)
+		})
+		return report, nil
+	}
+
+	netOK := run("dns", func() (DiagnosticStatus, string) {
+		return c.diagnoseDNS(ctx, u.Hostname())
+	}) != DiagnosticFail
+
+	switch {
+	case !netOK:
+		skip("tls", "dns check failed")
+	case u.Scheme != "https":
+		skip("tls", "the endpoint does not use https")
+	case c.ProxyURL != "" || c.UseProxyFromEnv:
+		skip("tls", "a proxy is configured")
+	default:
+		netOK = run("tls", func() (DiagnosticStatus, string) {
+			return c.diagnoseTLS(ctx, u)
+		}) != DiagnosticFail
+	}
+
+	authOK := run("auth", c.diagnoseAuth) != DiagnosticFail
+
+	if netOK {
+		run("clock", func() (DiagnosticStatus, string) {
+			return c.diagnoseClock(ctx, u)
+		})
+	} else {
+		skip("clock", "the server is not reachable")
+	}
+
+	if !netOK || !authOK {
+		skip("protocol", "a prerequisite check failed")
+		skip("read", "a prerequisite check failed")
+		return report, nil
+	}
+
+	var readErr error
+	run("protocol", func() (DiagnosticStatus, string) {
+		readErr = c.diagnoseRead(ctx)
+		if readErr != nil {
+			return DiagnosticFail, readErr.Error()
+		}
+		return DiagnosticOK, fmt.Sprintf("serial version %d, query version %d",
+			c.GetSerialVersion(), c.GetQueryVersion())
+	})
+
+	run("read", func() (DiagnosticStatus, string) {
+		if readErr != nil {
+			return DiagnosticFail, readErr.Error()
+		}
+		return DiagnosticOK, "read table metadata"
+	})
+
+	return report, nil
+}
+
+func (c *Client) diagnoseDNS(ctx context.Context, host string) (DiagnosticStatus, string) {
+	if ip := net.ParseIP(host); ip != nil {
+		return DiagnosticOK, fmt.Sprintf("%s is an IP address", host)
+	}
+
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		if c.ProxyURL != "" || c.UseProxyFromEnv {
+			// The host may be resolved by the proxy.
+			return DiagnosticWarn, fmt.Sprintf("cannot resolve %s locally: %v", host, err)
+		}
+		return DiagnosticFail, fmt.Sprintf("cannot resolve %s: %v", host, err)
+	}
+
+	return DiagnosticOK, fmt.Sprintf("%s resolved to %v", host, addrs)
+}
+
+func (c *Client) diagnoseTLS(ctx context.Context, u *url.URL) (DiagnosticStatus, string) {
+	cfg := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		ServerName:         c.ServerName,
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = u.Hostname()
+	}
+
+	if !c.InsecureSkipVerify && c.CertPath != "" {
+		roots, _ := x509.SystemCertPool()
+		if roots == nil {
+			roots = x509.NewCertPool()
+		}
+		certs, err := os.ReadFile(c.CertPath)
+		if err != nil {
+			return DiagnosticFail, err.Error()
+		}
+		roots.AppendCertsFromPEM(certs)
+		cfg.RootCAs = roots
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	dialer := &tls.Dialer{Config: cfg}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return DiagnosticFail, fmt.Sprintf("TLS handshake failed: %v", err)
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return DiagnosticWarn, "the server did not present a certificate"
+	}
+
+	cert := state.PeerCertificates[0]
+	detail := fmt.Sprintf("%s, certificate subject %q expires at %s",
+		tlsVersionName(state.Version), cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+	if c.InsecureSkipVerify {
+		return DiagnosticWarn, detail + ", certificate verification is disabled"
+	}
+	if time.Until(cert.NotAfter) < certExpiryWarn {
+		return DiagnosticWarn, detail
+	}
+
+	return DiagnosticOK, detail
+}
+
+func tlsVersionName(v uint16) string {
+	switch v {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	default:
+		return fmt.Sprintf("TLS 0x%04x", v)
+	}
+}
+
+func (c *Client) diagnoseAuth() (DiagnosticStatus, string) {
+	if c.AuthorizationProvider == nil {
+		return DiagnosticOK, "no authorization provider is configured"
+	}
+
+	httpReq, err := httputil.NewPostRequest(c.requestURL, []byte{})
+	if err != nil {
+		return DiagnosticFail, err.Error()
+	}
+	httpReq.Header.Add("Host", c.serverHost)
+
+	if _, err = c.getAuthString(c.AuthorizationProvider, &GetTableRequest{TableName: "noop"}); err != nil {
+		return DiagnosticFail, fmt.Sprintf("cannot get authorization string: %v", err)
+	}
+
+	if err = c.signHTTPRequest(context.Background(), c.AuthorizationProvider, httpReq); err != nil {
+		return DiagnosticFail, fmt.Sprintf("cannot sign request: %v", err)
+	}
+
+	return DiagnosticOK, fmt.Sprintf("authorization scheme %s", c.AuthorizationProvider.AuthorizationScheme())
+}
+
+func (c *Client) diagnoseClock(ctx context.Context, u *url.URL) (DiagnosticStatus, string) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.Scheme+"://"+u.Host, http.NoBody)
+	if err != nil {
+		return DiagnosticFail, err.Error()
+	}
+
+	start := time.Now()
+	httpResp, err := c.executor.Do(httpReq)
+	if err != nil {
+		return DiagnosticFail, fmt.Sprintf("cannot reach server: %v", err)
+	}
+	httpResp.Body.Close()
+	rtt := time.Since(start)
+
+	date := httpResp.Header.Get("Date")
+	if date == "" {
+		return DiagnosticSkip, "the server did not return a Date header"
+	}
+
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return DiagnosticSkip, fmt.Sprintf("invalid Date header %q", date)
+	}
+
+	// The Date header has a resolution of 1 second, compare with the local
+	// time in the middle of the round trip.
+	skew := start.Add(rtt / 2).Sub(serverTime).Truncate(time.Second)
+	detail := fmt.Sprintf("local clock differs from server by %v", skew)
+	if skew < 0 {
+		skew = -skew
+	}
+
+	switch {
+	case skew > maxClockSkew:
+		return DiagnosticFail, detail
+	case skew > maxClockSkewWarn:
+		return DiagnosticWarn, detail
+	default:
+		return DiagnosticOK, detail
+	}
+}
+
+// diagnoseRead issues a GetTable request for a table that probably does not
+// exist, which requires the protocol version to be negotiated with the server.
+func (c *Client) diagnoseRead(ctx context.Context) error {
+	_, err := c.getTableWithContext(ctx, &GetTableRequest{TableName: "noop"})
+	if err != nil && !nosqlerr.IsTableNotFound(err) {
+		return err
+	}
+	return nil
+}
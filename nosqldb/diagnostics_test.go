@@ -0,0 +1,59 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnose(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Report a server clock that is 3 minutes ahead.
+		w.Header().Set("Date", time.Now().Add(3*time.Minute).UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodPost {
+			http.Error(w, "mock server does not support requests", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(Config{
+		Endpoint:              srv.URL,
+		AuthorizationProvider: &DummyAccessTokenProvider{TenantID: "TestTenantId"},
+	})
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	report, err := client.Diagnose(ctx)
+	require.NoErrorf(t, err, "Diagnose() got error %v", err)
+
+	expect := map[string]DiagnosticStatus{
+		"dns":      DiagnosticOK,
+		"tls":      DiagnosticSkip,
+		"auth":     DiagnosticOK,
+		"clock":    DiagnosticWarn,
+		"protocol": DiagnosticFail,
+		"read":     DiagnosticFail,
+	}
+	require.Equalf(t, len(expect), len(report.Checks), "unexpected checks: %s", report)
+	for _, c := range report.Checks {
+		assert.Equalf(t, expect[c.Name], c.Status, "unexpected status of %q check: %s", c.Name, c.Detail)
+	}
+	assert.False(t, report.OK())
+
+	_, err = client.Diagnose(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,331 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/cloudsim"
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/kvstore"
+	"github.com/oracle/nosql-go-sdk/nosqldb/common"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// dsnSchemePrefix is the prefix of the scheme of a connection string.
+const dsnSchemePrefix = "nosql+"
+
+// Open creates a client from a connection string, which is parsed by ParseDSN.
+// It simplifies passing the configuration of a client through environment
+// variables and the options of operational tools. For example:
+//
+//	client, err := nosqldb.Open("nosql+cloud://region=eu-frankfurt-1?auth=instance-principal&compartment=ocid1.compartment.oc1..aaaa")
+func Open(dsn string) (*Client, error) {
+	cfg, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(*cfg)
+}
+
+// ParseDSN parses a connection string and returns the configuration it
+// specifies, including the authorization provider. The connection string is a
+// URL of the form:
+//
+//	nosql+<mode>://[username[:password]@]<location>[?<parameter>=<value>&...]
+//
+// where mode is one of "cloud", "cloudsim" or "onprem". Parameter values and
+// the credentials must be URL encoded.
+//
+// For the cloud service, the location is a region id such as
+// "us-ashburn-1", or "region=<id>", or a service endpoint. It can be omitted
+// to use the region of the OCI configuration file, or with instance-principal
+// the region of the compute instance. The parameters are:
+//
+//	auth          the authentication method: user-principal (the default),
+//	              which uses the OCI configuration file, instance-principal,
+//	              resource-principal, oke-workload-identity or session-token
+//	configFile    the path to the OCI configuration file
+//	profile       the profile to use in the OCI configuration file
+//	compartment   the compartment id or name used by default for requests
+//	delegationTokenFile
+//	              the file of a delegation token, with instance-principal
+//
+// For the cloud simulator and the on-premise server, the location is the
+// host and optional port of the proxy, for example "localhost:8080". The
+// parameters are:
+//
+//	tenant        the tenant id, for the cloud simulator
+//	configFile    the file with the username and password of the secure
+//	              on-premise server, instead of specifying them in the URL
+//	tls           if true, connect with HTTPS
+//	certPath      the path to the certificate of the server, with tls=true
+//	clientCert    the path to the client certificate for mutual TLS, with
+//	              tls=true
+//	clientKey     the path to the private key of clientCert
+//
+// The following parameters can be used with all modes:
+//
+//	timeout             the default request timeout, such as "10s"
+//	tableRequestTimeout the default table request timeout
+//	maxRetryDuration    the maximum time to retry a request, such as "30s"
+//	consistency         the default consistency: eventual or absolute
+//	namespace           the default namespace, for the on-premise server
+//	insecure            if true, the server certificate is not verified
+//	rateLimiting        if true, enables client side rate limiting
+//
+// Unknown parameters are rejected, to report misspelled ones.
+func ParseDSN(dsn string) (*Config, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		// The error includes the connection string, which may contain a
+		// password.
+		return nil, nosqlerr.NewIllegalArgument("invalid connection string: it is not a valid URL")
+	}
+
+	mode := strings.ToLower(u.Scheme)
+	if !strings.HasPrefix(mode, dsnSchemePrefix) {
+		return nil, nosqlerr.NewIllegalArgument("invalid connection string: the scheme must be "+
+			"nosql+cloud, nosql+cloudsim or nosql+onprem, got %q", u.Scheme)
+	}
+	mode = strings.TrimPrefix(mode, dsnSchemePrefix)
+
+	p := &dsnParams{values: u.Query()}
+	cfg := &Config{Mode: mode}
+	if err = p.common(cfg); err != nil {
+		return nil, err
+	}
+
+	switch mode {
+	case "cloud":
+		err = p.cloud(cfg, u)
+	case "cloudsim", "onprem":
+		err = p.onPremise(cfg, u)
+	default:
+		return nil, nosqlerr.NewIllegalArgument("invalid connection string: unknown mode %q, "+
+			"expected cloud, cloudsim or onprem", mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if unknown := p.unused(); len(unknown) > 0 {
+		return nil, nosqlerr.NewIllegalArgument("invalid connection string: unknown parameters %s",
+			strings.Join(unknown, ", "))
+	}
+	return cfg, nil
+}
+
+// dsnParams represents the parameters of a connection string, and records
+// the parameters that have been used.
+type dsnParams struct {
+	values url.Values
+	used   map[string]bool
+}
+
+func (p *dsnParams) get(name string) string {
+	if p.used == nil {
+		p.used = make(map[string]bool)
+	}
+	p.used[name] = true
+	return p.values.Get(name)
+}
+
+func (p *dsnParams) bool(name string) (bool, error) {
+	s := p.get(name)
+	if s == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, nosqlerr.NewIllegalArgument("invalid connection string: invalid value %q "+
+			"of parameter %s, expected true or false", s, name)
+	}
+	return b, nil
+}
+
+func (p *dsnParams) duration(name string) (time.Duration, error) {
+	s := p.get(name)
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, nosqlerr.NewIllegalArgument("invalid connection string: invalid duration %q "+
+			"of parameter %s", s, name)
+	}
+	return d, nil
+}
+
+// unused returns the names of the parameters that have not been used, sorted.
+func (p *dsnParams) unused() (names []string) {
+	for name := range p.values {
+		if !p.used[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// common sets the options that apply to all modes.
+func (p *dsnParams) common(cfg *Config) (err error) {
+	if cfg.RequestTimeout, err = p.duration("timeout"); err != nil {
+		return err
+	}
+	if cfg.TableRequestTimeout, err = p.duration("tableRequestTimeout"); err != nil {
+		return err
+	}
+	if cfg.MaxRetryDuration, err = p.duration("maxRetryDuration"); err != nil {
+		return err
+	}
+	if cfg.InsecureSkipVerify, err = p.bool("insecure"); err != nil {
+		return err
+	}
+	if cfg.RateLimitingEnabled, err = p.bool("rateLimiting"); err != nil {
+		return err
+	}
+
+	switch c := p.get("consistency"); strings.ToLower(c) {
+	case "":
+	case "eventual":
+		cfg.Consistency = types.Eventual
+	case "absolute":
+		cfg.Consistency = types.Absolute
+	default:
+		return nosqlerr.NewIllegalArgument("invalid connection string: invalid consistency %q, "+
+			"expected eventual or absolute", c)
+	}
+
+	cfg.Namespace = p.get("namespace")
+	return nil
+}
+
+func (p *dsnParams) cloud(cfg *Config, u *url.URL) (err error) {
+	if u.User != nil {
+		return nosqlerr.NewIllegalArgument("invalid connection string: credentials cannot be " +
+			"specified in the URL for the cloud service, use the auth parameter")
+	}
+
+	location := u.Host
+	switch {
+	case strings.HasPrefix(location, "region="):
+		cfg.Region = common.Region(strings.TrimPrefix(location, "region="))
+	case strings.HasPrefix(location, "endpoint="):
+		cfg.Endpoint = strings.TrimPrefix(location, "endpoint=")
+	case location != "":
+		if region, err := common.StringToRegion(location); err == nil {
+			cfg.Region = region
+		} else {
+			cfg.Endpoint = location
+		}
+	}
+
+	configFile := p.get("configFile")
+	profile := p.get("profile")
+	compartment := p.get("compartment")
+	delegationTokenFile := p.get("delegationTokenFile")
+
+	auth := strings.ToLower(p.get("auth"))
+	if delegationTokenFile != "" && auth != "instance-principal" {
+		return nosqlerr.NewIllegalArgument("invalid connection string: delegationTokenFile " +
+			"requires auth=instance-principal")
+	}
+
+	var sp *iam.SignatureProvider
+	switch auth {
+	case "", "user-principal":
+		if configFile == "" {
+			configFile = "~/.oci/config"
+		}
+		sp, err = iam.NewSignatureProviderFromFile(configFile, profile, "", compartment)
+	case "session-token":
+		if configFile == "" {
+			configFile = "~/.oci/config"
+		}
+		sp, err = iam.NewSessionTokenSignatureProviderFromFile(configFile, profile, "")
+		if err == nil && compartment != "" {
+			sp, err = iam.NewSignatureProviderWithConfiguration(sp.Profile(), compartment)
+		}
+	case "instance-principal":
+		if delegationTokenFile != "" {
+			sp, err = iam.NewSignatureProviderWithInstancePrincipalDelegationFromFile(compartment, delegationTokenFile)
+		} else {
+			sp, err = iam.NewSignatureProviderWithInstancePrincipal(compartment)
+		}
+	case "resource-principal":
+		sp, err = iam.NewSignatureProviderWithResourcePrincipal(compartment)
+	case "oke-workload-identity":
+		sp, err = iam.NewSignatureProviderWithOKEWorkloadIdentity(compartment)
+	default:
+		return nosqlerr.NewIllegalArgument("invalid connection string: unknown auth %q, expected "+
+			"user-principal, instance-principal, resource-principal, oke-workload-identity "+
+			"or session-token", auth)
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg.AuthorizationProvider = sp
+	return nil
+}
+
+func (p *dsnParams) onPremise(cfg *Config, u *url.URL) (err error) {
+	if u.Host == "" {
+		return nosqlerr.NewIllegalArgument("invalid connection string: the host of the %s "+
+			"proxy must be specified", cfg.Mode)
+	}
+
+	tls, err := p.bool("tls")
+	if err != nil {
+		return err
+	}
+	cfg.Endpoint = u.Host
+	if tls {
+		cfg.Endpoint = "https://" + u.Host
+		cfg.CertPath = p.get("certPath")
+		cfg.ClientCertPath = p.get("clientCert")
+		cfg.ClientKeyPath = p.get("clientKey")
+	}
+
+	tenant := p.get("tenant")
+	configFile := p.get("configFile")
+	if cfg.Mode == "cloudsim" {
+		if u.User != nil || configFile != "" {
+			return nosqlerr.NewIllegalArgument("invalid connection string: credentials cannot be " +
+				"specified for the cloud simulator")
+		}
+		if tenant != "" {
+			cfg.AuthorizationProvider = &cloudsim.AccessTokenProvider{TenantID: tenant}
+		}
+		return nil
+	}
+
+	if tenant != "" {
+		return nosqlerr.NewIllegalArgument("invalid connection string: the tenant parameter " +
+			"only applies to the cloud simulator")
+	}
+
+	switch {
+	case u.User != nil && configFile != "":
+		return nosqlerr.NewIllegalArgument("invalid connection string: specify the credentials " +
+			"either in the URL or with the configFile parameter")
+	case u.User != nil:
+		password, _ := u.User.Password()
+		cfg.Username = u.User.Username()
+		cfg.Password = []byte(password)
+	case configFile != "":
+		cfg.AuthorizationProvider, err = kvstore.NewAccessTokenProviderFromFile(configFile)
+	}
+	return err
+}
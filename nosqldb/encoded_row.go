@@ -0,0 +1,171 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// EncodedRow represents the value of a row that is serialized once, so that
+// it can be written by multiple put operations without being serialized
+// again. This reduces the cost of fan-out write patterns, where the same
+// value is written to many rows with different primary keys or TTLs.
+//
+// An EncodedRow is created with EncodeRow and used as PutRequest.EncodedValue.
+// It is immutable, and may be used by multiple requests concurrently.
+type EncodedRow struct {
+	// data holds the serialized fields of the row, each one as its name
+	// followed by its value.
+	data []byte
+
+	// fields holds the fields of the row in the order they are serialized.
+	fields []encodedField
+}
+
+// encodedField represents a field of an EncodedRow, whose serialized name
+// and value are data[start:end].
+type encodedField struct {
+	name       string
+	start, end int
+}
+
+// EncodeRow serializes the value of a row into an EncodedRow. The value may be
+// a *types.MapValue, or a native struct as used for PutRequest.StructValue.
+//
+// Changes made to value after EncodeRow returns are not reflected in the
+// returned EncodedRow.
+func EncodeRow(value any) (*EncodedRow, error) {
+	mv, err := toMapValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	if mv.IsOrdered() {
+		for i := 1; i <= mv.Len(); i++ {
+			k, _, _ := mv.GetByIndex(i)
+			keys = append(keys, k)
+		}
+	} else {
+		keys = make([]string, 0, mv.Len())
+		for k := range mv.Map() {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+	}
+
+	w := binary.NewWriter()
+	r := &EncodedRow{fields: make([]encodedField, 0, len(keys))}
+	for _, k := range keys {
+		v, _ := mv.Get(k)
+		if types.IsAbsent(v) {
+			continue
+		}
+		start := w.Size()
+		if _, err = w.WriteString(&k); err != nil {
+			return nil, err
+		}
+		if _, err = w.WriteFieldValue(v); err != nil {
+			return nil, nosqlerr.NewIllegalArgument("EncodeRow: cannot serialize field %q: %v", k, err)
+		}
+		r.fields = append(r.fields, encodedField{name: k, start: start, end: w.Size()})
+	}
+	r.data = w.Bytes()
+	return r, nil
+}
+
+// Len returns the number of fields of the row.
+func (r *EncodedRow) Len() int {
+	return len(r.fields)
+}
+
+// Size returns the size of the serialized fields of the row, in bytes.
+func (r *EncodedRow) Size() int {
+	return len(r.data)
+}
+
+// write writes the row as a map value. The fields of overrides, if any, are
+// written first, and replace the fields of the row with the same names.
+func (r *EncodedRow) write(w proto.Writer, overrides *types.MapValue) (n int, err error) {
+	off := w.Size()
+	if err = w.WriteByte(byte(types.Map)); err != nil {
+		return
+	}
+
+	// The size in bytes and the number of entries of the map are known once
+	// all entries are written, so write placeholders.
+	sizeOff := w.Size()
+	if _, err = w.WriteInt(0); err != nil {
+		return
+	}
+	if _, err = w.WriteInt(0); err != nil {
+		return
+	}
+
+	count := 0
+	var replaced map[string]bool
+	if overrides != nil {
+		replaced = make(map[string]bool, overrides.Len())
+		for k, v := range overrides.Map() {
+			if types.IsAbsent(v) {
+				continue
+			}
+			if _, err = w.WriteString(&k); err != nil {
+				return
+			}
+			if _, err = w.WriteFieldValue(v); err != nil {
+				return
+			}
+			replaced[strings.ToLower(k)] = true
+			count++
+		}
+	}
+
+	for _, f := range r.fields {
+		if replaced[strings.ToLower(f.name)] {
+			continue
+		}
+		if _, err = w.Write(r.data[f.start:f.end]); err != nil {
+			return
+		}
+		count++
+	}
+
+	if _, err = w.WriteIntAtOffset(w.Size()-sizeOff-4, sizeOff); err != nil {
+		return
+	}
+	if _, err = w.WriteIntAtOffset(count, sizeOff+4); err != nil {
+		return
+	}
+	return w.Size() - off, nil
+}
+
+// mapValue returns the row as a MapValue, with the fields of overrides as
+// described for write.
+func (r *EncodedRow) mapValue(overrides *types.MapValue) (*types.MapValue, error) {
+	w := binary.NewWriter()
+	if _, err := r.write(w, overrides); err != nil {
+		return nil, err
+	}
+	v, err := binary.NewReader(bytes.NewBuffer(w.Bytes())).ReadFieldValue()
+	if err != nil {
+		return nil, err
+	}
+	mv, ok := v.(*types.MapValue)
+	if !ok {
+		return nil, nosqlerr.New(nosqlerr.IllegalState, "EncodedRow: unexpected value of type %T", v)
+	}
+	return mv, nil
+}
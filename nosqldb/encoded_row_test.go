@@ -0,0 +1,77 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodedRow(t *testing.T) {
+	_, err := EncodeRow(nil)
+	assert.Truef(t, nosqlerr.IsIllegalArgument(err), "EncodeRow(nil): unexpected error %v", err)
+
+	type profile struct {
+		ID    int    `nosql:"id"`
+		Name  string `nosql:"name"`
+		Score int64  `nosql:"score"`
+	}
+	fromStruct, err := EncodeRow(&profile{ID: 0, Name: "Jane", Score: 42})
+	require.NoError(t, err)
+	fromMap, err := EncodeRow(types.NewMapValue(map[string]interface{}{
+		"id": 0, "name": "Jane", "score": int64(42), "absent": types.Absent,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 3, fromMap.Len())
+	assert.Equal(t, fromMap.Size(), fromStruct.Size())
+
+	tests := []struct {
+		desc    string
+		encoded *EncodedRow
+	}{
+		{"row encoded from a struct", fromStruct},
+		{"row encoded from a MapValue", fromMap},
+	}
+	for _, r := range tests {
+		// The same encoded value is put with different primary keys.
+		for id := 1; id <= 2; id++ {
+			req := &PutRequest{
+				TableName:    "users",
+				EncodedValue: r.encoded,
+				Value:        types.NewEmptyMapValue().Put("ID", id),
+				TTL:          &types.TimeToLive{Value: int64(id), Unit: types.Days},
+				Timeout:      time.Second,
+			}
+			require.NoError(t, req.validate())
+
+			w := binary.NewWriter()
+			require.NoError(t, req.serialize(w, 4, 4))
+			v, err := binary.NewReader(bytes.NewBuffer(w.Bytes())).ReadFieldValue()
+			require.NoError(t, err)
+			p, _ := v.(*types.MapValue).Get(PAYLOAD)
+			row, _ := p.(*types.MapValue).Get(VALUE)
+			expected := map[string]interface{}{"ID": id, "name": "Jane", "score": int64(42)}
+			assert.Equalf(t, expected, row.(*types.MapValue).Map(), "%s: unexpected row with id %d", r.desc, id)
+
+			value, err := req.rowValue()
+			require.NoError(t, err)
+			assert.Equalf(t, expected, value.Map(), "%s: unexpected rowValue() with id %d", r.desc, id)
+		}
+	}
+
+	req := &PutRequest{TableName: "users", EncodedValue: fromMap, StructValue: &profile{}}
+	err = req.validate()
+	assert.Truef(t, nosqlerr.IsIllegalArgument(err), "EncodedValue and StructValue: unexpected error %v", err)
+}
@@ -0,0 +1,94 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// buildAllowedEndpoints is a comma-separated list of endpoint patterns that
+// is set when an application is built, for example:
+//
+//	go build -ldflags "-X github.com/oracle/nosql-go-sdk/nosqldb.buildAllowedEndpoints=nosql.*.oci.oraclecloud.com"
+//
+// It applies in addition to Config.AllowedEndpoints.
+var buildAllowedEndpoints string
+
+// checkAllowedEndpoints verifies that the endpoints of the Config match the
+// allowed endpoint patterns. The Config must have been validated and its
+// defaults set.
+func (c *Config) checkAllowedEndpoints() error {
+	lists := [][]string{c.AllowedEndpoints}
+	if buildAllowedEndpoints != "" {
+		lists = append(lists, strings.Split(buildAllowedEndpoints, ","))
+	}
+
+	endpoints := append([]string{c.Endpoint}, c.AdditionalEndpoints...)
+	for _, patterns := range lists {
+		if len(patterns) == 0 {
+			continue
+		}
+		for _, e := range endpoints {
+			if !endpointAllowed(e, patterns) {
+				return fmt.Errorf("endpoint %q is not allowed, the allowed endpoints are %q",
+					e, strings.Join(patterns, ","))
+			}
+		}
+	}
+
+	return nil
+}
+
+// endpointAllowed reports whether the endpoint matches one of the patterns.
+// A pattern is matched against the host, the host and port, and the complete
+// endpoint, such as "https://nosql.us-ashburn-1.oci.oraclecloud.com:443".
+func endpointAllowed(endpoint string, patterns []string) bool {
+	protocol, host, port, err := parseEndpoint(endpoint)
+	if err != nil {
+		return false
+	}
+
+	candidates := []string{host, host + ":" + port, protocol + "://" + host + ":" + port}
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		for _, s := range candidates {
+			if matchWildcard(p, strings.ToLower(s)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchWildcard reports whether s matches the pattern, in which "*" matches
+// any sequence of characters.
+func matchWildcard(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
+}
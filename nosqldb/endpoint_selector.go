@@ -0,0 +1,183 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+)
+
+const (
+	// ewmaAlpha is the weight of a new latency sample in the moving average.
+	ewmaAlpha = 0.3
+
+	// exploreRatio is the fraction of requests sent to a random healthy
+	// endpoint rather than the fastest one.
+	exploreRatio = 0.05
+
+	// Bounds of the period an endpoint is considered unhealthy after a failure.
+	minUnhealthyPeriod = time.Second
+	maxUnhealthyPeriod = 30 * time.Second
+)
+
+// endpoint represents a server endpoint and its latency statistics.
+type endpoint struct {
+	requestURL string
+	host       string
+
+	// latency is the moving average of the request latency, in nanoseconds.
+	// It is 0 until the first request completes.
+	latency float64
+
+	// failures is the number of consecutive failures.
+	failures int
+
+	// unhealthyUntil is the time until which the endpoint is not used if
+	// other endpoints are healthy.
+	unhealthyUntil time.Time
+}
+
+// endpointSelector selects the endpoint each request is sent to, among the
+// endpoints configured with Config.Endpoint and Config.AdditionalEndpoints.
+type endpointSelector struct {
+	mu        sync.Mutex
+	endpoints []*endpoint
+	rand      *rand.Rand
+	now       func() time.Time
+}
+
+// newEndpointSelector returns an endpointSelector for the Config, or nil if
+// there is a single endpoint. The Config must have been validated.
+func newEndpointSelector(cfg *Config) (*endpointSelector, error) {
+	if len(cfg.AdditionalEndpoints) == 0 {
+		return nil, nil
+	}
+
+	s := &endpointSelector{
+		endpoints: []*endpoint{{
+			requestURL: cfg.Endpoint + sdkutil.DataServiceURI,
+			host:       cfg.host,
+		}},
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:  time.Now,
+	}
+
+	for _, e := range cfg.AdditionalEndpoints {
+		protocol, host, port, err := parseEndpoint(e)
+		if err != nil {
+			return nil, err
+		}
+		if protocol != cfg.protocol {
+			return nil, fmt.Errorf("endpoint %q does not use the same protocol as %q", e, cfg.Endpoint)
+		}
+		s.endpoints = append(s.endpoints, &endpoint{
+			requestURL: protocol + "://" + host + ":" + port + sdkutil.DataServiceURI,
+			host:       host,
+		})
+	}
+
+	return s, nil
+}
+
+// pick returns the endpoint a request should be sent to. It returns nil if
+// the selector is nil.
+func (s *endpointSelector) pick() *endpoint {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var healthy, unmeasured []*endpoint
+	for _, e := range s.endpoints {
+		if now.Before(e.unhealthyUntil) {
+			continue
+		}
+		healthy = append(healthy, e)
+		if e.latency == 0 {
+			unmeasured = append(unmeasured, e)
+		}
+	}
+
+	switch {
+	case len(healthy) == 0:
+		// All endpoints are unhealthy, use the one that recovers first.
+		best := s.endpoints[0]
+		for _, e := range s.endpoints[1:] {
+			if e.unhealthyUntil.Before(best.unhealthyUntil) {
+				best = e
+			}
+		}
+		return best
+
+	case len(unmeasured) > 0:
+		return unmeasured[s.rand.Intn(len(unmeasured))]
+
+	case s.rand.Float64() < exploreRatio:
+		return healthy[s.rand.Intn(len(healthy))]
+	}
+
+	best := healthy[0]
+	for _, e := range healthy[1:] {
+		if e.latency < best.latency {
+			best = e
+		}
+	}
+	return best
+}
+
+// record updates the statistics of the endpoint with the outcome of a
+// request. A request fails if no response is received or the server responds
+// with an HTTP status that indicates the server is unavailable.
+func (s *endpointSelector) record(e *endpoint, latency time.Duration, httpResp *http.Response, err error) {
+	if s == nil || e == nil {
+		return
+	}
+
+	// A request canceled by the caller says nothing about the endpoint.
+	failed := err != nil && !errors.Is(err, context.Canceled)
+	if httpResp != nil {
+		switch httpResp.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			failed = true
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if failed {
+		e.failures++
+		period := maxUnhealthyPeriod
+		if e.failures <= 5 {
+			period = minUnhealthyPeriod << (e.failures - 1)
+		}
+		e.unhealthyUntil = s.now().Add(period)
+		return
+	}
+
+	e.failures = 0
+	e.unhealthyUntil = time.Time{}
+	if latency <= 0 {
+		latency = 1
+	}
+	if e.latency == 0 {
+		e.latency = float64(latency)
+	} else {
+		e.latency = ewmaAlpha*float64(latency) + (1-ewmaAlpha)*e.latency
+	}
+}
@@ -0,0 +1,137 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEndpointSelector(t *testing.T) {
+	tests := []struct {
+		desc        string
+		additional  []string
+		requestURLs []string
+		ok          bool
+	}{
+		{
+			desc: "a single endpoint does not need a selector",
+			ok:   true,
+		},
+		{
+			desc:       "additional endpoints use the protocol and port of the endpoint by default",
+			additional: []string{"proxy2:8080", "http://proxy3"},
+			requestURLs: []string{
+				"http://proxy1:8080" + sdkutil.DataServiceURI,
+				"http://proxy2:8080" + sdkutil.DataServiceURI,
+				"http://proxy3:8080" + sdkutil.DataServiceURI,
+			},
+			ok: true,
+		},
+		{
+			desc:       "endpoints with different protocols",
+			additional: []string{"https://proxy2"},
+		},
+		{
+			desc:       "invalid endpoint",
+			additional: []string{"http://proxy2:port"},
+		},
+	}
+
+	for _, r := range tests {
+		cfg := Config{Mode: "onprem", Endpoint: "http://proxy1:8080"}
+		require.NoError(t, cfg.setDefaults())
+		cfg.AdditionalEndpoints = r.additional
+		s, err := newEndpointSelector(&cfg)
+		if !r.ok {
+			assert.Errorf(t, err, "%s: newEndpointSelector() should have failed", r.desc)
+			continue
+		}
+		if !assert.NoErrorf(t, err, "%s: newEndpointSelector() got error %v", r.desc, err) {
+			continue
+		}
+		var requestURLs []string
+		if s != nil {
+			for _, e := range s.endpoints {
+				requestURLs = append(requestURLs, e.requestURL)
+			}
+		}
+		assert.Equalf(t, r.requestURLs, requestURLs, "%s: unexpected endpoints", r.desc)
+	}
+}
+
+func TestEndpointSelector(t *testing.T) {
+	cfg := Config{
+		Mode:                "onprem",
+		Endpoint:            "http://proxy1:8080",
+		AdditionalEndpoints: []string{"proxy2:8080", "http://proxy3"},
+	}
+	require.NoError(t, cfg.setDefaults())
+	s, err := newEndpointSelector(&cfg)
+	require.NoError(t, err)
+	require.Len(t, s.endpoints, 3)
+	assert.Equal(t, "proxy3", s.endpoints[2].host)
+
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	s.rand = rand.New(rand.NewSource(1))
+
+	// Endpoints without latency samples are tried first.
+	seen := make(map[*endpoint]bool)
+	latencies := map[string]time.Duration{
+		"proxy1": 30 * time.Millisecond,
+		"proxy2": 10 * time.Millisecond,
+		"proxy3": 20 * time.Millisecond,
+	}
+	for i := 0; i < 3; i++ {
+		e := s.pick()
+		assert.False(t, seen[e], "endpoint %s was picked twice", e.host)
+		seen[e] = true
+		s.record(e, latencies[e.host], &http.Response{StatusCode: 200}, nil)
+	}
+
+	// The fastest endpoint is picked, except for a few requests.
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		counts[s.pick().host]++
+	}
+	assert.Greater(t, counts["proxy2"], 900)
+	assert.Greater(t, counts["proxy1"]+counts["proxy3"], 0)
+
+	// Failed endpoints are avoided until the unhealthy period expires.
+	s.record(s.endpoints[1], 0, nil, errors.New("connection refused"))
+	s.record(s.endpoints[2], 0, &http.Response{StatusCode: 503}, nil)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, "proxy1", s.pick().host)
+	}
+	now = now.Add(minUnhealthyPeriod)
+	counts = make(map[string]int)
+	for i := 0; i < 100; i++ {
+		counts[s.pick().host]++
+	}
+	assert.Greater(t, counts["proxy2"], 80)
+
+	// Without healthy endpoints, the first one to recover is used.
+	for _, e := range s.endpoints {
+		s.record(e, time.Millisecond, &http.Response{StatusCode: 200}, nil)
+		s.record(e, 0, nil, errors.New("connection refused"))
+	}
+	s.record(s.endpoints[0], 0, nil, errors.New("connection refused"))
+	assert.NotEqual(t, "proxy1", s.pick().host)
+
+	// A nil selector picks no endpoint.
+	var nilSelector *endpointSelector
+	assert.Nil(t, nilSelector.pick())
+}
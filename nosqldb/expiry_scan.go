@@ -0,0 +1,213 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// expiryColumn and expiryRowColumn are the names of the columns that hold the
+// expiration time and the value of a row in the results of the queries of
+// ScanExpiringRows.
+const (
+	expiryColumn    = "expiryScanExpirationTime"
+	expiryRowColumn = "expiryScanRow"
+)
+
+// ExpiryEvent represents a row that is about to expire.
+type ExpiryEvent struct {
+	// TableName represents the name of the table of the row.
+	TableName string
+
+	// Row represents the value of the row, or its fields specified by
+	// ExpiryScanOptions.Fields.
+	Row *types.MapValue
+
+	// ExpirationTime represents the expiration time of the row.
+	ExpirationTime time.Time
+}
+
+// ExpiryScanOptions specifies options for Client.ScanExpiringRows and
+// Client.WatchExpiringRows.
+type ExpiryScanOptions struct {
+	// Fields specifies the fields returned for each row.
+	// It is optional. If not set, all fields are returned.
+	Fields []string
+
+	// Where specifies an optional filter condition for the rows, in which the
+	// row is referenced with the table alias $t, for example "$t.state = 'open'".
+	Where string
+
+	// MaxReadKB specifies the limit on the amount of data read by each of the
+	// query requests used to scan the table. See QueryRequest.MaxReadKB.
+	// It is optional. If set to 0, the default limit is used.
+	MaxReadKB uint
+}
+
+// ScanExpiringRows scans the specified table for the rows whose expiration
+// time falls within window from now, and calls fn for each of them, in no
+// particular order. It returns the number of rows for which fn was called.
+//
+// NoSQL expires rows silently, so ScanExpiringRows can be used to archive
+// rows before they expire. If fn returns an error, the scan stops and the
+// error is returned. The scan reads the entire table, and consumes read units
+// for all rows of the table, so window should be large enough that rows can
+// be processed with a few scans a day. Row expiration times are rounded by the
+// server to hours or days, and expired rows may still be returned until they
+// are removed, so the events are only an approximation.
+func (c *Client) ScanExpiringRows(ctx context.Context, tableName string, window time.Duration,
+	opts *ExpiryScanOptions, fn func(ev *ExpiryEvent) error) (int, error) {
+
+	if ctx == nil {
+		return 0, errNilContext
+	}
+	if window <= 0 {
+		return 0, nosqlerr.NewIllegalArgument("ScanExpiringRows: window must be positive")
+	}
+
+	now := time.Now()
+	return c.scanExpiringRows(ctx, tableName, now, now.Add(window), opts, fn)
+}
+
+// WatchExpiringRows scans the specified table every interval for the rows
+// that will expire within window, and calls fn for each of them, until ctx is
+// done or fn returns an error. It returns the error of fn or of a scan, or the
+// error of ctx.
+//
+// The first scan reports the rows that expire within window from now. Each
+// following scan only reports the rows that expire between the end of the
+// window of the previous scan and the end of its own window, so a row is
+// reported once, unless its expiration time is extended and falls again
+// within a window. The interval should be shorter than window, so that rows
+// are reported ahead of their expiration.
+func (c *Client) WatchExpiringRows(ctx context.Context, tableName string, window, interval time.Duration,
+	opts *ExpiryScanOptions, fn func(ev *ExpiryEvent) error) error {
+
+	if ctx == nil {
+		return errNilContext
+	}
+	if window <= 0 || interval <= 0 {
+		return nosqlerr.NewIllegalArgument("WatchExpiringRows: window and interval must be positive")
+	}
+
+	scan := func(from, to time.Time) error {
+		_, err := c.scanExpiringRows(ctx, tableName, from, to, opts, fn)
+		return err
+	}
+	return watchExpiringRows(ctx, window, interval, time.Now, scan)
+}
+
+func watchExpiringRows(ctx context.Context, window, interval time.Duration, now func() time.Time,
+	scan func(from, to time.Time) error) error {
+
+	from := now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		to := now().Add(window)
+		if err := scan(from, to); err != nil {
+			return err
+		}
+		from = to
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanExpiringRows calls fn for the rows that expire after from and no later
+// than to.
+func (c *Client) scanExpiringRows(ctx context.Context, tableName string, from, to time.Time,
+	opts *ExpiryScanOptions, fn func(ev *ExpiryEvent) error) (int, error) {
+
+	if err := validateTableName(tableName); err != nil {
+		return 0, err
+	}
+	if fn == nil {
+		return 0, nosqlerr.NewIllegalArgument("ScanExpiringRows: fn must be non-nil")
+	}
+	if opts == nil {
+		opts = &ExpiryScanOptions{}
+	}
+
+	req := &QueryRequest{
+		Statement: expiryScanStatement(tableName, from, to, opts),
+		MaxReadKB: opts.MaxReadKB,
+	}
+	defer req.Close()
+
+	var n int
+	for {
+		res, err := c.QueryWithContext(ctx, req)
+		if err != nil {
+			return n, err
+		}
+
+		rows, err := res.GetResults()
+		if err != nil {
+			return n, err
+		}
+
+		for _, row := range rows {
+			if err = fn(newExpiryEvent(tableName, row)); err != nil {
+				return n, err
+			}
+			n++
+		}
+
+		if req.IsDone() {
+			return n, nil
+		}
+	}
+}
+
+// expiryScanStatement returns the query that selects the rows that expire
+// after from and no later than to.
+func expiryScanStatement(tableName string, from, to time.Time, opts *ExpiryScanOptions) string {
+	fields := "$t AS " + expiryRowColumn
+	if len(opts.Fields) > 0 {
+		qualified := make([]string, len(opts.Fields))
+		for i, f := range opts.Fields {
+			qualified[i] = "$t." + f
+		}
+		fields = strings.Join(qualified, ", ")
+	}
+
+	stmt := fmt.Sprintf("SELECT %s, expiration_time_millis($t) AS %s FROM %s $t "+
+		"WHERE expiration_time_millis($t) > %d AND expiration_time_millis($t) <= %d",
+		fields, expiryColumn, tableName, from.UnixMilli(), to.UnixMilli())
+	if opts.Where != "" {
+		stmt += " AND (" + opts.Where + ")"
+	}
+	return stmt
+}
+
+// newExpiryEvent creates the event for a result of the query of
+// expiryScanStatement.
+func newExpiryEvent(tableName string, res *types.MapValue) *ExpiryEvent {
+	ev := &ExpiryEvent{TableName: tableName, Row: res}
+	if ms, ok := res.GetInt64(expiryColumn); ok {
+		ev.ExpirationTime = toUnixTime(ms)
+	}
+	res.Delete(expiryColumn)
+	if v, ok := res.Get(expiryRowColumn); ok {
+		if row, ok := v.(*types.MapValue); ok {
+			ev.Row = row
+		}
+	}
+	return ev
+}
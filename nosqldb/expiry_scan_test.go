@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiryScanStatement(t *testing.T) {
+	from := time.UnixMilli(1000)
+	to := time.UnixMilli(5000)
+	stmt := expiryScanStatement("users", from, to, &ExpiryScanOptions{})
+	assert.Equal(t, "SELECT $t AS expiryScanRow, expiration_time_millis($t) AS expiryScanExpirationTime "+
+		"FROM users $t WHERE expiration_time_millis($t) > 1000 AND expiration_time_millis($t) <= 5000", stmt)
+
+	stmt = expiryScanStatement("users", from, to, &ExpiryScanOptions{Fields: []string{"id", "name"}, Where: "$t.age > 10"})
+	assert.Equal(t, "SELECT $t.id, $t.name, expiration_time_millis($t) AS expiryScanExpirationTime "+
+		"FROM users $t WHERE expiration_time_millis($t) > 1000 AND expiration_time_millis($t) <= 5000 "+
+		"AND ($t.age > 10)", stmt)
+
+	row := types.NewMapValue(map[string]interface{}{"id": 1})
+	res := types.NewMapValue(map[string]interface{}{expiryRowColumn: row, expiryColumn: int64(5000)})
+	ev := newExpiryEvent("users", res)
+	assert.Equal(t, row, ev.Row)
+	assert.Equal(t, int64(5000), ev.ExpirationTime.UnixMilli())
+
+	res = types.NewMapValue(map[string]interface{}{"id": 1, expiryColumn: int64(5000)})
+	ev = newExpiryEvent("users", res)
+	assert.Equal(t, map[string]interface{}{"id": 1}, ev.Row.Map())
+}
+
+func TestWatchExpiringRows(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := time.UnixMilli(0)
+	now := func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	type bounds struct{ from, to int64 }
+	var scans []bounds
+	scan := func(from, to time.Time) error {
+		scans = append(scans, bounds{from.UnixMilli(), to.UnixMilli()})
+		if len(scans) == 3 {
+			cancel()
+		}
+		return nil
+	}
+	err := watchExpiringRows(ctx, time.Minute, time.Millisecond, now, scan)
+	assert.Equal(t, context.Canceled, err)
+	// The windows of consecutive scans do not overlap.
+	assert.Equal(t, []bounds{{1000, 62000}, {62000, 63000}, {63000, 64000}}, scans)
+
+	errScan := errors.New("scan error")
+	err = watchExpiringRows(context.Background(), time.Minute, time.Millisecond, now, func(from, to time.Time) error {
+		return errScan
+	})
+	assert.Equal(t, errScan, err)
+
+	c := &Client{}
+	_, err = c.ScanExpiringRows(context.Background(), "t", 0, nil, func(*ExpiryEvent) error { return nil })
+	assert.True(t, nosqlerr.IsIllegalArgument(err))
+}
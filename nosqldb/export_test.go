@@ -21,7 +21,7 @@ func (c *Client) SetResponseHandler(fn HandleResponse) {
 }
 
 func (c *Client) ProcessRequest(req Request) (data []byte, serialVerUsed int16, queryVerUsed int16, err error) {
-	return c.processRequest(req)
+	return c.processRequest(context.Background(), req)
 }
 
 func (c *Client) DoExecute(ctx context.Context, req Request, data []byte, serialVerUsed int16, queryVerUsed int16) (Result, error) {
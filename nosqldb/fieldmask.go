@@ -0,0 +1,253 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// fieldNameRegexp matches a field name that can be used in a path expression.
+var fieldNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// FieldMaskUpdate describes a partial update of a row that only touches the
+// fields listed in a field mask, such as the google.protobuf.FieldMask used
+// by update methods of gRPC services.
+//
+// Each path of the mask is a dot-separated list of field names, for example
+// "name" or "address.city", which refers to a column of the table or to a
+// field nested in a JSON, MAP or RECORD column. Following the semantics of
+// FieldMask, a field that is listed in the mask but is absent from Value is
+// set to null.
+type FieldMaskUpdate struct {
+	// TableName specifies the name of table to update.
+	TableName string
+
+	// KeyFields specifies the names of the primary key columns of the table.
+	// The values of the primary key are taken from Value.
+	KeyFields []string
+
+	// Paths specifies the paths of the fields to update, for example the
+	// value returned by FieldMask.GetPaths(). Paths must not refer to the
+	// primary key columns.
+	Paths []string
+
+	// Value specifies the new values of the row. It must be a *types.MapValue
+	// or a struct, or pointer to struct, that is mapped to the row in the
+	// same way as PutRequest.StructValue.
+	Value any
+}
+
+// Statement returns the UPDATE statement that performs the partial update,
+// and the values to bind to the external variables used in the statement.
+func (u *FieldMaskUpdate) Statement() (stmt string, bindings map[string]types.FieldValue, err error) {
+	if err = validateTableName(u.TableName); err != nil {
+		return "", nil, err
+	}
+
+	if len(u.KeyFields) == 0 {
+		return "", nil, nosqlerr.NewIllegalArgument("FieldMaskUpdate: KeyFields must be non-empty")
+	}
+
+	if len(u.Paths) == 0 {
+		return "", nil, nosqlerr.NewIllegalArgument("FieldMaskUpdate: Paths must be non-empty")
+	}
+
+	value, err := toMapValue(u.Value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	keys := make(map[string]bool, len(u.KeyFields))
+	for _, k := range u.KeyFields {
+		if !fieldNameRegexp.MatchString(k) {
+			return "", nil, nosqlerr.NewIllegalArgument("FieldMaskUpdate: invalid key field %q", k)
+		}
+		keys[strings.ToLower(k)] = true
+	}
+
+	var decls, sets, conds []string
+	bindings = make(map[string]types.FieldValue, len(u.KeyFields)+len(u.Paths))
+	for i, p := range u.Paths {
+		fields, err := splitFieldPath(p)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if keys[strings.ToLower(fields[0])] {
+			return "", nil, nosqlerr.NewIllegalArgument("FieldMaskUpdate: cannot update "+
+				"the primary key field %q", fields[0])
+		}
+
+		v, ok := lookupFieldPath(value, fields)
+		if !ok {
+			v = types.NullValueInstance
+		}
+
+		name := fmt.Sprintf("$v%d", i)
+		decls = append(decls, name+" ANY;")
+		sets = append(sets, fmt.Sprintf("$t.%s = %s", strings.Join(fields, "."), name))
+		bindings[name] = v
+	}
+
+	for i, k := range u.KeyFields {
+		v, ok := value.Get(k)
+		if !ok {
+			return "", nil, nosqlerr.NewIllegalArgument("FieldMaskUpdate: missing value "+
+				"of the primary key field %q", k)
+		}
+
+		name := fmt.Sprintf("$k%d", i)
+		decls = append(decls, name+" ANYATOMIC;")
+		conds = append(conds, fmt.Sprintf("$t.%s = %s", k, name))
+		bindings[name] = v
+	}
+
+	stmt = fmt.Sprintf("DECLARE %s UPDATE %s $t SET %s WHERE %s",
+		strings.Join(decls, " "), u.TableName, strings.Join(sets, ", "), strings.Join(conds, " AND "))
+	return stmt, bindings, nil
+}
+
+// UpdateWithFieldMask performs the partial update of a row described by the
+// specified FieldMaskUpdate. It returns the result of the UPDATE query,
+// which contains the number of rows updated.
+func (c *Client) UpdateWithFieldMask(u *FieldMaskUpdate) (*QueryResult, error) {
+	if u == nil {
+		return nil, errNilRequest
+	}
+
+	stmt, bindings, err := u.Statement()
+	if err != nil {
+		return nil, err
+	}
+
+	prepRes, err := c.Prepare(&PrepareRequest{Statement: stmt})
+	if err != nil {
+		return nil, err
+	}
+
+	ps := prepRes.PreparedStatement
+	for name, v := range bindings {
+		if err = ps.SetVariable(name, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.Query(&QueryRequest{PreparedStatement: &ps})
+}
+
+// ApplyFieldMask copies the fields listed in paths from src to dst, which
+// can be used to merge a partial update into a row read from the table before
+// writing the row back with a put operation. Fields listed in paths that are
+// absent from src are removed from dst. Intermediate maps are created in dst
+// as needed.
+//
+// The src value must be a *types.MapValue or a struct, or pointer to struct.
+func ApplyFieldMask(dst *types.MapValue, src any, paths []string) error {
+	if dst == nil {
+		return nosqlerr.NewIllegalArgument("ApplyFieldMask: dst must be non-nil")
+	}
+
+	value, err := toMapValue(src)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range paths {
+		fields, err := splitFieldPath(p)
+		if err != nil {
+			return err
+		}
+
+		parent := dst
+		for _, f := range fields[:len(fields)-1] {
+			child, ok := parent.Get(f)
+			m, isMap := child.(*types.MapValue)
+			if !ok || !isMap {
+				m = types.NewEmptyMapValue()
+				parent.Put(f, m)
+			}
+			parent = m
+		}
+
+		last := fields[len(fields)-1]
+		if v, ok := lookupFieldPath(value, fields); ok {
+			parent.Put(last, v)
+		} else {
+			parent.Delete(last)
+		}
+	}
+
+	return nil
+}
+
+// splitFieldPath splits a field mask path into field names.
+func splitFieldPath(p string) ([]string, error) {
+	fields := strings.Split(p, ".")
+	for _, f := range fields {
+		if !fieldNameRegexp.MatchString(f) {
+			return nil, nosqlerr.NewIllegalArgument("invalid field path %q", p)
+		}
+	}
+	return fields, nil
+}
+
+// lookupFieldPath returns the value of the field at the specified path.
+func lookupFieldPath(m *types.MapValue, fields []string) (types.FieldValue, bool) {
+	var v types.FieldValue = m
+	for _, f := range fields {
+		switch mv := v.(type) {
+		case *types.MapValue:
+			var ok bool
+			if v, ok = mv.Get(f); !ok {
+				return nil, false
+			}
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = mv[f]; !ok {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// toMapValue converts a MapValue or a struct to a MapValue.
+func toMapValue(v any) (*types.MapValue, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nosqlerr.NewIllegalArgument("value must be non-nil")
+	case *types.MapValue:
+		return v, nil
+	}
+
+	data, err := binary.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	fv, err := binary.NewReader(bytes.NewBuffer(data)).ReadFieldValue()
+	if err != nil {
+		return nil, err
+	}
+
+	mv, ok := fv.(*types.MapValue)
+	if !ok {
+		return nil, nosqlerr.NewIllegalArgument("value of type %T cannot be converted to a MapValue", v)
+	}
+
+	return mv, nil
+}
@@ -0,0 +1,65 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldMaskUpdate(t *testing.T) {
+	type address struct {
+		City string `nosql:"city"`
+		Zip  string `nosql:"zip"`
+	}
+	type user struct {
+		ID      int     `nosql:"id"`
+		Name    string  `nosql:"name"`
+		Email   string  `nosql:"email"`
+		Address address `nosql:"address"`
+	}
+
+	u := &FieldMaskUpdate{
+		TableName: "users",
+		KeyFields: []string{"id"},
+		Paths:     []string{"name", "address.city"},
+		Value:     &user{ID: 7, Name: "Jane", Address: address{City: "Austin"}},
+	}
+	stmt, bindings, err := u.Statement()
+	require.NoError(t, err)
+	assert.Equal(t, "DECLARE $v0 ANY; $v1 ANY; $k0 ANYATOMIC; "+
+		"UPDATE users $t SET $t.name = $v0, $t.address.city = $v1 WHERE $t.id = $k0", stmt)
+	assert.Equal(t, map[string]types.FieldValue{"$v0": "Jane", "$v1": "Austin", "$k0": int64(7)}, bindings)
+
+	invalid := []*FieldMaskUpdate{
+		{TableName: "users", KeyFields: []string{"id"}, Paths: []string{"id"}, Value: u.Value},
+		{TableName: "users", KeyFields: []string{"id"}, Paths: []string{"name; DROP"}, Value: u.Value},
+		{TableName: "users", KeyFields: []string{"uid"}, Paths: []string{"name"}, Value: u.Value},
+		{TableName: "users", KeyFields: []string{"id"}, Value: u.Value},
+		{TableName: "users", KeyFields: []string{"id"}, Paths: []string{"name"}},
+	}
+	for i, u := range invalid {
+		_, _, err = u.Statement()
+		assert.Truef(t, nosqlerr.IsIllegalArgument(err), "Testcase %d: got error %v", i, err)
+	}
+
+	row := types.NewEmptyMapValue().Put("id", 7).Put("name", "John").Put("email", "john@example.com")
+	src := types.NewEmptyMapValue().Put("id", 7).Put("name", "Jane").
+		Put("address", map[string]interface{}{"city": "Austin"})
+	err = ApplyFieldMask(row, src, []string{"name", "email", "address.city"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":      7,
+		"name":    "Jane",
+		"address": types.NewEmptyMapValue().Put("city", "Austin"),
+	}, row.Map())
+}
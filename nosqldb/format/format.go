@@ -0,0 +1,552 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+// Package format provides functions that format the rows returned by queries
+// and other operations as aligned text tables, CSV or JSON, for command line
+// tools and debug logging.
+//
+// Values are formatted according to their types: timestamps use a
+// configurable layout, NUMBER values are written as exact decimals, binary
+// values are written in base64 and can be truncated, and the SQL NULL, JSON
+// null and EMPTY values are distinguished. For example:
+//
+//	rows, _ := res.GetResults()
+//	format.Write(os.Stdout, rows, &format.Options{MaxWidth: 40})
+//
+// writes:
+//
+//	+----+-------+----------------------+
+//	| id | name  | created              |
+//	+----+-------+----------------------+
+//	|  1 | Alice | 2024-01-02T03:04:05Z |
+//	|  2 | NULL  | 2024-01-03T00:00:00Z |
+//	+----+-------+----------------------+
+package format
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// Format represents an output format.
+type Format int
+
+const (
+	// Text writes the rows as a table of aligned columns with a header row.
+	Text Format = iota // 0
+
+	// CSV writes the rows as comma-separated values, as described in RFC 4180,
+	// with a header row. Maps and arrays are written as JSON.
+	CSV // 1
+
+	// JSON writes each row as a JSON object on its own line.
+	JSON // 2
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case Text:
+		return "text"
+	case CSV:
+		return "csv"
+	case JSON:
+		return "json"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// ParseFormat returns the format with the specified name, which is one of
+// "text", "csv" or "json", ignoring case.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "text", "table":
+		return Text, nil
+	case "csv":
+		return CSV, nil
+	case "json":
+		return JSON, nil
+	default:
+		return Text, fmt.Errorf("unknown format %q, expected text, csv or json", name)
+	}
+}
+
+// Options specifies options for formatting rows.
+type Options struct {
+	// Format specifies the output format. The default is Text.
+	Format Format
+
+	// Columns specifies the fields of the rows that are written, and their
+	// order. If not set, all fields of the rows are written, in the order of
+	// the rows if they are ordered, and sorted by name otherwise.
+	Columns []string
+
+	// TimeLayout specifies the layout used to format timestamps, as for
+	// time.Time.Format. If not set, time.RFC3339Nano is used.
+	TimeLayout string
+
+	// TimeLocation specifies the location timestamps are converted to. If not
+	// set, timestamps are written in their own location, which is UTC for the
+	// values returned by the server.
+	TimeLocation *time.Location
+
+	// MaxBinaryBytes specifies the maximum number of bytes of a binary value
+	// that are written. Longer values are truncated and followed by their size,
+	// for example "AQIDBA==...(1024 bytes)". If set to 0, binary values are
+	// written in full.
+	MaxBinaryBytes int
+
+	// MaxWidth specifies the maximum width of a column of a Text table, in
+	// characters. Longer values are truncated and end with "...". If set to 0,
+	// the columns are as wide as their values.
+	MaxWidth int
+
+	// Null specifies the text of a SQL NULL value in Text and CSV output. If
+	// not set, "NULL" is used.
+	Null string
+}
+
+func (o *Options) null() string {
+	if o.Null != "" {
+		return o.Null
+	}
+	return "NULL"
+}
+
+// Write writes the rows to w in the format specified by opts, which may be nil
+// to use the default options.
+func Write(w io.Writer, rows []*types.MapValue, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = Columns(rows)
+	}
+
+	switch opts.Format {
+	case Text:
+		return writeText(w, rows, columns, opts)
+	case CSV:
+		return writeCSV(w, rows, columns, opts)
+	case JSON:
+		return writeJSON(w, rows, columns, opts)
+	default:
+		return fmt.Errorf("unsupported format %v", opts.Format)
+	}
+}
+
+// String returns the rows formatted as specified by opts, which may be nil to
+// use the default options, for use in log messages.
+func String(rows []*types.MapValue, opts *Options) string {
+	var b strings.Builder
+	if err := Write(&b, rows, opts); err != nil {
+		return fmt.Sprintf("<cannot format rows: %v>", err)
+	}
+	return b.String()
+}
+
+// Columns returns the union of the fields of the rows, in the order of the
+// rows if they are ordered, such as query results, or sorted by name
+// otherwise.
+func Columns(rows []*types.MapValue) []string {
+	var columns []string
+	seen := make(map[string]bool)
+	add := func(c string) {
+		if !seen[c] {
+			seen[c] = true
+			columns = append(columns, c)
+		}
+	}
+
+	for _, r := range rows {
+		if r == nil {
+			continue
+		}
+		if r.IsOrdered() {
+			for i := 1; i <= r.Len(); i++ {
+				k, _, _ := r.GetByIndex(i)
+				add(k)
+			}
+			continue
+		}
+
+		keys := make([]string, 0, r.Len())
+		for k := range r.Map() {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			add(k)
+		}
+	}
+	return columns
+}
+
+// Value returns the text of a value in Text and CSV output. Maps and arrays
+// are formatted as JSON, with their values formatted as in JSON output.
+func Value(v types.FieldValue, opts *Options) string {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	switch v := v.(type) {
+	case nil, *types.NullValue:
+		return opts.null()
+	case *types.JSONNullValue:
+		return "null"
+	case *types.EmptyValue:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return formatTime(v, opts)
+	case []byte:
+		return formatBinary(v, opts)
+	case *big.Rat:
+		return formatRat(v)
+	case float64:
+		return formatFloat(v, 64)
+	case float32:
+		return formatFloat(float64(v), 32)
+	case json.Number:
+		return string(v)
+	case *types.MapValue, map[string]interface{}, []types.FieldValue, []interface{}, []*types.MapValue:
+		b, err := json.Marshal(jsonValue(v, opts))
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatTime(t time.Time, opts *Options) string {
+	if opts.TimeLocation != nil {
+		t = t.In(opts.TimeLocation)
+	}
+	layout := opts.TimeLayout
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+	return t.Format(layout)
+}
+
+func formatBinary(b []byte, opts *Options) string {
+	if opts.MaxBinaryBytes > 0 && len(b) > opts.MaxBinaryBytes {
+		return fmt.Sprintf("%s...(%d bytes)", base64.StdEncoding.EncodeToString(b[:opts.MaxBinaryBytes]), len(b))
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// formatFloat formats a floating point number as encoding/json does, without
+// an exponent unless the number is very small or very large.
+func formatFloat(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	return strconv.FormatFloat(f, format, -1, bits)
+}
+
+// formatRat formats a NUMBER value as an exact decimal if it has a finite
+// decimal representation, or with 20 decimal places otherwise.
+func formatRat(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	// The decimal representation is finite if the denominator has no prime
+	// factors other than 2 and 5.
+	d := new(big.Int).Set(r.Denom())
+	places := 0
+	mod := new(big.Int)
+	for _, p := range []*big.Int{big.NewInt(2), big.NewInt(5)} {
+		n := 0
+		for {
+			q, m := new(big.Int).QuoRem(d, p, mod)
+			if m.Sign() != 0 {
+				break
+			}
+			d = q
+			n++
+		}
+		if n > places {
+			places = n
+		}
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		places = 20
+	}
+
+	s := r.FloatString(places)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// jsonValue converts a value to a value that is marshaled to JSON as
+// specified by the options.
+func jsonValue(v types.FieldValue, opts *Options) interface{} {
+	switch v := v.(type) {
+	case nil, *types.NullValue, *types.JSONNullValue, *types.EmptyValue:
+		return nil
+	case time.Time:
+		return formatTime(v, opts)
+	case []byte:
+		return formatBinary(v, opts)
+	case *big.Rat:
+		return json.Number(formatRat(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return formatFloat(v, 64)
+		}
+		return v
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return formatFloat(float64(v), 32)
+		}
+		return json.Number(formatFloat(float64(v), 32))
+	case *types.MapValue:
+		return orderedJSON{v, Columns([]*types.MapValue{v}), opts}
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = jsonValue(e, opts)
+		}
+		return m
+	case []types.FieldValue:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = jsonValue(e, opts)
+		}
+		return a
+	case []*types.MapValue:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = jsonValue(e, opts)
+		}
+		return a
+	default:
+		return v
+	}
+}
+
+// orderedJSON marshals the specified fields of a row to a JSON object, in
+// order. Fields that are not in the row are omitted.
+type orderedJSON struct {
+	row     *types.MapValue
+	columns []string
+	opts    *Options
+}
+
+func (o orderedJSON) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	first := true
+	for _, c := range o.columns {
+		v, ok := o.row.Get(c)
+		if !ok {
+			continue
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+
+		k, _ := json.Marshal(c)
+		b.Write(k)
+		b.WriteByte(':')
+		e, err := json.Marshal(jsonValue(v, o.opts))
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", c, err)
+		}
+		b.Write(e)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func writeJSON(w io.Writer, rows []*types.MapValue, columns []string, opts *Options) error {
+	for _, r := range rows {
+		if r == nil {
+			continue
+		}
+		b, err := orderedJSON{r, columns, opts}.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		b = append(b, '\n')
+		if _, err = w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, rows []*types.MapValue, columns []string, opts *Options) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for _, r := range rows {
+		if r == nil {
+			continue
+		}
+		for i, c := range columns {
+			record[i] = ""
+			if v, ok := r.Get(c); ok {
+				record[i] = Value(v, opts)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeText(w io.Writer, rows []*types.MapValue, columns []string, opts *Options) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	// Numeric columns are aligned right.
+	numeric := make([]bool, len(columns))
+	for i := range numeric {
+		numeric[i] = true
+	}
+
+	cells := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		if r == nil {
+			continue
+		}
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			v, ok := r.Get(c)
+			if !ok {
+				continue
+			}
+			if !isNumber(v) && !isNull(v) {
+				numeric[i] = false
+			}
+			row[i] = textCell(Value(v, opts), opts.MaxWidth)
+		}
+		cells = append(cells, row)
+	}
+
+	widths := make([]int, len(columns))
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = textCell(c, opts.MaxWidth)
+		widths[i] = utf8.RuneCountInString(header[i])
+	}
+	for _, row := range cells {
+		for i, c := range row {
+			if n := utf8.RuneCountInString(c); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var sep strings.Builder
+	sep.WriteByte('+')
+	for _, n := range widths {
+		sep.WriteString(strings.Repeat("-", n+2))
+		sep.WriteByte('+')
+	}
+	sep.WriteByte('\n')
+
+	var b strings.Builder
+	writeRow := func(row []string, alignRight []bool) {
+		b.WriteByte('|')
+		for i, c := range row {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c))
+			b.WriteByte(' ')
+			if alignRight != nil && alignRight[i] {
+				b.WriteString(pad)
+				b.WriteString(c)
+			} else {
+				b.WriteString(c)
+				b.WriteString(pad)
+			}
+			b.WriteString(" |")
+		}
+		b.WriteByte('\n')
+	}
+
+	b.WriteString(sep.String())
+	writeRow(header, nil)
+	b.WriteString(sep.String())
+	for _, row := range cells {
+		writeRow(row, numeric)
+	}
+	b.WriteString(sep.String())
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// textCell returns the text of a cell of a Text table, with line breaks and
+// tabs escaped so that the table stays aligned, truncated to maxWidth.
+func textCell(s string, maxWidth int) string {
+	s = strings.NewReplacer("\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s)
+	if maxWidth > 0 && utf8.RuneCountInString(s) > maxWidth {
+		if maxWidth <= 3 {
+			return string([]rune(s)[:maxWidth])
+		}
+		return string([]rune(s)[:maxWidth-3]) + "..."
+	}
+	return s
+}
+
+func isNumber(v types.FieldValue) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, *big.Rat, json.Number:
+		return true
+	}
+	return false
+}
+
+func isNull(v types.FieldValue) bool {
+	switch v.(type) {
+	case nil, *types.NullValue, *types.JSONNullValue, *types.EmptyValue:
+		return true
+	}
+	return false
+}
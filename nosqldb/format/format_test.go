@@ -0,0 +1,116 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package format
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRows() []*types.MapValue {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	r1 := types.NewOrderedMapValue().
+		Put("id", 1).
+		Put("name", "Alice").
+		Put("created", ts).
+		Put("info", types.NewOrderedMapValue().Put("b", []byte{1, 2, 3}).Put("a", nil))
+	r2 := types.NewOrderedMapValue().
+		Put("id", 20).
+		Put("name", types.NullValueInstance).
+		Put("created", ts.Add(time.Hour)).
+		Put("price", big.NewRat(25, 2))
+	return []*types.MapValue{r1, r2}
+}
+
+func TestWriteText(t *testing.T) {
+	s := String(testRows(), &Options{Columns: []string{"id", "name", "created", "price"}})
+	expected := "" +
+		"+----+-------+----------------------+-------+\n" +
+		"| id | name  | created              | price |\n" +
+		"+----+-------+----------------------+-------+\n" +
+		"|  1 | Alice | 2024-01-02T03:04:05Z |       |\n" +
+		"| 20 | NULL  | 2024-01-02T04:04:05Z |  12.5 |\n" +
+		"+----+-------+----------------------+-------+\n"
+	assert.Equal(t, expected, s)
+
+	// Truncated columns and escaped line breaks.
+	row := types.NewOrderedMapValue().Put("description", "line 1\nline 2")
+	s = String([]*types.MapValue{row}, &Options{MaxWidth: 10})
+	expected = "" +
+		"+------------+\n" +
+		"| descrip... |\n" +
+		"+------------+\n" +
+		"| line 1\\... |\n" +
+		"+------------+\n"
+	assert.Equal(t, expected, s)
+
+	assert.Equal(t, []string{"id", "name", "created", "info", "price"}, Columns(testRows()))
+	assert.Equal(t, "", String(nil, nil))
+}
+
+func TestWriteCSV(t *testing.T) {
+	s := String(testRows(), &Options{Format: CSV, Null: `\N`})
+	expected := "" +
+		"id,name,created,info,price\n" +
+		`1,Alice,2024-01-02T03:04:05Z,"{""b"":""AQID"",""a"":null}",` + "\n" +
+		`20,\N,2024-01-02T04:04:05Z,,12.5` + "\n"
+	assert.Equal(t, expected, s)
+}
+
+func TestWriteJSON(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*3600)
+	s := String(testRows(), &Options{Format: JSON, TimeLayout: "2006-01-02 15:04:05 -0700", TimeLocation: loc})
+	expected := "" +
+		`{"id":1,"name":"Alice","created":"2024-01-02 05:04:05 +0200","info":{"b":"AQID","a":null}}` + "\n" +
+		`{"id":20,"name":null,"created":"2024-01-02 06:04:05 +0200","price":12.5}` + "\n"
+	assert.Equal(t, expected, s)
+}
+
+func TestValue(t *testing.T) {
+	opts := &Options{MaxBinaryBytes: 3}
+	assert.Equal(t, "AQID...(5 bytes)", Value([]byte{1, 2, 3, 4, 5}, opts))
+	assert.Equal(t, "AQID", Value([]byte{1, 2, 3}, opts))
+	assert.Equal(t, "NULL", Value(nil, nil))
+	assert.Equal(t, "null", Value(types.JSONNullValueInstance, nil))
+	assert.Equal(t, "", Value(types.EmptyValueInstance, nil))
+
+	assert.Equal(t, "0.1", Value(0.1, nil))
+	assert.Equal(t, "1e+21", Value(1e21, nil))
+	assert.Equal(t, "100000", Value(float64(100000), nil))
+	assert.Equal(t, "1.5", Value(float32(1.5), nil))
+	assert.Equal(t, "NaN", Value(math.NaN(), nil))
+	assert.Equal(t, "-Infinity", Value(math.Inf(-1), nil))
+
+	assert.Equal(t, "12345678901234567890", Value(new(big.Rat).SetInt64(1).SetFrac(bigInt("12345678901234567890"), big.NewInt(1)), nil))
+	assert.Equal(t, "0.001", Value(big.NewRat(1, 1000), nil))
+	assert.Equal(t, "-0.125", Value(big.NewRat(-1, 8), nil))
+	assert.Equal(t, "0.33333333333333333333", Value(big.NewRat(1, 3), nil))
+
+	assert.Equal(t, `[1,"a",null]`, Value([]types.FieldValue{1, "a", types.NullValueInstance}, nil))
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range []Format{Text, CSV, JSON} {
+		g, err := ParseFormat(f.String())
+		require.NoError(t, err)
+		assert.Equal(t, f, g)
+	}
+	_, err := ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func bigInt(s string) *big.Int {
+	i, _ := new(big.Int).SetString(s, 10)
+	return i
+}
@@ -0,0 +1,157 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// TableHooks specifies functions that a Client calls around the row
+// operations on a table, which can be used to centralize data rules such as
+// validation, defaulting of values and cache invalidation.
+//
+// Hooks apply to the put and delete operations performed with Client.Put(),
+// Client.Delete(), Client.WriteMultiple() and their context variants. They
+// are not applied to rows modified by queries or Client.MultiDelete().
+//
+// Any of the functions may be nil. Hooks run on the goroutine that executes
+// the operation.
+type TableHooks struct {
+	// BeforePut is called before a put operation is sent to the server. It
+	// may modify the request. If it returns an error, the operation fails
+	// with that error.
+	BeforePut func(ctx context.Context, req *PutRequest) error
+
+	// AfterPut is called after a put operation succeeded, with the version
+	// of the new row.
+	AfterPut func(ctx context.Context, req *PutRequest, version types.Version)
+
+	// BeforeDelete is called before a delete operation is sent to the
+	// server. If it returns an error, the operation fails with that error.
+	BeforeDelete func(ctx context.Context, req *DeleteRequest) error
+
+	// AfterDelete is called after a delete operation deleted a row.
+	AfterDelete func(ctx context.Context, req *DeleteRequest)
+}
+
+// SetTableHooks registers the hooks for the specified table, replacing the
+// hooks previously registered for the table, if any. If hooks is nil, the
+// hooks of the table are removed.
+//
+// Table names are case-insensitive. The table name must be specified in the
+// same form as in the requests, with or without a namespace prefix.
+func (c *Client) SetTableHooks(tableName string, hooks *TableHooks) {
+	c.hooksMux.Lock()
+	defer c.hooksMux.Unlock()
+
+	key := strings.ToLower(tableName)
+	if hooks == nil {
+		delete(c.tableHooks, key)
+		return
+	}
+
+	if c.tableHooks == nil {
+		c.tableHooks = make(map[string]*TableHooks)
+	}
+	c.tableHooks[key] = hooks
+}
+
+// getTableHooks returns the hooks registered for the table, or nil.
+func (c *Client) getTableHooks(tableName string) *TableHooks {
+	c.hooksMux.RLock()
+	defer c.hooksMux.RUnlock()
+	if len(c.tableHooks) == 0 {
+		return nil
+	}
+	return c.tableHooks[strings.ToLower(tableName)]
+}
+
+// runBeforeHooks calls the BeforePut and BeforeDelete hooks for the
+// operations of the specified request.
+func (c *Client) runBeforeHooks(ctx context.Context, req Request) error {
+	switch r := req.(type) {
+	case *PutRequest:
+		return c.beforePut(ctx, r)
+	case *DeleteRequest:
+		return c.beforeDelete(ctx, r)
+	case *WriteMultipleRequest:
+		if r.coalesced {
+			return nil
+		}
+		for _, op := range r.Operations {
+			if op == nil {
+				continue
+			}
+			var err error
+			if op.PutRequest != nil {
+				err = c.beforePut(ctx, op.PutRequest)
+			} else if op.DeleteRequest != nil {
+				err = c.beforeDelete(ctx, op.DeleteRequest)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) beforePut(ctx context.Context, req *PutRequest) error {
+	if h := c.getTableHooks(req.TableName); h != nil && h.BeforePut != nil {
+		return h.BeforePut(ctx, req)
+	}
+	return nil
+}
+
+func (c *Client) beforeDelete(ctx context.Context, req *DeleteRequest) error {
+	if h := c.getTableHooks(req.TableName); h != nil && h.BeforeDelete != nil {
+		return h.BeforeDelete(ctx, req)
+	}
+	return nil
+}
+
+func (c *Client) afterPut(ctx context.Context, req *PutRequest, version types.Version) {
+	if version == nil {
+		return
+	}
+	if h := c.getTableHooks(req.TableName); h != nil && h.AfterPut != nil {
+		h.AfterPut(ctx, req, version)
+	}
+}
+
+func (c *Client) afterDelete(ctx context.Context, req *DeleteRequest, success bool) {
+	if !success {
+		return
+	}
+	if h := c.getTableHooks(req.TableName); h != nil && h.AfterDelete != nil {
+		h.AfterDelete(ctx, req)
+	}
+}
+
+// runAfterWriteMultipleHooks calls the AfterPut and AfterDelete hooks for the
+// operations of a WriteMultiple request that succeeded.
+func (c *Client) runAfterWriteMultipleHooks(ctx context.Context, req *WriteMultipleRequest, res *WriteMultipleResult) {
+	if req.coalesced || !res.IsSuccess() {
+		return
+	}
+
+	for i, op := range req.Operations {
+		if op == nil || i >= len(res.ResultSet) || !res.ResultSet[i].Success {
+			continue
+		}
+		if op.PutRequest != nil {
+			c.afterPut(ctx, op.PutRequest, res.ResultSet[i].Version)
+		} else if op.DeleteRequest != nil {
+			c.afterDelete(ctx, op.DeleteRequest, true)
+		}
+	}
+}
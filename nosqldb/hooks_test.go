@@ -0,0 +1,85 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableHooks(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+
+	var events []string
+	errInvalid := nosqlerr.NewIllegalArgument("name is required")
+	client.SetTableHooks("Users", &TableHooks{
+		BeforePut: func(ctx context.Context, req *PutRequest) error {
+			events = append(events, "beforePut")
+			if !req.Value.Contains("name") {
+				return errInvalid
+			}
+			req.Value.Put("status", "active")
+			return nil
+		},
+		AfterPut: func(ctx context.Context, req *PutRequest, version types.Version) {
+			events = append(events, "afterPut")
+		},
+		AfterDelete: func(ctx context.Context, req *DeleteRequest) {
+			events = append(events, "afterDelete")
+		},
+	})
+
+	ctx := context.Background()
+	tests := []struct {
+		desc   string
+		req    Request
+		err    error
+		events []string
+	}{
+		{"valid row", &PutRequest{TableName: "users", Value: types.ToMapValue("name", "jane")}, nil, []string{"beforePut"}},
+		{"invalid row", &PutRequest{TableName: "users", Value: types.ToMapValue("id", 1)}, errInvalid, []string{"beforePut"}},
+		{"case-insensitive table name", &PutRequest{TableName: "USERS", Value: types.ToMapValue("name", "jane")}, nil, []string{"beforePut"}},
+		{"other table", &PutRequest{TableName: "T1", Value: types.ToMapValue("id", 1)}, nil, nil},
+		{"no BeforeDelete hook", &DeleteRequest{TableName: "users", Key: types.ToMapValue("id", 1)}, nil, nil},
+	}
+	for _, r := range tests {
+		events = nil
+		err = client.runBeforeHooks(ctx, r.req)
+		assert.Equalf(t, r.err, err, "%s: unexpected error", r.desc)
+		assert.Equalf(t, r.events, events, "%s: unexpected hooks called", r.desc)
+	}
+
+	// The hooks may modify the row, and their errors fail the put.
+	putReq := &PutRequest{TableName: "users", Value: types.ToMapValue("name", "jane")}
+	require.NoError(t, client.runBeforeHooks(ctx, putReq))
+	status, _ := putReq.Value.GetString("status")
+	assert.Equal(t, "active", status)
+	_, err = client.PutWithContext(ctx, &PutRequest{TableName: "users", Value: types.ToMapValue("id", 1)})
+	assert.Equal(t, errInvalid, err)
+
+	// The hooks are called for each operation of a WriteMultiple request.
+	events = nil
+	wmReq := &WriteMultipleRequest{}
+	wmReq.AddPutRequest(putReq, true)
+	wmReq.AddDeleteRequest(&DeleteRequest{TableName: "users", Key: types.ToMapValue("id", 1)}, true)
+	require.NoError(t, client.runBeforeHooks(ctx, wmReq))
+	client.runAfterWriteMultipleHooks(ctx, wmReq, &WriteMultipleResult{
+		FailedOperationIndex: -1,
+		ResultSet:            []OperationResult{{Success: true, Version: types.Version{1}}, {Success: true}},
+	})
+	assert.Equal(t, []string{"beforePut", "afterPut", "afterDelete"}, events)
+
+	client.SetTableHooks("USERS", nil)
+	assert.Nil(t, client.getTableHooks("users"))
+}
@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 )
 
@@ -97,6 +98,19 @@ func NewHTTPClient(cfg HTTPConfig) (*HTTPClient, error) {
 			RootCAs:            rootCAs,
 			ServerName:         cfg.ServerName,
 		}
+
+		switch {
+		case cfg.GetClientCertificate != nil:
+			tr.TLSClientConfig.GetClientCertificate = cfg.GetClientCertificate
+		case cfg.ClientCertPath != "" || cfg.ClientKeyPath != "":
+			l, err := newClientCertificateLoader(cfg.ClientCertPath, cfg.ClientKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			tr.TLSClientConfig.GetClientCertificate = l.getClientCertificate
+		case cfg.ClientCertificate != nil:
+			tr.TLSClientConfig.Certificates = []tls.Certificate{*cfg.ClientCertificate}
+		}
 	}
 
 	tr.DialContext = (&net.Dialer{
@@ -182,6 +196,103 @@ type HTTPConfig struct {
 	// If InsecureSkipVerify is true, this field is ignored.
 	ServerName string `json:"serverName,omitempty"`
 
+	// ClientCertPath specifies the path to a PEM-encoded certificate file that
+	// the client presents to servers that authenticate clients with
+	// certificates (mutual TLS). It must be specified with ClientKeyPath.
+	//
+	// The files are loaded again when they change, so that the certificate
+	// can be rotated without restarting the client. New connections use the
+	// new certificate, while established connections keep using the previous
+	// one until they are closed.
+	ClientCertPath string `json:"clientCertPath,omitempty"`
+
+	// ClientKeyPath specifies the path to the PEM-encoded private key file of
+	// the certificate specified by ClientCertPath.
+	ClientKeyPath string `json:"clientKeyPath,omitempty"`
+
+	// ClientCertificate specifies the certificate that the client presents to
+	// servers that authenticate clients with certificates.
+	// It is ignored if ClientCertPath is specified.
+	ClientCertificate *tls.Certificate `json:"-"`
+
+	// GetClientCertificate specifies a function that returns the certificate
+	// that the client presents for each new connection, for applications that
+	// rotate the certificate themselves. See tls.Config.GetClientCertificate.
+	// If specified, ClientCertPath and ClientCertificate are ignored.
+	GetClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error) `json:"-"`
+
 	// TODO:
 	// CipherSuites	   []uint16
 }
+
+// clientCertificateLoader loads a client certificate from files, and loads
+// it again when the files change.
+type clientCertificateLoader struct {
+	certPath string
+	keyPath  string
+
+	mutex   sync.Mutex
+	cert    *tls.Certificate
+	certMod fileVersion
+	keyMod  fileVersion
+}
+
+// fileVersion identifies a version of a file by its modification time and size.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) (fileVersion, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileVersion{}, err
+	}
+	return fileVersion{fi.ModTime(), fi.Size()}, nil
+}
+
+// newClientCertificateLoader creates a loader for the certificate and private
+// key in the specified files, and loads them.
+func newClientCertificateLoader(certPath, keyPath string) (*clientCertificateLoader, error) {
+	l := &clientCertificateLoader{certPath: certPath, keyPath: keyPath}
+	if _, err := l.load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// getClientCertificate implements the GetClientCertificate function of
+// tls.Config.
+func (l *clientCertificateLoader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return l.load()
+}
+
+// load returns the certificate, after loading it again if the files changed
+// since it was last loaded. If the changed files cannot be loaded, such as
+// when only one of them has been replaced yet, the previous certificate is
+// returned.
+func (l *clientCertificateLoader) load() (*tls.Certificate, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	certMod, err := statFile(l.certPath)
+	if err == nil {
+		var keyMod fileVersion
+		if keyMod, err = statFile(l.keyPath); err == nil {
+			if l.cert != nil && certMod == l.certMod && keyMod == l.keyMod {
+				return l.cert, nil
+			}
+
+			var cert tls.Certificate
+			if cert, err = tls.LoadX509KeyPair(l.certPath, l.keyPath); err == nil {
+				l.cert, l.certMod, l.keyMod = &cert, certMod, keyMod
+				return l.cert, nil
+			}
+		}
+	}
+
+	if l.cert != nil {
+		return l.cert, nil
+	}
+	return nil, fmt.Errorf("cannot load client certificate: %v", err)
+}
@@ -0,0 +1,35 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageIndexes(t *testing.T) {
+	indexes := []IndexInfo{{IndexName: "a"}, {IndexName: "b"}, {IndexName: "c"}}
+	tests := []struct {
+		desc         string
+		start, limit uint
+		want         []IndexInfo
+		wantLast     uint
+	}{
+		{"no start or limit", 0, 0, indexes, 3},
+		{"start only", 1, 0, indexes[1:], 3},
+		{"limit only", 0, 2, indexes[:2], 2},
+		{"limit past the end", 2, 2, indexes[2:], 3},
+		{"start past the end", 5, 1, indexes[3:], 3},
+	}
+	for _, r := range tests {
+		got, last := pageIndexes(indexes, r.start, r.limit)
+		assert.Equalf(t, r.want, got, "%s: unexpected indexes", r.desc)
+		assert.Equalf(t, r.wantLast, last, "%s: unexpected last index", r.desc)
+	}
+}
@@ -32,6 +32,9 @@ type Reader struct {
 
 	// A buffer that holds the bytes for decoding.
 	buf []byte
+
+	// pool is the pool of the MapValues that are read, if set.
+	pool *types.MapValuePool
 }
 
 // NewReader creates a reader for the binary protocol.
@@ -42,6 +45,12 @@ func NewReader(r *bytes.Buffer) *Reader {
 	}
 }
 
+// SetMapValuePool sets the pool from which the MapValues that are read are
+// taken. If pool is nil, which is the default, new MapValues are allocated.
+func (r *Reader) SetMapValuePool(pool *types.MapValuePool) {
+	r.pool = pool
+}
+
 // GetBuffer returns the underlying bytes Buffer.
 func (r *Reader) GetBuffer() *bytes.Buffer {
 	return r.rd
@@ -212,7 +221,12 @@ func (r *Reader) ReadMap() (*types.MapValue, error) {
 		return nil, err
 	}
 
-	value := types.NewOrderedMapValue()
+	var value *types.MapValue
+	if r.pool != nil {
+		value = r.pool.Get()
+	} else {
+		value = types.NewOrderedMapValue()
+	}
 	for i := 0; i < size; i++ {
 		k, err := r.ReadString()
 		if err != nil {
@@ -348,6 +362,14 @@ func (r *Reader) ReadStructValue(v any) error {
 	return UnmarshalFromReader(v, r)
 }
 
+// ReadStructValueStrict deserializes data into a native struct, and returns
+// an error for unknown fields and values that cannot be represented exactly
+// by their destination, see UnmarshalFromReaderStrict.
+// The passed in value must be a pointer to a struct.
+func (r *Reader) ReadStructValueStrict(v any) error {
+	return UnmarshalFromReaderStrict(v, r)
+}
+
 // ReadByteArray reads byte sequences and returns as a slice of byte or any error encountered.
 // The returned bytes could be nil.
 func (r *Reader) ReadByteArray() ([]byte, error) {
@@ -9,14 +9,17 @@ package binary
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"io"
 	"math"
+	"math/big"
 	"math/rand"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
 	"github.com/oracle/nosql-go-sdk/nosqldb/types"
 	"github.com/stretchr/testify/suite"
 )
@@ -114,6 +117,52 @@ func TestReadWrite(t *testing.T) {
 	suite.Run(t, &ReadWriteTestSuite{})
 }
 
+func (suite *ReadWriteTestSuite) TestWriteWithContext() {
+	arr := make([]types.FieldValue, 10000)
+	for i := range arr {
+		arr[i] = "Oracle NoSQL Database"
+	}
+	mv := types.NewMapValue(map[string]interface{}{"array": arr})
+
+	// A small value is written without checking the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w := NewWriter()
+	w.SetContext(ctx)
+	_, err := w.WriteMap(types.NewMapValue(map[string]interface{}{"k": 1}))
+	suite.NoErrorf(err, "WriteMap() got error %v", err)
+
+	// Large maps and arrays are not written when the context is done.
+	_, err = w.WriteMap(mv)
+	suite.Equalf(context.Canceled, err, "WriteMap() got unexpected error")
+	w.Reset()
+	_, err = w.WriteArray(arr)
+	suite.Equalf(context.Canceled, err, "WriteArray() got unexpected error")
+
+	w = NewWriter()
+	w.SetContext(context.Background())
+	_, err = w.WriteMap(mv)
+	suite.NoErrorf(err, "WriteMap() got error %v", err)
+}
+
+func (suite *ReadWriteTestSuite) TestReadMapWithPool() {
+	w := NewWriter()
+	mv := types.NewOrderedMapValue().Put("id", 1).Put("name", "Jack")
+	_, err := w.WriteFieldValue(mv)
+	suite.Require().NoError(err)
+
+	pool := &types.MapValuePool{Debug: true}
+	r := NewReader(bytes.NewBuffer(w.Bytes()))
+	r.SetMapValuePool(pool)
+	v, err := r.ReadFieldValue()
+	suite.Require().NoErrorf(err, "ReadFieldValue() got error %v", err)
+	suite.Equal(mv.Map(), v.(*types.MapValue).Map())
+
+	// The map is taken from the pool, so releasing it makes its use panic.
+	pool.Put(v.(*types.MapValue))
+	suite.Panics(func() { v.(*types.MapValue).Get("id") })
+}
+
 func (suite *ReadWriteTestSuite) TestReadWriteByte() {
 	w := NewWriter()
 	tests := []byte{0, 1, math.MaxUint8}
@@ -586,3 +635,218 @@ func (suite *ReadWriteTestSuite) TestReadWriteStruct() {
 	suite.Equalf(io.EOF, err, "ReadInt() got unexpected error")
 
 }
+
+func (suite *ReadWriteTestSuite) TestReadWriteStructWithLayout() {
+	type address struct {
+		Zip    int    `nosql:"zip"`
+		City   string `nosql:"city"`
+		Street string `nosql:"street"`
+	}
+	type row struct {
+		Info     map[string]int `nosql:"info"`
+		Home     address        `nosql:"home"`
+		Previous []*address     `nosql:"previous"`
+		ID       int            `nosql:"id"`
+	}
+
+	addrLayout := &proto.RecordLayout{Fields: []string{"street", "City", "zip"}}
+	layout := &proto.RecordLayout{
+		Fields: []string{"id", "home", "previous", "info"},
+		Nested: map[string]*proto.RecordLayout{
+			"home":     addrLayout,
+			"previous": addrLayout,
+		},
+	}
+	in := &row{
+		ID:       1,
+		Home:     address{Zip: 94065, City: "Redwood City", Street: "Oracle Pkwy"},
+		Previous: []*address{{Zip: 10001, City: "New York"}},
+		Info:     map[string]int{"z": 1, "a": 2},
+	}
+
+	keys := func(v interface{}) (keys []string) {
+		mv := v.(*types.MapValue)
+		for i := 1; i <= mv.Len(); i++ {
+			k, _, _ := mv.GetByIndex(i)
+			keys = append(keys, k)
+		}
+		return keys
+	}
+
+	w := NewWriter()
+	_, err := w.WriteStructValueWithLayout(in, layout)
+	suite.Require().NoErrorf(err, "WriteStructValueWithLayout() got error %v", err)
+	_, err = w.WriteStructValue(in)
+	suite.Require().NoErrorf(err, "WriteStructValue() got error %v", err)
+
+	// The fields are written in the order of the layout.
+	r := NewReader(bytes.NewBuffer(w.Bytes()))
+	v, err := r.ReadFieldValue()
+	suite.Require().NoErrorf(err, "ReadFieldValue() got error %v", err)
+	suite.Equal([]string{"id", "home", "previous", "info"}, keys(v))
+	home, _ := v.(*types.MapValue).Get("home")
+	suite.Equal([]string{"street", "city", "zip"}, keys(home))
+	prev, _ := v.(*types.MapValue).Get("previous")
+	suite.Equal([]string{"street", "city", "zip"}, keys(prev.([]types.FieldValue)[0]))
+	info, _ := v.(*types.MapValue).Get("info")
+	suite.Equal([]string{"a", "z"}, keys(info))
+
+	// Without a layout, the fields are written in declaration order.
+	v, err = r.ReadFieldValue()
+	suite.Require().NoErrorf(err, "ReadFieldValue() got error %v", err)
+	suite.Equal([]string{"info", "home", "previous", "id"}, keys(v))
+	home, _ = v.(*types.MapValue).Get("home")
+	suite.Equal([]string{"zip", "city", "street"}, keys(home))
+
+	// The struct is read back regardless of the order of the fields.
+	r = NewReader(bytes.NewBuffer(w.Bytes()))
+	out := &row{}
+	err = UnmarshalFromReader(out, r)
+	if suite.NoErrorf(err, "UnmarshalFromReader() got error %v", err) {
+		suite.Equal(in.Home, out.Home)
+		suite.Equal(in.Previous, out.Previous)
+		suite.Equal(in.Info, out.Info)
+		suite.Equal(in.ID, out.ID)
+	}
+}
+
+func (suite *ReadWriteTestSuite) TestReadWriteNulls() {
+	type nulls struct {
+		JSONNull types.FieldValue
+		SQLNull  types.FieldValue
+		Absent   types.FieldValue
+		Nil      interface{}
+		Ptr      *int
+		Str      string
+	}
+
+	// Absent fields of a struct and entries of a map are omitted.
+	w := NewWriter()
+	in := &nulls{
+		JSONNull: types.JSONNullValueInstance,
+		SQLNull:  types.SQLNull,
+		Absent:   types.Absent,
+		Str:      "s",
+	}
+	err := MarshalToWriter(in, w)
+	suite.Require().NoErrorf(err, "MarshalToWriter() got error %v", err)
+	mv := types.NewMapValue(map[string]interface{}{"a": types.Absent, "j": nil, "s": types.SQLNull})
+	_, err = w.WriteFieldValue(mv)
+	suite.Require().NoErrorf(err, "WriteFieldValue() got error %v", err)
+
+	r := NewReader(bytes.NewBuffer(w.Bytes()))
+	out := &nulls{Absent: "unchanged", Str: "x"}
+	err = UnmarshalFromReader(out, r)
+	if suite.NoErrorf(err, "UnmarshalFromReader() got error %v", err) {
+		// A nil interface is written as an SQL NULL, which is decoded as
+		// nil into an interface{} that is not a types.FieldValue.
+		suite.Equal(&nulls{
+			JSONNull: types.JSONNullValueInstance,
+			SQLNull:  types.SQLNull,
+			Absent:   "unchanged",
+			Str:      "s",
+		}, out)
+	}
+
+	v, err := r.ReadFieldValue()
+	if suite.NoErrorf(err, "ReadFieldValue() got error %v", err) {
+		suite.Equal(map[string]interface{}{
+			"j": types.JSONNullValueInstance,
+			"s": types.NullValueInstance,
+		}, v.(*types.MapValue).Map())
+	}
+
+	// Len counts the absent entry, which is not written.
+	suite.Equal(3, mv.Len())
+
+	// Nulls are decoded as nil into elements of type interface{}.
+	var elems struct {
+		Plain  []interface{}
+		Values []types.FieldValue
+	}
+	err = DecodeMapValue(&elems, types.NewMapValue(map[string]interface{}{
+		"Plain":  []types.FieldValue{types.SQLNull, types.JSONNullValueInstance},
+		"Values": []types.FieldValue{types.SQLNull, types.JSONNullValueInstance},
+	}))
+	if suite.NoErrorf(err, "DecodeMapValue() got error %v", err) {
+		suite.Equal([]interface{}{nil, nil}, elems.Plain)
+		suite.Equal([]types.FieldValue{types.SQLNull, types.JSONNullValueInstance}, elems.Values)
+	}
+
+	// Absent is not a valid array element.
+	absentElems := []struct {
+		desc  string
+		write func() error
+	}{
+		{"WriteFieldValue", func() error {
+			_, err := NewWriter().WriteFieldValue([]types.FieldValue{types.Absent})
+			return err
+		}},
+		{"MarshalToWriter", func() error {
+			return MarshalToWriter(&struct{ A []types.FieldValue }{[]types.FieldValue{types.Absent}}, NewWriter())
+		}},
+	}
+	for _, r := range absentElems {
+		suite.Errorf(r.write(), "%s() should fail for an absent array element", r.desc)
+	}
+}
+
+func (suite *ReadWriteTestSuite) TestDecodeStrict() {
+	type row struct {
+		ID    int32
+		Score float32
+		Total float64
+	}
+
+	tests := []struct {
+		desc     string
+		value    *types.MapValue
+		lenient  row
+		strictOK bool
+	}{
+		{"exact values", types.NewMapValue(map[string]interface{}{"id": 1, "score": 0.5, "total": new(big.Rat).SetFrac64(1, 4)}),
+			row{1, 0.5, 0.25}, true},
+		{"unknown column", types.NewMapValue(map[string]interface{}{"id": 1, "name": "n"}),
+			row{ID: 1}, false},
+		{"int64 to int32", types.NewMapValue(map[string]interface{}{"id": int64(math.MaxInt32 + 1)}),
+			row{ID: math.MinInt32}, false},
+		{"double to float32", types.NewMapValue(map[string]interface{}{"score": 0.1}),
+			row{Score: 0.1}, false},
+		{"NUMBER to float64", types.NewMapValue(map[string]interface{}{"total": new(big.Rat).SetFrac64(1, 3)}),
+			row{Total: 1.0 / 3}, false},
+	}
+
+	for _, r := range tests {
+		for _, strict := range []bool{false, true} {
+			var out row
+			var err error
+			if strict {
+				err = DecodeMapValueStrict(&out, r.value)
+			} else {
+				err = DecodeMapValue(&out, r.value)
+			}
+
+			w := NewWriter()
+			w.WriteFieldValue(r.value)
+			var outR row
+			var errR error
+			if strict {
+				errR = UnmarshalFromReaderStrict(&outR, NewReader(bytes.NewBuffer(w.Bytes())))
+			} else {
+				errR = UnmarshalFromReader(&outR, NewReader(bytes.NewBuffer(w.Bytes())))
+			}
+
+			if !strict || r.strictOK {
+				if suite.NoErrorf(err, "%s: DecodeMapValue(strict=%t) got error %v", r.desc, strict, err) {
+					suite.Equalf(r.lenient, out, "%s: DecodeMapValue(strict=%t) got unexpected value", r.desc, strict)
+				}
+				if suite.NoErrorf(errR, "%s: UnmarshalFromReader(strict=%t) got error %v", r.desc, strict, errR) {
+					suite.Equalf(r.lenient, outR, "%s: UnmarshalFromReader(strict=%t) got unexpected value", r.desc, strict)
+				}
+			} else {
+				suite.Errorf(err, "%s: DecodeMapValueStrict() should have failed", r.desc)
+				suite.Errorf(errR, "%s: UnmarshalFromReaderStrict() should have failed", r.desc)
+			}
+		}
+	}
+}
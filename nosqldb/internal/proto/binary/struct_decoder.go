@@ -24,7 +24,10 @@ type structDecoder struct {
 	// The underlying MapValue being decoded
 	mv *types.MapValue
 
-	// TODO: more?
+	// strict specifies whether unknown fields and values that cannot be
+	// represented exactly by the destination field are errors, see
+	// DecodeMapValueStrict.
+	strict bool
 }
 
 // decodeMap reads a structured byte sequences that represent the encoding of a
@@ -105,8 +108,8 @@ func (sr *structDecoder) decodeMap(m map[string]interface{}, v reflect.Value) er
 					}
 					subv = subv.Field(i)
 				}
-			} else {
-				//fmt.Fprintf(os.Stdout, "nosql: unknown field '%s'\n", key)
+			} else if sr.strict {
+				return fmt.Errorf("binary.structDecoder: unknown field %q for %v", key, t)
 			}
 		}
 
@@ -175,14 +178,12 @@ func (sr *structDecoder) decodeFieldValue(mv any, v reflect.Value) error {
 
 	// Handle nil values differently
 	if mv == nil {
-		v = indirect(v, true)
-		v.Set(reflect.Zero(v.Type()))
+		setNull(v, types.JSONNullValueInstance)
 		return nil
 	}
 	switch mv.(type) {
 	case *types.EmptyValue, *types.NullValue, *types.JSONNullValue:
-		v = indirect(v, true)
-		v.Set(reflect.Zero(v.Type()))
+		setNull(v, mv)
 		return nil
 	}
 
@@ -219,24 +220,19 @@ func (sr *structDecoder) decodeFieldValue(mv any, v reflect.Value) error {
 	}
 
 	if val, ok := mv.(float64); ok {
-		if v.Type().Kind() == reflect.Interface {
-			v.Set(reflect.ValueOf(&val))
-		} else {
-			v.Set(reflect.ValueOf(val))
-		}
-		return nil
+		return setDouble(v, val, sr.strict)
 	}
 
 	if val, ok := mv.(int); ok {
-		return setLong(v, int64(val))
+		return setLong(v, int64(val), sr.strict)
 	}
 
 	if val, ok := mv.(int32); ok {
-		return setLong(v, int64(val))
+		return setLong(v, int64(val), sr.strict)
 	}
 
 	if val, ok := mv.(int64); ok {
-		return setLong(v, val)
+		return setLong(v, val, sr.strict)
 	}
 
 	if s, ok := mv.(string); ok {
@@ -257,6 +253,10 @@ func (sr *structDecoder) decodeFieldValue(mv any, v reflect.Value) error {
 		return nil
 	}
 
+	if val, ok := mv.(*big.Rat); ok {
+		return setNumber(v, val, sr.strict)
+	}
+
 	if val, ok := mv.(big.Rat); ok {
 		v.Set(reflect.ValueOf(val))
 		return nil
@@ -272,6 +272,15 @@ func DecodeMapValue(v any, mv *types.MapValue) error {
 	return sr.decode(mv, v)
 }
 
+// DecodeMapValueStrict is like DecodeMapValue, but returns an error for a
+// field of the MapValue that has no corresponding struct field, and for a
+// value that cannot be represented exactly by its destination, such as a LONG
+// that overflows an int32 or a NUMBER that is not exactly a float64.
+func DecodeMapValueStrict(v any, mv *types.MapValue) error {
+	sr := &structDecoder{mv: mv, strict: true}
+	return sr.decode(mv, v)
+}
+
 func (sr *structDecoder) decode(mv *types.MapValue, v any) (err error) {
 	// catch panics
 	defer func() {
@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"os"
 	"reflect"
@@ -26,7 +27,10 @@ type StructReader struct {
 	// The underlying binary.Reader.
 	reader *Reader
 
-	// TODO: more?
+	// strict specifies whether unknown fields and values that cannot be
+	// represented exactly by the destination field are errors, see
+	// UnmarshalFromReaderStrict.
+	strict bool
 }
 
 // NewStructReader creates a reader for the binary protocol.
@@ -159,6 +163,8 @@ func (sr *StructReader) ReadMap(v reflect.Value) error {
 					}
 					subv = subv.Field(i)
 				}
+			} else if sr.strict {
+				return fmt.Errorf("binary.StructReader: unknown field %q for %v", *key, t)
 			} else {
 				fmt.Fprintf(os.Stdout, "nosql: unknown field '%s'\n", *key)
 			}
@@ -251,13 +257,21 @@ func (sr *StructReader) ReadArray(v reflect.Value) error {
 	return nil
 }
 
-func setLong(v reflect.Value, val int64) error {
+// setLong sets v to an integer value. If strict is true, it is an error if
+// the value overflows v.
+func setLong(v reflect.Value, val int64, strict bool) error {
 	switch v.Type().Kind() {
 	case reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32,
 		reflect.Uint64:
+		if strict && (val < 0 || v.OverflowUint(uint64(val))) {
+			return fmt.Errorf("value %d overflows %v", val, v.Type())
+		}
 		v.SetUint(uint64(val))
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
 		reflect.Int64:
+		if strict && v.OverflowInt(val) {
+			return fmt.Errorf("value %d overflows %v", val, v.Type())
+		}
 		v.SetInt(val)
 	case reflect.Interface:
 		v.Set(reflect.ValueOf(&val))
@@ -267,6 +281,61 @@ func setLong(v reflect.Value, val int64) error {
 	return nil
 }
 
+// setDouble sets v to a DOUBLE value. If strict is true, it is an error if
+// the value cannot be represented exactly by v.
+func setDouble(v reflect.Value, val float64, strict bool) error {
+	switch v.Type().Kind() {
+	case reflect.Float32, reflect.Float64:
+		if strict && v.Kind() == reflect.Float32 && float64(float32(val)) != val && !math.IsNaN(val) {
+			return fmt.Errorf("value %v cannot be represented exactly by %v", val, v.Type())
+		}
+		v.SetFloat(val)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(&val))
+	default:
+		v.Set(reflect.ValueOf(val))
+	}
+	return nil
+}
+
+// setNumber sets v to a NUMBER value. A NUMBER can be decoded into a big.Rat,
+// an interface, a floating point number, or an integer if it is an integer
+// value. If strict is true, it is an error if the value cannot be represented
+// exactly by v.
+func setNumber(v reflect.Value, val *big.Rat, strict bool) error {
+	switch v.Type() {
+	case ratType:
+		v.Set(reflect.ValueOf(*val))
+		return nil
+	case ratPtrType:
+		v.Set(reflect.ValueOf(val))
+		return nil
+	}
+
+	switch v.Type().Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, exact := val.Float64()
+		if strict && (!exact || v.OverflowFloat(f) || v.Kind() == reflect.Float32 && float64(float32(f)) != f) {
+			return fmt.Errorf("NUMBER value %s cannot be represented exactly by %v", val.RatString(), v.Type())
+		}
+		v.SetFloat(f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !val.IsInt() || !val.Num().IsInt64() {
+			return fmt.Errorf("NUMBER value %s cannot be represented by %v", val.RatString(), v.Type())
+		}
+		return setLong(v, val.Num().Int64(), strict)
+	default:
+		v.Set(reflect.ValueOf(val))
+	}
+	return nil
+}
+
+var (
+	ratType    = reflect.TypeOf(big.Rat{})
+	ratPtrType = reflect.TypeOf(&big.Rat{})
+)
+
 // ReadFieldValue reads a fixed or variable length of bytes, decodes them and
 // sets the result into the passed-in Value
 func (sr *StructReader) ReadFieldValue(v reflect.Value) error {
@@ -277,17 +346,14 @@ func (sr *StructReader) ReadFieldValue(v reflect.Value) error {
 
 	// Handle nil values differently
 	switch types.DbType(t) {
-	case types.JSONNull, types.Null, types.Empty:
-		if !v.IsValid() {
-			return nil
-		}
-		v = indirect(v, true)
-		v.Set(reflect.Zero(v.Type()))
-		//switch v.Kind() {
-		//case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice:
-		//v.Set(reflect.Zero(v.Type()))
-		// otherwise, ignore null for primitives/string (same as json library)
-		//}
+	case types.JSONNull:
+		setNull(v, types.JSONNullValueInstance)
+		return nil
+	case types.Null:
+		setNull(v, types.SQLNull)
+		return nil
+	case types.Empty:
+		setNull(v, types.EmptyValueInstance)
 		return nil
 	}
 
@@ -340,11 +406,7 @@ func (sr *StructReader) ReadFieldValue(v reflect.Value) error {
 		if !v.IsValid() {
 			return nil
 		}
-		if v.Type().Kind() == reflect.Interface {
-			v.Set(reflect.ValueOf(&val))
-		} else {
-			v.Set(reflect.ValueOf(val))
-		}
+		return setDouble(v, val, sr.strict)
 
 	case types.Integer:
 		val, err := sr.reader.ReadPackedInt()
@@ -354,7 +416,7 @@ func (sr *StructReader) ReadFieldValue(v reflect.Value) error {
 		if !v.IsValid() {
 			return nil
 		}
-		return setLong(v, int64(val))
+		return setLong(v, int64(val), sr.strict)
 
 	case types.Long:
 		val, err := sr.reader.ReadPackedLong()
@@ -364,7 +426,7 @@ func (sr *StructReader) ReadFieldValue(v reflect.Value) error {
 		if !v.IsValid() {
 			return nil
 		}
-		return setLong(v, val)
+		return setLong(v, val, sr.strict)
 
 	case types.String:
 		s, err := sr.reader.ReadString()
@@ -417,12 +479,12 @@ func (sr *StructReader) ReadFieldValue(v reflect.Value) error {
 			return nil
 		}
 		number, ok := new(big.Rat).SetString(*s)
-		if ok {
-			v.Set(reflect.ValueOf(number))
-		} else {
+		if !ok {
 			// Return as a string.
 			v.SetString(*s)
+			return nil
 		}
+		return setNumber(v, number, sr.strict)
 
 	default:
 		return fmt.Errorf("binary.StructReader: unsupported field value %v of type %[1]T", t)
@@ -435,6 +497,15 @@ func UnmarshalFromReader(v any, r *Reader) error {
 	return sr.Unmarshal(v)
 }
 
+// UnmarshalFromReaderStrict is like UnmarshalFromReader, but returns an error
+// for a field of a map value that has no corresponding struct field, and for
+// a value that cannot be represented exactly by its destination, such as a
+// LONG that overflows an int32 or a NUMBER that is not exactly a float64.
+func UnmarshalFromReaderStrict(v any, r *Reader) error {
+	sr := &StructReader{reader: r, strict: true}
+	return sr.Unmarshal(v)
+}
+
 func (sr *StructReader) Unmarshal(v any) (err error) {
 	// catch panics
 	defer func() {
@@ -458,6 +529,25 @@ func (sr *StructReader) Unmarshal(v any) (err error) {
 	return sr.ReadFieldValue(rv)
 }
 
+// fieldValueType is the type of types.FieldValue.
+var fieldValueType = reflect.TypeOf((*types.FieldValue)(nil)).Elem()
+
+// setNull sets v, the destination of a JSON null, an SQL NULL or an empty
+// value. A types.FieldValue receives null, the value that represents it, so
+// that the kinds of nulls can be distinguished; other destinations, including
+// a plain interface{}, are set to their zero value.
+func setNull(v reflect.Value, null types.FieldValue) {
+	if !v.IsValid() {
+		return
+	}
+	v = indirect(v, true)
+	if v.Type() == fieldValueType {
+		v.Set(reflect.ValueOf(null))
+		return
+	}
+	v.Set(reflect.Zero(v.Type()))
+}
+
 // indirect walks down v allocating pointers as needed,
 // until it gets to a non-pointer.
 // If decodingNull is true, indirect stops at the first settable pointer so it
@@ -24,6 +24,7 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
 	"github.com/oracle/nosql-go-sdk/nosqldb/types"
 )
 
@@ -50,7 +51,10 @@ import (
 // Struct values encode as NoSQL MapValues.
 // Each exported struct field becomes a member of the object, using the
 // field name as the object key, unless the field is omitted for one of the
-// reasons given below.
+// reasons given below. The members are written in the order of the fields of
+// the struct. Nested structs may be bound to RECORD values; see
+// [Writer.WriteStructValueWithLayout] to write their members in the order of
+// the fields of the RECORD instead.
 //
 // The encoding of each struct field can be customized by the format string
 // stored under the "nosql" key in the struct field's tag.
@@ -160,9 +164,13 @@ func Marshal(v any) ([]byte, error) {
 // MarshalToWriter does the same as above, but writes to
 // the existing underlying byte buffer in the given Writer.
 func MarshalToWriter(v any, w *Writer) error {
+	return marshalToWriter(v, w, encOpts{escapeHTML: true})
+}
+
+func marshalToWriter(v any, w *Writer, opts encOpts) error {
 	e := &encodeState{ptrSeen: make(map[any]struct{})}
 	e.buf = w.buf
-	err := e.marshal(v, encOpts{escapeHTML: true})
+	err := e.marshal(v, opts)
 	if err != nil {
 		return err
 	}
@@ -282,6 +290,9 @@ type encOpts struct {
 	//quoted bool
 	// escapeHTML causes '<', '>', and '&' to be escaped in NoSQL strings.
 	escapeHTML bool
+	// layout specifies the order of the fields of the struct being encoded,
+	// if it is bound to a RECORD value.
+	layout *proto.RecordLayout
 }
 
 type encoderFunc func(e *encodeState, v reflect.Value, opts encOpts)
@@ -351,12 +362,42 @@ func newTypeEncoder(t reflect.Type) encoderFunc {
 	case reflect.Array:
 		return newArrayEncoder(t)
 	case reflect.Pointer:
+		if nullTypes[t] {
+			return nullEncoder
+		}
 		return newPtrEncoder(t)
 	default:
 		return unsupportedTypeEncoder
 	}
 }
 
+// nullTypes are the types of the values that represent a JSON null, an SQL
+// NULL, an empty value and an absent field.
+var nullTypes = map[reflect.Type]bool{
+	reflect.TypeOf(types.JSONNullValueInstance): true,
+	reflect.TypeOf(types.NullValueInstance):     true,
+	reflect.TypeOf(types.EmptyValueInstance):    true,
+	reflect.TypeOf(types.Absent):                true,
+}
+
+// nullEncoder encodes the values of nullTypes. Absent values are omitted by
+// the struct and map encoders, and are an error elsewhere.
+func nullEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	if _, err := e.WriteFieldValue(v.Interface()); err != nil {
+		e.error(err)
+	}
+}
+
+// isAbsent reports whether v is an interface or a pointer that holds
+// types.Absent.
+func isAbsent(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		return !v.IsNil() && types.IsAbsent(v.Interface())
+	}
+	return false
+}
+
 func invalidValueEncoder(e *encodeState, v reflect.Value, _ encOpts) {
 	e.writeOneByte(byte(types.Null))
 }
@@ -442,10 +483,15 @@ func (se structEncoder) encode(e *encodeState, v reflect.Value, opts encOpts) {
 		e.error(fmt.Errorf("nosql: error writing: %v", err))
 	}
 
+	fields := se.fields.list
+	if opts.layout != nil {
+		fields = orderFields(fields, opts.layout)
+	}
+
 	numFields := 0
 FieldLoop:
-	for i := range se.fields.list {
-		f := &se.fields.list[i]
+	for i := range fields {
+		f := &fields[i]
 
 		// Find the nested struct field by following f.index.
 		fv := v
@@ -459,7 +505,7 @@ FieldLoop:
 			fv = fv.Field(i)
 		}
 
-		if f.omitEmpty && isEmptyValue(fv) {
+		if f.omitEmpty && isEmptyValue(fv) || isAbsent(fv) {
 			continue
 		}
 
@@ -469,8 +515,10 @@ FieldLoop:
 			e.error(fmt.Errorf("nosql: error writing: %v", err))
 		}
 
-		// write field value
-		f.encoder(e, fv, opts)
+		// write field value, with the layout of its RECORD values if any
+		fopts := opts
+		fopts.layout = opts.layout.NestedLayout(f.name)
+		f.encoder(e, fv, fopts)
 		numFields += 1
 	}
 	// Calculate the number of bytes consumed by the struct and overwrite
@@ -485,6 +533,29 @@ FieldLoop:
 	//return w.Size() - off, nil
 }
 
+// orderFields returns the fields in the order of the fields of the RECORD
+// described by layout. The fields that are not fields of the RECORD follow, in
+// declaration order.
+func orderFields(list []field, layout *proto.RecordLayout) []field {
+	pos := make([]int, len(list))
+	ordered := make([]int, len(list))
+	for i := range list {
+		if pos[i] = layout.Index(list[i].name); pos[i] < 0 {
+			pos[i] = len(layout.Fields)
+		}
+		ordered[i] = i
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return pos[ordered[i]] < pos[ordered[j]]
+	})
+
+	fields := make([]field, len(list))
+	for i, j := range ordered {
+		fields[i] = list[j]
+	}
+	return fields
+}
+
 func newStructEncoder(t reflect.Type) encoderFunc {
 	se := structEncoder{fields: cachedTypeFields(t)}
 	return se.encode
@@ -523,6 +594,14 @@ func (me mapEncoder) encode(e *encodeState, v reflect.Value, opts encOpts) {
 		}
 		sv[i].v = mi.Value()
 	}
+	// Omit the absent entries.
+	present := sv[:0]
+	for _, kv := range sv {
+		if !isAbsent(kv.v) {
+			present = append(present, kv)
+		}
+	}
+	sv = present
 	sort.Slice(sv, func(i, j int) bool {
 		return sv[i].ks < sv[j].ks
 	})
@@ -8,12 +8,14 @@
 package binary
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"sort"
 	"strconv"
 	"time"
 	"unicode/utf8"
@@ -30,8 +32,23 @@ import (
 type Writer struct {
 	// The underlying byte buffer.
 	buf []byte
+
+	// sortMapKeys specifies whether the entries of MapValues are written in
+	// the sorted order of their keys.
+	sortMapKeys bool
+
+	// ctx is checked while the entries of maps and arrays are written, so
+	// that the encoding of large values stops when it is done.
+	ctx context.Context
+
+	// checkedSize is the size of the buffer when ctx was last checked.
+	checkedSize int
 }
 
+// contextCheckInterval is the number of bytes written between the checks of
+// the context of a Writer.
+const contextCheckInterval = 64 * 1024
+
 // Initial capacity for the buffer.
 const initCap int = 64
 
@@ -42,6 +59,32 @@ func NewWriter() *Writer {
 	}
 }
 
+// SetSortMapKeys specifies whether the entries of MapValues are written in
+// the sorted order of their keys, rather than in the unspecified iteration
+// order of Go maps, so that the encoding of a value is always the same.
+func (w *Writer) SetSortMapKeys(sortMapKeys bool) {
+	w.sortMapKeys = sortMapKeys
+}
+
+// SetContext sets the context that is checked while the entries of maps and
+// arrays are written. When the context is done, the write returns the error
+// of the context. The context is checked every 64KB written, so that small
+// values are written without overhead.
+func (w *Writer) SetContext(ctx context.Context) {
+	w.ctx = ctx
+	w.checkedSize = len(w.buf)
+}
+
+// checkContext returns the error of the context of the writer if it is done
+// and at least contextCheckInterval bytes were written since the last check.
+func (w *Writer) checkContext() error {
+	if w.ctx == nil || len(w.buf)-w.checkedSize < contextCheckInterval {
+		return nil
+	}
+	w.checkedSize = len(w.buf)
+	return w.ctx.Err()
+}
+
 // Write writes len(p) bytes from p to the buffer.
 func (w *Writer) Write(p []byte) (n int, err error) {
 	off := w.ensure(len(p))
@@ -91,6 +134,7 @@ func (w *Writer) Reset() {
 	if len(w.buf) > 0 {
 		w.buf = w.buf[:0]
 	}
+	w.checkedSize = 0
 }
 
 // WriteInt16 encodes and writes the int16 value to the buffer.
@@ -182,6 +226,22 @@ func (w *Writer) WriteBoolean(value bool) (int, error) {
 	return w.writeOneByte(0)
 }
 
+// writeMapEntry writes the key and value of a MapValue entry, unless the
+// value is absent.
+func (w *Writer) writeMapEntry(k string, v interface{}) error {
+	if types.IsAbsent(v) {
+		return nil
+	}
+	if err := w.checkContext(); err != nil {
+		return err
+	}
+	if _, err := w.WriteString(&k); err != nil {
+		return err
+	}
+	_, err := w.WriteFieldValue(v)
+	return err
+}
+
 // WriteMap encodes and writes the map value to the buffer.
 func (w *Writer) WriteMap(value *types.MapValue) (n int, err error) {
 	if value == nil {
@@ -198,21 +258,25 @@ func (w *Writer) WriteMap(value *types.MapValue) (n int, err error) {
 	}
 
 	startOff := off + c
-	// The number of entries in the MapValue.
-	size := value.Len()
-	_, err = w.WriteInt(size)
+	// The absent fields are omitted, so the number of entries written is that
+	// of the other fields, which can be less than value.Len().
+	m := value.Map()
+	keys := make([]string, 0, len(m))
+	for k, v := range m {
+		if !types.IsAbsent(v) {
+			keys = append(keys, k)
+		}
+	}
+	if w.sortMapKeys {
+		sort.Strings(keys)
+	}
+	_, err = w.WriteInt(len(keys))
 	if err != nil {
 		return w.Size() - off, err
 	}
 
-	for k, v := range value.Map() {
-		_, err = w.WriteString(&k)
-		if err != nil {
-			return w.Size() - off, err
-		}
-
-		_, err = w.WriteFieldValue(v)
-		if err != nil {
+	for _, k := range keys {
+		if err = w.writeMapEntry(k, m[k]); err != nil {
 			return w.Size() - off, err
 		}
 	}
@@ -244,6 +308,9 @@ func (w *Writer) WriteArray(value []types.FieldValue) (n int, err error) {
 	}
 
 	for _, v := range value {
+		if err = w.checkContext(); err != nil {
+			return w.Size() - off, err
+		}
 		_, err = w.WriteFieldValue(v)
 		if err != nil {
 			return w.Size() - off, err
@@ -542,6 +609,9 @@ func (w *Writer) WriteFieldValue(value types.FieldValue) (int, error) {
 	case nil:
 		return w.writeOneByte(byte(types.JSONNull))
 
+	case *types.AbsentValue:
+		return 0, errors.New("binary.Writer: an absent value can only be the value of a map entry")
+
 	default:
 		return 0, fmt.Errorf("binary.Writer: unsupported field value %v of type %[1]T", v)
 	}
@@ -677,9 +747,16 @@ func (w *Writer) writeOneByte(b byte) (n int, err error) {
 }
 
 func (w *Writer) WriteStructValue(value any) (n int, err error) {
+	return w.WriteStructValueWithLayout(value, nil)
+}
+
+// WriteStructValueWithLayout writes a native struct, with the fields of the
+// structs bound to RECORD values in the order described by layout. If layout
+// is nil, the fields are written in declaration order.
+func (w *Writer) WriteStructValueWithLayout(value any, layout *proto.RecordLayout) (n int, err error) {
 	// get previous size of writer buffer
 	psize := w.Size()
-	err = MarshalToWriter(value, w)
+	err = marshalToWriter(value, w, encOpts{escapeHTML: true, layout: layout})
 	if err != nil {
 		return 0, err
 	}
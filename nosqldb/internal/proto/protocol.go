@@ -195,6 +195,10 @@ type Reader interface {
 	// ReadStructValue reads a native struct
 	ReadStructValue(value any) error
 
+	// ReadStructValueStrict reads a native struct, and returns an error for
+	// unknown fields and values that cannot be represented exactly
+	ReadStructValueStrict(value any) error
+
 	// ReadByteArray reads an array of bytes.
 	// The returned bytes may be nil.
 	ReadByteArray() ([]byte, error)
@@ -286,6 +290,10 @@ type Writer interface {
 	// WriteStructValue writes a native struct
 	WriteStructValue(value any) (int, error)
 
+	// WriteStructValueWithLayout writes a native struct, with the fields of
+	// the structs bound to RECORD values in the order described by layout.
+	WriteStructValueWithLayout(value any, layout *RecordLayout) (int, error)
+
 	// Size reports the number of bytes written by the writer.
 	Size() int
 
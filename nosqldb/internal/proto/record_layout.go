@@ -0,0 +1,50 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package proto
+
+import "strings"
+
+// RecordLayout describes the order of the fields of a RECORD, as declared in
+// the schema of a table. It is used to write native structs bound to RECORD
+// values with their fields in the declared order, regardless of the order of
+// the fields of the structs.
+//
+// The layout of a row describes the columns of the table, so that the layouts
+// of the RECORD columns can be looked up by column name.
+type RecordLayout struct {
+	// Fields specifies the names of the fields in declared order.
+	Fields []string
+
+	// Nested specifies the layouts of the values of the fields that are
+	// RECORD values, or ARRAY or MAP values of RECORD values, keyed by the
+	// lower case field name.
+	Nested map[string]*RecordLayout
+}
+
+// Index returns the position of the specified field in the RECORD, or -1 if
+// the RECORD has no such field. Field names are case-insensitive.
+func (l *RecordLayout) Index(name string) int {
+	if l == nil {
+		return -1
+	}
+	for i, f := range l.Fields {
+		if strings.EqualFold(f, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// NestedLayout returns the layout of the RECORD values of the specified
+// field, or nil if its values are not RECORD values.
+func (l *RecordLayout) NestedLayout(name string) *RecordLayout {
+	if l == nil {
+		return nil
+	}
+	return l.Nested[strings.ToLower(name)]
+}
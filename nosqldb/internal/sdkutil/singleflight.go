@@ -0,0 +1,83 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package sdkutil
+
+import (
+	"context"
+	"sync"
+)
+
+// SingleFlight suppresses duplicate concurrent calls of a function, such as
+// the renewal of a token that many goroutines find expired at the same time,
+// so that only one call runs and the other callers wait for its result.
+//
+// The zero value is ready to use.
+type SingleFlight struct {
+	mu   sync.Mutex
+	call *flightCall
+}
+
+// flightCall represents a call of SingleFlight.Do in progress.
+type flightCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+// Do calls fn and returns its results, unless a call is already in progress,
+// in which case it waits for that call to complete and returns its results.
+// The shared reports whether the results were those of another call.
+func (g *SingleFlight) Do(fn func() (interface{}, error)) (val interface{}, err error, shared bool) {
+	g.mu.Lock()
+	if c := g.call; c != nil {
+		g.mu.Unlock()
+		<-c.done
+		return c.val, c.err, true
+	}
+	c := &flightCall{done: make(chan struct{})}
+	g.call = c
+	g.mu.Unlock()
+
+	g.run(c, fn)
+	return c.val, c.err, false
+}
+
+// DoContext is like Do, but stops waiting for the call when ctx is done, in
+// which case it returns ctx.Err(). The call runs in a new goroutine and is not
+// stopped when ctx is done, its results are returned to the other callers
+// that wait for it.
+func (g *SingleFlight) DoContext(ctx context.Context, fn func() (interface{}, error)) (val interface{}, err error, shared bool) {
+	g.mu.Lock()
+	c := g.call
+	shared = c != nil
+	if !shared {
+		c = &flightCall{done: make(chan struct{})}
+		g.call = c
+		go g.run(c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err, shared
+	case <-ctx.Done():
+		return nil, ctx.Err(), shared
+	}
+}
+
+// run calls fn for the call c, and completes c with its results.
+func (g *SingleFlight) run(c *flightCall, fn func() (interface{}, error)) {
+	defer func() {
+		g.mu.Lock()
+		g.call = nil
+		g.mu.Unlock()
+		close(c.done)
+	}()
+
+	c.val, c.err = fn()
+}
@@ -0,0 +1,93 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package sdkutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleFlight(t *testing.T) {
+	var g SingleFlight
+	var calls int32
+	release := make(chan struct{})
+	errRenew := errors.New("renewal failed")
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil, errRenew
+	}
+
+	const n = 50
+	var wg sync.WaitGroup
+	var started sync.WaitGroup
+	var numShared int32
+	started.Add(n)
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			started.Done()
+			_, err, shared := g.Do(fn)
+			if err != errRenew {
+				t.Errorf("got error %v, want %v", err, errRenew)
+			}
+			if shared {
+				atomic.AddInt32(&numShared, 1)
+			}
+		}()
+	}
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got+numShared != n || got >= n {
+		t.Errorf("got %d calls and %d shared results for %d concurrent callers", got, numShared, n)
+	}
+
+	// A call after the previous one completed runs again.
+	val, err, shared := g.Do(func() (interface{}, error) { return "token", nil })
+	if val != "token" || err != nil || shared {
+		t.Errorf("got Do() = (%v, %v, %v), want (token, nil, false)", val, err, shared)
+	}
+}
+
+func TestSingleFlightDoContext(t *testing.T) {
+	var g SingleFlight
+	release := make(chan struct{})
+	fn := func() (interface{}, error) {
+		<-release
+		return "token", nil
+	}
+
+	// The caller stops waiting when its context is canceled.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err, shared := g.DoContext(ctx, fn)
+	if err != context.DeadlineExceeded || shared {
+		t.Errorf("got DoContext() = (%v, %v), want (%v, false)", err, shared, context.DeadlineExceeded)
+	}
+
+	// The call continues, and its results are shared with the next caller.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		val, err, shared := g.DoContext(context.Background(), fn)
+		if val != "token" || err != nil || !shared {
+			t.Errorf("got DoContext() = (%v, %v, %v), want (token, nil, true)", val, err, shared)
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	<-done
+}
@@ -0,0 +1,167 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+//go:build go1.23
+
+package nosqldb
+
+import (
+	"iter"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// QueryAll returns an iterator over all results of the query, which executes
+// the query in batches as the results are consumed:
+//
+//	for row, err := range client.QueryAll(req) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(row)
+//	}
+//
+// The iteration stops after the first error. If the loop exits before all
+// results are consumed, the query is closed with QueryRequest.Close().
+//
+// This requires Go 1.23 or later.
+func (c *Client) QueryAll(req *QueryRequest) iter.Seq2[*types.MapValue, error] {
+	return func(yield func(*types.MapValue, error) bool) {
+		for {
+			res, err := c.Query(req)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			rows, err := res.GetResults()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, row := range rows {
+				if !yield(row, nil) {
+					req.Close()
+					return
+				}
+			}
+
+			if req.IsDone() {
+				return
+			}
+		}
+	}
+}
+
+// ListTablesAll returns an iterator over the names of all tables returned by
+// the ListTables operation. If req.Limit is set, the tables are retrieved in
+// pages of that size, starting at req.StartIndex. The req value is not
+// modified.
+//
+// The iteration stops after the first error.
+//
+// This requires Go 1.23 or later.
+func (c *Client) ListTablesAll(req *ListTablesRequest) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		if req == nil {
+			yield("", errNilRequest)
+			return
+		}
+
+		pageReq := *req
+		for {
+			res, err := c.ListTables(&pageReq)
+			if err != nil {
+				yield("", err)
+				return
+			}
+
+			for _, table := range res.Tables {
+				if !yield(table, nil) {
+					return
+				}
+			}
+
+			if pageReq.Limit == 0 || uint(len(res.Tables)) < pageReq.Limit {
+				return
+			}
+			pageReq.StartIndex = res.LastIndexReturned
+		}
+	}
+}
+
+// GetIndexesAll returns an iterator over the information of the indexes
+// returned by the GetIndexes operation, starting at req.StartIndex and
+// stopping after req.Limit indexes if set. The req value is not modified.
+//
+// The server returns the information of all the indexes of the table in one
+// response, so the indexes are retrieved with a single request.
+//
+// The iteration stops after the first error.
+//
+// This requires Go 1.23 or later.
+func (c *Client) GetIndexesAll(req *GetIndexesRequest) iter.Seq2[IndexInfo, error] {
+	return func(yield func(IndexInfo, error) bool) {
+		if req == nil {
+			yield(IndexInfo{}, errNilRequest)
+			return
+		}
+
+		allReq := *req
+		allReq.StartIndex, allReq.Limit = 0, 0
+		res, err := c.GetIndexes(&allReq)
+		if err != nil {
+			yield(IndexInfo{}, err)
+			return
+		}
+
+		indexes, _ := pageIndexes(res.Indexes, req.StartIndex, req.Limit)
+		for _, index := range indexes {
+			if !yield(index, nil) {
+				return
+			}
+		}
+	}
+}
+
+// TableUsageAll returns an iterator over the usage records returned by the
+// GetTableUsage operation. If req.Limit is set, the records are retrieved in
+// pages of that size, starting at req.StartIndex. The req value is not
+// modified.
+//
+// The iteration stops after the first error.
+//
+// This requires Go 1.23 or later.
+func (c *Client) TableUsageAll(req *TableUsageRequest) iter.Seq2[TableUsage, error] {
+	return func(yield func(TableUsage, error) bool) {
+		if req == nil {
+			yield(TableUsage{}, errNilRequest)
+			return
+		}
+
+		pageReq := *req
+		for {
+			res, err := c.GetTableUsage(&pageReq)
+			if err != nil {
+				yield(TableUsage{}, err)
+				return
+			}
+
+			for _, usage := range res.UsageRecords {
+				if !yield(usage, nil) {
+					return
+				}
+			}
+
+			if pageReq.Limit == 0 || uint(len(res.UsageRecords)) < pageReq.Limit {
+				return
+			}
+			pageReq.StartIndex = res.LastIndexReturned
+		}
+	}
+}
@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+//go:build go1.23
+
+package nosqldb
+
+import (
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIteratorErrors checks that the iterators yield the error of an invalid
+// request once and then stop.
+func TestIteratorErrors(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+
+	var errs []error
+	for row, err := range client.QueryAll(&QueryRequest{}) {
+		assert.Nil(t, row)
+		errs = append(errs, err)
+	}
+
+	for table, err := range client.ListTablesAll(nil) {
+		assert.Empty(t, table)
+		errs = append(errs, err)
+	}
+
+	for _, err := range client.GetIndexesAll(&GetIndexesRequest{}) {
+		errs = append(errs, err)
+	}
+
+	for _, err := range client.TableUsageAll(nil) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 4)
+	for i, err := range errs {
+		assert.Truef(t, nosqlerr.IsIllegalArgument(err), "Testcase %d: got error %v", i, err)
+	}
+}
+
+func TestGetIndexesAllPages(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	client.SetSerialVersion(3)
+	executor := &indexesExecutor{indexes: []string{"a", "b", "c", "d", "e"}}
+	client.executor = executor
+
+	req := &GetIndexesRequest{TableName: "users", StartIndex: 1, Limit: 2}
+	var names []string
+	for index, err := range client.GetIndexesAll(req) {
+		require.NoError(t, err)
+		names = append(names, index.IndexName)
+	}
+	assert.Equal(t, []string{"b", "c"}, names)
+	assert.Equal(t, 1, executor.requests, "the indexes are retrieved once")
+	assert.Equal(t, uint(1), req.StartIndex, "the request is not modified")
+
+	executor.requests = 0
+	names = nil
+	for index, err := range client.GetIndexesAll(&GetIndexesRequest{TableName: "users", StartIndex: 1}) {
+		require.NoError(t, err)
+		names = append(names, index.IndexName)
+	}
+	assert.Equal(t, []string{"b", "c", "d", "e"}, names)
+	assert.Equal(t, 1, executor.requests)
+}
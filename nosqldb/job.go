@@ -0,0 +1,515 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// CheckpointStore represents a store for the checkpoints of jobs, see Job.
+// Implementations must be safe for concurrent use.
+type CheckpointStore interface {
+	// SaveCheckpoint stores the checkpoint of the job, replacing the previous
+	// one. The data is a JSON document.
+	SaveCheckpoint(ctx context.Context, jobID string, data []byte) error
+
+	// LoadCheckpoint returns the last checkpoint stored for the job, or nil if
+	// there is none.
+	LoadCheckpoint(ctx context.Context, jobID string) ([]byte, error)
+
+	// DeleteCheckpoint deletes the checkpoint of the job, if any.
+	DeleteCheckpoint(ctx context.Context, jobID string) error
+}
+
+// FileCheckpointStore is a CheckpointStore that stores each checkpoint in a
+// file of a directory. A checkpoint is written to a temporary file that is
+// then renamed, so that a crash does not leave a partial checkpoint.
+type FileCheckpointStore struct {
+	// Dir specifies the directory of the checkpoint files. It is created if
+	// it does not exist.
+	Dir string
+}
+
+func (s *FileCheckpointStore) path(jobID string) string {
+	return filepath.Join(s.Dir, url.PathEscape(jobID)+".json")
+}
+
+// SaveCheckpoint implements the CheckpointStore interface.
+func (s *FileCheckpointStore) SaveCheckpoint(ctx context.Context, jobID string, data []byte) error {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.Dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path(jobID))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// LoadCheckpoint implements the CheckpointStore interface.
+func (s *FileCheckpointStore) LoadCheckpoint(ctx context.Context, jobID string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(jobID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// DeleteCheckpoint implements the CheckpointStore interface.
+func (s *FileCheckpointStore) DeleteCheckpoint(ctx context.Context, jobID string) error {
+	err := os.Remove(s.path(jobID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps checkpoints in memory.
+// It does not survive process restarts and is intended for tests.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string][]byte
+}
+
+// SaveCheckpoint implements the CheckpointStore interface.
+func (s *MemoryCheckpointStore) SaveCheckpoint(ctx context.Context, jobID string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkpoints == nil {
+		s.checkpoints = make(map[string][]byte)
+	}
+	s.checkpoints[jobID] = append([]byte(nil), data...)
+	return nil
+}
+
+// LoadCheckpoint implements the CheckpointStore interface.
+func (s *MemoryCheckpointStore) LoadCheckpoint(ctx context.Context, jobID string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[jobID], nil
+}
+
+// DeleteCheckpoint implements the CheckpointStore interface.
+func (s *MemoryCheckpointStore) DeleteCheckpoint(ctx context.Context, jobID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.checkpoints, jobID)
+	return nil
+}
+
+// JobCheckpoint represents the progress of a job.
+type JobCheckpoint struct {
+	// JobID represents the id of the job.
+	JobID string `json:"jobID"`
+
+	// ContinuationToken represents the position of a scan job, which is the
+	// token of the page that follows the rows processed so far.
+	ContinuationToken string `json:"continuationToken,omitempty"`
+
+	// CompletedSegments represents the indexes of the completed segments of a
+	// job, such as the batches of a write job, in increasing order.
+	CompletedSegments []int `json:"completedSegments,omitempty"`
+
+	// FailedItems represents the items that could not be processed.
+	FailedItems []FailedItem `json:"failedItems,omitempty"`
+
+	// Processed represents the number of items processed successfully.
+	Processed int64 `json:"processed"`
+
+	// Done represents whether the job has completed.
+	Done bool `json:"done"`
+
+	// UpdatedAt represents the time the checkpoint was saved.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// FailedItem represents an item that a job could not process.
+type FailedItem struct {
+	// Index represents the index of the item in the input of the job, for
+	// jobs that process a slice of items, or -1.
+	Index int `json:"index"`
+
+	// Key represents the primary key of the row that failed, in the typed
+	// JSON format of jsonutil.ToShellJSON, for jobs that process rows of a
+	// table.
+	Key string `json:"key,omitempty"`
+
+	// Error represents the error message.
+	Error string `json:"error"`
+}
+
+// KeyValue returns the primary key of the row that failed, if any.
+func (f FailedItem) KeyValue() (*types.MapValue, error) {
+	if f.Key == "" {
+		return nil, nil
+	}
+	return jsonutil.FromShellJSON(f.Key)
+}
+
+// Job represents a long-running bulk or scan job whose progress is saved to a
+// CheckpointStore, so that it can be resumed after the process restarts.
+//
+// The progress of a job consists of a continuation token, the indexes of the
+// completed segments, the items that failed and the number of items
+// processed. Client.RunScanJob and Client.RunWriteJob update it as they run
+// and save it periodically. Applications that implement their own jobs can
+// update it with the methods of Job and save it with Save.
+//
+// To resume a job after a restart, create it with the same id and store, call
+// Resume, and run it again with the same input:
+//
+//	job, err := nosqldb.NewJob("import-2024-01-02", &nosqldb.FileCheckpointStore{Dir: "checkpoints"})
+//	if _, err = job.Resume(ctx); err != nil {
+//	    return err
+//	}
+//	err = client.RunWriteJob(ctx, job, ops, shardKey, nil)
+//
+// The methods of a Job are safe for concurrent use.
+type Job struct {
+	// ID represents the id of the job, which identifies its checkpoint.
+	ID string
+
+	// Store represents the store of the checkpoints.
+	Store CheckpointStore
+
+	// SaveEvery specifies the number of segments, such as pages of a scan job
+	// or batches of a write job, that are processed between checkpoints.
+	// If set to 0, a checkpoint is saved after each segment.
+	SaveEvery int
+
+	mu        sync.Mutex
+	cp        JobCheckpoint
+	completed map[int]bool
+}
+
+// NewJob creates a job with the specified id and checkpoint store.
+func NewJob(id string, store CheckpointStore) (*Job, error) {
+	if id == "" {
+		return nil, nosqlerr.NewIllegalArgument("NewJob: id must be non-empty")
+	}
+	if store == nil {
+		return nil, nosqlerr.NewIllegalArgument("NewJob: store must be non-nil")
+	}
+	return &Job{ID: id, Store: store, cp: JobCheckpoint{JobID: id}}, nil
+}
+
+// Resume loads the last checkpoint of the job from the store, if any, and
+// reports whether there was one.
+func (j *Job) Resume(ctx context.Context) (bool, error) {
+	data, err := j.Store.LoadCheckpoint(ctx, j.ID)
+	if err != nil || data == nil {
+		return false, err
+	}
+
+	var cp JobCheckpoint
+	if err = json.Unmarshal(data, &cp); err != nil {
+		return false, fmt.Errorf("invalid checkpoint of job %s: %v", j.ID, err)
+	}
+	if cp.JobID != j.ID {
+		return false, fmt.Errorf("invalid checkpoint of job %s: it is the checkpoint of job %s", j.ID, cp.JobID)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cp = cp
+	j.completed = make(map[int]bool, len(cp.CompletedSegments))
+	for _, i := range cp.CompletedSegments {
+		j.completed[i] = true
+	}
+	return true, nil
+}
+
+// Save saves the current progress of the job to the store.
+func (j *Job) Save(ctx context.Context) error {
+	j.mu.Lock()
+	j.cp.JobID = j.ID
+	j.cp.UpdatedAt = time.Now().UTC()
+	data, err := json.Marshal(&j.cp)
+	j.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return j.Store.SaveCheckpoint(ctx, j.ID, data)
+}
+
+// Reset discards the progress of the job and deletes its checkpoint, so that
+// the job starts over when it is run again.
+func (j *Job) Reset(ctx context.Context) error {
+	j.mu.Lock()
+	j.cp = JobCheckpoint{JobID: j.ID}
+	j.completed = nil
+	j.mu.Unlock()
+	return j.Store.DeleteCheckpoint(ctx, j.ID)
+}
+
+// Checkpoint returns a copy of the current progress of the job.
+func (j *Job) Checkpoint() JobCheckpoint {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	cp := j.cp
+	cp.CompletedSegments = append([]int(nil), j.cp.CompletedSegments...)
+	cp.FailedItems = append([]FailedItem(nil), j.cp.FailedItems...)
+	return cp
+}
+
+// ContinuationToken returns the continuation token of the job.
+func (j *Job) ContinuationToken() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.cp.ContinuationToken
+}
+
+// SetContinuationToken sets the continuation token of the job.
+func (j *Job) SetContinuationToken(token string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cp.ContinuationToken = token
+}
+
+// SegmentDone reports whether the segment with the specified index has
+// completed.
+func (j *Job) SegmentDone(i int) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.completed[i]
+}
+
+// MarkSegmentDone records that the segment with the specified index has
+// completed.
+func (j *Job) MarkSegmentDone(i int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.completed[i] {
+		return
+	}
+	if j.completed == nil {
+		j.completed = make(map[int]bool)
+	}
+	j.completed[i] = true
+	j.cp.CompletedSegments = append(j.cp.CompletedSegments, i)
+	sort.Ints(j.cp.CompletedSegments)
+}
+
+// AddFailedItem records an item that could not be processed.
+func (j *Job) AddFailedItem(item FailedItem) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cp.FailedItems = append(j.cp.FailedItems, item)
+}
+
+// AddProcessed adds n to the number of items processed successfully.
+func (j *Job) AddProcessed(n int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cp.Processed += n
+}
+
+// Done reports whether the job has completed.
+func (j *Job) Done() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.cp.Done
+}
+
+// finish marks the job as completed and saves its checkpoint.
+func (j *Job) finish(ctx context.Context) error {
+	j.mu.Lock()
+	j.cp.Done = true
+	j.mu.Unlock()
+	return j.Save(ctx)
+}
+
+// segmentCompleted is called when a segment of the job is processed, and
+// saves a checkpoint every SaveEvery segments.
+func (j *Job) segmentCompleted(ctx context.Context, n *int) error {
+	*n++
+	if j.SaveEvery > 1 && *n%j.SaveEvery != 0 {
+		return nil
+	}
+	return j.Save(ctx)
+}
+
+// RunScanJob processes the rows of a table in the order of the key columns of
+// the paginator, calling fn for each row, and records its progress in the job.
+// It saves a checkpoint after every job.SaveEvery pages, and when it returns.
+//
+// If the job is resumed, the scan starts after the last row of the last page
+// that was completely processed, so rows of a page that was in progress when
+// the process stopped are processed again, and fn must be idempotent. If fn
+// returns an error for a row, the row is recorded as a failed item, with its
+// key, and the scan continues. If a page cannot be read, or ctx is done,
+// RunScanJob saves a checkpoint and returns the error; running the job again
+// retries the page.
+//
+// If the job is done, RunScanJob returns immediately.
+func (c *Client) RunScanJob(ctx context.Context, job *Job, p *KeysetPaginator, fn func(row *types.MapValue) error) error {
+	if job == nil || p == nil || fn == nil {
+		return nosqlerr.NewIllegalArgument("RunScanJob: job, paginator and fn must be non-nil")
+	}
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	page := func(token string) ([]*types.MapValue, string, error) {
+		return p.Page(c, token)
+	}
+	key := func(row *types.MapValue) string {
+		k := types.NewOrderedMapValue()
+		for _, kc := range p.KeyColumns {
+			v, _ := row.Get(kc.Name)
+			k.Put(kc.Name, v)
+		}
+		s, _ := jsonutil.ToShellJSON(k)
+		return s
+	}
+	return runScanJob(ctx, job, page, key, fn)
+}
+
+func runScanJob(ctx context.Context, job *Job, page func(token string) ([]*types.MapValue, string, error),
+	key func(row *types.MapValue) string, fn func(row *types.MapValue) error) error {
+
+	if job.Done() {
+		return nil
+	}
+
+	var pages int
+	for {
+		if err := ctx.Err(); err != nil {
+			job.Save(ctx)
+			return err
+		}
+
+		rows, next, err := page(job.ContinuationToken())
+		if err != nil {
+			job.Save(ctx)
+			return err
+		}
+
+		var processed int64
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				job.AddFailedItem(FailedItem{Index: -1, Key: key(row), Error: err.Error()})
+				continue
+			}
+			processed++
+		}
+		job.AddProcessed(processed)
+
+		if next == "" {
+			return job.finish(ctx)
+		}
+		job.SetContinuationToken(next)
+		if err = job.segmentCompleted(ctx, &pages); err != nil {
+			return err
+		}
+	}
+}
+
+// RunWriteJob executes write operations in batches planned by
+// PlanWriteBatches, and records its progress in the job. Each batch is a
+// segment of the job, identified by its index in the plan, so the job must be
+// run again with the same operations, shard key and options to resume it. It
+// saves a checkpoint after every job.SaveEvery batches, and when it returns.
+//
+// The operations that fail, such as a PutRequest with PutIfAbsent for a row
+// that exists, are recorded as failed items with their index in ops. If a
+// WriteMultiple request fails, or ctx is done, RunWriteJob saves a checkpoint
+// and returns the error; running the job again retries the batch.
+//
+// If the job is done, RunWriteJob returns immediately.
+func (c *Client) RunWriteJob(ctx context.Context, job *Job, ops []*WriteOperation, shardKey []string, opts *BatchPlanOptions) error {
+	if job == nil {
+		return nosqlerr.NewIllegalArgument("RunWriteJob: job must be non-nil")
+	}
+
+	batches, err := PlanWriteBatches(ops, shardKey, opts)
+	if err != nil {
+		return err
+	}
+	return runWriteJob(ctx, job, batches, c.WriteMultipleWithContext)
+}
+
+func runWriteJob(ctx context.Context, job *Job, batches []*WriteBatch,
+	write func(ctx context.Context, req *WriteMultipleRequest) (*WriteMultipleResult, error)) error {
+
+	if job.Done() {
+		return nil
+	}
+
+	var n int
+	for i, b := range batches {
+		if job.SegmentDone(i) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			job.Save(ctx)
+			return err
+		}
+
+		req := &WriteMultipleRequest{Operations: b.Operations}
+		res, err := write(ctx, req)
+		if err != nil {
+			job.Save(ctx)
+			return err
+		}
+
+		var processed int64
+		for j := range b.Operations {
+			if j < len(res.ResultSet) && !res.ResultSet[j].Success {
+				job.AddFailedItem(FailedItem{Index: b.Indexes[j], Error: "the operation did not succeed"})
+				continue
+			}
+			processed++
+		}
+		if !res.IsSuccess() && res.FailedOperationIndex >= 0 && res.FailedOperationIndex < len(b.Operations) {
+			// The batch was aborted: none of its operations were executed.
+			processed = 0
+			job.AddFailedItem(FailedItem{
+				Index: b.Indexes[res.FailedOperationIndex],
+				Error: "the operation failed and aborted its batch",
+			})
+			for j, idx := range b.Indexes {
+				if j != res.FailedOperationIndex {
+					job.AddFailedItem(FailedItem{Index: idx, Error: "the batch was aborted"})
+				}
+			}
+		}
+		job.AddProcessed(processed)
+
+		job.MarkSegmentDone(i)
+		if err = job.segmentCompleted(ctx, &n); err != nil {
+			return err
+		}
+	}
+	return job.finish(ctx)
+}
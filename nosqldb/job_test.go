@@ -0,0 +1,170 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobSaveResume(t *testing.T) {
+	ctx := context.Background()
+	stores := []CheckpointStore{&MemoryCheckpointStore{}, &FileCheckpointStore{Dir: t.TempDir()}}
+	for _, store := range stores {
+		job, err := NewJob("job/1", store)
+		if !assert.NoErrorf(t, err, "NewJob") {
+			continue
+		}
+		ok, err := job.Resume(ctx)
+		assert.NoErrorf(t, err, "Resume")
+		assert.Falsef(t, ok, "Resume with no checkpoint")
+
+		job.SetContinuationToken("token")
+		job.MarkSegmentDone(3)
+		job.MarkSegmentDone(1)
+		job.MarkSegmentDone(3)
+		job.AddFailedItem(FailedItem{Index: 2, Error: "failed"})
+		job.AddProcessed(10)
+		assert.NoErrorf(t, job.Save(ctx), "Save")
+
+		resumed, _ := NewJob("job/1", store)
+		ok, err = resumed.Resume(ctx)
+		assert.NoErrorf(t, err, "Resume")
+		assert.Truef(t, ok, "Resume")
+		cp := resumed.Checkpoint()
+		assert.Equalf(t, "token", cp.ContinuationToken, "ContinuationToken")
+		assert.Equalf(t, []int{1, 3}, cp.CompletedSegments, "CompletedSegments")
+		assert.Equalf(t, []FailedItem{{Index: 2, Error: "failed"}}, cp.FailedItems, "FailedItems")
+		assert.Equalf(t, int64(10), cp.Processed, "Processed")
+		assert.Truef(t, resumed.SegmentDone(3), "SegmentDone(3)")
+		assert.Falsef(t, resumed.SegmentDone(2), "SegmentDone(2)")
+
+		// A checkpoint of another job is rejected.
+		other, _ := NewJob("job/2", store)
+		data, _ := store.LoadCheckpoint(ctx, "job/1")
+		store.SaveCheckpoint(ctx, "job/2", data)
+		_, err = other.Resume(ctx)
+		assert.Errorf(t, err, "Resume with the checkpoint of another job")
+
+		assert.NoErrorf(t, resumed.Reset(ctx), "Reset")
+		ok, err = resumed.Resume(ctx)
+		assert.Falsef(t, ok || err != nil, "Resume after Reset")
+	}
+
+	_, err := NewJob("", &MemoryCheckpointStore{})
+	assert.Truef(t, nosqlerr.IsIllegalArgument(err), "NewJob with empty id")
+	_, err = NewJob("job", nil)
+	assert.Truef(t, nosqlerr.IsIllegalArgument(err), "NewJob with nil store")
+}
+
+func TestRunScanJob(t *testing.T) {
+	ctx := context.Background()
+	pages := map[string][]int{"": {1, 2}, "t1": {3, 4}, "t2": {5}}
+	next := map[string]string{"": "t1", "t1": "t2", "t2": ""}
+	failPage := "t1"
+	page := func(token string) ([]*types.MapValue, string, error) {
+		if token == failPage {
+			return nil, "", errors.New("page error")
+		}
+		var rows []*types.MapValue
+		for _, id := range pages[token] {
+			rows = append(rows, types.NewMapValue(map[string]interface{}{"id": id}))
+		}
+		return rows, next[token], nil
+	}
+	key := func(row *types.MapValue) string {
+		id, _ := row.GetInt("id")
+		return strconv.Itoa(id)
+	}
+	var seen []int
+	fn := func(row *types.MapValue) error {
+		id, _ := row.GetInt("id")
+		seen = append(seen, id)
+		if id == 4 {
+			return errors.New("row error")
+		}
+		return nil
+	}
+
+	store := &MemoryCheckpointStore{}
+	job, _ := NewJob("scan", store)
+	assert.Error(t, runScanJob(ctx, job, page, key, fn))
+
+	// Resume the job after a restart.
+	failPage = ""
+	job, _ = NewJob("scan", store)
+	ok, err := job.Resume(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "t1", job.ContinuationToken())
+	require.NoError(t, runScanJob(ctx, job, page, key, fn))
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, seen)
+	cp := job.Checkpoint()
+	assert.True(t, cp.Done)
+	assert.Equal(t, int64(4), cp.Processed)
+	assert.Equal(t, []FailedItem{{Index: -1, Key: "4", Error: "row error"}}, cp.FailedItems)
+
+	// A job that is done is not run again.
+	require.NoError(t, runScanJob(ctx, job, page, key, fn))
+	assert.Len(t, seen, 5)
+}
+
+func TestRunWriteJob(t *testing.T) {
+	ctx := context.Background()
+	var ops []*WriteOperation
+	for i := 0; i < 6; i++ {
+		ops = append(ops, &WriteOperation{PutRequest: &PutRequest{
+			TableName: "t",
+			Value:     types.NewMapValue(map[string]interface{}{"sk": i % 2, "id": i}),
+		}})
+	}
+	batches, err := PlanWriteBatches(ops, []string{"sk"}, &BatchPlanOptions{MaxOperations: 2})
+	require.NoError(t, err)
+	require.Len(t, batches, 4)
+
+	var calls int
+	write := func(ctx context.Context, req *WriteMultipleRequest) (*WriteMultipleResult, error) {
+		calls++
+		if calls == 2 {
+			return nil, errors.New("write error")
+		}
+		res := &WriteMultipleResult{FailedOperationIndex: -1}
+		for _, op := range req.Operations {
+			id, _ := op.PutRequest.Value.GetInt("id")
+			res.ResultSet = append(res.ResultSet, OperationResult{Success: id != 5})
+		}
+		return res, nil
+	}
+
+	store := &MemoryCheckpointStore{}
+	job, _ := NewJob("write", store)
+	assert.Error(t, runWriteJob(ctx, job, batches, write))
+
+	job, _ = NewJob("write", store)
+	_, err = job.Resume(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, job.Checkpoint().CompletedSegments)
+	require.NoError(t, runWriteJob(ctx, job, batches, write))
+
+	// The failed batch is retried, and the completed one is not.
+	assert.Equal(t, 5, calls)
+	cp := job.Checkpoint()
+	assert.True(t, cp.Done)
+	assert.Equal(t, []int{0, 1, 2, 3}, cp.CompletedSegments)
+	assert.Equal(t, int64(5), cp.Processed)
+	require.Len(t, cp.FailedItems, 1)
+	assert.Equal(t, 5, cp.FailedItems[0].Index)
+}
@@ -0,0 +1,387 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// Type wrappers of the typed JSON format of this SDK, which preserve the
+// database types of values that cannot be represented faithfully by plain JSON.
+// The format is specific to this SDK, it is not the output format of the
+// Oracle NoSQL SQL shell.
+//
+// A wrapped value is a JSON object with exactly one field whose name is one
+// of the following, for example:
+//
+//	{"$binary": "AQID"}
+//	{"$timestamp": "2024-01-02T03:04:05.123456789Z"}
+//	{"$number": "12345678901234567890.123"}
+//	{"$long": "9007199254740993"}
+//	{"$double": "NaN"}
+const (
+	ShellBinary    = "$binary"
+	ShellTimestamp = "$timestamp"
+	ShellNumber    = "$number"
+	ShellLong      = "$long"
+	ShellDouble    = "$double"
+)
+
+// FromShellJSON decodes a JSON object in the typed JSON format of this SDK,
+// as written by ToShellJSON, into a MapValue that can be used as a row or key.
+//
+// Plain JSON values are mapped as described in the types package. Objects that
+// use one of the type wrappers are converted to the corresponding Go
+// driver type:
+//
+//	$binary       []byte (base64 encoded)
+//	$timestamp    time.Time
+//	$number       *big.Rat
+//	$long         int64
+//	$double       float64, including "NaN", "Infinity" and "-Infinity"
+//
+// A JSON null is mapped to types.JSONNullValueInstance.
+func FromShellJSON(jsonStr string) (*types.MapValue, error) {
+	d := json.NewDecoder(strings.NewReader(jsonStr))
+	d.UseNumber()
+	var m map[string]interface{}
+	if err := d.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	if m == nil {
+		return nil, fmt.Errorf("expect a JSON object, got null")
+	}
+
+	v, err := fromShellValue(m)
+	if err != nil {
+		return nil, err
+	}
+
+	mv, ok := v.(*types.MapValue)
+	if !ok {
+		return nil, fmt.Errorf("expect a JSON object, got a wrapped %T value", v)
+	}
+
+	return mv, nil
+}
+
+// fromShellValue converts a value decoded by encoding/json into a FieldValue.
+func fromShellValue(v interface{}) (types.FieldValue, error) {
+	switch v := v.(type) {
+	case nil:
+		return types.JSONNullValueInstance, nil
+
+	case json.Number:
+		return fromJSONNumber(v)
+
+	case []interface{}:
+		arr := make([]types.FieldValue, len(v))
+		for i, e := range v {
+			fv, err := fromShellValue(e)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = fv
+		}
+		return arr, nil
+
+	case map[string]interface{}:
+		if len(v) == 1 {
+			for k, e := range v {
+				if strings.HasPrefix(k, "$") {
+					if fv, ok, err := fromShellWrapper(k, e); ok || err != nil {
+						return fv, err
+					}
+				}
+			}
+		}
+
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			fv, err := fromShellValue(e)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = fv
+		}
+		return types.NewMapValue(m), nil
+
+	default:
+		return v, nil
+	}
+}
+
+// fromShellWrapper converts a wrapped value. It returns false if name is not
+// a known type wrapper, in which case the object is treated as a regular map.
+func fromShellWrapper(name string, v interface{}) (fv types.FieldValue, ok bool, err error) {
+	var s string
+	switch e := v.(type) {
+	case string:
+		s = e
+	case json.Number:
+		s = e.String()
+	default:
+		return nil, false, nil
+	}
+
+	switch name {
+	case ShellBinary:
+		fv, err = base64.StdEncoding.DecodeString(s)
+	case ShellTimestamp:
+		fv, err = types.ParseDateTime(s)
+	case ShellNumber:
+		r, valid := new(big.Rat).SetString(s)
+		if !valid {
+			err = fmt.Errorf("invalid number %q", s)
+		}
+		fv = r
+	case ShellLong:
+		fv, err = strconv.ParseInt(s, 10, 64)
+	case ShellDouble:
+		switch s {
+		case "NaN":
+			fv = math.NaN()
+		case "Infinity":
+			fv = math.Inf(1)
+		case "-Infinity":
+			fv = math.Inf(-1)
+		default:
+			fv, err = strconv.ParseFloat(s, 64)
+		}
+	default:
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid %s value: %v", name, err)
+	}
+
+	return fv, true, nil
+}
+
+// fromJSONNumber maps a JSON number to the most appropriate numeric type.
+func fromJSONNumber(n json.Number) (types.FieldValue, error) {
+	s := n.String()
+	if i64, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if i64 >= math.MinInt32 && i64 <= math.MaxInt32 {
+			return int(i64), nil
+		}
+		return i64, nil
+	}
+
+	if !strings.ContainsAny(s, ".eE") {
+		// An integer that overflows int64.
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", s)
+		}
+		return r, nil
+	}
+
+	f64, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q: %v", s, err)
+	}
+	return f64, nil
+}
+
+// ToShellJSON encodes the specified value in the typed JSON format of this
+// SDK. Values that have no exact plain JSON representation are
+// encoded using the type wrappers understood by FromShellJSON, so that
+//
+//	FromShellJSON(ToShellJSON(v))
+//
+// returns a value equal to v. Keys of maps are written in sorted order unless
+// the MapValue keeps insertion order.
+func ToShellJSON(v types.FieldValue) (string, error) {
+	var buf bytes.Buffer
+	if err := writeShellValue(&buf, v); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func writeShellValue(buf *bytes.Buffer, v types.FieldValue) error {
+	switch v := v.(type) {
+	case nil, *types.JSONNullValue, *types.NullValue, *types.EmptyValue:
+		buf.WriteString("null")
+
+	case *types.MapValue:
+		if v == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		var keys []string
+		if v.IsOrdered() {
+			for i := 1; i <= v.Len(); i++ {
+				k, _, _ := v.GetByIndex(i)
+				keys = append(keys, k)
+			}
+		} else {
+			keys = sortedKeys(v.Map())
+		}
+		return writeShellObject(buf, keys, v.Get)
+
+	case map[string]interface{}:
+		return writeShellObject(buf, sortedKeys(v), func(k string) (interface{}, bool) {
+			e, ok := v[k]
+			return e, ok
+		})
+
+	case []types.FieldValue:
+		return writeShellArray(buf, len(v), func(i int) types.FieldValue { return v[i] })
+
+	case []interface{}:
+		return writeShellArray(buf, len(v), func(i int) types.FieldValue { return v[i] })
+
+	case []byte:
+		writeShellWrapper(buf, ShellBinary, base64.StdEncoding.EncodeToString(v))
+
+	case time.Time:
+		writeShellWrapper(buf, ShellTimestamp, v.UTC().Format(types.ISO8601ZLayout))
+
+	case *big.Rat:
+		if v == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		writeShellWrapper(buf, ShellNumber, ratString(v))
+
+	case int64:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			writeShellWrapper(buf, ShellLong, strconv.FormatInt(v, 10))
+		} else {
+			buf.WriteString(strconv.FormatInt(v, 10))
+		}
+
+	case float32:
+		return writeShellValue(buf, float64(v))
+
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			writeShellWrapper(buf, ShellDouble, "NaN")
+		case math.IsInf(v, 1):
+			writeShellWrapper(buf, ShellDouble, "Infinity")
+		case math.IsInf(v, -1):
+			writeShellWrapper(buf, ShellDouble, "-Infinity")
+		case v == math.Trunc(v) && math.Abs(v) < 1e21:
+			// Keep a fraction so the value is read back as a double.
+			buf.WriteString(strconv.FormatFloat(v, 'f', 1, 64))
+		default:
+			buf.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		}
+
+	case *string:
+		if v == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeShellValue(buf, *v)
+
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+
+	return nil
+}
+
+func writeShellObject(buf *bytes.Buffer, keys []string, get func(string) (interface{}, bool)) error {
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(k)
+		buf.Write(name)
+		buf.WriteByte(':')
+		e, _ := get(k)
+		if err := writeShellValue(buf, e); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func writeShellArray(buf *bytes.Buffer, n int, get func(int) types.FieldValue) error {
+	buf.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeShellValue(buf, get(i)); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+func writeShellWrapper(buf *bytes.Buffer, name, value string) {
+	s, _ := json.Marshal(value)
+	buf.WriteString(`{"` + name + `":`)
+	buf.Write(s)
+	buf.WriteByte('}')
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ratString returns the decimal representation of r if it can be represented
+// exactly, otherwise returns the "a/b" form.
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	// A fraction has a finite decimal expansion if and only if its
+	// denominator has no prime factors other than 2 and 5.
+	d := new(big.Int).Set(r.Denom())
+	two, five, zero := big.NewInt(2), big.NewInt(5), big.NewInt(0)
+	m := new(big.Int)
+	n2, n5 := 0, 0
+	for m.Mod(d, two).Cmp(zero) == 0 {
+		d.Quo(d, two)
+		n2++
+	}
+	for m.Mod(d, five).Cmp(zero) == 0 {
+		d.Quo(d, five)
+		n5++
+	}
+	if d.IsInt64() && d.Int64() == 1 {
+		if n5 > n2 {
+			n2 = n5
+		}
+		return r.FloatString(n2)
+	}
+
+	return r.RatString()
+}
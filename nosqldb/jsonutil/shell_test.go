@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+package jsonutil
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+func (suite *JSONUtilTestSuite) TestFromShellJSON() {
+	jsonStr := `{
+		"id": 1,
+		"big": 3000000000,
+		"dbl": 1.5,
+		"bin": {"$binary": "AQID"},
+		"ts": {"$timestamp": "2024-01-02T03:04:05.123Z"},
+		"num": {"$number": "12345678901234567890.125"},
+		"long": {"$long": "7"},
+		"nan": {"$double": "NaN"},
+		"nul": null,
+		"arr": [1, {"$long": "2"}],
+		"nested": {"$unknown": "x"}
+	}`
+	mv, err := FromShellJSON(jsonStr)
+	suite.Require().NoErrorf(err, "FromShellJSON() got error %v", err)
+
+	v, _ := mv.Get("id")
+	suite.Equal(1, v)
+	v, _ = mv.Get("big")
+	suite.Equal(int64(3000000000), v)
+	v, _ = mv.Get("dbl")
+	suite.Equal(1.5, v)
+	v, _ = mv.Get("bin")
+	suite.Equal([]byte{1, 2, 3}, v)
+	v, _ = mv.Get("ts")
+	suite.Equal(time.Date(2024, 1, 2, 3, 4, 5, 123000000, time.UTC), v)
+	v, _ = mv.Get("num")
+	r, _ := new(big.Rat).SetString("12345678901234567890.125")
+	suite.Equal(0, r.Cmp(v.(*big.Rat)))
+	v, _ = mv.Get("long")
+	suite.Equal(int64(7), v)
+	v, _ = mv.Get("nan")
+	suite.True(math.IsNaN(v.(float64)))
+	v, _ = mv.Get("nul")
+	suite.Equal(types.JSONNullValueInstance, v)
+	v, _ = mv.Get("arr")
+	suite.Equal([]types.FieldValue{1, int64(2)}, v)
+	v, _ = mv.Get("nested")
+	suite.IsType(&types.MapValue{}, v)
+
+	invalidInputs := []string{
+		`{"bin": {"$binary": "!!"}}`,
+		`{"num": {"$number": "abc"}}`,
+		`{"$long": "1"}`,
+		`null`,
+		`[1]`,
+	}
+	for _, s := range invalidInputs {
+		_, err = FromShellJSON(s)
+		suite.Errorf(err, "FromShellJSON(%s) should have failed", s)
+	}
+}
+
+func (suite *JSONUtilTestSuite) TestToShellJSON() {
+	mv := types.NewOrderedMapValue()
+	mv.Put("id", 1)
+	mv.Put("long", int64(math.MaxInt64))
+	mv.Put("dbl", 2.0)
+	mv.Put("inf", math.Inf(-1))
+	mv.Put("bin", []byte{1, 2, 3})
+	mv.Put("ts", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	mv.Put("num", big.NewRat(1, 8))
+	mv.Put("third", big.NewRat(1, 3))
+	mv.Put("arr", []interface{}{"a", nil})
+
+	s, err := ToShellJSON(mv)
+	suite.Require().NoErrorf(err, "ToShellJSON() got error %v", err)
+	suite.Equal(`{"id":1,"long":{"$long":"9223372036854775807"},"dbl":2.0,`+
+		`"inf":{"$double":"-Infinity"},"bin":{"$binary":"AQID"},`+
+		`"ts":{"$timestamp":"2024-01-02T03:04:05Z"},"num":{"$number":"0.125"},`+
+		`"third":{"$number":"1/3"},"arr":["a",null]}`, s)
+
+	back, err := FromShellJSON(s)
+	suite.Require().NoErrorf(err, "FromShellJSON() got error %v", err)
+	for i := 1; i <= mv.Len(); i++ {
+		k, want, _ := mv.GetByIndex(i)
+		got, ok := back.Get(k)
+		suite.Truef(ok, "field %s is missing", k)
+		switch want := want.(type) {
+		case *big.Rat:
+			suite.Equalf(0, want.Cmp(got.(*big.Rat)), "unexpected value of %s", k)
+		case []interface{}:
+			suite.Equalf([]types.FieldValue{"a", types.JSONNullValueInstance}, got, "unexpected value of %s", k)
+		default:
+			suite.Equalf(want, got, "unexpected value of %s", k)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// ValidateFieldRange validates the partial primary key and the field range of
+// a MultiDeleteRequest, or of a query that scans a range of the primary key
+// index, against the schema of the specified table, before the request is
+// sent. It checks that:
+//
+//   - the field of the range is a column of the primary key
+//   - the key contains exactly the primary key columns that precede it
+//   - the bounds of the range are values of the type of the column, and the
+//     range is not empty, see types.FieldRange.ValidateFor
+//
+// The key may be nil if the range is on the first primary key column. The
+// table metadata is retrieved with GetTableCached. An IllegalArgument error is
+// returned if the key or the range is not valid.
+func (c *Client) ValidateFieldRange(namespace, tableName string, key *types.MapValue, r *types.FieldRange) error {
+	if r == nil {
+		return nosqlerr.NewIllegalArgument("FieldRange is nil")
+	}
+
+	table, err := c.GetTableCached(namespace, tableName)
+	if err != nil {
+		return err
+	}
+
+	var schema struct {
+		PrimaryKey []string `json:"primaryKey"`
+		Fields     []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"fields"`
+	}
+	if err = json.Unmarshal([]byte(table.Schema), &schema); err != nil || len(schema.PrimaryKey) == 0 {
+		return nosqlerr.New(nosqlerr.IllegalState,
+			"cannot determine the primary key of table %q from its schema", tableName)
+	}
+
+	columnTypes := make(map[string]string, len(schema.Fields))
+	for _, f := range schema.Fields {
+		columnTypes[strings.ToLower(f.Name)] = f.Type
+	}
+	return validateKeyRange(schema.PrimaryKey, columnTypes, key, r)
+}
+
+// validateKeyRange validates a partial key and a field range for a table with
+// the specified primary key. The columnTypes map the lower case names of the
+// columns to their types, as declared in the table schema.
+func validateKeyRange(primaryKey []string, columnTypes map[string]string, key *types.MapValue, r *types.FieldRange) error {
+	pos := -1
+	for i, k := range primaryKey {
+		if strings.EqualFold(k, r.FieldPath) {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return nosqlerr.NewIllegalArgument("FieldRange field %q is not a primary key column, "+
+			"the primary key is %s", r.FieldPath, strings.Join(primaryKey, ", "))
+	}
+
+	prefix := primaryKey[:pos]
+	valid := key == nil && len(prefix) == 0 || key != nil && key.Len() == len(prefix)
+	for _, k := range prefix {
+		if valid && !hasFieldFold(key, k) {
+			valid = false
+		}
+	}
+	if !valid {
+		return nosqlerr.NewIllegalArgument("the Key for a FieldRange on %q must contain exactly "+
+			"the primary key columns that precede it: %s", r.FieldPath, strings.Join(prefix, ", "))
+	}
+
+	t, ok := rangeColumnType(columnTypes[strings.ToLower(r.FieldPath)])
+	if !ok {
+		return nosqlerr.NewIllegalArgument("a FieldRange cannot be specified on column %q of type %s",
+			r.FieldPath, columnTypes[strings.ToLower(r.FieldPath)])
+	}
+	if err := r.ValidateFor(t); err != nil {
+		return nosqlerr.NewIllegalArgument("%v", err)
+	}
+	return nil
+}
+
+// hasFieldFold reports whether m has a field with the specified name, under
+// case-folding.
+func hasFieldFold(m *types.MapValue, name string) bool {
+	for k := range m.Map() {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeColumnType returns the type of the bounds of a range on a column of
+// the specified schema type, such as "INTEGER" or "TIMESTAMP(3)".
+func rangeColumnType(schemaType string) (types.DbType, bool) {
+	if i := strings.IndexByte(schemaType, '('); i >= 0 {
+		schemaType = schemaType[:i]
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(schemaType)) {
+	case "INTEGER":
+		return types.Integer, true
+	case "LONG":
+		return types.Long, true
+	case "FLOAT", "DOUBLE":
+		return types.Double, true
+	case "NUMBER":
+		return types.Number, true
+	case "STRING", "ENUM":
+		return types.String, true
+	case "TIMESTAMP":
+		return types.Timestamp, true
+	case "BOOLEAN":
+		return types.Boolean, true
+	default:
+		return 0, false
+	}
+}
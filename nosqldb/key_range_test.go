@@ -0,0 +1,56 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKeyRange(t *testing.T) {
+	pk := []string{"tenant", "id", "ts"}
+	columnTypes := map[string]string{"tenant": "STRING", "id": "INTEGER", "ts": "TIMESTAMP(3)", "data": "JSON"}
+	key := types.NewMapValue(map[string]interface{}{"tenant": "t1", "ID": 1})
+
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tenant := types.NewMapValue(map[string]interface{}{"tenant": "t1"})
+	tests := []struct {
+		desc    string
+		key     *types.MapValue
+		r       *types.FieldRange
+		wantErr bool
+	}{
+		{"range on the last key column", key, types.NewFieldRange("ts").GreaterThan(ts), false},
+		{"range on the first key column", nil, types.NewFieldRange("tenant").Between("a", "b"), false},
+		{"not a primary key column", key, types.NewFieldRange("data").GreaterThan(1), true},
+		{"missing preceding column", tenant, types.NewFieldRange("ts").GreaterThan(ts), true},
+		{"key contains the range column", types.NewMapValue(map[string]interface{}{"tenant": "t1", "id": 1, "ts": ts}), types.NewFieldRange("ts").GreaterThan(ts), true},
+		{"key contains other columns", types.NewMapValue(map[string]interface{}{"tenant": "t1", "x": 1}), types.NewFieldRange("ts").GreaterThan(ts), true},
+		{"no key for a later column", nil, types.NewFieldRange("id").GreaterThan(1), true},
+		{"bound not of the column type", tenant, types.NewFieldRange("id").GreaterThan("1"), true},
+		{"start after end", key, types.NewFieldRange("ts").Between(ts, ts.Add(-time.Hour)), true},
+	}
+	for _, r := range tests {
+		err := validateKeyRange(pk, columnTypes, r.key, r.r)
+		if r.wantErr {
+			assert.Truef(t, nosqlerr.IsIllegalArgument(err), "%s: expected IllegalArgument, got %v", r.desc, err)
+		} else {
+			assert.NoErrorf(t, err, "%s: got error %v", r.desc, err)
+		}
+	}
+
+	typ, ok := rangeColumnType("TIMESTAMP(9)")
+	assert.True(t, ok)
+	assert.Equal(t, types.Timestamp, typ)
+	_, ok = rangeColumnType("JSON")
+	assert.False(t, ok)
+}
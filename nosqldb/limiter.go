@@ -0,0 +1,264 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+)
+
+// ErrClientBusy is returned when a request cannot be executed because the
+// maximum number of concurrent requests configured for the Client has been
+// reached, and Config.QueueWhenBusy is not set.
+var ErrClientBusy = errors.New("the maximum number of concurrent requests has been reached")
+
+// weightedSemaphore is a counting semaphore whose permits are acquired with a
+// weight. Waiters are served in FIFO order, so a request with a large weight
+// is not starved by requests with smaller weights.
+type weightedSemaphore struct {
+	size    int64
+	cur     int64
+	mu      sync.Mutex
+	waiters list.List
+}
+
+type semaphoreWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+func newWeightedSemaphore(size int64) *weightedSemaphore {
+	return &weightedSemaphore{size: size}
+}
+
+// tryAcquire acquires n permits without blocking. It reports whether the
+// permits were acquired.
+func (s *weightedSemaphore) tryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// acquire acquires n permits, blocking until they are available or ctx is
+// done. It returns ctx.Err() if ctx is done first.
+func (s *weightedSemaphore) acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+
+	w := semaphoreWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-w.ready:
+			// Acquired the permits after ctx was done, keep them.
+			s.mu.Unlock()
+			return nil
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// If this was the first waiter, the waiters behind it may be
+			// able to proceed now.
+			if isFront && s.size > s.cur {
+				s.notifyWaiters()
+			}
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+
+	case <-w.ready:
+		return nil
+	}
+}
+
+// release releases n permits.
+func (s *weightedSemaphore) release(n int64) {
+	s.mu.Lock()
+	s.cur -= n
+	s.notifyWaiters()
+	s.mu.Unlock()
+}
+
+// notifyWaiters wakes up the waiters at the front of the queue whose permits
+// are available. It must be called with s.mu held.
+func (s *weightedSemaphore) notifyWaiters() {
+	for {
+		next := s.waiters.Front()
+		if next == nil {
+			return
+		}
+
+		w := next.Value.(semaphoreWaiter)
+		if s.size-s.cur < w.n {
+			return
+		}
+
+		s.cur += w.n
+		s.waiters.Remove(next)
+		close(w.ready)
+	}
+}
+
+// concurrencyLimiter limits the number of requests a Client executes
+// concurrently, overall and optionally per table.
+type concurrencyLimiter struct {
+	global   *weightedSemaphore
+	perTable int64
+	queue    bool
+	weight   func(req Request) int
+
+	mu     sync.Mutex
+	tables map[string]*weightedSemaphore
+}
+
+// newConcurrencyLimiter returns a concurrencyLimiter for the Config, or nil
+// if the number of concurrent requests is not limited.
+func newConcurrencyLimiter(cfg *Config) *concurrencyLimiter {
+	if cfg.MaxConcurrentRequests <= 0 && cfg.MaxConcurrentRequestsPerTable <= 0 {
+		return nil
+	}
+
+	l := &concurrencyLimiter{
+		perTable: int64(cfg.MaxConcurrentRequestsPerTable),
+		queue:    cfg.QueueWhenBusy,
+		weight:   cfg.RequestWeight,
+	}
+	if cfg.MaxConcurrentRequests > 0 {
+		l.global = newWeightedSemaphore(int64(cfg.MaxConcurrentRequests))
+	}
+	if l.perTable > 0 {
+		l.tables = make(map[string]*weightedSemaphore)
+	}
+	return l
+}
+
+// tableSemaphore returns the semaphore of the table of req, or nil if there
+// is no per table limit or req does not operate on a table.
+func (l *concurrencyLimiter) tableSemaphore(req Request) *weightedSemaphore {
+	if l.perTable <= 0 || req.getTableName() == "" {
+		return nil
+	}
+
+	key := strings.ToLower(req.getTableName())
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.tables[key]
+	if !ok {
+		s = newWeightedSemaphore(l.perTable)
+		l.tables[key] = s
+	}
+	return s
+}
+
+// requestWeight returns the weight of req, which is at least 1 and at most
+// the size of the semaphore, so that any request can eventually proceed.
+func requestWeight(req Request, weight func(Request) int, sems ...*weightedSemaphore) int64 {
+	n := int64(1)
+	if weight != nil {
+		if w := weight(req); w > 1 {
+			n = int64(w)
+		}
+	}
+
+	for _, s := range sems {
+		if s != nil && n > s.size {
+			n = s.size
+		}
+	}
+	return n
+}
+
+// unlimitedKey is the context key that marks the internal requests that are
+// not subject to the concurrency limits.
+type unlimitedKey struct{}
+
+// withoutConcurrencyLimit returns a copy of ctx for internal requests, such as
+// the GetTable request that fills the schema cache, which may be issued while
+// the permits of the request they serve are held and must not wait for them.
+func withoutConcurrencyLimit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unlimitedKey{}, true)
+}
+
+// acquire acquires the permits required to execute req. It returns a function
+// that releases the permits, which must be called when the request completes.
+//
+// If the permits are not available and queueing is disabled, it returns
+// ErrClientBusy. Otherwise it waits until the permits are available, ctx is
+// done or the request timeout elapses.
+func (l *concurrencyLimiter) acquire(ctx context.Context, req Request) (release func(), err error) {
+	if l == nil || ctx.Value(unlimitedKey{}) != nil {
+		return func() {}, nil
+	}
+
+	table := l.tableSemaphore(req)
+	n := requestWeight(req, l.weight, l.global, table)
+
+	// Acquire the table permits first so that requests on a busy table queue
+	// behind each other rather than occupy the global permits.
+	sems := make([]*weightedSemaphore, 0, 2)
+	for _, s := range []*weightedSemaphore{table, l.global} {
+		if s != nil {
+			sems = append(sems, s)
+		}
+	}
+
+	releaseAll := func(acquired []*weightedSemaphore) {
+		for _, s := range acquired {
+			s.release(n)
+		}
+	}
+
+	if !l.queue {
+		for i, s := range sems {
+			if !s.tryAcquire(n) {
+				releaseAll(sems[:i])
+				return nil, ErrClientBusy
+			}
+		}
+		return func() { releaseAll(sems) }, nil
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		if timeout := req.timeout(); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
+	start := time.Now()
+	for i, s := range sems {
+		if err = s.acquire(ctx, n); err != nil {
+			releaseAll(sems[:i])
+			if errors.Is(err, context.Canceled) {
+				return nil, err
+			}
+			return nil, nosqlerr.NewWithCause(nosqlerr.RequestTimeout, err,
+				"request could not be executed within %v as the maximum number of "+
+					"concurrent requests has been reached", time.Since(start).Round(time.Millisecond))
+		}
+	}
+	return func() { releaseAll(sems) }, nil
+}
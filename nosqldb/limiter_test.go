@@ -0,0 +1,129 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyLimiter(t *testing.T) {
+	l := newConcurrencyLimiter(&Config{MaxConcurrentRequests: 3, MaxConcurrentRequestsPerTable: 2})
+	require.NotNil(t, l)
+	assert.Nil(t, newConcurrencyLimiter(&Config{}))
+
+	ctx := context.Background()
+	get := func(table string) Request {
+		return &GetRequest{TableName: table, Timeout: 50 * time.Millisecond}
+	}
+
+	// Requests over the per table or global limits fail at once.
+	tests := []struct {
+		desc  string
+		table string
+		busy  bool
+	}{
+		{"first request on t1", "t1", false},
+		{"case-insensitive table name", "T1", false},
+		{"per table limit", "t1", true},
+		{"request on t2", "t2", false},
+		{"global limit", "t3", true},
+	}
+	var releases []func()
+	for _, r := range tests {
+		release, err := l.acquire(ctx, get(r.table))
+		if r.busy {
+			assert.Equalf(t, ErrClientBusy, err, "%s: acquire() should have failed", r.desc)
+			continue
+		}
+		require.NoErrorf(t, err, "%s: acquire() got error %v", r.desc, err)
+		releases = append(releases, release)
+	}
+
+	// Internal requests are not limited.
+	release, err := l.acquire(withoutConcurrencyLimit(ctx), get("t1"))
+	require.NoError(t, err)
+	release()
+
+	// Released permits are available to other requests.
+	releases[0]()
+	release, err = l.acquire(ctx, get("t3"))
+	require.NoError(t, err)
+	release()
+	for _, release := range releases[1:] {
+		release()
+	}
+
+	// Queued requests wait for a permit until the request timeout elapses.
+	l = newConcurrencyLimiter(&Config{
+		MaxConcurrentRequests: 2,
+		QueueWhenBusy:         true,
+		RequestWeight: func(req Request) int {
+			if _, ok := req.(*WriteMultipleRequest); ok {
+				return 5
+			}
+			return 1
+		},
+	})
+	r1, err := l.acquire(ctx, &WriteMultipleRequest{})
+	require.NoError(t, err)
+	_, err = l.acquire(ctx, get("t1"))
+	assert.True(t, nosqlerr.Is(err, nosqlerr.RequestTimeout), "got error %v", err)
+
+	done := make(chan error)
+	go func() {
+		release, err := l.acquire(ctx, get("t1"))
+		if err == nil {
+			release()
+		}
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	r1()
+	assert.NoError(t, <-done)
+
+	cctx, cancel := context.WithCancel(ctx)
+	r1, err = l.acquire(ctx, &WriteMultipleRequest{})
+	require.NoError(t, err)
+	cancel()
+	_, err = l.acquire(cctx, get("t1"))
+	assert.Equal(t, context.Canceled, err)
+	r1()
+}
+
+func TestRequestWeight(t *testing.T) {
+	weight := func(n int) func(Request) int {
+		return func(Request) int { return n }
+	}
+
+	tests := []struct {
+		desc   string
+		weight func(Request) int
+		sizes  []int64
+		want   int64
+	}{
+		{"no weight function", nil, []int64{5}, 1},
+		{"weight less than 1", weight(0), []int64{5}, 1},
+		{"weight within the limits", weight(3), []int64{5, 4}, 3},
+		{"weight over the limits", weight(10), []int64{5, 2}, 2},
+		{"no limits", weight(10), nil, 10},
+	}
+	for _, r := range tests {
+		var sems []*weightedSemaphore
+		for _, size := range r.sizes {
+			sems = append(sems, newWeightedSemaphore(size))
+		}
+		sems = append(sems, nil)
+		assert.Equalf(t, r.want, requestWeight(&GetRequest{}, r.weight, sems...), "%s: unexpected weight", r.desc)
+	}
+}
@@ -130,6 +130,16 @@ func IsSecurityInfoUnavailable(err error) bool {
 	return Is(err, SecurityInfoUnavailable)
 }
 
+// IsEtagMismatch returns true if the specified error is an EtagMismatch error,
+// otherwise returns false.
+//
+// This error is returned by the cloud service when the MatchETag specified
+// in a table request does not match the current ETag of the table, which
+// means the table has been modified since the ETag was obtained.
+func IsEtagMismatch(err error) bool {
+	return Is(err, EtagMismatch)
+}
+
 // ErrorCode represents the error code.
 // Error codes are divided into categories as follows:
 //
@@ -259,7 +269,9 @@ const (
 	// This may be related to on-premise vs cloud service configurations.
 	OperationNotSupported // 21
 
-	// EtagMismatch is used only by the cloud REST service.
+	// EtagMismatch error indicates the MatchETag specified in a request
+	// does not match the current ETag of the target table.
+	// This is used only by the cloud service.
 	EtagMismatch // 22
 
 	// CannotCancelWorkRequest is used only by the cloud REST service.
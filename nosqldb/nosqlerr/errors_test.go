@@ -62,11 +62,12 @@ func (suite *NoSQLErrorsTestSuite) TestIsErrors() {
 	e3 := New(SecurityInfoUnavailable, "cannot get access token from authorization server")
 	e4 := NewIllegalState("illegal state: Unknown")
 	e5 := NewRequestTimeout("request timed out after 5s")
+	e6 := New(EtagMismatch, "ETag does not match")
 
 	expectCodes := []ErrorCode{
 		IllegalArgument, TableNotFound, SecurityInfoUnavailable, RequestTimeout,
 	}
-	errs := [...]*Error{e1, e2, e3, e4, e5}
+	errs := [...]*Error{e1, e2, e3, e4, e5, e6}
 	var ok bool
 	for _, e := range errs {
 		ok = IsIllegalArgument(e)
@@ -90,8 +91,15 @@ func (suite *NoSQLErrorsTestSuite) TestIsErrors() {
 			suite.Falsef(ok, "IsSecurityInfoUnavailable(err=%v) should have returned false", e)
 		}
 
+		ok = IsEtagMismatch(e)
+		if e == e6 {
+			suite.Truef(ok, "IsEtagMismatch(err=%v) should have returned true", e)
+		} else {
+			suite.Falsef(ok, "IsEtagMismatch(err=%v) should have returned false", e)
+		}
+
 		ok = Is(e, expectCodes...)
-		if e != e4 {
+		if e != e4 && e != e6 {
 			suite.Truef(ok, "Is(err=%v, expectCodes=%v) should have returned true", e, expectCodes)
 		} else {
 			suite.Falsef(ok, "Is(err=%v, expectCodes=%v) should have returned true", e, expectCodes)
@@ -11,6 +11,7 @@ package nosqldb
 // protocol field name.
 var NsonFieldsMap = map[string]string{
 	ABORT_ON_FAIL:              "ABORT_ON_FAIL",
+	BATCH_COUNTER:              "BATCH_COUNTER",
 	BIND_VARIABLES:             "BIND_VARIABLES",
 	COMPARTMENT_OCID:           "COMPARTMENT_OCID",
 	CONSISTENCY:                "CONSISTENCY",
@@ -80,6 +81,7 @@ var NsonFieldsMap = map[string]string{
 	QUERY_NAME:                 "QUERY_NAME",
 	QUERY_OPERATION:            "QUERY_OPERATION",
 	QUERY_PLAN_STRING:          "QUERY_PLAN_STRING",
+	QUERY_BATCH_TRACES:         "QUERY_BATCH_TRACES",
 	QUERY_RESULTS:              "QUERY_RESULTS",
 	QUERY_RESULT_SCHEMA:        "QUERY_RESULT_SCHEMA",
 	QUERY_VERSION:              "QUERY_VERSION",
@@ -28,6 +28,7 @@ import (
 
 const (
 	ABORT_ON_FAIL              = "a"
+	BATCH_COUNTER              = "bc"
 	BIND_VARIABLES             = "bv"
 	COMPARTMENT_OCID           = "cc"
 	CONSISTENCY                = "co"
@@ -97,6 +98,7 @@ const (
 	QUERY_NAME                 = "qn"
 	QUERY_OPERATION            = "qo"
 	QUERY_PLAN_STRING          = "qs"
+	QUERY_BATCH_TRACES         = "qts"
 	QUERY_RESULTS              = "qr"
 	QUERY_RESULT_SCHEMA        = "qc"
 	QUERY_VERSION              = "qv"
@@ -211,7 +213,7 @@ func (req *GetRequest) serialize(w proto.Writer, serialVersion int16, _ int16) (
 			return
 		}
 	} else if req.StructValue != nil {
-		if err = ns.writeStructField(KEY, req.StructValue); err != nil {
+		if err = ns.writeStructField(KEY, req.StructValue, nil); err != nil {
 			return
 		}
 	} else {
@@ -245,6 +247,7 @@ func (req *GetRequest) deserialize(r proto.Reader, serialVersion int16, _ int16)
 			} else if req.StructValue != nil {
 				res.StructValue = req.StructValue
 			}
+			res.strictDecode = req.DecodeMode == DecodeStrict
 			err = readNsonRow(r, res)
 		case TOPOLOGY_INFO:
 			err = res.SetTopologyOrErr(readNsonTopologyInfo(r))
@@ -1003,12 +1006,18 @@ func (req *PutRequest) serializeInternal(w proto.Writer, _ int16, addTableName b
 		return
 	}
 
-	if req.Value != nil {
-		if err = ns.writeField(VALUE, req.Value); err != nil {
+	if req.EncodedValue != nil {
+		ns.startField(VALUE)
+		if _, err = req.EncodedValue.write(ns.writer, req.value()); err != nil {
+			return
+		}
+		ns.endField(VALUE)
+	} else if req.Value != nil {
+		if err = ns.writeField(VALUE, req.value()); err != nil {
 			return
 		}
 	} else if req.StructValue != nil {
-		if err = ns.writeStructField(VALUE, req.StructValue); err != nil {
+		if err = ns.writeStructField(VALUE, req.StructValue, req.recordLayout); err != nil {
 			return
 		}
 	} else {
@@ -1485,8 +1494,16 @@ func (req *QueryRequest) serialize(w proto.Writer, serialVersion int16, queryVer
 	if err = ns.writeNZField(NUMBER_LIMIT, int(req.Limit)); err != nil {
 		return
 	}
-	if err = ns.writeNZField(TRACE_LEVEL, req.traceLevel); err != nil {
-		return
+	if req.TraceLevel > 0 {
+		if err = ns.writeField(TRACE_LEVEL, req.TraceLevel); err != nil {
+			return
+		}
+		if err = ns.writeField(TRACE_AT_LOG_FILES, req.TraceToLogFiles); err != nil {
+			return
+		}
+		if err = ns.writeField(BATCH_COUNTER, req.batchNumber); err != nil {
+			return
+		}
 	}
 
 	if err = ns.writeField(QUERY_VERSION, queryVersion); err != nil {
@@ -1924,9 +1941,9 @@ func (ns *NsonSerializer) writeField(key string, value types.FieldValue) (err er
 	return nil
 }
 
-func (ns *NsonSerializer) writeStructField(key string, value any) (err error) {
+func (ns *NsonSerializer) writeStructField(key string, value any, layout *proto.RecordLayout) (err error) {
 	ns.startField(key)
-	if _, err = ns.writer.WriteStructValue(value); err != nil {
+	if _, err = ns.writer.WriteStructValueWithLayout(value, layout); err != nil {
 		return
 	}
 	ns.endField(key)
@@ -2460,7 +2477,9 @@ func readNsonRow(r proto.Reader, res *GetResult) error {
 		case ROW_VERSION:
 			res.Version, err = readNsonVersion(r)
 		case VALUE:
-			if res.StructValue != nil {
+			if res.StructValue != nil && res.strictDecode {
+				err = r.ReadStructValueStrict(res.StructValue)
+			} else if res.StructValue != nil {
 				err = r.ReadStructValue(res.StructValue)
 			} else {
 				res.Value, err = readNsonRowValue(r)
@@ -2663,7 +2682,9 @@ func readNsonPrepareOrQuery(qreq *QueryRequest, qres *QueryResult,
 				shardIDs = topo.ShardIDs
 			}
 		case QUERY_RESULTS:
-			if qres != nil {
+			if qres != nil && qreq != nil && qreq.RowVisitor != nil && !qreq.isInternalRequest() {
+				err = visitNsonQueryResults(r, qreq.RowVisitor)
+			} else if qres != nil {
 				err = readNsonQueryResults(r, qres)
 			} else {
 				err = skipNsonField(r, name)
@@ -2715,6 +2736,12 @@ func readNsonPrepareOrQuery(qreq *QueryRequest, qres *QueryResult,
 			if err == nil {
 				operation = byte(val)
 			}
+		case QUERY_BATCH_TRACES:
+			var traces []QueryTrace
+			traces, err = readNsonQueryTraces(r)
+			if err == nil && qreq != nil {
+				qreq.addTraces(traces)
+			}
 		default:
 			err = skipNsonField(r, name)
 		}
@@ -2871,6 +2898,37 @@ func readNsonIntArray(r proto.Reader) (arr []int, err error) {
 	return
 }
 
+// readNsonQueryTraces reads the traces of a query, which are returned as an
+// array of strings that alternate the name of a batch and its trace.
+func readNsonQueryTraces(r proto.Reader) (traces []QueryTrace, err error) {
+	if err = readNsonType(r, types.Array); err != nil {
+		return nil, err
+	}
+	// length in bytes: ignored
+	if _, err = r.ReadInt(); err != nil {
+		return nil, err
+	}
+	numElements, err := r.ReadInt()
+	if err != nil {
+		return nil, err
+	}
+	if numElements%2 != 0 {
+		return nil, fmt.Errorf("invalid query traces: odd number of elements %d", numElements)
+	}
+	traces = make([]QueryTrace, 0, numElements/2)
+	for i := 0; i < numElements; i += 2 {
+		var t QueryTrace
+		if t.Batch, err = readNsonString(r); err != nil {
+			return nil, err
+		}
+		if t.Trace, err = readNsonString(r); err != nil {
+			return nil, err
+		}
+		traces = append(traces, t)
+	}
+	return
+}
+
 func readNsonVirtualScans(r proto.Reader) (scans []*virtualScan, err error) {
 	// array of virtual scans
 	if err = readNsonType(r, types.Array); err != nil {
@@ -2922,6 +2980,28 @@ func readNsonQueryResults(r proto.Reader, qres *QueryResult) (err error) {
 	return nil
 }
 
+// visitNsonQueryResults decodes the query results and passes each row to v,
+// instead of adding them to the QueryResult.
+func visitNsonQueryResults(r proto.Reader, v RowVisitor) (err error) {
+	if err = readNsonType(r, types.Array); err != nil {
+		return
+	}
+	// length in bytes: ignored
+	if _, err = r.ReadInt(); err != nil {
+		return
+	}
+	var numElements int
+	if numElements, err = r.ReadInt(); err != nil {
+		return
+	}
+	for i := 0; i < numElements; i++ {
+		if err = visitNsonRow(r, v); err != nil {
+			return
+		}
+	}
+	return nil
+}
+
 func readNsonPhase1Results(arr []byte, res *QueryResult) (err error) {
 	if len(arr) == 0 {
 		return nil
@@ -0,0 +1,289 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// KeyColumn describes a column used to order the rows of a KeysetPaginator.
+type KeyColumn struct {
+	// Name specifies the name of the column.
+	Name string
+
+	// Type specifies the database type of the column, such as "INTEGER",
+	// "LONG", "STRING" or "TIMESTAMP(3)". It is used to declare the external
+	// variable that holds the column value of the last row of a page.
+	Type string
+}
+
+// KeysetPaginator implements stable pagination over the rows of a table, which
+// can be used to serve limit/offset style HTTP APIs without the cost and
+// inconsistency of skipping rows.
+//
+// Rows are returned in the order of KeyColumns, which must uniquely identify
+// a row and be usable in an ORDER BY clause, typically the primary key columns
+// of the table in their declared order. Each page is fetched with a query of
+// the form:
+//
+//	DECLARE $pk0 <type>; ...
+//	SELECT <Select> FROM <TableName>
+//	WHERE (<Where>) AND <rows after the last row of previous page>
+//	ORDER BY <KeyColumns> LIMIT <PageSize + 1>
+//
+// The position of a page is represented by an opaque page token that encodes
+// the key of the last row returned. Tokens are signed with Secret using
+// HMAC-SHA256 so that a token handed out to an untrusted client cannot be
+// forged or modified.
+//
+// A KeysetPaginator is safe for concurrent use once it is configured.
+type KeysetPaginator struct {
+	// TableName specifies the name of table to paginate.
+	TableName string
+
+	// KeyColumns specifies the columns used to order the rows.
+	KeyColumns []KeyColumn
+
+	// Select specifies the projection of the query. It must include all of
+	// the KeyColumns. If not set, "*" is used.
+	Select string
+
+	// Where specifies an optional filter condition for the rows.
+	Where string
+
+	// PageSize specifies the maximum number of rows returned in a page.
+	PageSize uint
+
+	// Secret specifies the key used to sign page tokens.
+	Secret []byte
+}
+
+func (p *KeysetPaginator) validate() error {
+	if err := validateTableName(p.TableName); err != nil {
+		return err
+	}
+
+	if len(p.KeyColumns) == 0 {
+		return nosqlerr.NewIllegalArgument("KeysetPaginator: KeyColumns must be non-empty")
+	}
+
+	for _, kc := range p.KeyColumns {
+		if kc.Name == "" || kc.Type == "" {
+			return nosqlerr.NewIllegalArgument("KeysetPaginator: the Name and Type of KeyColumn must be non-empty")
+		}
+	}
+
+	if p.PageSize == 0 {
+		return nosqlerr.NewIllegalArgument("KeysetPaginator: PageSize must be greater than 0")
+	}
+
+	if len(p.Secret) == 0 {
+		return nosqlerr.NewIllegalArgument("KeysetPaginator: Secret must be non-empty")
+	}
+
+	return nil
+}
+
+// Statement returns the query statement used to fetch a page. If afterKey is
+// true, the statement selects the rows that follow the key bound to the
+// external variables $pk0, $pk1, ..., which correspond to KeyColumns.
+func (p *KeysetPaginator) Statement(afterKey bool) string {
+	var sb strings.Builder
+	if afterKey {
+		sb.WriteString("DECLARE")
+		for i, kc := range p.KeyColumns {
+			fmt.Fprintf(&sb, " $pk%d %s;", i, kc.Type)
+		}
+		sb.WriteString(" ")
+	}
+
+	sel := p.Select
+	if sel == "" {
+		sel = "*"
+	}
+	fmt.Fprintf(&sb, "SELECT %s FROM %s", sel, p.TableName)
+
+	var conds []string
+	if p.Where != "" {
+		conds = append(conds, "("+p.Where+")")
+	}
+
+	if afterKey {
+		// (k0 > $pk0) OR (k0 = $pk0 AND k1 > $pk1) OR ...
+		var alts []string
+		for i, kc := range p.KeyColumns {
+			var terms []string
+			for j := 0; j < i; j++ {
+				terms = append(terms, fmt.Sprintf("%s = $pk%d", p.KeyColumns[j].Name, j))
+			}
+			terms = append(terms, fmt.Sprintf("%s > $pk%d", kc.Name, i))
+			alts = append(alts, "("+strings.Join(terms, " AND ")+")")
+		}
+		conds = append(conds, "("+strings.Join(alts, " OR ")+")")
+	}
+
+	if len(conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(conds, " AND "))
+	}
+
+	names := make([]string, len(p.KeyColumns))
+	for i, kc := range p.KeyColumns {
+		names[i] = kc.Name
+	}
+	fmt.Fprintf(&sb, " ORDER BY %s LIMIT %d", strings.Join(names, ", "), p.PageSize+1)
+	return sb.String()
+}
+
+// Page returns the page of rows that follows the position represented by
+// token. An empty token requests the first page.
+//
+// The returned nextToken is empty if there are no more rows.
+func (p *KeysetPaginator) Page(client *Client, token string) (rows []*types.MapValue, nextToken string, err error) {
+	if client == nil {
+		return nil, "", errNilClient
+	}
+
+	if err = p.validate(); err != nil {
+		return nil, "", err
+	}
+
+	var key *types.MapValue
+	if token != "" {
+		if key, err = p.DecodeToken(token); err != nil {
+			return nil, "", err
+		}
+	}
+
+	prepRes, err := client.Prepare(&PrepareRequest{Statement: p.Statement(key != nil)})
+	if err != nil {
+		return nil, "", err
+	}
+
+	stmt := prepRes.PreparedStatement
+	for i, kc := range p.KeyColumns {
+		if key == nil {
+			break
+		}
+		v, _ := key.Get(kc.Name)
+		if err = stmt.SetVariable(fmt.Sprintf("$pk%d", i), v); err != nil {
+			return nil, "", err
+		}
+	}
+
+	req := &QueryRequest{PreparedStatement: &stmt}
+	defer req.Close()
+
+	for {
+		res, err := client.Query(req)
+		if err != nil {
+			return nil, "", err
+		}
+
+		results, err := res.GetResults()
+		if err != nil {
+			return nil, "", err
+		}
+		rows = append(rows, results...)
+
+		if req.IsDone() {
+			break
+		}
+	}
+
+	if uint(len(rows)) <= p.PageSize {
+		return rows, "", nil
+	}
+
+	rows = rows[:p.PageSize]
+	nextToken, err = p.EncodeToken(rows[len(rows)-1])
+	if err != nil {
+		return nil, "", err
+	}
+
+	return rows, nextToken, nil
+}
+
+// EncodeToken returns a signed page token that represents the position after
+// the specified row. The row must contain values for all of the KeyColumns.
+func (p *KeysetPaginator) EncodeToken(row *types.MapValue) (string, error) {
+	if row == nil {
+		return "", nosqlerr.NewIllegalArgument("KeysetPaginator: row must be non-nil")
+	}
+
+	key := types.NewOrderedMapValue()
+	for _, kc := range p.KeyColumns {
+		v, ok := row.Get(kc.Name)
+		if !ok {
+			return "", nosqlerr.NewIllegalArgument("KeysetPaginator: the row does not "+
+				"contain the key column %q", kc.Name)
+		}
+		key.Put(kc.Name, v)
+	}
+
+	payload, err := jsonutil.ToShellJSON(key)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(payload)) + "." + enc.EncodeToString(p.sign([]byte(payload))), nil
+}
+
+// DecodeToken verifies the signature of the specified page token and returns
+// the key it represents.
+func (p *KeysetPaginator) DecodeToken(token string) (*types.MapValue, error) {
+	errInvalid := nosqlerr.NewIllegalArgument("KeysetPaginator: invalid page token")
+
+	data, sig, found := strings.Cut(token, ".")
+	if !found {
+		return nil, errInvalid
+	}
+
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(data)
+	if err != nil {
+		return nil, errInvalid
+	}
+
+	mac, err := enc.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, p.sign(payload)) {
+		return nil, errInvalid
+	}
+
+	key, err := jsonutil.FromShellJSON(string(payload))
+	if err != nil {
+		return nil, errInvalid
+	}
+
+	for _, kc := range p.KeyColumns {
+		if !key.Contains(kc.Name) {
+			return nil, errInvalid
+		}
+	}
+
+	return key, nil
+}
+
+// sign computes the HMAC of the token payload. The table name is included so
+// that a token issued for one table cannot be used with another.
+func (p *KeysetPaginator) sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, p.Secret)
+	h.Write([]byte(p.TableName))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return h.Sum(nil)
+}
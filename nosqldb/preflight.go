@@ -0,0 +1,227 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+)
+
+// PermissionCheck represents the result of an operation performed on a table
+// to check the permissions of the client.
+type PermissionCheck struct {
+	// TableName specifies the table the operation was performed on.
+	TableName string `json:"tableName"`
+
+	// Operation specifies the operation that was performed, which is either
+	// "get table" or "read rows".
+	Operation string `json:"operation"`
+
+	// Permission specifies the permission required by the operation, such as
+	// NOSQL_TABLE_READ for the cloud service.
+	Permission string `json:"permission"`
+
+	// Status specifies the outcome of the operation. It is DiagnosticOK or
+	// DiagnosticFail.
+	Status DiagnosticStatus `json:"status"`
+
+	// Detail describes the error if the operation failed.
+	Detail string `json:"detail,omitempty"`
+
+	// Statement specifies a policy statement, or a GRANT statement for an
+	// on-premise server, that gives the missing permission. It is only set
+	// if the operation failed because the permission is missing.
+	Statement string `json:"statement,omitempty"`
+
+	// Elapsed specifies the time spent on the operation.
+	Elapsed time.Duration `json:"elapsed"`
+
+	err error
+}
+
+// PermissionReport represents the result of Client.CheckPermissions().
+type PermissionReport struct {
+	// Checks specifies the results of the operations, in the order performed.
+	Checks []PermissionCheck `json:"checks"`
+}
+
+// OK reports whether all operations succeeded.
+func (r *PermissionReport) OK() bool {
+	return r.firstFailure() == nil
+}
+
+func (r *PermissionReport) firstFailure() *PermissionCheck {
+	for i := range r.Checks {
+		if r.Checks[i].Status == DiagnosticFail {
+			return &r.Checks[i]
+		}
+	}
+	return nil
+}
+
+// MissingStatements returns the distinct statements that give the permissions
+// found to be missing, in the order the permissions were checked.
+func (r *PermissionReport) MissingStatements() []string {
+	var stmts []string
+	seen := make(map[string]bool)
+	for _, c := range r.Checks {
+		if c.Statement != "" && !seen[c.Statement] {
+			seen[c.Statement] = true
+			stmts = append(stmts, c.Statement)
+		}
+	}
+	return stmts
+}
+
+// Summary returns a human readable summary of the failed operations and of
+// the statements that give the missing permissions.
+func (r *PermissionReport) Summary() string {
+	var failed []string
+	for _, c := range r.Checks {
+		if c.Status == DiagnosticFail {
+			failed = append(failed, fmt.Sprintf("  %s on table %s (%s): %s",
+				c.Operation, c.TableName, c.Permission, c.Detail))
+		}
+	}
+	if len(failed) == 0 {
+		return fmt.Sprintf("all %d permission checks passed", len(r.Checks))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d of %d permission checks failed:\n%s",
+		len(failed), len(r.Checks), strings.Join(failed, "\n"))
+	if stmts := r.MissingStatements(); len(stmts) > 0 {
+		sb.WriteString("\nthe following statements give the missing permissions:\n  ")
+		sb.WriteString(strings.Join(stmts, "\n  "))
+	}
+	return sb.String()
+}
+
+// String returns a JSON string representation of the PermissionReport.
+func (r PermissionReport) String() string {
+	return jsonutil.AsPrettyJSON(r)
+}
+
+// Err returns nil if all operations succeeded. Otherwise it returns an error
+// with the code of the error of the first failed operation, and the Summary
+// of the report as message.
+func (r *PermissionReport) Err() error {
+	c := r.firstFailure()
+	if c == nil {
+		return nil
+	}
+
+	code := nosqlerr.UnknownError
+	var e *nosqlerr.Error
+	if errors.As(c.err, &e) {
+		code = e.Code
+	}
+	return nosqlerr.NewWithCause(code, c.err, "%s", r.Summary())
+}
+
+// CheckPermissions performs minimal operations on each of the specified
+// tables to check that the client has the permissions it needs, and returns
+// a report of the results. It is intended to be called when an application
+// starts, to report missing IAM policy statements in a readable form rather
+// than as NotAuthorized errors of later requests. See also
+// Config.PreflightTables.
+//
+// For each table, the metadata of the table is retrieved, and a query that
+// reads at most one row is executed. The query consumes a small amount of
+// read capacity.
+//
+// The statements suggested for missing permissions use placeholders, such as
+// <group> and <compartment>, which must be replaced before use.
+//
+// Failures are reported in the returned PermissionReport rather than as an
+// error. The returned error is non-nil only if ctx is nil.
+func (c *Client) CheckPermissions(ctx context.Context, tableNames ...string) (*PermissionReport, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	report := &PermissionReport{}
+	for _, table := range tableNames {
+		report.Checks = append(report.Checks, c.checkPermission(table, "get table", func() error {
+			_, err := c.getTableWithContext(ctx, &GetTableRequest{TableName: table})
+			return err
+		}))
+
+		report.Checks = append(report.Checks, c.checkPermission(table, "read rows", func() error {
+			req := &QueryRequest{
+				Statement: "SELECT * FROM " + table + " LIMIT 1",
+				Limit:     1,
+			}
+			defer req.Close()
+			_, err := c.QueryWithContext(ctx, req)
+			return err
+		}))
+	}
+
+	return report, nil
+}
+
+// checkPermission performs the operation op on the table and returns the
+// result as a PermissionCheck.
+func (c *Client) checkPermission(table, op string, fn func() error) PermissionCheck {
+	check := PermissionCheck{
+		TableName:  table,
+		Operation:  op,
+		Permission: c.requiredPermission(op),
+	}
+
+	start := time.Now()
+	err := fn()
+	check.Elapsed = time.Since(start)
+	if err == nil {
+		check.Status = DiagnosticOK
+		return check
+	}
+
+	check.Status = DiagnosticFail
+	check.Detail = err.Error()
+	check.err = err
+	if nosqlerr.Is(err, nosqlerr.InsufficientPermission, nosqlerr.InvalidAuthorization) {
+		check.Statement = c.grantStatement(table, op)
+	}
+	return check
+}
+
+// requiredPermission returns the name of the permission required by the
+// operation.
+func (c *Client) requiredPermission(op string) string {
+	switch {
+	case strings.EqualFold(c.Mode, "onprem"):
+		return "READ_TABLE"
+	case op == "get table":
+		return "NOSQL_TABLE_READ"
+	default:
+		return "NOSQL_ROWS_READ"
+	}
+}
+
+// grantStatement returns a statement that gives the permission required by
+// the operation on the table.
+func (c *Client) grantStatement(table, op string) string {
+	if strings.EqualFold(c.Mode, "onprem") {
+		return "GRANT READ_TABLE ON " + table + " TO <role>"
+	}
+
+	resource := "nosql-rows"
+	if op == "get table" {
+		resource = "nosql-tables"
+	}
+	return "Allow group <group> to read " + resource + " in compartment <compartment>" +
+		" where target.nosql-table.name = '" + table + "'"
+}
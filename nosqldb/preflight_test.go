@@ -0,0 +1,59 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPermissions(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	client.SetSerialVersion(3)
+
+	mockExec := &mockExecutor{
+		errChan: make(chan error),
+	}
+	client.executor = mockExec
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case mockExec.errChan <- nosqlerr.New(nosqlerr.InsufficientPermission, "not authorized"):
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	report, err := client.CheckPermissions(context.Background(), "orders")
+	require.NoError(t, err)
+	require.Len(t, report.Checks, 2)
+	assert.False(t, report.OK())
+	assert.Equal(t, "NOSQL_TABLE_READ", report.Checks[0].Permission)
+	assert.Equal(t, "NOSQL_ROWS_READ", report.Checks[1].Permission)
+	assert.Equal(t, []string{
+		"Allow group <group> to read nosql-tables in compartment <compartment> where target.nosql-table.name = 'orders'",
+		"Allow group <group> to read nosql-rows in compartment <compartment> where target.nosql-table.name = 'orders'",
+	}, report.MissingStatements())
+
+	err = report.Err()
+	assert.True(t, nosqlerr.Is(err, nosqlerr.InsufficientPermission), "got error %v", err)
+	assert.Contains(t, err.Error(), "2 of 2 permission checks failed")
+
+	report = &PermissionReport{Checks: []PermissionCheck{{Status: DiagnosticOK}}}
+	assert.True(t, report.OK())
+	assert.NoError(t, report.Err())
+	assert.Equal(t, "all 1 permission checks passed", report.Summary())
+}
@@ -0,0 +1,54 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreparedStatementWithBindings(t *testing.T) {
+	stmt, err := newPreparedStatement("declare $a integer; $b integer; select * from T where a = $a and b = $b",
+		"", make([]byte, minSerializedStmtLen), nil, 0, 0, map[string]int{"$a": 0, "$b": 1})
+	require.NoError(t, err)
+	require.NoError(t, stmt.SetVariable("$a", 1))
+
+	bound, err := stmt.WithBindings(map[string]interface{}{"$b": 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"$a": 1, "$b": 2}, bound.bindVariables)
+	assert.Equal(t, []types.FieldValue{1, 2}, bound.getBoundVarValues())
+	assert.Equal(t, map[string]interface{}{"$a": 1}, stmt.bindVariables)
+
+	// A bound copy can be bound again without changing the original.
+	rebound, err := bound.WithBindings(map[string]interface{}{"$a": 3})
+	require.NoError(t, err)
+	assert.Equal(t, []types.FieldValue{3, 2}, rebound.getBoundVarValues())
+	assert.Equal(t, []types.FieldValue{1, 2}, bound.getBoundVarValues())
+
+	_, err = stmt.WithBindings(map[string]interface{}{"$c": 3})
+	assert.Error(t, err)
+
+	// A shared statement can be bound concurrently.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bound, err := stmt.WithBindings(map[string]interface{}{"$b": i})
+			if assert.NoError(t, err) {
+				assert.Equal(t, []types.FieldValue{1, i}, bound.getBoundVarValues())
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, map[string]interface{}{"$a": 1}, stmt.bindVariables)
+}
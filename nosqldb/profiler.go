@@ -0,0 +1,441 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/big"
+	"math/bits"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/common"
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// ProfileOptions specifies options for Client.ProfileTable.
+type ProfileOptions struct {
+	// MaxRows specifies the maximum number of rows scanned.
+	// It is optional. If set to 0, all rows of the table are scanned.
+	MaxRows int
+
+	// ReadUnitsPerSecond specifies the maximum rate at which the scan
+	// consumes read units, to limit its impact on other users of the table.
+	// It is optional. If set to 0, the rate is not limited by the profiler,
+	// but may be limited by the rate limiting of the client.
+	ReadUnitsPerSecond float64
+
+	// MaxReadKB specifies the limit on the amount of data read by each of the
+	// query requests used to scan the table. See QueryRequest.MaxReadKB.
+	// It is optional. If set to 0, the default limit is used.
+	MaxReadKB uint
+}
+
+// TableProfile represents the statistics of the columns of a table computed
+// by Client.ProfileTable.
+type TableProfile struct {
+	// TableName represents the name of the table.
+	TableName string `json:"tableName"`
+
+	// RowsScanned represents the number of rows scanned.
+	RowsScanned int `json:"rowsScanned"`
+
+	// Complete indicates whether all rows of the table were scanned.
+	Complete bool `json:"complete"`
+
+	// ReadUnits represents the read units consumed by the scan.
+	ReadUnits int `json:"readUnits"`
+
+	// Elapsed represents the duration of the scan.
+	Elapsed time.Duration `json:"elapsed"`
+
+	// Columns represents the statistics of the top-level fields of the rows,
+	// sorted by name.
+	Columns []ColumnProfile `json:"columns"`
+}
+
+// String returns a JSON string representation of the TableProfile.
+func (p TableProfile) String() string {
+	return jsonutil.AsPrettyJSON(p)
+}
+
+// ColumnProfile represents the statistics of a column computed by
+// Client.ProfileTable.
+type ColumnProfile struct {
+	// Name represents the name of the column.
+	Name string `json:"name"`
+
+	// Nulls represents the number of rows in which the column is SQL NULL,
+	// JSON null, or missing.
+	Nulls int `json:"nulls"`
+
+	// NullRate represents the fraction of the scanned rows in which the column
+	// is null.
+	NullRate float64 `json:"nullRate"`
+
+	// DistinctEstimate represents an estimate of the number of distinct
+	// non-null values of the column, computed with HyperLogLog. Its relative
+	// error is typically less than 2%.
+	DistinctEstimate uint64 `json:"distinctEstimate"`
+
+	// Types represents the number of non-null values of each type, such as
+	// "INTEGER" or "STRING".
+	Types map[string]int `json:"types"`
+
+	// Min and Max represent the smallest and largest non-null values. They are
+	// only computed for numbers, strings and timestamps, and are nil if the
+	// values of the column are of different kinds.
+	Min types.FieldValue `json:"min,omitempty"`
+	Max types.FieldValue `json:"max,omitempty"`
+
+	// Size represents the distribution of the serialized sizes of the
+	// non-null values.
+	Size SizeDistribution `json:"size"`
+
+	// kind is the kind of the values for Min and Max, or "mixed".
+	kind string
+	hll  *hyperLogLog
+}
+
+// SizeDistribution represents the distribution of the sizes of values in
+// bytes.
+type SizeDistribution struct {
+	Min  int     `json:"min"`
+	Max  int     `json:"max"`
+	Mean float64 `json:"mean"`
+
+	// Histogram represents the number of values whose size is at most each
+	// power of two, and greater than the previous power of two, keyed by the
+	// power of two.
+	Histogram map[int]int `json:"histogram"`
+
+	total int
+	count int
+}
+
+// ProfileTable scans a table and returns the statistics of its columns, such
+// as the rate of null values, an estimate of the number of distinct values,
+// the minimum and maximum values, and the distribution of the sizes of the
+// values. It is intended for reviewing the schema of a table and planning
+// indexes. The String method of the result returns a JSON report.
+//
+// The scan consumes read units for all scanned rows. Use
+// opts.ReadUnitsPerSecond to limit the rate of the scan, and opts.MaxRows to
+// profile a part of a large table. The opts may be nil to use the default
+// options.
+func (c *Client) ProfileTable(ctx context.Context, tableName string, opts *ProfileOptions) (*TableProfile, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	if err := validateTableName(tableName); err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &ProfileOptions{}
+	}
+	if opts.MaxRows < 0 || opts.ReadUnitsPerSecond < 0 {
+		return nil, nosqlerr.NewIllegalArgument("ProfileTable: MaxRows and ReadUnitsPerSecond must not be negative")
+	}
+
+	req := &QueryRequest{
+		Statement: "SELECT * FROM " + tableName,
+		MaxReadKB: opts.MaxReadKB,
+	}
+	defer req.Close()
+	if opts.ReadUnitsPerSecond > 0 {
+		req.SetReadRateLimiter(common.NewSimpleRateLimiterWithDuration(opts.ReadUnitsPerSecond, 1))
+	}
+
+	start := time.Now()
+	p := newTableProfiler(tableName)
+	for opts.MaxRows == 0 || p.profile.RowsScanned < opts.MaxRows {
+		res, err := c.QueryWithContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		p.profile.ReadUnits += res.ReadUnits
+
+		rows, err := res.GetResults()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			if opts.MaxRows > 0 && p.profile.RowsScanned == opts.MaxRows {
+				break
+			}
+			p.add(row)
+		}
+
+		if req.IsDone() {
+			p.profile.Complete = true
+			break
+		}
+	}
+
+	p.profile.Elapsed = time.Since(start)
+	return p.result(), nil
+}
+
+// tableProfiler computes the statistics of the rows it is given.
+type tableProfiler struct {
+	profile TableProfile
+	columns map[string]*ColumnProfile
+}
+
+func newTableProfiler(tableName string) *tableProfiler {
+	return &tableProfiler{
+		profile: TableProfile{TableName: tableName},
+		columns: make(map[string]*ColumnProfile),
+	}
+}
+
+func (p *tableProfiler) add(row *types.MapValue) {
+	for name, v := range row.Map() {
+		col, ok := p.columns[name]
+		if !ok {
+			// The column was missing in the rows scanned before.
+			col = &ColumnProfile{
+				Name:  name,
+				Nulls: p.profile.RowsScanned,
+				Types: make(map[string]int),
+				hll:   newHyperLogLog(),
+			}
+			p.columns[name] = col
+		}
+		col.add(v)
+	}
+
+	p.profile.RowsScanned++
+	for name, col := range p.columns {
+		if _, ok := row.Get(name); !ok {
+			col.Nulls++
+		}
+	}
+}
+
+// result returns the profile with the statistics of all columns.
+func (p *tableProfiler) result() *TableProfile {
+	profile := p.profile
+	profile.Columns = make([]ColumnProfile, 0, len(p.columns))
+	for _, col := range p.columns {
+		if profile.RowsScanned > 0 {
+			col.NullRate = float64(col.Nulls) / float64(profile.RowsScanned)
+		}
+		col.DistinctEstimate = col.hll.estimate()
+		if col.Size.count > 0 {
+			col.Size.Mean = float64(col.Size.total) / float64(col.Size.count)
+		}
+		if col.kind == "mixed" {
+			col.Min, col.Max = nil, nil
+		}
+		profile.Columns = append(profile.Columns, *col)
+	}
+
+	sort.Slice(profile.Columns, func(i, j int) bool {
+		return profile.Columns[i].Name < profile.Columns[j].Name
+	})
+	return &profile
+}
+
+func (col *ColumnProfile) add(v types.FieldValue) {
+	v = derefString(v)
+	if isNullValue(v) {
+		col.Nulls++
+		return
+	}
+
+	col.Types[profileTypeName(v)]++
+	col.hll.add(profileHash(v))
+
+	w := binary.NewWriter()
+	if _, err := w.WriteFieldValue(v); err == nil {
+		col.Size.add(w.Size())
+	}
+
+	kind, ok := comparableKind(v)
+	switch {
+	case col.kind == "mixed":
+	case !ok || (col.kind != "" && col.kind != kind):
+		col.kind = "mixed"
+	default:
+		col.kind = kind
+		if col.Min == nil || compareProfileValues(v, col.Min) < 0 {
+			col.Min = v
+		}
+		if col.Max == nil || compareProfileValues(v, col.Max) > 0 {
+			col.Max = v
+		}
+	}
+}
+
+func (s *SizeDistribution) add(size int) {
+	if s.count == 0 || size < s.Min {
+		s.Min = size
+	}
+	if size > s.Max {
+		s.Max = size
+	}
+	s.total += size
+	s.count++
+
+	if s.Histogram == nil {
+		s.Histogram = make(map[int]int)
+	}
+	bucket := 1
+	if size > 1 {
+		bucket = 1 << bits.Len(uint(size-1))
+	}
+	s.Histogram[bucket]++
+}
+
+// profileTypeName returns the name of the database type of a value.
+func profileTypeName(v types.FieldValue) string {
+	switch v.(type) {
+	case int, int8, int16, int32, uint8, uint16:
+		return "INTEGER"
+	case int64, uint32, uint, uint64:
+		return "LONG"
+	case float32, float64:
+		return "DOUBLE"
+	case *big.Rat, json.Number:
+		return "NUMBER"
+	case string:
+		return "STRING"
+	case bool:
+		return "BOOLEAN"
+	case time.Time:
+		return "TIMESTAMP"
+	case []byte:
+		return "BINARY"
+	}
+	if _, ok := asMap(v); ok {
+		return "MAP"
+	}
+	if _, ok := asArray(v); ok {
+		return "ARRAY"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// comparableKind returns the kind of values a value can be compared with for
+// the minimum and maximum.
+func comparableKind(v types.FieldValue) (string, bool) {
+	switch v.(type) {
+	case string:
+		return "string", true
+	case time.Time:
+		return "time", true
+	}
+	if _, ok := numericRat(v); ok {
+		return "number", true
+	}
+	return "", false
+}
+
+// compareProfileValues compares two values of the same comparable kind.
+func compareProfileValues(a, b types.FieldValue) int {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case time.Time:
+		switch t := b.(time.Time); {
+		case a.Before(t):
+			return -1
+		case a.After(t):
+			return 1
+		}
+		return 0
+	}
+	ra, _ := numericRat(a)
+	rb, _ := numericRat(b)
+	return ra.Cmp(rb)
+}
+
+// profileHash returns a hash of a value for estimating the number of distinct
+// values. Numbers that are equal hash to the same value regardless of their
+// types.
+func profileHash(v types.FieldValue) uint64 {
+	h := fnv.New64a()
+	if r, ok := numericRat(v); ok {
+		fmt.Fprintf(h, "n%s", r.RatString())
+	} else {
+		switch v := v.(type) {
+		case string:
+			fmt.Fprintf(h, "s%s", v)
+		case time.Time:
+			fmt.Fprintf(h, "t%d", v.UnixNano())
+		default:
+			fmt.Fprintf(h, "%T", v)
+			data, _ := json.Marshal(v)
+			h.Write(data)
+		}
+	}
+	return mix64(h.Sum64())
+}
+
+// mix64 improves the distribution of the bits of a hash, with the finalizer
+// of SplitMix64.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// hllPrecision is the number of bits of a hash used to select a register of
+// a hyperLogLog, which has 2^hllPrecision registers.
+const hllPrecision = 14
+
+// hyperLogLog estimates the number of distinct hashes added to it.
+type hyperLogLog struct {
+	registers []uint8
+}
+
+func newHyperLogLog() *hyperLogLog {
+	return &hyperLogLog{registers: make([]uint8, 1<<hllPrecision)}
+}
+
+func (h *hyperLogLog) add(hash uint64) {
+	idx := hash >> (64 - hllPrecision)
+	rank := uint8(bits.LeadingZeros64(hash<<hllPrecision|1<<(hllPrecision-1))) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+func (h *hyperLogLog) estimate() uint64 {
+	m := float64(len(h.registers))
+	var sum float64
+	var zeros int
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	e := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if e <= 2.5*m && zeros > 0 {
+		// Use linear counting for small cardinalities.
+		e = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(e))
+}
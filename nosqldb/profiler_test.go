@@ -0,0 +1,84 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileTable(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	_, err = client.ProfileTable(context.Background(), "", nil)
+	assert.True(t, nosqlerr.IsIllegalArgument(err), "got error %v", err)
+	_, err = client.ProfileTable(context.Background(), "orders", &ProfileOptions{MaxRows: -1})
+	assert.True(t, nosqlerr.IsIllegalArgument(err), "got error %v", err)
+
+	p := newTableProfiler("orders")
+	for i := 0; i < 1000; i++ {
+		row := types.NewMapValue(map[string]interface{}{
+			"id":     i,
+			"status": []string{"new", "paid", "shipped"}[i%3],
+		})
+		if i%4 == 0 {
+			row.Put("note", types.NullValueInstance)
+		} else if i%4 == 1 {
+			row.Put("note", "note "+strconv.Itoa(i))
+		}
+		if i == 500 {
+			row.Put("status", 7)
+		}
+		p.add(row)
+	}
+
+	profile := p.result()
+	assert.Equal(t, 1000, profile.RowsScanned)
+	require.Len(t, profile.Columns, 3)
+	id, note, status := profile.Columns[0], profile.Columns[1], profile.Columns[2]
+
+	assert.Equal(t, "id", id.Name)
+	assert.Equal(t, 0, id.Nulls)
+	assert.InDelta(t, 1000, float64(id.DistinctEstimate), 20)
+	assert.Equal(t, 0, id.Min)
+	assert.Equal(t, 999, id.Max)
+	assert.Equal(t, map[string]int{"INTEGER": 1000}, id.Types)
+
+	// The note column is null or missing in 3 of 4 rows.
+	assert.Equal(t, "note", note.Name)
+	assert.Equal(t, 750, note.Nulls)
+	assert.Equal(t, 0.75, note.NullRate)
+	assert.InDelta(t, 250, float64(note.DistinctEstimate), 5)
+	assert.Equal(t, "note 1", note.Min)
+
+	// Min and Max are not computed for values of different kinds.
+	assert.Equal(t, "status", status.Name)
+	assert.Equal(t, uint64(4), status.DistinctEstimate)
+	assert.Nil(t, status.Min)
+	assert.Equal(t, map[string]int{"STRING": 999, "INTEGER": 1}, status.Types)
+	var sized int
+	for size, n := range status.Size.Histogram {
+		assert.GreaterOrEqual(t, size, status.Size.Min)
+		sized += n
+	}
+	assert.Equal(t, 1000, sized)
+	assert.Greater(t, status.Size.Mean, 0.0)
+
+	hll := newHyperLogLog()
+	for i := 0; i < 100000; i++ {
+		hll.add(profileHash(i))
+	}
+	assert.InEpsilon(t, 100000, float64(hll.estimate()), 0.03)
+	assert.Contains(t, profile.String(), `"distinctEstimate"`)
+}
@@ -0,0 +1,79 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"sync"
+)
+
+// protocolInfo represents the protocol versions negotiated with a server.
+type protocolInfo struct {
+	serialVersion       int16
+	queryVersion        int16
+	serverSerialVersion int
+}
+
+// protocolRegistry caches the protocol versions negotiated by clients for
+// each request URL, for the clients created with
+// Config.CacheProtocolVersions. It is shared by all clients of the process.
+var protocolRegistry = struct {
+	mu    sync.Mutex
+	infos map[string]protocolInfo
+}{}
+
+// ResetProtocolVersionCache removes the protocol versions cached for the
+// clients created with Config.CacheProtocolVersions, so that new clients
+// negotiate them again with the server. It should be called when the servers
+// are upgraded to support newer protocol versions.
+//
+// Existing clients are not affected.
+func ResetProtocolVersionCache() {
+	protocolRegistry.mu.Lock()
+	defer protocolRegistry.mu.Unlock()
+	protocolRegistry.infos = nil
+}
+
+// loadProtocolVersions sets the protocol versions of a new client to the
+// versions cached for its request URL, if any.
+func (c *Client) loadProtocolVersions() {
+	if !c.CacheProtocolVersions {
+		return
+	}
+
+	protocolRegistry.mu.Lock()
+	info, ok := protocolRegistry.infos[c.requestURL]
+	protocolRegistry.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	c.serialVersion = info.serialVersion
+	c.queryVersion = info.queryVersion
+	c.serverSerialVersion = info.serverSerialVersion
+	c.logger.Fine("Using cached serial version %d and query version %d for %s",
+		info.serialVersion, info.queryVersion, c.requestURL)
+}
+
+// storeProtocolVersions caches the protocol versions of the client for its
+// request URL. It must be called with c.lockMux held.
+func (c *Client) storeProtocolVersions() {
+	if !c.CacheProtocolVersions {
+		return
+	}
+
+	protocolRegistry.mu.Lock()
+	defer protocolRegistry.mu.Unlock()
+	if protocolRegistry.infos == nil {
+		protocolRegistry.infos = make(map[string]protocolInfo)
+	}
+	protocolRegistry.infos[c.requestURL] = protocolInfo{
+		serialVersion:       c.serialVersion,
+		queryVersion:        c.queryVersion,
+		serverSerialVersion: c.serverSerialVersion,
+	}
+}
@@ -0,0 +1,49 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheProtocolVersions(t *testing.T) {
+	defer ResetProtocolVersionCache()
+	cfg := Config{
+		Endpoint:              "protocolCacheHost:8080",
+		AuthorizationProvider: &DummyAccessTokenProvider{TenantID: "TestTenantId"},
+		CacheProtocolVersions: true,
+	}
+	c1, err := NewClient(cfg)
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	require.True(t, c1.decrementSerialVersion(c1.serialVersion))
+	require.True(t, c1.decrementQueryVersion(c1.queryVersion))
+	c1.setServerSerialVersion(http.Header{"X-Nosql-Serial-Version": []string{"3"}})
+
+	c2, err := NewClient(cfg)
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	assert.Equal(t, proto.DefaultSerialVersion-1, c2.GetSerialVersion())
+	assert.Equal(t, proto.DefaultQueryVersion-1, c2.GetQueryVersion())
+	assert.Equal(t, 3, c2.GetServerSerialVersion())
+
+	cfg.CacheProtocolVersions = false
+	c3, err := NewClient(cfg)
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	assert.Equal(t, proto.DefaultSerialVersion, c3.GetSerialVersion())
+
+	ResetProtocolVersionCache()
+	cfg.CacheProtocolVersions = true
+	c4, err := NewClient(cfg)
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	assert.Equal(t, proto.DefaultSerialVersion, c4.GetSerialVersion())
+	assert.Equal(t, proto.DefaultQueryVersion, c4.GetQueryVersion())
+}
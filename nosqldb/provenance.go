@@ -0,0 +1,177 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// ProvenanceConfig specifies the columns that a Client stamps on every row
+// written by a put operation, either with Client.Put() or as part of a
+// Client.WriteMultiple() operation.
+//
+// Column names that are empty are not stamped. The columns must exist in the
+// target tables with compatible types: a STRING for UpdatedByColumn and
+// OriginServiceColumn, and a TIMESTAMP for UpdatedAtColumn.
+//
+// The values stamped on the rows are obtained from the Provenance associated
+// with the context passed to Client.PutWithContext() or
+// Client.WriteMultipleWithContext(), see WithProvenance().
+//
+// Provenance columns are only stamped on rows specified as PutRequest.Value,
+// rows specified as PutRequest.StructValue are left unchanged. The columns are
+// written along with the row, PutRequest.Value itself is not modified.
+type ProvenanceConfig struct {
+	// UpdatedByColumn specifies the name of column that stores the identity
+	// of the principal that last updated the row.
+	UpdatedByColumn string `json:"updatedByColumn,omitempty"`
+
+	// UpdatedAtColumn specifies the name of column that stores the time when
+	// the row was last updated.
+	UpdatedAtColumn string `json:"updatedAtColumn,omitempty"`
+
+	// OriginServiceColumn specifies the name of column that stores the name
+	// of the service that last updated the row.
+	OriginServiceColumn string `json:"originServiceColumn,omitempty"`
+
+	// OriginService specifies the default service name used when the context
+	// does not carry one.
+	OriginService string `json:"originService,omitempty"`
+
+	// Required specifies whether a put operation must fail with an
+	// IllegalArgument error if the context does not carry the value required
+	// for UpdatedByColumn.
+	Required bool `json:"required,omitempty"`
+
+	// Now returns the time stamped on UpdatedAtColumn.
+	// If not set, time.Now() is used.
+	Now func() time.Time `json:"-"`
+}
+
+// Provenance represents the origin of a write operation.
+type Provenance struct {
+	// UpdatedBy specifies the identity of the principal that performs the write.
+	UpdatedBy string
+
+	// OriginService specifies the name of the service that performs the write.
+	OriginService string
+}
+
+// provenanceKey is the context key for Provenance values.
+type provenanceKey struct{}
+
+// WithProvenance returns a copy of ctx that carries the specified Provenance.
+func WithProvenance(ctx context.Context, p Provenance) context.Context {
+	return context.WithValue(ctx, provenanceKey{}, p)
+}
+
+// ProvenanceFromContext returns the Provenance associated with ctx, if any.
+func ProvenanceFromContext(ctx context.Context) (p Provenance, ok bool) {
+	if ctx == nil {
+		return
+	}
+
+	p, ok = ctx.Value(provenanceKey{}).(Provenance)
+	return
+}
+
+// stamp sets the provenance columns on the rows of put operations in req.
+//
+// The columns are set on a copy of PutRequest.Value, which is written instead
+// of it, so that the value specified by the application is left unchanged and
+// a request that is executed again is stamped anew.
+func (pc *ProvenanceConfig) stamp(ctx context.Context, req Request) error {
+	if pc == nil {
+		return nil
+	}
+
+	switch r := req.(type) {
+	case *PutRequest:
+		return pc.stampPut(ctx, r)
+
+	case *WriteMultipleRequest:
+		if r.coalesced {
+			return nil
+		}
+		for _, op := range r.Operations {
+			if op == nil || op.PutRequest == nil {
+				continue
+			}
+
+			if err := pc.stampPut(ctx, op.PutRequest); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (pc *ProvenanceConfig) stampPut(ctx context.Context, req *PutRequest) error {
+	p, _ := ProvenanceFromContext(ctx)
+	if pc.Required && pc.UpdatedByColumn != "" && p.UpdatedBy == "" {
+		return nosqlerr.NewIllegalArgument("PutRequest: the context does not "+
+			"specify a value for the provenance column %q", pc.UpdatedByColumn)
+	}
+
+	req.stampedValue = nil
+	if req.Value == nil {
+		return nil
+	}
+
+	v := copyMapValue(req.Value)
+	if pc.UpdatedByColumn != "" && p.UpdatedBy != "" {
+		v.Put(pc.UpdatedByColumn, p.UpdatedBy)
+	}
+
+	if pc.UpdatedAtColumn != "" {
+		now := time.Now
+		if pc.Now != nil {
+			now = pc.Now
+		}
+		v.Put(pc.UpdatedAtColumn, now().UTC())
+	}
+
+	if pc.OriginServiceColumn != "" {
+		origin := p.OriginService
+		if origin == "" {
+			origin = pc.OriginService
+		}
+		if origin != "" {
+			v.Put(pc.OriginServiceColumn, origin)
+		}
+	}
+
+	req.stampedValue = v
+	return nil
+}
+
+// copyMapValue returns a shallow copy of v that keeps the insertion order of
+// an ordered MapValue.
+func copyMapValue(v *types.MapValue) *types.MapValue {
+	if !v.IsOrdered() {
+		m := make(map[string]interface{}, v.Len())
+		for k, x := range v.Map() {
+			m[k] = x
+		}
+		return types.NewMapValue(m)
+	}
+
+	c := types.NewOrderedMapValue()
+	for i := 1; ; i++ {
+		k, x, ok := v.GetByIndex(i)
+		if !ok {
+			return c
+		}
+		c.Put(k, x)
+	}
+}
@@ -0,0 +1,295 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+const (
+	defaultProxyHealthPath    = "/V2/health"
+	defaultProxyStatsPath     = "/V2/stats"
+	defaultProxyStatsInterval = time.Minute
+)
+
+// ProxyStatsOptions specifies options for Client.ProxyStats and
+// Client.StartProxyStatsPoller.
+type ProxyStatsOptions struct {
+	// HealthPath specifies the path of the health endpoint of the proxy.
+	// It is optional. If not set, "/V2/health" is used.
+	HealthPath string
+
+	// StatsPath specifies the path of the statistics endpoint of the proxy,
+	// which returns the statistics as a JSON object.
+	// It is optional. If not set, "/V2/stats" is used.
+	StatsPath string
+
+	// Interval specifies the interval between the polls of
+	// Client.StartProxyStatsPoller.
+	// It is optional. If set to 0, the default interval of 1 minute is used.
+	Interval time.Duration
+
+	// Timeout specifies the timeout of each poll.
+	// It is optional. If set to 0, the Client's default request timeout is used.
+	Timeout time.Duration
+
+	// OnPoll specifies an optional function that is called with the stats
+	// returned by each poll of Client.StartProxyStatsPoller.
+	OnPoll func(stats *ProxyStats)
+}
+
+// ProxyStats represents the health and the statistics reported by the proxy
+// of an on-premise deployment.
+type ProxyStats struct {
+	// Endpoint represents the endpoint of the proxy.
+	Endpoint string `json:"endpoint"`
+
+	// Time represents the time the stats were retrieved.
+	Time time.Time `json:"time"`
+
+	// Healthy reports whether the health endpoint of the proxy returned a
+	// successful response.
+	Healthy bool `json:"healthy"`
+
+	// HealthStatus represents the HTTP status code returned by the health
+	// endpoint, or 0 if the proxy could not be reached.
+	HealthStatus int `json:"healthStatus"`
+
+	// Latency represents the round trip time of the health request, as
+	// observed by the client.
+	Latency time.Duration `json:"latency"`
+
+	// Metrics represents the statistics returned by the stats endpoint, such
+	// as the latency and error counts of the requests handled by the proxy.
+	// Their names and format depend on the version of the proxy. It is nil if
+	// the stats could not be retrieved.
+	Metrics *types.MapValue `json:"metrics,omitempty"`
+
+	// Err represents the error that occurred when the health or the stats
+	// endpoint was requested, if any.
+	Err error `json:"-"`
+}
+
+// String returns a JSON string representation of the ProxyStats.
+func (s ProxyStats) String() string {
+	return jsonutil.AsJSON(s)
+}
+
+// HealthView combines the stats reported by the proxy with the metrics
+// collected by the client, for a unified view of the health of an on-premise
+// deployment.
+type HealthView struct {
+	// Proxy represents the latest stats reported by the proxy. It is nil if
+	// the proxy has not been polled yet.
+	Proxy *ProxyStats `json:"proxy"`
+
+	// MessageSizes represents the sizes of the messages exchanged with the
+	// proxy by the client, see Client.MessageSizeStats.
+	MessageSizes map[string]MessageSizeStats `json:"messageSizes"`
+}
+
+// String returns a JSON string representation of the HealthView.
+func (v HealthView) String() string {
+	return jsonutil.AsJSON(v)
+}
+
+// ProxyStats retrieves the health and the statistics of the proxy of an
+// on-premise deployment from the health and stats endpoints of the proxy,
+// which must be enabled in the proxy configuration.
+//
+// Errors that occur when the endpoints are requested are reported in
+// ProxyStats.Err rather than returned, so that an unreachable proxy is
+// reported as unhealthy. An error is returned if the Client connects to the
+// cloud service.
+func (c *Client) ProxyStats(ctx context.Context, opts *ProxyStatsOptions) (*ProxyStats, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+	if c.isCloud {
+		return nil, nosqlerr.NewIllegalArgument("proxy stats are only available for on-premise deployments")
+	}
+	if opts == nil {
+		opts = &ProxyStatsOptions{}
+	}
+	return c.pollProxyStats(ctx, opts), nil
+}
+
+func (c *Client) pollProxyStats(ctx context.Context, opts *ProxyStatsOptions) *ProxyStats {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = c.DefaultRequestTimeout()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stats := &ProxyStats{Endpoint: c.Endpoint, Time: time.Now()}
+	path := opts.HealthPath
+	if path == "" {
+		path = defaultProxyHealthPath
+	}
+	start := time.Now()
+	status, _, err := c.getProxyEndpoint(ctx, path)
+	stats.Latency = time.Since(start)
+	stats.HealthStatus = status
+	stats.Healthy = err == nil && status/100 == 2
+	if err != nil {
+		stats.Err = err
+		return stats
+	}
+
+	path = opts.StatsPath
+	if path == "" {
+		path = defaultProxyStatsPath
+	}
+	status, data, err := c.getProxyEndpoint(ctx, path)
+	switch {
+	case err != nil:
+		stats.Err = err
+	case status/100 != 2:
+		stats.Err = fmt.Errorf("proxy stats endpoint %s returned %d %s", path, status, http.StatusText(status))
+	default:
+		if stats.Metrics, err = types.NewMapValueFromJSON(string(data)); err != nil {
+			stats.Err = nosqlerr.NewWithCause(nosqlerr.BadProtocolMessage, err, "invalid proxy stats")
+		}
+	}
+	return stats
+}
+
+// getProxyEndpoint sends a GET request for the specified path of the proxy,
+// and returns the status code and the body of the response.
+func (c *Client) getProxyEndpoint(ctx context.Context, path string) (int, []byte, error) {
+	reqURL := strings.TrimSuffix(c.Endpoint, "/") + path
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("User-Agent", sdkutil.UserAgent())
+	httpReq.Header.Set("x-nosql-request-id", strconv.Itoa(int(c.nextRequestID())))
+
+	authStr, err := c.getAuthString(c.AuthorizationProvider, &GetTableRequest{TableName: "noop"})
+	if err != nil {
+		return 0, nil, err
+	}
+	if authStr != "" {
+		httpReq.Header.Set("Authorization", authStr)
+	}
+	if err = c.signHTTPRequest(ctx, c.AuthorizationProvider, httpReq); err != nil {
+		return 0, nil, err
+	}
+
+	httpResp, err := c.executor.Do(httpReq)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer httpResp.Body.Close()
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return httpResp.StatusCode, nil, err
+	}
+	return httpResp.StatusCode, data, nil
+}
+
+// ProxyStatsPoller polls the health and the statistics of the proxy of an
+// on-premise deployment in the background. It is created with
+// Client.StartProxyStatsPoller, and must be stopped with Stop.
+type ProxyStatsPoller struct {
+	client *Client
+	opts   ProxyStatsOptions
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	latest *ProxyStats
+}
+
+// StartProxyStatsPoller starts polling the health and the statistics of the
+// proxy every opts.Interval, see Client.ProxyStats. The first poll is done
+// immediately. The latest stats are available from ProxyStatsPoller.Latest,
+// and along with the client metrics from ProxyStatsPoller.Health.
+//
+// An error is returned if the Client connects to the cloud service.
+func (c *Client) StartProxyStatsPoller(opts *ProxyStatsOptions) (*ProxyStatsPoller, error) {
+	if c.isCloud {
+		return nil, nosqlerr.NewIllegalArgument("proxy stats are only available for on-premise deployments")
+	}
+
+	p := &ProxyStatsPoller{client: c, done: make(chan struct{})}
+	if opts != nil {
+		p.opts = *opts
+	}
+	if p.opts.Interval <= 0 {
+		p.opts.Interval = defaultProxyStatsInterval
+	}
+
+	var ctx context.Context
+	ctx, p.cancel = context.WithCancel(context.Background())
+	go p.run(ctx)
+	return p, nil
+}
+
+func (p *ProxyStatsPoller) run(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+	for {
+		stats := p.client.pollProxyStats(ctx, &p.opts)
+		if ctx.Err() != nil {
+			return
+		}
+
+		p.mu.Lock()
+		p.latest = stats
+		p.mu.Unlock()
+		if p.opts.OnPoll != nil {
+			p.opts.OnPoll(stats)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Latest returns the stats returned by the latest poll, or nil if the proxy
+// has not been polled yet.
+func (p *ProxyStatsPoller) Latest() *ProxyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.latest
+}
+
+// Health returns the latest stats of the proxy along with the metrics
+// collected by the client.
+func (p *ProxyStatsPoller) Health() *HealthView {
+	return &HealthView{
+		Proxy:        p.Latest(),
+		MessageSizes: p.client.MessageSizeStats(false),
+	}
+}
+
+// Stop stops polling and waits for the poll in progress, if any, to return.
+// It is safe to call Stop more than once.
+func (p *ProxyStatsPoller) Stop() {
+	p.cancel()
+	<-p.done
+}
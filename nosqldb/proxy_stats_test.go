@@ -0,0 +1,86 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyStats(t *testing.T) {
+	healthy := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/V2/health":
+			if !healthy {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			}
+		case "/V2/stats":
+			w.Write([]byte(`{"requestLatencyAvgMs": 3, "requestErrors": 2}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(Config{
+		Mode:                  "onprem",
+		Endpoint:              srv.URL,
+		AuthorizationProvider: &DummyAccessTokenProvider{TenantID: "TestTenantId"},
+	})
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	defer client.Close()
+
+	stats, err := client.ProxyStats(context.Background(), nil)
+	require.NoErrorf(t, err, "ProxyStats() got error %v", err)
+	assert.True(t, stats.Healthy)
+	assert.Equal(t, http.StatusOK, stats.HealthStatus)
+	assert.NoError(t, stats.Err)
+	require.NotNil(t, stats.Metrics)
+	n, ok := stats.Metrics.GetInt64("requestErrors")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), n)
+
+	// Unknown stats endpoint.
+	stats, err = client.ProxyStats(context.Background(), &ProxyStatsOptions{StatsPath: "/none"})
+	require.NoErrorf(t, err, "ProxyStats() got error %v", err)
+	assert.True(t, stats.Healthy)
+	assert.Error(t, stats.Err)
+	assert.Nil(t, stats.Metrics)
+
+	healthy = false
+	polled := make(chan *ProxyStats, 1)
+	p, err := client.StartProxyStatsPoller(&ProxyStatsOptions{
+		Interval: time.Hour,
+		OnPoll:   func(s *ProxyStats) { polled <- s },
+	})
+	require.NoErrorf(t, err, "StartProxyStatsPoller() got error %v", err)
+	stats = <-polled
+	p.Stop()
+	p.Stop()
+	assert.False(t, stats.Healthy)
+	assert.Equal(t, http.StatusServiceUnavailable, stats.HealthStatus)
+	view := p.Health()
+	assert.Equal(t, stats, view.Proxy)
+	assert.NotNil(t, view.MessageSizes)
+
+	_, err = client.ProxyStats(nil, nil)
+	assert.Error(t, err)
+
+	cloud, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	_, err = cloud.StartProxyStatsPoller(nil)
+	assert.Truef(t, nosqlerr.IsIllegalArgument(err), "StartProxyStatsPoller() for cloud got error %v", err)
+}
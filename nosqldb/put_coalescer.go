@@ -0,0 +1,267 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// putCoalescer groups the puts that share a shard key within a time window
+// into WriteMultiple requests, see Config.PutCoalescingWindow.
+type putCoalescer struct {
+	window time.Duration
+
+	mu sync.Mutex
+	// batches maps the group of each pending batch to the batch.
+	batches map[putGroup]*putBatch
+	// shardKeys caches the shard key fields of tables, which cannot change.
+	shardKeys map[string][]string
+}
+
+// putGroup identifies the puts that can be executed in the same
+// WriteMultiple request.
+type putGroup struct {
+	namespace  string
+	tableName  string
+	shardKey   string
+	durability types.Durability
+	// authProvider is the AuthorizationProvider carried by the context of
+	// the puts, if any, so that the puts of different principals are not
+	// signed with the credentials of one of them.
+	authProvider AuthorizationProvider
+}
+
+// putBatch represents the puts of a group that are waiting to be executed.
+type putBatch struct {
+	// ctx is the context of the first put, whose values are used to execute
+	// the batch.
+	ctx   context.Context
+	puts  []*pendingPut
+	timer *time.Timer
+	// flushing is set when the batch starts executing, after which its puts
+	// cannot be removed.
+	flushing bool
+}
+
+// pendingPut represents a put that is waiting for the result of its batch.
+type pendingPut struct {
+	req  *PutRequest
+	done chan struct{}
+	res  *PutResult
+	err  error
+}
+
+// newPutCoalescer returns a putCoalescer for the specified configuration, or
+// nil if puts are not coalesced.
+func newPutCoalescer(cfg *Config) *putCoalescer {
+	if cfg.PutCoalescingWindow <= 0 {
+		return nil
+	}
+	return &putCoalescer{
+		window:    cfg.PutCoalescingWindow,
+		batches:   make(map[putGroup]*putBatch),
+		shardKeys: make(map[string][]string),
+	}
+}
+
+// coalescePut executes req as part of a WriteMultiple request along with the
+// other puts of its group issued within the coalescing window. It reports
+// false if req cannot be coalesced, such as when the shard key of the table
+// cannot be determined, in which case req must be executed on its own.
+//
+// The before hooks are run and the provenance is stamped with ctx, as for a
+// put executed on its own. The caller runs the after hooks.
+func (c *Client) coalescePut(ctx context.Context, req *PutRequest) (res *PutResult, ok bool, err error) {
+	pc := c.putCoalescer
+	group, ok := c.putGroup(ctx, req)
+	if !ok {
+		return nil, false, nil
+	}
+
+	if err = c.runBeforeHooks(ctx, req); err != nil {
+		return nil, true, err
+	}
+	if err = c.Provenance.stamp(ctx, req); err != nil {
+		return nil, true, err
+	}
+
+	p := &pendingPut{req: req, done: make(chan struct{})}
+	pc.mu.Lock()
+	b := pc.batches[group]
+	if b == nil {
+		b = &putBatch{ctx: detachedContext{ctx}}
+		b.timer = time.AfterFunc(pc.window, func() { c.flushPuts(group, b) })
+		pc.batches[group] = b
+	}
+	b.puts = append(b.puts, p)
+	full := len(b.puts) == maxCloudBatchOps
+	if full {
+		// Later puts of the group are added to a new batch.
+		delete(pc.batches, group)
+	}
+	pc.mu.Unlock()
+
+	if full && b.timer.Stop() {
+		go c.flushPuts(group, b)
+	}
+
+	select {
+	case <-p.done:
+		return p.res, true, p.err
+	case <-ctx.Done():
+	}
+
+	// The put is removed from its batch, unless the batch is executing, in
+	// which case its result is returned, so that a put that is written is
+	// not reported as failed.
+	pc.mu.Lock()
+	if !b.flushing {
+		for i, q := range b.puts {
+			if q == p {
+				b.puts = append(b.puts[:i:i], b.puts[i+1:]...)
+				break
+			}
+		}
+		pc.mu.Unlock()
+		return nil, true, ctx.Err()
+	}
+	pc.mu.Unlock()
+
+	<-p.done
+	return p.res, true, p.err
+}
+
+// putGroup returns the group of req, or false if the shard key of its row
+// cannot be determined, or if the AuthorizationProvider carried by ctx cannot
+// be compared with those of other puts.
+func (c *Client) putGroup(ctx context.Context, req *PutRequest) (putGroup, bool) {
+	pc := c.putCoalescer
+	authProvider, ok := AuthorizationProviderFromContext(ctx)
+	if ok && reflect.TypeOf(authProvider).Kind() != reflect.Ptr {
+		return putGroup{}, false
+	}
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = c.DefaultNamespace()
+	}
+
+	tableKey := namespace + ":" + req.TableName
+	pc.mu.Lock()
+	shardKey, ok := pc.shardKeys[tableKey]
+	pc.mu.Unlock()
+	if !ok {
+		var err error
+		if _, shardKey, err = c.tableKeys(ctx, namespace, req.TableName); err != nil {
+			return putGroup{}, false
+		}
+		pc.mu.Lock()
+		pc.shardKeys[tableKey] = shardKey
+		pc.mu.Unlock()
+	}
+
+	row, err := req.rowValue()
+	if err != nil || row == nil {
+		return putGroup{}, false
+	}
+	k, err := types.KeyFromMapValue(row, shardKey...)
+	if err != nil {
+		return putGroup{}, false
+	}
+	key, err := k.Encode()
+	if err != nil {
+		return putGroup{}, false
+	}
+
+	return putGroup{
+		namespace:    namespace,
+		tableName:    req.TableName,
+		shardKey:     key,
+		durability:   req.Durability,
+		authProvider: authProvider,
+	}, true
+}
+
+// flushPuts executes the puts of batch b in a WriteMultiple request and
+// delivers the result of each put.
+func (c *Client) flushPuts(group putGroup, b *putBatch) {
+	pc := c.putCoalescer
+	pc.mu.Lock()
+	if pc.batches[group] == b {
+		delete(pc.batches, group)
+	}
+	b.flushing = true
+	puts := b.puts
+	pc.mu.Unlock()
+
+	wmReq := &WriteMultipleRequest{
+		TableName:  group.tableName,
+		Namespace:  group.namespace,
+		Durability: group.durability,
+		AllowSplit: true,
+		coalesced:  true,
+	}
+	var added []*pendingPut
+	for _, p := range puts {
+		// The request is copied as it is modified when added.
+		sub := *p.req
+		if p.req.Timeout > wmReq.Timeout {
+			wmReq.Timeout = p.req.Timeout
+		}
+		if err := wmReq.AddPutRequest(&sub, false); err != nil {
+			p.err = err
+			close(p.done)
+			continue
+		}
+		added = append(added, p)
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	wmRes, err := c.WriteMultipleWithContext(b.ctx, wmReq)
+	for i, p := range added {
+		switch {
+		case err != nil:
+			p.err = err
+		case i >= len(wmRes.ResultSet):
+			p.err = errUnexpectedResult
+		default:
+			r := wmRes.ResultSet[i]
+			p.res = &PutResult{
+				WriteResult:    r.WriteResult,
+				Version:        r.Version,
+				GeneratedValue: r.GeneratedValue,
+			}
+		}
+		close(p.done)
+	}
+}
+
+// detachedContext is a context that carries the values of its parent, but is
+// never canceled, so that a batch is executed even if the put that created
+// it is canceled.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
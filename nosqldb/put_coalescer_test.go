@@ -0,0 +1,191 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutCoalescing(t *testing.T) {
+	client, err := newMockClient()
+	require.NoError(t, err)
+	client.SetSerialVersion(3)
+	executor := &writeMultipleExecutor{}
+	client.executor = executor
+	client.putCoalescer = newPutCoalescer(&Config{PutCoalescingWindow: 50 * time.Millisecond})
+	client.schemaCache = newSchemaCache(time.Minute)
+	client.schemaCache.put("", "users", &TableResult{
+		TableName: "users",
+		State:     types.Active,
+		Schema:    `{"name":"users","primaryKey":["region","id"],"shardKey":["region"]}`,
+	})
+
+	put := func(region string, id int) (*PutResult, error) {
+		return client.Put(&PutRequest{
+			TableName: "users",
+			Value:     types.NewMapValue(map[string]interface{}{"region": region, "id": id}),
+		})
+	}
+
+	// The puts of each shard key are executed in one WriteMultiple request.
+	var wg sync.WaitGroup
+	results := make([]*PutResult, 6)
+	for i := range results {
+		region := "us"
+		if i >= 4 {
+			region = "eu"
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, err := put(region, i)
+			assert.NoError(t, err)
+			results[i] = res
+		}(i)
+	}
+	wg.Wait()
+
+	assert.ElementsMatch(t, []int{4, 2}, executor.requests())
+	versions := make(map[string]bool)
+	for _, res := range results {
+		require.NotNil(t, res)
+		require.NotNil(t, res.Version)
+		versions[string(res.Version)] = true
+	}
+	assert.Len(t, versions, 4, "the result of each put of a request")
+
+	// A batch is executed as soon as it is full.
+	client.putCoalescer = newPutCoalescer(&Config{PutCoalescingWindow: time.Minute})
+	start := time.Now()
+	for i := 0; i < maxCloudBatchOps; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := put("ap", i)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	assert.Less(t, int64(time.Since(start)), int64(10*time.Second), "the window has not elapsed")
+	assert.Equal(t, maxCloudBatchOps, executor.requests()[2])
+
+	// The put returns when its context is canceled, and is not written.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.PutWithContext(ctx, &PutRequest{
+		TableName: "users",
+		Value:     types.NewMapValue(map[string]interface{}{"region": "us", "id": 1}),
+	})
+	assert.Equal(t, context.Canceled, err)
+	for group, b := range client.putCoalescer.batches {
+		client.flushPuts(group, b)
+	}
+	assert.Len(t, executor.requests(), 3, "the canceled put is not written")
+
+	// The puts of different authorization providers are not coalesced, and
+	// the hooks of each put are run with its context.
+	client.putCoalescer = newPutCoalescer(&Config{PutCoalescingWindow: 50 * time.Millisecond})
+	type callerKey struct{}
+	var mu sync.Mutex
+	var before, after []interface{}
+	client.SetTableHooks("users", &TableHooks{
+		BeforePut: func(ctx context.Context, req *PutRequest) error {
+			mu.Lock()
+			defer mu.Unlock()
+			before = append(before, ctx.Value(callerKey{}))
+			return nil
+		},
+		AfterPut: func(ctx context.Context, req *PutRequest, version types.Version) {
+			mu.Lock()
+			defer mu.Unlock()
+			after = append(after, ctx.Value(callerKey{}))
+		},
+	})
+	for _, tenant := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(tenant string) {
+			defer wg.Done()
+			ctx := WithAuthorizationProvider(context.Background(), &DummyAccessTokenProvider{TenantID: tenant})
+			_, err := client.PutWithContext(context.WithValue(ctx, callerKey{}, tenant), &PutRequest{
+				TableName: "users",
+				Value:     types.NewMapValue(map[string]interface{}{"region": "us", "id": 1}),
+			})
+			assert.NoError(t, err)
+		}(tenant)
+	}
+	wg.Wait()
+	assert.Equal(t, []int{1, 1}, executor.requests()[3:])
+	assert.ElementsMatch(t, []interface{}{"a", "b"}, before)
+	assert.ElementsMatch(t, []interface{}{"a", "b"}, after)
+}
+
+// writeMultipleExecutor responds to WriteMultiple requests with a successful
+// result for each operation, whose version is the index of the operation.
+type writeMultipleExecutor struct {
+	mu  sync.Mutex
+	ops []int
+}
+
+func (e *writeMultipleExecutor) requests() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]int(nil), e.ops...)
+}
+
+func (e *writeMultipleExecutor) Do(req *http.Request) (*http.Response, error) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	r := binary.NewReader(bytes.NewBuffer(data))
+	r.ReadInt16()
+	r.ReadByte()
+	r.ReadPackedInt()
+	r.ReadString()
+	n, err := r.ReadPackedInt()
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	e.ops = append(e.ops, n)
+	e.mu.Unlock()
+
+	w := binary.NewWriter()
+	w.WriteByte(0)
+	w.WriteBoolean(true)
+	for i := 0; i < 3; i++ {
+		w.WritePackedInt(0)
+	}
+	w.WritePackedInt(n)
+	for i := 0; i < n; i++ {
+		w.WriteBoolean(true)
+		w.WriteBoolean(true)
+		w.WriteVersion(types.Version{byte(i)})
+		w.WriteBoolean(false)
+		w.WriteBoolean(false)
+	}
+	body := w.Bytes()
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        make(http.Header),
+		Request:       req,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}, nil
+}
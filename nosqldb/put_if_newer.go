@@ -0,0 +1,122 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/common"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// maxPutIfNewerAttempts is the number of times PutIfNewer reads the existing
+// row and attempts the conditional put when the row is modified concurrently.
+const maxPutIfNewerAttempts = 5
+
+// PutIfNewer is like PutIfNewerWithContext, using context.Background().
+func (c *Client) PutIfNewer(req *PutRequest, modTime time.Time) (*PutResult, error) {
+	return c.PutIfNewerWithContext(context.Background(), req, modTime)
+}
+
+// PutIfNewerWithContext puts the row specified by req only if the row does
+// not exist, or if its modification time is before modTime. This implements
+// last-writer-wins conflict resolution for applications that synchronize rows
+// from another source, where modTime is the time the row was modified in that
+// source.
+//
+// The existing row is read with absolute consistency and the row is put with
+// the PutIfAbsent or PutIfVersion option, so that a concurrent modification of
+// the row is detected. In that case the row is read again and the comparison
+// is repeated, up to a few times.
+//
+// If the row is put, the returned PutResult.Success() is true. Otherwise, the
+// returned PutResult.Success() is false and PutResult.ExistingVersion and
+// PutResult.ExistingModificationTime describe the existing row.
+//
+// The PutOption and MatchVersion of req must not be set. The primary key
+// columns of the table are obtained with GetTableCached.
+func (c *Client) PutIfNewerWithContext(ctx context.Context, req *PutRequest, modTime time.Time) (*PutResult, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	if req.PutOption != 0 || req.MatchVersion != nil {
+		return nil, nosqlerr.NewIllegalArgument("PutIfNewer: PutOption and MatchVersion must not be set")
+	}
+
+	key, err := c.primaryKeyOf(req)
+	if err != nil {
+		return nil, err
+	}
+
+	getReq := &GetRequest{
+		TableName:   req.TableName,
+		Namespace:   req.Namespace,
+		Key:         key,
+		Consistency: types.Absolute,
+		Timeout:     req.Timeout,
+	}
+
+	var res *PutResult
+	for i := 0; i < maxPutIfNewerAttempts; i++ {
+		getRes, err := c.GetWithContext(ctx, getReq)
+		if err != nil {
+			return nil, err
+		}
+
+		putReq := *req
+		putReq.InternalRequestData = common.InternalRequestData{}
+		if getRes.RowExists() {
+			if getRes.ModificationTime >= modTime.UnixMilli() {
+				res = &PutResult{Capacity: getRes.Capacity}
+				res.ExistingVersion = getRes.Version
+				res.ExistingModificationTime = getRes.ModificationTime
+				return res, nil
+			}
+			putReq.PutOption = types.PutIfVersion
+			putReq.MatchVersion = getRes.Version
+		} else {
+			putReq.PutOption = types.PutIfAbsent
+		}
+
+		if res, err = c.PutWithContext(ctx, &putReq); err != nil || res.Success() {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// primaryKeyOf returns the primary key of the row specified by req.
+func (c *Client) primaryKeyOf(req *PutRequest) (*types.MapValue, error) {
+	value, err := req.rowValue()
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nosqlerr.NewIllegalArgument("PutRequest: Value must be non-nil")
+	}
+
+	primaryKey, _, err := c.tableKeys(context.Background(), req.Namespace, req.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	key := types.NewEmptyMapValue()
+	for _, field := range primaryKey {
+		v, ok := value.Get(field)
+		if !ok {
+			return nil, nosqlerr.NewIllegalArgument("PutRequest: Value is missing primary key field %q", field)
+		}
+		key.Put(field, v)
+	}
+
+	return key, nil
+}
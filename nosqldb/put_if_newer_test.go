@@ -0,0 +1,50 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutIfNewerArguments(t *testing.T) {
+	client, err := newMockClient()
+	require.NoError(t, err)
+	client.schemaCache = newSchemaCache(time.Minute)
+	client.schemaCache.put("", "users", &TableResult{
+		TableName: "users",
+		State:     types.Active,
+		Schema:    `{"name":"users","primaryKey":["region","id"],"shardKey":["region"]}`,
+	})
+
+	value := types.NewEmptyMapValue()
+	value.Put("id", 7).Put("region", "eu").Put("name", "Jane")
+	key, err := client.primaryKeyOf(&PutRequest{TableName: "users", Value: value})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"region": "eu", "id": 7}, key.Map())
+
+	now := time.Now()
+	_, err = client.PutIfNewer(nil, now)
+	assert.Equal(t, errNilRequest, err)
+
+	invalid := []*PutRequest{
+		{TableName: "users", Value: value, PutOption: types.PutIfAbsent},
+		{TableName: "users", Value: value, MatchVersion: types.Version{1}},
+		{TableName: "users"},
+		{TableName: "users", Value: types.NewEmptyMapValue().Put("id", 7)},
+	}
+	for i, req := range invalid {
+		_, err = client.PutIfNewer(req, now)
+		assert.Truef(t, nosqlerr.IsIllegalArgument(err), "Testcase %d: unexpected error %v", i+1, err)
+	}
+}
@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTraces(t *testing.T) {
+	req := &QueryRequest{Statement: "SELECT * FROM users", Timeout: time.Second, Consistency: types.Eventual, TraceLevel: 33}
+	err := req.validate()
+	assert.Truef(t, nosqlerr.IsIllegalArgument(err), "TraceLevel 33: unexpected error %v", err)
+
+	// The trace options and the batch counter are sent with the request. The
+	// batch is numbered by the client, not when the request is serialized.
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	client.SetSerialVersion(4)
+	req.TraceLevel = 3
+	req.TraceToLogFiles = true
+	for batch := 1; batch <= 2; batch++ {
+		data, _, _, err := client.processRequest(context.Background(), req)
+		require.NoError(t, err)
+		again, _, _, err := client.serializeRequest(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, data, again, "the batch is numbered once")
+
+		r := binary.NewReader(bytes.NewBuffer(data))
+		_, err = r.ReadInt16()
+		require.NoError(t, err)
+		v, err := r.ReadFieldValue()
+		require.NoError(t, err)
+		p, _ := v.(*types.MapValue).Get(PAYLOAD)
+		payload, ok := p.(*types.MapValue)
+		require.Truef(t, ok, "unexpected payload %T", p)
+		level, _ := payload.GetInt(TRACE_LEVEL)
+		assert.Equal(t, 3, level)
+		toLogFiles, _ := payload.Get(TRACE_AT_LOG_FILES)
+		assert.Equal(t, true, toLogFiles)
+		counter, _ := payload.GetInt(BATCH_COUNTER)
+		assert.Equal(t, batch, counter)
+	}
+
+	// The traces returned by the server are added to the request.
+	w := binary.NewWriter()
+	_, err = w.WriteFieldValue(types.NewOrderedMapValue().Put(QUERY_BATCH_TRACES,
+		[]types.FieldValue{"batch-1", "trace 1", "batch-2", "trace 2"}))
+	require.NoError(t, err)
+	req.PreparedStatement = &PreparedStatement{statement: []byte{1}}
+	qres := &QueryResult{request: req}
+	code, err := readNsonPrepareOrQuery(req, qres, nil, nil, binary.NewReader(bytes.NewBuffer(w.Bytes())), 4, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+	expected := []QueryTrace{{"batch-1", "trace 1"}, {"batch-2", "trace 2"}}
+	assert.Equal(t, expected, req.Traces())
+
+	// Traces and batches of internal requests are recorded on the request of
+	// the application.
+	req.driver = &queryDriver{request: req}
+	internal := req.copyInternal()
+	internal.addTraces([]QueryTrace{{"batch-3", "trace 3"}})
+	assert.Equal(t, 3, internal.nextBatch())
+	assert.Equal(t, 3, req.batchCounter)
+	assert.Len(t, req.Traces(), 3)
+	assert.Equal(t, req.Traces(), internal.Traces())
+
+	// An odd number of elements is a protocol error.
+	w = binary.NewWriter()
+	_, err = w.WriteFieldValue(types.NewOrderedMapValue().Put(QUERY_BATCH_TRACES, []types.FieldValue{"batch-1"}))
+	require.NoError(t, err)
+	_, err = readNsonPrepareOrQuery(req, qres, nil, nil, binary.NewReader(bytes.NewBuffer(w.Bytes())), 4, 4)
+	assert.Error(t, err)
+}
@@ -200,11 +200,11 @@ func (rcb *runtimeControlBlock) trace(level int, messageFormat string, messageAr
 		data := md5.Sum([]byte(sql))
 		// To generate a compact output, use the first 4 bytes as a tag.
 		rcb.sqlHashTag = data[:4]
-		queryLogger.Trace("[%x] SQL: %s", rcb.sqlHashTag, sql)
+		queryLogger.Trace("[%x] SQL: %s", rcb.sqlHashTag, rcb.getClient().Redaction.RedactStatement(sql))
 	}
 
 	tag := fmt.Sprintf("[%x] ", rcb.sqlHashTag)
-	queryLogger.Trace(tag+messageFormat, messageArgs...)
+	queryLogger.Trace(tag+messageFormat, rcb.getClient().Redaction.redactArgs(messageArgs)...)
 }
 
 // openIter is a convenience method that sets the plan iterator at specified
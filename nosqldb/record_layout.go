@@ -0,0 +1,79 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"strings"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
+)
+
+// recordLayout returns the layout of the specified table, which describes the
+// order of the fields of its RECORD columns, or nil if the layout is not
+// available.
+//
+// The layout is only available if the schema cache is enabled, so that native
+// structs are written without additional requests otherwise. If the table
+// metadata cannot be retrieved, nil is returned and the fields of the structs
+// are written in declaration order.
+func (c *Client) recordLayout(namespace, tableName string) *proto.RecordLayout {
+	if c.schemaCache == nil {
+		return nil
+	}
+
+	if _, err := c.GetTableCached(namespace, tableName); err != nil {
+		c.logger.Debug("cannot get the layout of the RECORD columns of table %s: %v", tableName, err)
+		return nil
+	}
+	layout, _ := c.schemaCache.recordLayout(namespace, tableName)
+	return layout
+}
+
+// tableRecordLayout returns the layout of the rows of a table, or nil if none
+// of its columns has RECORD values.
+func tableRecordLayout(table *TableResult) *proto.RecordLayout {
+	columns, err := tableColumns(table)
+	if err != nil {
+		return nil
+	}
+	if layout := newRecordLayout(columns); layout.Nested != nil {
+		return layout
+	}
+	return nil
+}
+
+// newRecordLayout returns the layout of a RECORD, or of a row, with the
+// specified fields.
+func newRecordLayout(fields []schemaField) *proto.RecordLayout {
+	layout := &proto.RecordLayout{Fields: make([]string, len(fields))}
+	for i := range fields {
+		layout.Fields[i] = fields[i].Name
+		if nested := valueLayout(&fields[i]); nested != nil {
+			if layout.Nested == nil {
+				layout.Nested = make(map[string]*proto.RecordLayout)
+			}
+			layout.Nested[strings.ToLower(fields[i].Name)] = nested
+		}
+	}
+	return layout
+}
+
+// valueLayout returns the layout of the RECORD values of field f, which are
+// the values of f itself or the elements of an ARRAY or MAP, or nil if the
+// values of f are not RECORD values.
+func valueLayout(f *schemaField) *proto.RecordLayout {
+	switch strings.ToUpper(f.Type) {
+	case "RECORD":
+		return newRecordLayout(f.Fields)
+	case "ARRAY", "MAP":
+		if f.Collection != nil {
+			return valueLayout(f.Collection)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutStructRecordLayout(t *testing.T) {
+	client, err := newMockClient()
+	require.NoError(t, err)
+	assert.Nil(t, client.recordLayout("", "users"), "layout without schema cache")
+
+	client.schemaCache = newSchemaCache(time.Minute)
+	client.schemaCache.put("", "users", &TableResult{
+		TableName: "users",
+		State:     types.Active,
+		Schema: `{"name":"users","fields":[
+			{"name":"id","type":"INTEGER"},
+			{"name":"home","type":"RECORD","fields":[
+				{"name":"street","type":"STRING"},
+				{"name":"city","type":"STRING"}]},
+			{"name":"previous","type":"ARRAY","collection":{"type":"RECORD","fields":[
+				{"name":"street","type":"STRING"},
+				{"name":"city","type":"STRING"}]}},
+			{"name":"doc","type":"JSON"}]}`,
+	})
+	client.schemaCache.put("", "flat", &TableResult{
+		TableName: "flat",
+		State:     types.Active,
+		Schema:    `{"name":"flat","fields":[{"name":"id","type":"INTEGER"}]}`,
+	})
+	assert.Nil(t, client.recordLayout("", "flat"), "layout of a table without RECORD columns")
+
+	type address struct {
+		City   string
+		Street string
+	}
+	type user struct {
+		Previous []address `nosql:"previous"`
+		Home     *address  `nosql:"home"`
+		Doc      address   `nosql:"doc"`
+		ID       int       `nosql:"id"`
+	}
+	addr := address{City: "Austin", Street: "Congress Ave"}
+	req := &PutRequest{
+		TableName:    "users",
+		StructValue:  &user{ID: 1, Home: &addr, Previous: []address{addr}, Doc: addr},
+		recordLayout: client.recordLayout("", "users"),
+	}
+	require.NotNil(t, req.recordLayout)
+
+	w := binary.NewWriter()
+	require.NoError(t, req.serialize(w, 4, 4))
+	v, err := binary.NewReader(bytes.NewBuffer(w.Bytes())).ReadFieldValue()
+	require.NoError(t, err)
+	p, _ := v.(*types.MapValue).Get(PAYLOAD)
+	row, _ := p.(*types.MapValue).Get(VALUE)
+
+	keys := func(v interface{}) (keys []string) {
+		mv := v.(*types.MapValue)
+		for i := 1; i <= mv.Len(); i++ {
+			k, _, _ := mv.GetByIndex(i)
+			keys = append(keys, k)
+		}
+		return keys
+	}
+	field := func(name string) interface{} {
+		v, _ := row.(*types.MapValue).Get(name)
+		return v
+	}
+	// The fields of RECORD values are written in declared order, those of
+	// JSON values in declaration order.
+	tests := []struct {
+		desc  string
+		value interface{}
+		want  []string
+	}{
+		{"row", row, []string{"id", "home", "previous", "doc"}},
+		{"RECORD column", field("home"), []string{"Street", "City"}},
+		{"ARRAY of RECORD column", field("previous").([]types.FieldValue)[0], []string{"Street", "City"}},
+		{"JSON column", field("doc"), []string{"City", "Street"}},
+	}
+	for _, r := range tests {
+		assert.Equalf(t, r.want, keys(r.value), "%s: unexpected field order", r.desc)
+	}
+}
@@ -0,0 +1,212 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// defaultRedactionPlaceholder is the default value that replaces redacted values.
+const defaultRedactionPlaceholder = "[REDACTED]"
+
+// statementLiteral matches string and numeric literals in SQL statements.
+var statementLiteral = regexp.MustCompile(`'(?:[^']|'')*'|"(?:[^"\\]|\\.)*"|\b[0-9]+(?:\.[0-9]+)?(?:[eE][-+]?[0-9]+)?\b`)
+
+// RedactionPolicy specifies how sensitive values are removed from the
+// information that a Client writes to logs and returns in errors, such as
+// query statements, bind variable values and row contents.
+//
+// A Client applies the policy to query trace output and to error messages of
+// query operations. Applications can use the Redact* methods to apply the
+// same policy to their own logs and debug dumps.
+type RedactionPolicy struct {
+	// Columns specifies the patterns of column names whose values are
+	// redacted. Patterns use the syntax of path.Match and are matched, case
+	// insensitively, against both the name of a field and its dot-separated
+	// path from the top level of the row, for example "ssn", "*_token" or
+	// "address.*".
+	Columns []string `json:"columns,omitempty"`
+
+	// RedactStatementLiterals specifies whether string and numeric literals
+	// in query statements are replaced with "?".
+	RedactStatementLiterals bool `json:"redactStatementLiterals,omitempty"`
+
+	// RedactBindValues specifies whether the values of bind variables are
+	// redacted regardless of the names of variables.
+	RedactBindValues bool `json:"redactBindValues,omitempty"`
+
+	// Placeholder specifies the value that replaces redacted values.
+	// If not set, "[REDACTED]" is used.
+	Placeholder string `json:"placeholder,omitempty"`
+}
+
+func (p *RedactionPolicy) placeholder() string {
+	if p.Placeholder != "" {
+		return p.Placeholder
+	}
+	return defaultRedactionPlaceholder
+}
+
+// matchColumn reports whether the column with the specified name and path
+// should be redacted.
+func (p *RedactionPolicy) matchColumn(name, fieldPath string) bool {
+	name, fieldPath = strings.ToLower(name), strings.ToLower(fieldPath)
+	for _, pattern := range p.Columns {
+		pattern = strings.ToLower(pattern)
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, fieldPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactStatement returns the statement with literals replaced if
+// RedactStatementLiterals is set, otherwise returns the statement unchanged.
+func (p *RedactionPolicy) RedactStatement(stmt string) string {
+	if p == nil || !p.RedactStatementLiterals {
+		return stmt
+	}
+	return statementLiteral.ReplaceAllString(stmt, "?")
+}
+
+// RedactBindValue returns the value to display for the bind variable with
+// the specified name.
+func (p *RedactionPolicy) RedactBindValue(name string, value types.FieldValue) types.FieldValue {
+	if p == nil {
+		return value
+	}
+
+	if p.RedactBindValues || p.matchColumn(strings.TrimPrefix(name, "$"), "") {
+		return p.placeholder()
+	}
+
+	return p.RedactValue(value)
+}
+
+// RedactValue returns a copy of the specified value in which the values of
+// columns that match the policy are replaced with the placeholder. The
+// specified value is not modified.
+func (p *RedactionPolicy) RedactValue(value types.FieldValue) types.FieldValue {
+	if p == nil || len(p.Columns) == 0 {
+		return value
+	}
+	return p.redact(value, "")
+}
+
+func (p *RedactionPolicy) redact(value types.FieldValue, parent string) types.FieldValue {
+	join := func(k string) string {
+		if parent == "" {
+			return k
+		}
+		return parent + "." + k
+	}
+
+	switch v := value.(type) {
+	case *types.MapValue:
+		if v == nil {
+			return v
+		}
+		var res *types.MapValue
+		if v.IsOrdered() {
+			res = types.NewOrderedMapValue()
+			for i := 1; i <= v.Len(); i++ {
+				k, e, _ := v.GetByIndex(i)
+				res.Put(k, p.redactField(k, join(k), e))
+			}
+		} else {
+			m := make(map[string]interface{}, v.Len())
+			for k, e := range v.Map() {
+				m[k] = p.redactField(k, join(k), e)
+			}
+			res = types.NewMapValue(m)
+		}
+		return res
+
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = p.redactField(k, join(k), e)
+		}
+		return m
+
+	case []types.FieldValue:
+		arr := make([]types.FieldValue, len(v))
+		for i, e := range v {
+			arr[i] = p.redact(e, parent)
+		}
+		return arr
+
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, e := range v {
+			arr[i] = p.redact(e, parent)
+		}
+		return arr
+
+	default:
+		return value
+	}
+}
+
+func (p *RedactionPolicy) redactField(name, fieldPath string, value types.FieldValue) types.FieldValue {
+	if p.matchColumn(name, fieldPath) {
+		return p.placeholder()
+	}
+	return p.redact(value, fieldPath)
+}
+
+// redactArgs applies the policy to arguments of a log message.
+func (p *RedactionPolicy) redactArgs(args []interface{}) []interface{} {
+	if p == nil || len(p.Columns) == 0 {
+		return args
+	}
+
+	res := make([]interface{}, len(args))
+	for i, arg := range args {
+		res[i] = p.RedactValue(arg)
+	}
+	return res
+}
+
+// redactError removes the statement of a query operation from the message
+// of the specified error, if the statement appears in the message.
+func (p *RedactionPolicy) redactError(err error, req Request) error {
+	if p == nil || !p.RedactStatementLiterals || err == nil {
+		return err
+	}
+
+	var stmt string
+	switch r := req.(type) {
+	case *QueryRequest:
+		stmt = r.Statement
+		if r.PreparedStatement != nil {
+			stmt = r.PreparedStatement.sqlText
+		}
+	case *PrepareRequest:
+		stmt = r.Statement
+	case *TableRequest:
+		stmt = r.Statement
+	}
+
+	e, ok := err.(*nosqlerr.Error)
+	if !ok || stmt == "" || !strings.Contains(e.Message, stmt) {
+		return err
+	}
+
+	redacted := *e
+	redacted.Message = strings.ReplaceAll(e.Message, stmt, p.RedactStatement(stmt))
+	return &redacted
+}
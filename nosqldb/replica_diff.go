@@ -0,0 +1,189 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// ReplicaRow represents a row as read from one region of a Global Active
+// Table.
+type ReplicaRow struct {
+	// Region represents the region the row is read from.
+	Region string `json:"region"`
+
+	// Exists indicates whether the row exists in the region.
+	Exists bool `json:"exists"`
+
+	// Value represents the value of the row, or nil if the row does not exist.
+	Value *types.MapValue `json:"value,omitempty"`
+
+	// Version represents the version of the row in the region. Versions are
+	// local to a region and are not comparable across regions.
+	Version types.Version `json:"version,omitempty"`
+
+	// ModificationTime represents the modification time of the row, in
+	// milliseconds since January 1 1970.
+	ModificationTime int64 `json:"modificationTime,omitempty"`
+
+	// ExpirationTime represents the expiration time of the row. A zero value
+	// indicates that the row does not expire.
+	ExpirationTime time.Time `json:"expirationTime,omitempty"`
+
+	// Err represents the error that occurred reading the row from the region,
+	// in which case the other fields are not valid.
+	Err error `json:"-"`
+}
+
+// ReplicaRowDiff represents the differences between the copies of a row in
+// the regions of a Global Active Table.
+type ReplicaRowDiff struct {
+	// Rows represents the row read from each region, sorted by region.
+	Rows []ReplicaRow `json:"rows"`
+
+	// ExistenceDiffers indicates whether the row exists in some regions but
+	// not in others.
+	ExistenceDiffers bool `json:"existenceDiffers"`
+
+	// ModificationTimeDiffers indicates whether the modification times of the
+	// row differ among the regions where it exists.
+	ModificationTimeDiffers bool `json:"modificationTimeDiffers"`
+
+	// DifferentFields represents the sorted names of the top-level fields
+	// whose values differ among the regions where the row exists.
+	DifferentFields []string `json:"differentFields,omitempty"`
+}
+
+// Consistent returns true if the row was read from all regions, and it either
+// does not exist in any region or has the same value and modification time in
+// all regions.
+func (d ReplicaRowDiff) Consistent() bool {
+	for _, r := range d.Rows {
+		if r.Err != nil {
+			return false
+		}
+	}
+	return !d.ExistenceDiffers && !d.ModificationTimeDiffers && len(d.DifferentFields) == 0
+}
+
+// String returns a JSON string representation of the ReplicaRowDiff.
+func (d ReplicaRowDiff) String() string {
+	return jsonutil.AsJSON(d)
+}
+
+// CompareReplicaRows reads the row with the specified primary key from each
+// region of a Global Active Table and reports how the copies differ. It is
+// intended for diagnosing conflicts between concurrent writes in different
+// regions.
+//
+// The clients map specifies the Client connected to each region, keyed by
+// the region name. The rows are read concurrently with absolute consistency.
+// An error reading the row from a region is reported in the Err field of the
+// corresponding ReplicaRow rather than returned.
+func CompareReplicaRows(ctx context.Context, clients map[string]*Client, tableName string, key *types.MapValue) (*ReplicaRowDiff, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	if len(clients) == 0 {
+		return nil, nosqlerr.NewIllegalArgument("CompareReplicaRows: clients must be non-empty")
+	}
+
+	if key == nil {
+		return nil, nosqlerr.NewIllegalArgument("CompareReplicaRows: key must be non-nil")
+	}
+
+	diff := &ReplicaRowDiff{Rows: make([]ReplicaRow, 0, len(clients))}
+	for region := range clients {
+		diff.Rows = append(diff.Rows, ReplicaRow{Region: region})
+	}
+	sort.Slice(diff.Rows, func(i, j int) bool {
+		return diff.Rows[i].Region < diff.Rows[j].Region
+	})
+
+	var wg sync.WaitGroup
+	for i := range diff.Rows {
+		wg.Add(1)
+		go func(r *ReplicaRow) {
+			defer wg.Done()
+			res, err := clients[r.Region].GetWithContext(ctx, &GetRequest{
+				TableName:   tableName,
+				Key:         key,
+				Consistency: types.Absolute,
+			})
+			if err != nil {
+				r.Err = err
+				return
+			}
+			if r.Exists = res.RowExists(); r.Exists {
+				r.Value = res.Value
+				r.Version = res.Version
+				r.ModificationTime = res.ModificationTime
+				r.ExpirationTime = res.ExpirationTime
+			}
+		}(&diff.Rows[i])
+	}
+	wg.Wait()
+
+	diff.compare()
+	return diff, nil
+}
+
+// compare computes the differences between the rows that were read.
+func (d *ReplicaRowDiff) compare() {
+	var existing []*ReplicaRow
+	var missing bool
+	for i := range d.Rows {
+		switch r := &d.Rows[i]; {
+		case r.Err != nil:
+		case r.Exists:
+			existing = append(existing, r)
+		default:
+			missing = true
+		}
+	}
+
+	d.ExistenceDiffers = missing && len(existing) > 0
+	if len(existing) < 2 {
+		return
+	}
+
+	fields := make(map[string]struct{})
+	for _, r := range existing {
+		for k := range r.Value.Map() {
+			fields[k] = struct{}{}
+		}
+	}
+
+	first := existing[0]
+	for _, r := range existing[1:] {
+		if r.ModificationTime != first.ModificationTime {
+			d.ModificationTimeDiffers = true
+		}
+	}
+
+	for k := range fields {
+		v0, ok0 := first.Value.Get(k)
+		for _, r := range existing[1:] {
+			v, ok := r.Value.Get(k)
+			if ok != ok0 || !reflect.DeepEqual(v, v0) {
+				d.DifferentFields = append(d.DifferentFields, k)
+				break
+			}
+		}
+	}
+	sort.Strings(d.DifferentFields)
+}
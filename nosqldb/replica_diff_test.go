@@ -0,0 +1,45 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicaRowDiff(t *testing.T) {
+	_, err := CompareReplicaRows(context.Background(), nil, "users", types.NewEmptyMapValue())
+	assert.True(t, nosqlerr.IsIllegalArgument(err))
+
+	row := func(region string, modTime int64, name string) ReplicaRow {
+		value := types.NewEmptyMapValue()
+		value.Put("id", 1).Put("name", name)
+		return ReplicaRow{Region: region, Exists: true, Value: value, Version: types.Version(region), ModificationTime: modTime}
+	}
+
+	d := &ReplicaRowDiff{Rows: []ReplicaRow{row("us-ashburn-1", 100, "a"), row("us-phoenix-1", 100, "a")}}
+	d.compare()
+	assert.True(t, d.Consistent(), "versions are local to a region and are not compared")
+
+	d = &ReplicaRowDiff{Rows: []ReplicaRow{row("us-ashburn-1", 100, "a"), row("us-phoenix-1", 120, "b"), {Region: "eu-frankfurt-1"}}}
+	d.compare()
+	assert.False(t, d.Consistent())
+	assert.True(t, d.ExistenceDiffers)
+	assert.True(t, d.ModificationTimeDiffers)
+	assert.Equal(t, []string{"name"}, d.DifferentFields)
+
+	d = &ReplicaRowDiff{Rows: []ReplicaRow{row("us-ashburn-1", 100, "a"), {Region: "us-phoenix-1", Err: errors.New("timeout")}}}
+	d.compare()
+	assert.False(t, d.ExistenceDiffers)
+	assert.False(t, d.Consistent())
+}
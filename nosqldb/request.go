@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/oracle/nosql-go-sdk/nosqldb/common"
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
 	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
 	"github.com/oracle/nosql-go-sdk/nosqldb/types"
 )
@@ -49,6 +50,12 @@ type GetRequest struct {
 	// struct of the given type with its fields filled in with the row value.
 	StructType reflect.Type
 
+	// DecodeMode specifies how the row is decoded into StructValue or
+	// StructType. It is optional.
+	// If not set, the default value configured for Client is used, which is
+	// determined by RequestConfig.DefaultDecodeMode().
+	DecodeMode DecodeMode `json:"decodeMode,omitempty"`
+
 	// Timeout specifies the timeout value for the request.
 	// It is optional.
 	// If set, it must be greater than or equal to 1 millisecond, otherwise an
@@ -100,6 +107,10 @@ func (r *GetRequest) validate() (err error) {
 		return
 	}
 
+	if err = validateDecodeMode(r.DecodeMode); err != nil {
+		return
+	}
+
 	return
 }
 
@@ -110,6 +121,10 @@ func (r *GetRequest) setDefaults(cfg *RequestConfig) {
 		r.Timeout = cfg.DefaultRequestTimeout()
 	}
 
+	if r.DecodeMode == DecodeDefault {
+		r.DecodeMode = cfg.DefaultDecodeMode()
+	}
+
 	if r.Consistency == 0 {
 		r.Consistency = cfg.DefaultConsistency()
 	}
@@ -253,6 +268,19 @@ type GetIndexesRequest struct {
 	// all indexes on the table.
 	IndexName string `json:"indexName,omitempty"`
 
+	// StartIndex specifies the index of the first index information to
+	// return, such as GetIndexesResult.LastIndexReturned from a previous
+	// request, so that the indexes can be paged. It is optional.
+	// If not set, the list starts at index 0.
+	StartIndex uint `json:"startIndex,omitempty"`
+
+	// Limit specifies the maximum number of index information to return.
+	// It is optional. If set to 0, there is no limit.
+	//
+	// The server returns the information of all the indexes of the table,
+	// StartIndex and Limit are applied by the client.
+	Limit uint `json:"limit,omitempty"`
+
 	// Timeout specifies the timeout value for the request.
 	// It is optional.
 	// If set, it must be greater than or equal to 1 millisecond, otherwise an
@@ -1202,8 +1230,25 @@ type PutRequest struct {
 	// annotations in the struct definition.
 	// Only exported (capitalized) fields in the struct will be used, similar
 	// to json encoding.
+	//
+	// Nested structs, and slices and maps of structs, may be used for RECORD
+	// columns and for ARRAY and MAP columns of RECORD values. If the schema
+	// cache is enabled with Config.SchemaCacheTTL, the fields of the nested
+	// structs are written in the order of the fields declared by the RECORD,
+	// regardless of the order of the fields of the structs. Otherwise they
+	// are written in declaration order.
 	StructValue any
 
+	// EncodedValue specifies the value of the row to put, serialized once with
+	// EncodeRow. It may be used instead of StructValue to put the same value
+	// multiple times without serializing it again.
+	//
+	// If Value is also specified, its fields are written along with those of
+	// EncodedValue, and replace the fields of EncodedValue with the same
+	// names. This is typically used to specify a different primary key for
+	// each put of the same EncodedValue.
+	EncodedValue *EncodedRow
+
 	// PutOption specifies the put option for the operation.
 	//
 	// It is optional and performs an unconditional put by default.
@@ -1285,6 +1330,16 @@ type PutRequest struct {
 	// It is copied from the parent WriteOperation, and is for internal use only.
 	abortOnFail bool
 
+	// recordLayout specifies the order of the fields of the RECORD columns of
+	// the table, used to write StructValue.
+	// It is for internal use only.
+	recordLayout *proto.RecordLayout
+
+	// stampedValue is a shallow copy of Value with the provenance columns
+	// set, which is written instead of Value so that Value is left unchanged.
+	// It is for internal use only.
+	stampedValue *types.MapValue
+
 	// Namespace is used on-premises only. It defines a namespace to use
 	// for the request. It is optional.
 	// If a namespace is specified in the table name for the request
@@ -1304,6 +1359,10 @@ func (r *PutRequest) validate() (err error) {
 		return
 	}
 
+	if r.EncodedValue != nil && r.StructValue != nil {
+		return nosqlerr.NewIllegalArgument("PutRequest: EncodedValue and StructValue are mutual exclusive, cannot specify both of them")
+	}
+
 	if !r.isSubRequest {
 		if err = validateTimeout(r.Timeout); err != nil {
 			return
@@ -1325,6 +1384,29 @@ func (r *PutRequest) validate() (err error) {
 	return nil
 }
 
+// rowValue returns the value of the row to put, as specified by Value,
+// StructValue or EncodedValue, or nil if none of them is specified.
+func (r *PutRequest) rowValue() (*types.MapValue, error) {
+	switch {
+	case r.EncodedValue != nil:
+		return r.EncodedValue.mapValue(r.value())
+	case r.Value != nil:
+		return r.value(), nil
+	case r.StructValue != nil:
+		return toMapValue(r.StructValue)
+	}
+	return nil, nil
+}
+
+// value returns the value written for Value, which is the copy of Value
+// stamped with the provenance columns if any.
+func (r *PutRequest) value() *types.MapValue {
+	if r.stampedValue != nil {
+		return r.stampedValue
+	}
+	return r.Value
+}
+
 func (r *PutRequest) setDefaults(cfg *RequestConfig) {
 	if r.Timeout == 0 {
 		r.Timeout = cfg.DefaultRequestTimeout()
@@ -1711,8 +1793,38 @@ type QueryRequest struct {
 	// which is determined by RequestConfig.DefaultRequestTimeout().
 	Timeout time.Duration `json:"timeout"`
 
-	// traceLevel sets the desired tracing level used to trace the query execution.
-	traceLevel int
+	// TraceLevel specifies the level of tracing of the query execution on the
+	// server, for performance investigations with Oracle support. Higher levels
+	// produce more detailed traces. Tracing slows down the query.
+	// It is optional. If set, it must be between 0 and 32. The default is 0,
+	// which disables tracing.
+	//
+	// The traces are returned with the query results, see QueryResult.Traces,
+	// unless TraceToLogFiles is set. Returning the traces and TraceToLogFiles
+	// require a proxy that supports the V4 protocol; with older proxies, the
+	// traces are only written to the server log files. Client side tracing of
+	// the query execution is configured by the NOSQL_QUERY_TRACE_LEVEL
+	// environment variable instead.
+	TraceLevel int `json:"traceLevel,omitempty"`
+
+	// TraceToLogFiles specifies whether the server writes the traces to its
+	// log files instead of returning them. It only applies if TraceLevel is
+	// greater than 0.
+	TraceToLogFiles bool `json:"traceToLogFiles,omitempty"`
+
+	// batchCounter is the number of batches of results requested from the
+	// server, which is sent with traced requests to identify the batches in
+	// the traces.
+	batchCounter int
+
+	// batchNumber is the number of the batch of results requested by this
+	// request, which is assigned by the client before the request is
+	// serialized, so that it does not change when the request is serialized
+	// again.
+	batchNumber int
+
+	// traces represents the traces returned by the server.
+	traces []QueryTrace
 
 	// continuationKey specifies the continuation key.
 	// This is used to continue an operation that returned this key in its QueryResult.
@@ -1726,6 +1838,14 @@ type QueryRequest struct {
 	// submitted for execution by the receiveIter.
 	isInternal bool
 
+	// affinityKey is the value of the session affinity header sent with all
+	// batches of the query, see SessionAffinityConfig.
+	affinityKey string
+
+	// authProvider is the AuthorizationProvider carried by the context used
+	// to execute the query, which is also used for all batches of the query.
+	authProvider AuthorizationProvider
+
 	// shardID represents the id of shard at which the QueryRequest should be executed.
 	// This is only used for advanced queries where sorting is required.
 	shardID *int
@@ -1756,6 +1876,22 @@ type QueryRequest struct {
 	// be accessed by the GetStructResults method of QueryResult.
 	StructType reflect.Type
 
+	// DecodeMode specifies how the results are decoded into structures of
+	// StructType. It is optional.
+	// If not set, the default value configured for Client is used, which is
+	// determined by RequestConfig.DefaultDecodeMode().
+	DecodeMode DecodeMode
+
+	// RowVisitor is an optional field that specifies a visitor the rows of
+	// the query results are passed to as they are decoded, instead of being
+	// returned by QueryResult.GetResults. This allows the fields of wide rows
+	// to be filtered without allocating a MapValue for each row.
+	//
+	// The rows of advanced queries, such as queries with ORDER BY or GROUP BY,
+	// are computed by the client from partial results, and are passed to the
+	// visitor once computed.
+	RowVisitor RowVisitor
+
 	common.InternalRequestData
 }
 
@@ -1768,18 +1904,71 @@ func (r *QueryRequest) validate() (err error) {
 		return
 	}
 
+	if err = validateDecodeMode(r.DecodeMode); err != nil {
+		return
+	}
+
 	if r.Statement == "" && r.PreparedStatement == nil {
 		return nosqlerr.NewIllegalArgument("QueryRequest: either Statement or PreparedStatement should be set")
 	}
 
+	if r.TraceLevel < 0 || r.TraceLevel > maxQueryTraceLevel {
+		return nosqlerr.NewIllegalArgument("QueryRequest: TraceLevel must be between 0 and %d, got %d",
+			maxQueryTraceLevel, r.TraceLevel)
+	}
+
 	return
 }
 
+// maxQueryTraceLevel is the maximum value of QueryRequest.TraceLevel.
+const maxQueryTraceLevel = 32
+
+// QueryTrace represents a trace of the execution of a query on the server.
+type QueryTrace struct {
+	// Batch identifies the batch of results the trace is for.
+	Batch string `json:"batch"`
+
+	// Trace represents the trace output.
+	Trace string `json:"trace"`
+}
+
+// Traces returns the traces of the query execution returned by the server so
+// far, if TraceLevel is set. See also QueryResult.Traces.
+func (r *QueryRequest) Traces() []QueryTrace {
+	return r.topRequest().traces
+}
+
+// topRequest returns the query request of the application this request is
+// executed for, which is the request itself unless it is an internal request.
+func (r *QueryRequest) topRequest() *QueryRequest {
+	if r.isInternal && r.driver != nil && r.driver.request != nil {
+		return r.driver.request
+	}
+	return r
+}
+
+// nextBatch returns the number of the next batch of results requested by the
+// query.
+func (r *QueryRequest) nextBatch() int {
+	top := r.topRequest()
+	top.batchCounter++
+	return top.batchCounter
+}
+
+func (r *QueryRequest) addTraces(traces []QueryTrace) {
+	top := r.topRequest()
+	top.traces = append(top.traces, traces...)
+}
+
 func (r *QueryRequest) setDefaults(cfg *RequestConfig) {
 	if r.Timeout == 0 {
 		r.Timeout = cfg.DefaultRequestTimeout()
 	}
 
+	if r.DecodeMode == DecodeDefault {
+		r.DecodeMode = cfg.DefaultDecodeMode()
+	}
+
 	if r.Consistency == 0 {
 		r.Consistency = cfg.DefaultConsistency()
 	}
@@ -1829,10 +2018,13 @@ func (r *QueryRequest) copyInternal() *QueryRequest {
 		Durability:           r.Durability,
 		PreparedStatement:    r.PreparedStatement,
 		driver:               r.driver,
-		traceLevel:           r.traceLevel,
+		TraceLevel:           r.TraceLevel,
+		TraceToLogFiles:      r.TraceToLogFiles,
 		TableName:            r.TableName,
 		InternalRequestData:  r.InternalRequestData,
 		isInternal:           true,
+		affinityKey:          r.affinityKey,
+		authProvider:         r.authProvider,
 	}
 }
 
@@ -1927,13 +2119,13 @@ func (r *QueryRequest) Close() {
 // PreparedStatement encapsulates a prepared query statement. It includes state
 // that can be sent to a server and executed without re-parsing the query. It
 // includes bind variables which may be set for each successive use of the
-// query. The prepared query itself is read-only but this object contains a
-// mutable map of bind variables and is not goroutine-safe if variables are
-// used.
+// query. The prepared query itself is read-only.
 //
-// A single instance of PreparedStatement is goroutine-safe if bind variables
-// are not used. If bind variables are to be used and the statement shared
-// among goroutines additional instances of PreparedStatement should be created.
+// A PreparedStatement can be shared among goroutines if its bind variables are
+// not modified. To execute a shared statement with different values of the
+// bind variables, use WithBindings, which returns a bound copy and leaves the
+// shared statement unchanged. SetVariable modifies the statement and is not
+// goroutine-safe.
 type PreparedStatement struct {
 
 	// sqlText represents the application provided SQL text.
@@ -2035,6 +2227,32 @@ func (p *PreparedStatement) SetVariable(name string, value interface{}) error {
 	return nil
 }
 
+// WithBindings returns a copy of the prepared statement with the specified
+// values bound to the variables of the query, in addition to the variables
+// already bound in p. Values for variables already bound are overwritten in
+// the copy.
+//
+// The statement p is not modified, so a single prepared statement can be
+// shared among goroutines that each execute it with their own bindings. The
+// copy shares the prepared query with p and is cheap to create.
+//
+// An IllegalArgument error is returned if the query does not contain one of
+// the variables. The types of the values are validated when the query is
+// executed.
+func (p *PreparedStatement) WithBindings(bindings map[string]interface{}) (*PreparedStatement, error) {
+	bound := *p
+	bound.bindVariables = make(map[string]interface{}, len(p.bindVariables)+len(bindings))
+	for name, value := range p.bindVariables {
+		bound.bindVariables[name] = value
+	}
+	for name, value := range bindings {
+		if err := bound.SetVariable(name, value); err != nil {
+			return nil, err
+		}
+	}
+	return &bound, nil
+}
+
 func (p *PreparedStatement) isSimpleQuery() bool {
 	return p.driverQueryPlan == nil
 }
@@ -2111,6 +2329,11 @@ type WriteMultipleRequest struct {
 	// This is for internal use, and is set automatically by client.
 	checkSubReqSize bool
 
+	// coalesced represents whether the request executes puts coalesced by
+	// the client, whose hooks are run and provenance is stamped for each put
+	// with the context of its caller, see Config.PutCoalescingWindow.
+	coalesced bool
+
 	// Namespace is used on-premises only. It defines a namespace to use
 	// for the request. It is optional.
 	// If a namespace is specified in the table name in the SQL query string
@@ -2125,6 +2348,16 @@ type WriteMultipleRequest struct {
 	// be ignored.
 	Namespace string `json:"namespace,omitempty"`
 
+	// AllowSplit specifies whether the client may split the request into
+	// multiple requests, executed one after the other, when the request has
+	// too many operations or is too large to be executed in a single request.
+	// The operations of each of the split requests are executed in a single
+	// transaction, but the operations of the request as a whole are not.
+	// If an operation with AbortOnFail set fails, the operations of the
+	// subsequent split requests are not executed.
+	// It is optional and defaults to false.
+	AllowSplit bool `json:"allowSplit,omitempty"`
+
 	common.InternalRequestData
 }
 
@@ -2287,6 +2520,8 @@ type MultiDeleteRequest struct {
 
 	// FieldRange specifies the FieldRange to be used for the operation.
 	// It is optional, but required to delete a specific range of rows.
+	// Use types.NewFieldRange to build it, and Client.ValidateFieldRange to
+	// validate it and the Key against the table schema.
 	FieldRange *types.FieldRange `json:"fieldRange,omitempty"`
 
 	// MaxWriteKB specifies the limit on the total KB write during this operation.
@@ -2457,6 +2692,16 @@ func validateConsistency(consistency types.Consistency) error {
 	}
 }
 
+func validateDecodeMode(m DecodeMode) error {
+	switch m {
+	case DecodeDefault, DecodeLenient, DecodeStrict:
+		return nil
+
+	default:
+		return nosqlerr.NewIllegalArgument("DecodeMode must be either DecodeLenient or DecodeStrict")
+	}
+}
+
 // validateTableName validates the specified table name is non-empty.
 func validateTableName(tableName string) error {
 	if tableName == "" {
@@ -0,0 +1,98 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// headerRecorder is a request executor that records the headers of requests
+// and responds to them with mockExecutor.
+type headerRecorder struct {
+	mockExecutor
+	header http.Header
+}
+
+func (r *headerRecorder) Do(req *http.Request) (*http.Response, error) {
+	r.header = req.Header.Clone()
+	return r.mockExecutor.Do(req)
+}
+
+// ssoProvider is an authorization provider with a custom scheme.
+type ssoProvider struct {
+	DummyAccessTokenProvider
+}
+
+func (p *ssoProvider) AuthorizationScheme() string {
+	return "SSO"
+}
+
+func (p *ssoProvider) AuthorizationString(req auth.Request) (string, error) {
+	return "SSO " + p.TenantID, nil
+}
+
+func (p *ssoProvider) SignHTTPRequest(req *http.Request) error {
+	req.Header.Set("X-SSO-Session", "session1")
+	return nil
+}
+
+func TestRequestSigner(t *testing.T) {
+	signer := RequestSignerFunc(func(req *http.Request) error {
+		req.Header.Set("X-SSO-Token", "token1")
+		return nil
+	})
+	tests := []struct {
+		desc   string
+		cfg    Config
+		header map[string]string
+	}{
+		{
+			"RequestSigner",
+			Config{Mode: "onprem", Endpoint: "localhost:8080", RequestSigner: signer},
+			map[string]string{"X-SSO-Token": "token1", "Authorization": ""},
+		},
+		{
+			"custom authorization scheme",
+			Config{Endpoint: "localhost:8080", AuthorizationProvider: &ssoProvider{DummyAccessTokenProvider{TenantID: "user1"}}},
+			map[string]string{"Authorization": "SSO user1", "X-SSO-Session": "session1"},
+		},
+	}
+
+	key := types.NewMapValue(map[string]interface{}{"id": 1})
+	for _, r := range tests {
+		client, err := NewClient(r.cfg)
+		require.NoErrorf(t, err, "%s: NewClient() got error %v", r.desc, err)
+		client.SetSerialVersion(3)
+		exec := &headerRecorder{mockExecutor: mockExecutor{errChan: make(chan error, 1)}}
+		client.executor = exec
+		exec.errChan <- nosqlerr.New(nosqlerr.TableNotFound, "table not found")
+		_, err = client.Get(&GetRequest{TableName: "T1", Key: key})
+		assert.Truef(t, nosqlerr.Is(err, nosqlerr.TableNotFound), "%s: Get() got error %v, "+
+			"want TableNotFound", r.desc, err)
+		for k, v := range r.header {
+			assert.Equalf(t, v, exec.header.Get(k), "%s: unexpected %s header", r.desc, k)
+		}
+		client.Close()
+	}
+
+	cfg := Config{
+		Mode:                  "onprem",
+		Endpoint:              "localhost:8080",
+		RequestSigner:         signer,
+		AuthorizationProvider: &DummyAccessTokenProvider{},
+	}
+	_, err := NewClient(cfg)
+	assert.Errorf(t, err, "NewClient() with both RequestSigner and AuthorizationProvider should have failed")
+}
@@ -8,11 +8,14 @@
 package nosqldb
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
 	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
 	"github.com/oracle/nosql-go-sdk/nosqldb/types"
 	"github.com/stretchr/testify/suite"
@@ -439,3 +442,130 @@ func (suite *RequestTestSuite) TestReflectStruct() {
 		}
 	}
 }
+
+func (suite *RequestTestSuite) TestProvenanceStamp() {
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	pc := &ProvenanceConfig{
+		UpdatedByColumn:     "updated_by",
+		UpdatedAtColumn:     "updated_at",
+		OriginServiceColumn: "origin_service",
+		OriginService:       "default-svc",
+		Now:                 func() time.Time { return now },
+	}
+
+	ctx := WithProvenance(context.Background(), Provenance{UpdatedBy: "alice"})
+	put := &PutRequest{TableName: "T", Value: types.ToMapValue("id", 1)}
+	wm := &WriteMultipleRequest{}
+	wm.AddPutRequest(&PutRequest{TableName: "T", Value: types.ToMapValue("id", 1)}, false)
+	wm.AddDeleteRequest(&DeleteRequest{TableName: "T", Key: types.ToMapValue("id", 1)}, false)
+
+	for _, req := range []Request{put, wm} {
+		suite.NoError(pc.stamp(ctx, req))
+	}
+	for _, r := range []*PutRequest{put, wm.Operations[0].PutRequest} {
+		suite.Equal(map[string]interface{}{
+			"id":             1,
+			"updated_by":     "alice",
+			"updated_at":     now,
+			"origin_service": "default-svc",
+		}, r.value().Map())
+		suite.Equal(map[string]interface{}{"id": 1}, r.Value.Map(), "the request value should not be modified")
+	}
+
+	// A request executed again is stamped with the new provenance.
+	ordered := types.NewOrderedMapValue()
+	ordered.Put("id", 1)
+	put = &PutRequest{TableName: "T", Value: ordered}
+	suite.NoError(pc.stamp(ctx, put))
+	suite.NoError(pc.stamp(WithProvenance(context.Background(), Provenance{UpdatedBy: "bob"}), put))
+	v := put.value()
+	suite.True(v.IsOrdered())
+	k, by, _ := v.GetByIndex(2)
+	suite.Equal("updated_by", k)
+	suite.Equal("bob", by)
+	suite.Equal(4, v.Len())
+	suite.Equal(1, put.Value.Len())
+
+	put = &PutRequest{TableName: "T", Value: types.NewEmptyMapValue()}
+	pc.Required = true
+	err := pc.stamp(context.Background(), put)
+	suite.Truef(nosqlerr.IsIllegalArgument(err), "stamp() should have failed with IllegalArgument, got %v", err)
+	suite.Nil(put.stampedValue)
+
+	var nilConfig *ProvenanceConfig
+	suite.NoError(nilConfig.stamp(ctx, put))
+}
+
+func (suite *RequestTestSuite) TestKeysetPaginator() {
+	p := &KeysetPaginator{
+		TableName:  "users",
+		KeyColumns: []KeyColumn{{Name: "tenant", Type: "STRING"}, {Name: "id", Type: "LONG"}},
+		Where:      "age > 18",
+		PageSize:   10,
+		Secret:     []byte("secret"),
+	}
+	suite.NoError(p.validate())
+	suite.Equal("SELECT * FROM users WHERE (age > 18) ORDER BY tenant, id LIMIT 11", p.Statement(false))
+	suite.Equal("DECLARE $pk0 STRING; $pk1 LONG; SELECT * FROM users WHERE (age > 18) AND "+
+		"((tenant > $pk0) OR (tenant = $pk0 AND id > $pk1)) ORDER BY tenant, id LIMIT 11", p.Statement(true))
+
+	row := types.NewMapValue(map[string]interface{}{"tenant": "acme", "id": int64(1) << 40, "name": "jane"})
+	token, err := p.EncodeToken(row)
+	suite.Require().NoError(err)
+	key, err := p.DecodeToken(token)
+	suite.Require().NoError(err)
+	suite.Equal(map[string]interface{}{"tenant": "acme", "id": int64(1) << 40}, key.Map())
+
+	// Tampered payload, signature and token of another table are rejected.
+	other := *p
+	other.TableName = "orders"
+	otherToken, _ := other.EncodeToken(row)
+	payload, sig, _ := strings.Cut(token, ".")
+	badTokens := []string{"", "abc", payload + ".AAAA", "e30." + sig, otherToken}
+	for _, t := range badTokens {
+		_, err = p.DecodeToken(t)
+		suite.Truef(nosqlerr.IsIllegalArgument(err), "DecodeToken(%q) should have failed", t)
+	}
+
+	_, err = p.EncodeToken(types.ToMapValue("id", 1))
+	suite.Truef(nosqlerr.IsIllegalArgument(err), "EncodeToken() should have failed with a missing key column")
+
+	_, _, err = p.Page(nil, "")
+	suite.Error(err)
+}
+
+func (suite *RequestTestSuite) TestRedactionPolicy() {
+	p := &RedactionPolicy{
+		Columns:                 []string{"SSN", "*_token", "address.street"},
+		RedactStatementLiterals: true,
+	}
+
+	addr := types.NewOrderedMapValue()
+	addr.Put("street", "1 Main St")
+	addr.Put("city", "Springfield")
+	row := types.NewOrderedMapValue()
+	row.Put("id", 1)
+	row.Put("ssn", "123-45-6789")
+	row.Put("api_token", "abc")
+	row.Put("address", addr)
+	row.Put("contacts", []types.FieldValue{types.ToMapValue("ssn", "x")})
+
+	res := p.RedactValue(row).(*types.MapValue)
+	suite.Equal(`{"address":{"city":"Springfield","street":"[REDACTED]"},"api_token":"[REDACTED]",`+
+		`"contacts":[{"ssn":"[REDACTED]"}],"id":1,"ssn":"[REDACTED]"}`, jsonutil.AsJSON(res))
+	v, _ := row.GetString("ssn")
+	suite.Equal("123-45-6789", v, "the original value should not be modified")
+
+	suite.Equal("select * from users where name = ? and age > ? limit ?",
+		p.RedactStatement("select * from users where name = 'O''Brien' and age > 21.5 limit 10"))
+	suite.Equal("[REDACTED]", p.RedactBindValue("$ssn", "123"))
+	suite.Equal(42, p.RedactBindValue("$age", 42))
+
+	stmt := "select * from users where ssn = '123-45-6789'"
+	err := p.redactError(nosqlerr.NewIllegalArgument("invalid query %s", stmt), &QueryRequest{Statement: stmt})
+	suite.Equal("invalid query select * from users where ssn = ?", err.(*nosqlerr.Error).Message)
+
+	var nilPolicy *RedactionPolicy
+	suite.Equal(stmt, nilPolicy.RedactStatement(stmt))
+	suite.Equal(row, nilPolicy.RedactValue(row))
+}
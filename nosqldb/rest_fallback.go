@@ -0,0 +1,456 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/sdkutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// restAPIVersion is the version path of the REST API of the cloud service.
+const restAPIVersion = "/20190828"
+
+// binaryBlockedError is returned for a response to a binary protocol request
+// that indicates the request was rejected before it reached the service, such
+// as a page returned by a proxy or firewall that does not allow the binary
+// content type.
+type binaryBlockedError struct {
+	url         *url.URL
+	statusCode  int
+	contentType string
+}
+
+func (e *binaryBlockedError) Error() string {
+	return fmt.Sprintf("the binary protocol appears to be blocked on %s: error response: %d %s (Content-Type: %q)",
+		e.url.Host, e.statusCode, http.StatusText(e.statusCode), e.contentType)
+}
+
+// checkBinaryBlocked returns a *binaryBlockedError if Config.RESTFallback is
+// enabled and the response is one a middlebox returns for a request it blocks:
+// an HTML page, or a status code that rejects the content type.
+func (c *Client) checkBinaryBlocked(httpResp *http.Response) error {
+	if !c.restFallbackEnabled() || httpResp.Request == nil {
+		return nil
+	}
+
+	contentType := httpResp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "text/html",
+		httpResp.StatusCode == http.StatusNotAcceptable,
+		httpResp.StatusCode == http.StatusUnsupportedMediaType:
+		return &binaryBlockedError{
+			url:         httpResp.Request.URL,
+			statusCode:  httpResp.StatusCode,
+			contentType: contentType,
+		}
+	}
+	return nil
+}
+
+// restEndpoints records the endpoints on which the binary protocol is blocked
+// and the REST API is used instead.
+type restEndpoints struct {
+	mu    sync.RWMutex
+	hosts map[string]bool
+}
+
+func (r *restEndpoints) add(host string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hosts == nil {
+		r.hosts = make(map[string]bool)
+	}
+	r.hosts[host] = true
+}
+
+func (r *restEndpoints) contains(host string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.hosts[host]
+}
+
+// restFallbackEnabled reports whether Config.RESTFallback is enabled and
+// applies to the client, which requires a single endpoint.
+func (c *Client) restFallbackEnabled() bool {
+	return c.RESTFallback && c.endpoints == nil
+}
+
+// UsesREST reports whether the client uses the REST API rather than the
+// binary protocol for the requests it supports on its Endpoint, because
+// Config.RESTFallback is enabled and the binary protocol was found to be
+// blocked.
+func (c *Client) UsesREST() bool {
+	if !c.restFallbackEnabled() {
+		return false
+	}
+	u, err := url.Parse(c.requestURL)
+	return err == nil && c.restEndpoints.contains(u.Host)
+}
+
+// executeWithREST executes the request with the REST API if the binary
+// protocol is known to be blocked on the endpoint of the client. Otherwise it
+// executes the request with the binary protocol, and if the binary protocol is
+// found to be blocked, executes the request again with the REST API.
+func (c *Client) executeWithREST(ctx context.Context, req Request, data []byte, serialVerUsed, queryVerUsed int16) (Result, error) {
+	if !c.restFallbackEnabled() || !c.restSupported(ctx, req) {
+		return c.doExecute(ctx, req, data, serialVerUsed, queryVerUsed)
+	}
+
+	if u, err := url.Parse(c.requestURL); err == nil && c.restEndpoints.contains(u.Host) {
+		res, err := c.executeREST(ctx, req, u)
+		return res, c.enrichAuthError(ctx, req, err, u.Host)
+	}
+
+	res, err := c.doExecute(ctx, req, data, serialVerUsed, queryVerUsed)
+	blocked, ok := err.(*binaryBlockedError)
+	if !ok {
+		return res, err
+	}
+
+	c.logger.Warn("%v, using the REST API for supported requests on %s", blocked, blocked.url.Host)
+	c.restEndpoints.add(blocked.url.Host)
+	res, err = c.executeREST(ctx, req, blocked.url)
+	return res, c.enrichAuthError(ctx, req, err, blocked.url.Host)
+}
+
+// restSupported reports whether the request can be executed with the REST
+// API. Only single row get, put and delete requests that are signed with an
+// iam.SignatureProvider and use options the REST API supports are.
+func (c *Client) restSupported(ctx context.Context, req Request) bool {
+	if _, ok := asSignatureProvider(c.authProviderFor(ctx, req)); !ok {
+		return false
+	}
+
+	switch r := req.(type) {
+	case *GetRequest:
+		return r.Key != nil && r.StructType == nil
+	case *PutRequest:
+		return r.PutOption != types.PutIfVersion && (r.TTL == nil || r.TTL.Unit == types.Days)
+	case *DeleteRequest:
+		return r.Key != nil && r.MatchVersion == nil
+	}
+	return false
+}
+
+// restUsage is the usage reported by the REST API.
+type restUsage struct {
+	ReadUnitsConsumed  int `json:"readUnitsConsumed"`
+	WriteUnitsConsumed int `json:"writeUnitsConsumed"`
+}
+
+func (u *restUsage) capacity() Capacity {
+	if u == nil {
+		return Capacity{}
+	}
+	// The REST API does not report the KB read, which differ from the read
+	// units for reads with Absolute consistency.
+	return Capacity{ReadUnits: u.ReadUnitsConsumed, WriteKB: u.WriteUnitsConsumed}
+}
+
+// executeREST executes the request with the REST API of the service at the
+// specified URL.
+func (c *Client) executeREST(ctx context.Context, req Request, endpoint *url.URL) (Result, error) {
+	ap, _ := asSignatureProvider(c.authProviderFor(ctx, req))
+	base := endpoint.Scheme + "://" + endpoint.Host + restAPIVersion
+
+	params := url.Values{}
+	params.Set("compartmentId", ap.CompartmentID())
+	params.Set("timeoutInMs", strconv.FormatInt(req.timeout().Milliseconds(), 10))
+
+	switch r := req.(type) {
+	case *GetRequest:
+		addRESTKey(params, r.Key)
+		if r.Consistency == types.Absolute {
+			params.Set("consistency", "ABSOLUTE")
+		} else {
+			params.Set("consistency", "EVENTUAL")
+		}
+
+		var resp struct {
+			Value            json.RawMessage `json:"value"`
+			TimeOfExpiration *time.Time      `json:"timeOfExpiration"`
+			Usage            *restUsage      `json:"usage"`
+		}
+		if err := c.doREST(ctx, ap, req.timeout(), http.MethodGet, restRowsURL(base, r.TableName, params), nil, &resp); err != nil {
+			return nil, err
+		}
+
+		value, err := restMapValue(resp.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		res := &GetResult{Capacity: resp.Usage.capacity()}
+		if value != nil && value.Len() > 0 {
+			res.Value = value
+			if resp.TimeOfExpiration != nil {
+				res.ExpirationTime = *resp.TimeOfExpiration
+			}
+		}
+		return res, nil
+
+	case *PutRequest:
+		value, err := r.rowValue()
+		if err != nil {
+			return nil, err
+		}
+
+		body := map[string]interface{}{
+			"compartmentId":  ap.CompartmentID(),
+			"value":          value,
+			"isGetReturnRow": r.ReturnRow,
+			"timeoutInMs":    req.timeout().Milliseconds(),
+			"isExactMatch":   r.ExactMatch,
+		}
+		switch r.PutOption {
+		case types.PutIfAbsent:
+			body["option"] = "IF_ABSENT"
+		case types.PutIfPresent:
+			body["option"] = "IF_PRESENT"
+		}
+		if r.UseTableTTL {
+			body["isTtlUseTableDefault"] = true
+		} else if r.TTL != nil {
+			body["ttl"] = r.TTL.Value
+		}
+		if r.IdentityCacheSize > 0 {
+			body["identityCacheSize"] = r.IdentityCacheSize
+		}
+
+		var resp struct {
+			Version         string           `json:"version"`
+			ExistingVersion string           `json:"existingVersion"`
+			ExistingValue   json.RawMessage  `json:"existingValue"`
+			GeneratedValue  *json.RawMessage `json:"generatedValue"`
+			Usage           *restUsage       `json:"usage"`
+		}
+		if err := c.doREST(ctx, ap, req.timeout(), http.MethodPut, restRowsURL(base, r.TableName, nil), body, &resp); err != nil {
+			return nil, err
+		}
+
+		existing, err := restMapValue(resp.ExistingValue)
+		if err != nil {
+			return nil, err
+		}
+
+		res := &PutResult{
+			Capacity: resp.Usage.capacity(),
+			Version:  restVersion(resp.Version),
+			WriteResult: WriteResult{
+				ExistingVersion: restVersion(resp.ExistingVersion),
+				ExistingValue:   existing,
+			},
+		}
+		if resp.GeneratedValue != nil {
+			var v interface{}
+			d := json.NewDecoder(bytes.NewReader(*resp.GeneratedValue))
+			d.UseNumber()
+			if err := d.Decode(&v); err == nil {
+				res.GeneratedValue = v
+			}
+		}
+		return res, nil
+
+	case *DeleteRequest:
+		addRESTKey(params, r.Key)
+		params.Set("isGetReturnRow", strconv.FormatBool(r.ReturnRow))
+
+		var resp struct {
+			IsSuccess       bool            `json:"isSuccess"`
+			ExistingVersion string          `json:"existingVersion"`
+			ExistingValue   json.RawMessage `json:"existingValue"`
+			Usage           *restUsage      `json:"usage"`
+		}
+		if err := c.doREST(ctx, ap, req.timeout(), http.MethodDelete, restRowsURL(base, r.TableName, params), nil, &resp); err != nil {
+			return nil, err
+		}
+
+		existing, err := restMapValue(resp.ExistingValue)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DeleteResult{
+			Capacity: resp.Usage.capacity(),
+			Success:  resp.IsSuccess,
+			WriteResult: WriteResult{
+				ExistingVersion: restVersion(resp.ExistingVersion),
+				ExistingValue:   existing,
+			},
+		}, nil
+	}
+
+	return nil, nosqlerr.New(nosqlerr.OperationNotSupported, "%T is not supported with the REST API", req)
+}
+
+func restRowsURL(base, tableName string, params url.Values) string {
+	u := base + "/tables/" + url.PathEscape(tableName) + "/rows"
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	return u
+}
+
+// addRESTKey adds the primary key to the query parameters in the format of
+// the REST API, which is a "key" parameter of the form "column:value" for each
+// field of the key.
+func addRESTKey(params url.Values, key *types.MapValue) {
+	fields := make([]string, 0, key.Len())
+	for field := range key.Map() {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		v, _ := key.Get(field)
+		var s string
+		switch v := v.(type) {
+		case time.Time:
+			s = v.UTC().Format(time.RFC3339Nano)
+		case *string:
+			s = *v
+		default:
+			s = fmt.Sprint(v)
+		}
+		params.Add("key", field+":"+s)
+	}
+}
+
+// restMapValue converts a row returned by the REST API to a MapValue. It
+// returns nil if the row is absent or null.
+func restMapValue(data json.RawMessage) (*types.MapValue, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	v, err := types.NewMapValueFromJSON(string(data))
+	if err != nil {
+		return nil, nosqlerr.NewWithCause(nosqlerr.BadProtocolMessage, err, "invalid row in REST API response")
+	}
+	return v, nil
+}
+
+// restVersion converts the version of a row returned by the REST API, which is
+// base64 encoded, to a types.Version.
+func restVersion(s string) types.Version {
+	if s == "" {
+		return nil
+	}
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return b
+	}
+	return types.Version(s)
+}
+
+// doREST sends a request to the REST API and decodes the JSON response into
+// out. The request fails if no response is received within timeout. Requests
+// with the REST API are not retried.
+func (c *Client) doREST(ctx context.Context, ap *iam.SignatureProvider, timeout time.Duration, method, reqURL string, body interface{}, out interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("User-Agent", sdkutil.UserAgent())
+	httpReq.Header.Set("x-nosql-request-id", strconv.Itoa(int(c.nextRequestID())))
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Content-Length", strconv.Itoa(len(data)))
+		httpReq.Header.Set("X-Nosql-Hash-Body", "true")
+	} else {
+		httpReq.Body = http.NoBody
+	}
+	// The request is signed without the signature cache of the provider,
+	// which holds the signature of the binary protocol requests, whose target
+	// and body differ.
+	if err = iam.SignRequest(ap, httpReq); err != nil {
+		return err
+	}
+
+	httpResp, err := c.executor.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	respData, err := io.ReadAll(httpResp.Body)
+	httpResp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode/100 != 2 {
+		return restError(httpResp.StatusCode, respData)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(respData))
+	d.UseNumber()
+	if err = d.Decode(out); err != nil {
+		return nosqlerr.NewWithCause(nosqlerr.BadProtocolMessage, err, "invalid REST API response")
+	}
+	return nil
+}
+
+// restError converts an error response of the REST API to an error.
+func restError(statusCode int, data []byte) error {
+	var resp struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	msg := fmt.Sprintf("error response: %d %s", statusCode, http.StatusText(statusCode))
+	if err := json.Unmarshal(data, &resp); err == nil && resp.Message != "" {
+		msg = resp.Code + ": " + resp.Message
+	}
+
+	code := nosqlerr.UnknownError
+	switch {
+	case statusCode == http.StatusBadRequest:
+		code = nosqlerr.IllegalArgument
+	case statusCode == http.StatusUnauthorized:
+		code = nosqlerr.InvalidAuthorization
+	case statusCode == http.StatusForbidden:
+		code = nosqlerr.InsufficientPermission
+	case statusCode == http.StatusNotFound:
+		code = nosqlerr.TableNotFound
+		if !strings.Contains(strings.ToLower(msg), "table") {
+			code = nosqlerr.ResourceNotFound
+		}
+	case statusCode == http.StatusTooManyRequests:
+		code = nosqlerr.OperationLimitExceeded
+	case statusCode == http.StatusServiceUnavailable:
+		code = nosqlerr.ServiceUnavailable
+	case statusCode >= 500:
+		code = nosqlerr.ServerError
+	}
+	return nosqlerr.New(code, "%s", msg)
+}
@@ -0,0 +1,270 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"crypto"
+	cryptorand "crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/auth/iam"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTFallback(t *testing.T) {
+	var binaryRequests int
+	var restRequests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/20190828/") {
+			// A proxy that blocks the binary protocol.
+			binaryRequests++
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "<html><body>Request blocked</body></html>")
+			return
+		}
+
+		if strings.Contains(r.URL.Path, "/tables/slow/") {
+			// A request that does not get a response.
+			<-r.Context().Done()
+			return
+		}
+		restRequests = append(restRequests, r.Method+" "+r.URL.Path)
+		if r.Method != http.MethodPut {
+			assert.Equal(t, "ocid1.compartment.oc1..test", r.URL.Query().Get("compartmentId"))
+		}
+		assert.NotEmpty(t, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, []string{"id:1"}, r.URL.Query()["key"])
+			io.WriteString(w, `{"value": {"id": 1, "name": "n1"}, "usage": {"readUnitsConsumed": 1}}`)
+		case http.MethodDelete:
+			io.WriteString(w, `{"isSuccess": true, "usage": {"writeUnitsConsumed": 1}}`)
+		default:
+			http.Error(w, `{"code": "TableNotFound", "message": "table orders not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	signer, err := iam.NewSignatureProviderWithAuthorizationStringProvider(iam.AuthorizationStringProviderFunc(
+		func(req *http.Request) (string, error) { return `Signature version="1"`, nil }), "ocid1.compartment.oc1..test")
+	require.NoError(t, err)
+	client, err := NewClient(Config{
+		Endpoint:              srv.URL,
+		AuthorizationProvider: signer,
+		RESTFallback:          true,
+	})
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	defer client.Close()
+
+	// The binary protocol is found to be blocked by the first request, which
+	// is sent again with the REST API.
+	key := types.NewMapValue(map[string]interface{}{"id": 1})
+	getRes, err := client.Get(&GetRequest{TableName: "orders", Key: key})
+	require.NoError(t, err)
+	assert.True(t, client.UsesREST())
+	assert.Equal(t, 1, binaryRequests)
+	assert.Equal(t, []string{"GET /20190828/tables/orders/rows"}, restRequests)
+	assert.Equal(t, "n1", getRes.Value.Map()["name"])
+	assert.Equal(t, 1, getRes.ReadUnits)
+
+	tests := []struct {
+		desc    string
+		do      func() error
+		wantErr bool
+		rest    []string
+		binary  int
+	}{
+		{
+			desc: "delete",
+			do: func() error {
+				res, err := client.Delete(&DeleteRequest{TableName: "orders", Key: key})
+				if err == nil && !res.Success {
+					err = errors.New("the row was not deleted")
+				}
+				return err
+			},
+			rest: []string{"DELETE /20190828/tables/orders/rows"},
+		},
+		{
+			desc: "put on a missing table",
+			do: func() error {
+				_, err := client.Put(&PutRequest{TableName: "orders", Value: key})
+				if !nosqlerr.IsTableNotFound(err) {
+					return fmt.Errorf("got error %v, want TableNotFound", err)
+				}
+				return nil
+			},
+			rest: []string{"PUT /20190828/tables/orders/rows"},
+		},
+		{
+			desc: "delete not supported by the REST API",
+			do: func() error {
+				_, err := client.Delete(&DeleteRequest{TableName: "orders", Key: key, MatchVersion: types.Version("v1")})
+				return err
+			},
+			wantErr: true,
+			binary:  1,
+		},
+		{
+			desc: "get that times out",
+			do: func() error {
+				_, err := client.Get(&GetRequest{TableName: "slow", Key: key, Timeout: 100 * time.Millisecond})
+				return err
+			},
+			wantErr: true,
+		},
+	}
+	for _, r := range tests {
+		binaryRequests, restRequests = 0, nil
+		start := time.Now()
+		err := r.do()
+		if r.wantErr {
+			assert.Errorf(t, err, "%s: the request should have failed", r.desc)
+		} else {
+			assert.NoErrorf(t, err, "%s: got error %v", r.desc, err)
+		}
+		assert.Equalf(t, r.rest, restRequests, "%s: unexpected REST requests", r.desc)
+		assert.Equalf(t, r.binary, binaryRequests, "%s: unexpected binary protocol requests", r.desc)
+		assert.Lessf(t, int64(time.Since(start)), int64(5*time.Second), "%s: the request timeout was not honored", r.desc)
+	}
+
+	// The fallback is not used by a client with additional endpoints.
+	other := httptest.NewServer(srv.Config.Handler)
+	defer other.Close()
+	client, err = NewClient(Config{
+		Endpoint:              srv.URL,
+		AdditionalEndpoints:   []string{other.URL},
+		AuthorizationProvider: signer,
+		RESTFallback:          true,
+	})
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	defer client.Close()
+	restRequests = nil
+	_, err = client.Get(&GetRequest{TableName: "orders", Key: key})
+	assert.Error(t, err)
+	assert.False(t, client.UsesREST())
+	assert.Empty(t, restRequests)
+}
+
+// TestRESTFallbackSignatures checks that the REST requests and the binary
+// protocol requests of a key based SignatureProvider are signed for their own
+// target, rather than with a cached signature of the other protocol.
+func TestRESTFallbackSignatures(t *testing.T) {
+	key, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+	require.NoError(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var targets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		targets = append(targets, r.Method+" "+r.URL.Path)
+		verifyRequestSignature(t, r, &key.PublicKey)
+		if !strings.HasPrefix(r.URL.Path, "/20190828/") {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "<html><body>Request blocked</body></html>")
+			return
+		}
+
+		var body struct {
+			TimeoutInMs int64 `json:"timeoutInMs"`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			io.WriteString(w, `{"value": {"id": 1}, "usage": {"readUnitsConsumed": 2}}`)
+		case http.MethodPut:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, int64(5000), body.TimeoutInMs, "the default request timeout")
+			io.WriteString(w, `{"version": "djE=", "usage": {"writeUnitsConsumed": 1}}`)
+		default:
+			io.WriteString(w, `{"isSuccess": true, "usage": {"writeUnitsConsumed": 1}}`)
+		}
+	}))
+	defer srv.Close()
+
+	// The provider has no region, so that the endpoint of the test server is
+	// used.
+	signer, err := iam.NewSignatureProviderWithConfiguration(iam.NewRawConfigurationProvider(
+		"ocid1.tenancy.oc1..test", "ocid1.user.oc1..test", "", "20:3b:97:13:55:1c:5b:0d:d3:37:d8:50:4e:c5:3a:34",
+		string(pemKey), nil), "ocid1.compartment.oc1..test")
+	require.NoError(t, err)
+	client, err := NewClient(Config{
+		Endpoint:              srv.URL,
+		AuthorizationProvider: signer,
+		RESTFallback:          true,
+		RequestConfig:         RequestConfig{RequestTimeout: 5 * time.Second},
+	})
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	defer client.Close()
+
+	key1 := types.NewMapValue(map[string]interface{}{"id": 1})
+	getRes, err := client.Get(&GetRequest{TableName: "orders", Key: key1, Consistency: types.Absolute})
+	require.NoError(t, err)
+	assert.Equal(t, 2, getRes.ReadUnits)
+	assert.Equal(t, 0, getRes.ReadKB, "the KB read are not reported")
+	_, err = client.Delete(&DeleteRequest{TableName: "orders", Key: key1})
+	require.NoError(t, err)
+	_, err = client.Put(&PutRequest{TableName: "orders", Value: key1})
+	require.NoError(t, err)
+	// A binary protocol request after the REST requests.
+	_, err = client.Delete(&DeleteRequest{TableName: "orders", Key: key1, MatchVersion: types.Version("v1")})
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"POST /V2/nosql/data",
+		"GET /20190828/tables/orders/rows",
+		"DELETE /20190828/tables/orders/rows",
+		"PUT /20190828/tables/orders/rows",
+		"POST /V2/nosql/data",
+	}, targets)
+}
+
+// verifyRequestSignature verifies the OCI signature of the Authorization
+// header of r against the headers of r.
+func verifyRequestSignature(t *testing.T, r *http.Request, pub *rsa.PublicKey) {
+	params := make(map[string]string)
+	for _, m := range regexp.MustCompile(`(\w+)="([^"]*)"`).FindAllStringSubmatch(r.Header.Get("Authorization"), -1) {
+		params[m[1]] = m[2]
+	}
+
+	var lines []string
+	for _, h := range strings.Fields(params["headers"]) {
+		switch h {
+		case "(request-target)":
+			lines = append(lines, h+": "+strings.ToLower(r.Method)+" "+r.URL.RequestURI())
+		case "host":
+			lines = append(lines, h+": "+r.Host)
+		default:
+			lines = append(lines, h+": "+r.Header.Get(h))
+		}
+	}
+	sig, err := base64.StdEncoding.DecodeString(params["signature"])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	assert.NoErrorf(t, rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig),
+		"signature of %s %s", r.Method, r.URL.Path)
+}
@@ -32,6 +32,8 @@ type Result interface {
 
 	Delayed() *DelayInfo
 
+	Sizes() *SizeInfo
+
 	GetTopologyInfo() *common.TopologyInfo
 	SetTopology(*common.TopologyInfo)
 }
@@ -57,6 +59,20 @@ func (d *DelayInfo) setRetryTime(t time.Duration) {
 	d.RetryTime = t
 }
 
+// SizeInfo contains the sizes of the messages exchanged with the server for
+// a completed request.
+type SizeInfo struct {
+	// RequestSize represents the size in bytes of the serialized request.
+	RequestSize int `json:"requestSize"`
+	// ResponseSize represents the size in bytes of the serialized response.
+	ResponseSize int `json:"responseSize"`
+}
+
+// Sizes returns the message size information for a completed request.
+func (s *SizeInfo) Sizes() *SizeInfo {
+	return s
+}
+
 // Capacity represents the read/write throughput consumed by an operation.
 type Capacity struct {
 	// ReadKB represents the number of kilo bytes consumed for reads.
@@ -126,7 +142,15 @@ type GetResult struct {
 	// Added in SDK Version 1.3.0
 	ModificationTime int64 `json:"modificationTime"`
 
+	// strictDecode specifies whether the row is decoded into StructValue in
+	// DecodeStrict mode.
+	strictDecode bool
+
+	// pool is the pool that Value was taken from, if any.
+	pool *types.MapValuePool
+
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 }
 
@@ -150,6 +174,22 @@ func (r GetResult) RowExists() bool {
 	return len(r.Version) > 0
 }
 
+// Release releases Value to the MapValuePool of the client, if one is set by
+// Config.MapValuePool, and sets Value to nil. Value, and any value taken from
+// it, must not be used after it is released.
+// Release does nothing if the client has no MapValuePool.
+func (r *GetResult) Release() {
+	if r.pool == nil {
+		return
+	}
+	r.pool.Put(r.Value)
+	r.Value = nil
+}
+
+func (r *GetResult) setMapValuePool(pool *types.MapValuePool) {
+	r.pool = pool
+}
+
 // SystemResult represents a result returned from Client.GetSystemStatus() and
 // Client.DoSystemRequest() operations. It encapsulates the state of the
 // operation requested.
@@ -174,6 +214,7 @@ func (r GetResult) RowExists() bool {
 type SystemResult struct {
 	noCapacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// State represents the current state of the operation.
@@ -275,6 +316,7 @@ func (r *SystemResult) WaitForCompletion(client *Client, timeout, pollInterval t
 type TableResult struct {
 	noCapacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// TableName represents the name of target table.
@@ -403,19 +445,82 @@ func (r *TableResult) WaitForCompletion(client *Client, timeout, pollInterval ti
 		return nil, err
 	}
 
-	var req *GetTableRequest
-	var res *TableResult
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := r.pollTableState(ctx, client, pollInterval)
+	if err == context.DeadlineExceeded {
+		return nil, nosqlerr.NewRequestTimeout("table %q does not reach a terminal state "+
+			"within specified time %v", r.TableName, timeout)
+	}
+	return res, err
+}
+
+// WaitForCompletionWithContext is like WaitForCompletion, but waits until ctx
+// is done rather than for a timeout.
+//
+// If ctx is canceled before the operation completes, the operation is
+// cancelled on the server with Cancel where the service supports it, and the
+// error of ctx is returned. If the deadline of ctx elapses, the operation is
+// not cancelled and the error of ctx is returned.
+func (r *TableResult) WaitForCompletionWithContext(ctx context.Context, client *Client, pollInterval time.Duration) (*TableResult, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	if r == nil {
+		return nil, nosqlerr.NewIllegalArgument("TableResult must be non-nil")
+	}
+
+	if r.State.IsTerminal() {
+		return r, nil
+	}
+
+	if r.OperationID == "" {
+		return nil, nosqlerr.NewIllegalArgument("OperationID must not be empty")
+	}
+
+	if client == nil {
+		return nil, errNilClient
+	}
+
+	if pollInterval == 0 {
+		pollInterval = 500 * time.Millisecond
+	} else if pollInterval < time.Millisecond {
+		return nil, nosqlerr.NewIllegalArgument("the specified poll interval %v is less than the allowed minimum of %v",
+			pollInterval, time.Millisecond)
+	}
+
+	res, err := r.pollTableState(ctx, client, pollInterval)
+	if err == nil || ctx.Err() == nil {
+		return res, err
+	}
+
+	if ctx.Err() == context.Canceled {
+		// The context of the wait is canceled, use a new one for the
+		// cancellation itself.
+		cerr := r.Cancel(context.Background(), client)
+		if cerr != nil && !nosqlerr.Is(cerr, nosqlerr.OperationNotSupported) {
+			return nil, cerr
+		}
+	}
+	return nil, ctx.Err()
+}
+
+// pollTableState gets the state of the table with the operation id of r every
+// pollInterval, until the table reaches a terminal state or ctx is done, in
+// which case it returns ctx.Err().
+//
+// This instance is modified with any change in table state or metadata.
+func (r *TableResult) pollTableState(ctx context.Context, client *Client, pollInterval time.Duration) (*TableResult, error) {
 	// Creates a GetTableRequest with the table name and operation id.
-	req = &GetTableRequest{
+	req := &GetTableRequest{
 		TableName:   r.TableName,
 		OperationID: r.OperationID,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
 	for {
-		res, err = client.getTableWithContext(ctx, req)
+		res, err := client.getTableWithContext(ctx, req)
 		if err != nil {
 			return nil, err
 		}
@@ -430,18 +535,44 @@ func (r *TableResult) WaitForCompletion(client *Client, timeout, pollInterval ti
 			r.IsLocalReplicaInitialized = res.IsLocalReplicaInitialized
 			r.SchemaFrozen = res.SchemaFrozen
 			r.Replicas = res.Replicas
+			r.MatchETag = res.MatchETag
 			return r, nil
 		}
 
 		// Target table has not reached the desired state, continue to check
-		// its status after the specified delay if specified timeout has not elapsed.
-		if shouldRetryAfter(ctx, pollInterval) {
-			continue
+		// its status after the specified delay if ctx is not done.
+		if !shouldRetryAfter(ctx, pollInterval) {
+			return nil, ctx.Err()
 		}
+	}
+}
 
-		return nil, nosqlerr.NewRequestTimeout("table %q does not reach a terminal state "+
-			"within specified time %v", r.TableName, timeout)
+// Cancel cancels the in-progress table operation represented by this instance
+// on the server, where the service allows it.
+//
+// Cancellation of work requests is only available in the REST API of the
+// cloud service, the protocol used by the SDK does not support it yet. Until
+// it does, Cancel returns an OperationNotSupported error, and the operation
+// continues on the server.
+func (r *TableResult) Cancel(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		return errNilContext
+	}
+
+	if r == nil {
+		return nosqlerr.NewIllegalArgument("TableResult must be non-nil")
+	}
+
+	if r.OperationID == "" {
+		return nosqlerr.NewIllegalArgument("OperationID must not be empty")
 	}
+
+	if client == nil {
+		return errNilClient
+	}
+
+	return nosqlerr.New(nosqlerr.OperationNotSupported,
+		"cancellation of table operation %q is not supported by the service", r.OperationID)
 }
 
 // ListTablesResult represents the result of a Client.ListTables() operation.
@@ -452,6 +583,7 @@ func (r *TableResult) WaitForCompletion(client *Client, timeout, pollInterval ti
 type ListTablesResult struct {
 	noCapacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// Tables represents a slice of string that contains table names returned
@@ -519,6 +651,7 @@ type ReplicaStats struct {
 type ReplicaStatsResult struct {
 	noCapacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// TableName represents the name of the table. It should match that given in
@@ -546,11 +679,17 @@ func (r ReplicaStatsResult) String() string {
 type GetIndexesResult struct {
 	noCapacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// Indexes represents a slice of IndexInfo that contains index information
 	// returned by the operation.
 	Indexes []IndexInfo `json:"indexes"`
+
+	// LastIndexReturned represents the index of the next index information
+	// after the ones returned. This can be provided to GetIndexesRequest to
+	// page the indexes.
+	LastIndexReturned uint `json:"lastIndexReturned"`
 }
 
 // String returns a JSON string representation of the GetIndexesResult.
@@ -613,6 +752,7 @@ func (r WriteResult) ExistingValueAsJSON() string {
 type DeleteResult struct {
 	Capacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// WriteResult is used to get the information about the existing row such as
@@ -639,6 +779,7 @@ func (r DeleteResult) String() string {
 type PutResult struct {
 	Capacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// WriteResult is used to get the information about the existing row such as
@@ -720,6 +861,7 @@ func (r TableUsage) String() string {
 type TableUsageResult struct {
 	noCapacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// TableName represents table name used by the operation.
@@ -754,6 +896,7 @@ func (r TableUsageResult) String() string {
 type WriteMultipleResult struct {
 	Capacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// ResultSet represents the list of execution results for the operations.
@@ -762,6 +905,12 @@ type WriteMultipleResult struct {
 	// FailedOperationIndex represents the index of failed operation that
 	// results in the entire WriteMultiple operation aborting.
 	FailedOperationIndex int `json:"failedOperationIndex"`
+
+	// CommittedOperations represents the number of operations, from the
+	// beginning of the request, that were committed before the operation
+	// aborted. It is only non-zero if the request was split into multiple
+	// requests, see WriteMultipleRequest.AllowSplit.
+	CommittedOperations int `json:"committedOperations,omitempty"`
 }
 
 // String returns a JSON string representation of the WriteMultipleResult.
@@ -827,6 +976,7 @@ func (r OperationResult) String() string {
 type MultiDeleteResult struct {
 	Capacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// ContinuationKey represents the continuation key where the next
@@ -849,6 +999,7 @@ func (r MultiDeleteResult) String() string {
 type PrepareResult struct {
 	Capacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// PreparedStatement represents the value of the prepared statement.
@@ -879,6 +1030,7 @@ func (r PrepareResult) String() string {
 type QueryResult struct {
 	Capacity
 	DelayInfo
+	SizeInfo
 	common.InternalResultData
 
 	// The query request with which this query result is associated.
@@ -915,6 +1067,13 @@ type QueryResult struct {
 	contKeysPerPart   [][]byte
 
 	virtualScans []*virtualScan
+
+	// pool is the pool that the results were taken from, if any.
+	pool *types.MapValuePool
+
+	// traces represents the traces returned by the server for the batches of
+	// results requested to compute this result.
+	traces []QueryTrace
 }
 
 func newQueryResult(req *QueryRequest, isComputed bool) *QueryResult {
@@ -942,6 +1101,16 @@ func (r *QueryResult) compute() (err error) {
 	}
 
 	r.isComputed = true
+	if v := r.request.RowVisitor; v != nil {
+		// The rows of advanced queries are computed by the driver, and
+		// passed to the visitor once computed.
+		for _, row := range r.results {
+			if err = visitRow(row, v); err != nil {
+				return
+			}
+		}
+		r.results = nil
+	}
 	return
 }
 
@@ -956,6 +1125,25 @@ func (r *QueryResult) GetResults() (res []*types.MapValue, err error) {
 	return r.results, nil
 }
 
+// Release releases the query results to the MapValuePool of the client, if
+// one is set by Config.MapValuePool. The results, and any value taken from
+// them, must not be used after they are released.
+// Release does nothing if the client has no MapValuePool.
+func (r *QueryResult) Release() {
+	if r.pool == nil {
+		return
+	}
+	for i, v := range r.results {
+		r.pool.Put(v)
+		r.results[i] = nil
+	}
+	r.results = nil
+}
+
+func (r *QueryResult) setMapValuePool(pool *types.MapValuePool) {
+	r.pool = pool
+}
+
 // GetStructResults returns query results as a slice of pointers to allocated
 // structures of the type given in the StructType field of the QueryRequest.
 // QueryRequest.StructType must have been set before execution of the query for
@@ -974,7 +1162,12 @@ func (r *QueryResult) GetStructResults() (res []any, err error) {
 	arr := make([]any, size, size)
 	for i := 0; i < size; i++ {
 		arr[i] = reflect.New(r.request.StructType).Interface()
-		if err = binary.DecodeMapValue(arr[i], r.results[i]); err != nil {
+		if r.request.DecodeMode == DecodeStrict {
+			err = binary.DecodeMapValueStrict(arr[i], r.results[i])
+		} else {
+			err = binary.DecodeMapValue(arr[i], r.results[i])
+		}
+		if err != nil {
 			return
 		}
 	}
@@ -1005,6 +1198,14 @@ func (r *QueryResult) ConsumedCapacity() (*Capacity, error) {
 	}, nil
 }
 
+// Traces returns the traces of the query execution returned by the server for
+// the batches of results requested to compute this result, if
+// QueryRequest.TraceLevel is set and QueryRequest.TraceToLogFiles is not.
+// QueryRequest.Traces returns the traces of all batches of the query.
+func (r *QueryResult) Traces() []QueryTrace {
+	return r.traces
+}
+
 // String returns a JSON string representation of the QueryResult.
 func (r QueryResult) String() string {
 	return jsonutil.AsJSON(r)
@@ -0,0 +1,119 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"sort"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// ColumnMetadata describes a column of a batch of query results, so that
+// generic tools can format results whose shape they do not know.
+//
+// The metadata is derived from the values of the batch, as the server does
+// not return the schema of query results with each batch. A column of a JSON
+// field may therefore have values of several types, and a column that has no
+// null value in a batch may have one in another batch.
+type ColumnMetadata struct {
+	// Name is the name of the column.
+	Name string
+
+	// Type is the type of the first value of the column that is not null. If
+	// all values of the column are null, it is the type of the first value,
+	// which is types.Null, types.JSONNull or types.Empty.
+	Type types.DbType
+
+	// Mixed reports whether the values of the column that are not null are
+	// not all of Type, such as the values of a JSON field.
+	Mixed bool
+
+	// Nullable reports whether the column is null in some rows, or missing
+	// from some rows.
+	Nullable bool
+}
+
+// Columns returns the metadata of the columns of the results of this batch,
+// in the order of the columns of the query. It returns nil if the batch has
+// no results.
+func (r *QueryResult) Columns() ([]ColumnMetadata, error) {
+	if err := r.compute(); err != nil {
+		return nil, err
+	}
+	return columnMetadata(r.results), nil
+}
+
+// columnMetadata returns the metadata of the columns of rows. The columns are
+// in the order of the rows if they are ordered, or sorted by name otherwise.
+func columnMetadata(rows []*types.MapValue) []ColumnMetadata {
+	var columns []ColumnMetadata
+	// index maps column names to their index in columns.
+	index := make(map[string]int)
+	// hasType reports whether the Type of a column is the type of a value
+	// that is not null, and numValues the number of rows that have a value
+	// for the column.
+	var hasType []bool
+	var numValues []int
+
+	add := func(name string, v types.FieldValue) {
+		t, _ := types.TypeOf(v)
+		isNull := t == types.Null || t == types.JSONNull || t == types.Empty
+		i, ok := index[name]
+		if !ok {
+			i = len(columns)
+			index[name] = i
+			columns = append(columns, ColumnMetadata{Name: name, Type: t})
+			hasType = append(hasType, !isNull)
+			numValues = append(numValues, 0)
+		}
+
+		numValues[i]++
+		c := &columns[i]
+		switch {
+		case isNull:
+			c.Nullable = true
+		case !hasType[i]:
+			c.Type, hasType[i] = t, true
+		case c.Type != t:
+			c.Mixed = true
+		}
+	}
+
+	numRows := 0
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		numRows++
+		if row.IsOrdered() {
+			for i := 1; i <= row.Len(); i++ {
+				k, v, _ := row.GetByIndex(i)
+				add(k, v)
+			}
+			continue
+		}
+
+		m := row.Map()
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			add(k, m[k])
+		}
+	}
+
+	// A column that is missing from some rows is nullable.
+	for i := range columns {
+		if numValues[i] < numRows {
+			columns[i].Nullable = true
+		}
+	}
+	return columns
+}
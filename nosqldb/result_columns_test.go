@@ -0,0 +1,62 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryResultColumns(t *testing.T) {
+	row1 := types.NewOrderedMapValue().Put("id", 1).Put("name", "a").Put("info", 1.5)
+	row2 := types.NewOrderedMapValue().Put("id", 2).Put("name", types.NullValueInstance).Put("info", "x")
+	row3 := types.NewOrderedMapValue().Put("id", 3).Put("name", "c").Put("extra", int64(5))
+
+	tests := []struct {
+		desc    string
+		results []*types.MapValue
+		want    []ColumnMetadata
+	}{
+		{
+			desc:    "mixed, null and missing values",
+			results: []*types.MapValue{row1, row2, row3},
+			want: []ColumnMetadata{
+				{Name: "id", Type: types.Integer},
+				{Name: "name", Type: types.String, Nullable: true},
+				{Name: "info", Type: types.Double, Mixed: true, Nullable: true},
+				{Name: "extra", Type: types.Long, Nullable: true},
+			},
+		},
+		{
+			// The type of a column that is always null is the type of its nulls.
+			desc:    "column of nulls",
+			results: []*types.MapValue{types.NewMapValue(map[string]interface{}{"b": types.JSONNullValueInstance, "a": true})},
+			want: []ColumnMetadata{
+				{Name: "a", Type: types.Boolean},
+				{Name: "b", Type: types.JSONNull, Nullable: true},
+			},
+		},
+		{
+			desc:    "no rows",
+			results: nil,
+			want:    nil,
+		},
+	}
+	for _, r := range tests {
+		res := &QueryResult{isComputed: true, results: r.results}
+		columns, err := res.Columns()
+		if assert.NoErrorf(t, err, "%s: Columns() got error %v", r.desc, err) {
+			assert.Equalf(t, r.want, columns, "%s: unexpected columns", r.desc)
+		}
+	}
+
+	_, err := (&QueryResult{}).Columns()
+	assert.Error(t, err)
+}
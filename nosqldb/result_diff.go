@@ -0,0 +1,397 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// RowChange represents a row whose value differs between two result sets.
+type RowChange struct {
+	// Key represents the primary key of the row, encoded with
+	// types.Key.Encode().
+	Key string `json:"key"`
+
+	// Fields represents the sorted names of the top-level fields whose values
+	// differ.
+	Fields []string `json:"fields"`
+
+	// Old represents the row in the first result set.
+	Old *types.MapValue `json:"old"`
+
+	// New represents the row in the second result set.
+	New *types.MapValue `json:"new"`
+}
+
+// ResultDiff represents the differences between two result sets, as returned
+// by DiffResults.
+type ResultDiff struct {
+	// Added represents the rows of the second result set whose primary key
+	// is not in the first result set.
+	Added []*types.MapValue `json:"added,omitempty"`
+
+	// Removed represents the rows of the first result set whose primary key
+	// is not in the second result set.
+	Removed []*types.MapValue `json:"removed,omitempty"`
+
+	// Changed represents the rows that are in both result sets with
+	// different values.
+	Changed []RowChange `json:"changed,omitempty"`
+
+	// Unchanged represents the number of rows that are in both result sets
+	// with equal values.
+	Unchanged int `json:"unchanged"`
+}
+
+// Equal returns true if the result sets contain the same rows.
+func (d *ResultDiff) Equal() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String returns a JSON string representation of the ResultDiff.
+func (d ResultDiff) String() string {
+	return jsonutil.AsJSON(d)
+}
+
+// DiffResults compares two result sets, such as the results of a query on a
+// table before and after a migration, and reports the rows that were added,
+// removed or changed. Rows are matched by the values of the specified primary
+// key fields, which must be present in every row and unique within each result
+// set.
+//
+// Values are compared by type-aware equality rather than by their Go types:
+//
+//   - numeric values are equal if they represent the same number, whether
+//     they are int, int64, float64, *big.Rat or json.Number values
+//   - a time.Time value is equal to a string in ISO 8601 format that
+//     represents the same instant
+//   - a []byte value is equal to its base64 encoding
+//   - a nil value is equal to SQL NULL and JSON null
+//   - maps and arrays are equal if their elements are equal
+//
+// This allows a result set to be compared with an expected result set read
+// from JSON, for example with ReadJSONLines. Primary key values are matched
+// by their encoding with types.Key, which is the same for all integer types
+// and json.Number values that represent the same integer.
+func DiffResults(before, after []*types.MapValue, keyFields ...string) (*ResultDiff, error) {
+	if len(keyFields) == 0 {
+		return nil, fmt.Errorf("DiffResults: keyFields must be non-empty")
+	}
+
+	beforeKeys, beforeRows, err := indexRows(before, keyFields)
+	if err != nil {
+		return nil, fmt.Errorf("DiffResults: first result set: %v", err)
+	}
+	_, afterRows, err := indexRows(after, keyFields)
+	if err != nil {
+		return nil, fmt.Errorf("DiffResults: second result set: %v", err)
+	}
+
+	d := &ResultDiff{}
+	for i, key := range beforeKeys {
+		oldRow := before[i]
+		newRow, ok := afterRows[key]
+		if !ok {
+			d.Removed = append(d.Removed, oldRow)
+			continue
+		}
+
+		if fields := diffFields(oldRow, newRow); len(fields) > 0 {
+			d.Changed = append(d.Changed, RowChange{Key: key, Fields: fields, Old: oldRow, New: newRow})
+		} else {
+			d.Unchanged++
+		}
+	}
+
+	for _, row := range after {
+		key, _ := encodeRowKey(row, keyFields)
+		if _, ok := beforeRows[key]; !ok {
+			d.Added = append(d.Added, row)
+		}
+	}
+
+	return d, nil
+}
+
+// ReadJSONLines reads rows from r in the JSON Lines format, where each
+// non-empty line is a JSON object that represents a row. Numbers are read as
+// json.Number values.
+func ReadJSONLines(r io.Reader) ([]*types.MapValue, error) {
+	var rows []*types.MapValue
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 64<<20)
+	for line := 1; sc.Scan(); line++ {
+		s := strings.TrimSpace(sc.Text())
+		if s == "" {
+			continue
+		}
+		row, err := types.NewMapValueFromJSON(s)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		rows = append(rows, row)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// indexRows returns the encoded keys of the rows, in the order of the rows,
+// and a map of the rows by encoded key.
+func indexRows(rows []*types.MapValue, keyFields []string) ([]string, map[string]*types.MapValue, error) {
+	keys := make([]string, len(rows))
+	byKey := make(map[string]*types.MapValue, len(rows))
+	for i, row := range rows {
+		key, err := encodeRowKey(row, keyFields)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %v", i, err)
+		}
+		if _, dup := byKey[key]; dup {
+			return nil, nil, fmt.Errorf("row %d: duplicate primary key %s", i, key)
+		}
+		keys[i] = key
+		byKey[key] = row
+	}
+	return keys, byKey, nil
+}
+
+func encodeRowKey(row *types.MapValue, keyFields []string) (string, error) {
+	k, err := types.KeyFromMapValue(row, keyFields...)
+	if err != nil {
+		return "", err
+	}
+	return k.Encode()
+}
+
+// diffFields returns the sorted names of the top-level fields whose values
+// differ between the rows.
+func diffFields(a, b *types.MapValue) []string {
+	var fields []string
+	for k, va := range a.Map() {
+		vb, _ := b.Get(k)
+		if !valuesEqual(va, vb) {
+			fields = append(fields, k)
+		}
+	}
+	for k, vb := range b.Map() {
+		if _, ok := a.Get(k); !ok && !valuesEqual(nil, vb) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// valuesEqual reports whether the values are equal by type-aware equality,
+// as described in DiffResults.
+func valuesEqual(a, b types.FieldValue) bool {
+	a, b = derefString(a), derefString(b)
+	if isNullValue(a) || isNullValue(b) {
+		return nullsEqual(a, b)
+	}
+
+	if ra, ok := numericRat(a); ok {
+		rb, ok := numericRat(b)
+		return ok && ra.Cmp(rb) == 0
+	}
+
+	switch va := a.(type) {
+	case time.Time:
+		return timeEqual(va, b)
+	case []byte:
+		return bytesEqual(va, b)
+	case string:
+		switch vb := b.(type) {
+		case string:
+			return va == vb
+		case time.Time:
+			return timeEqual(vb, va)
+		case []byte:
+			return bytesEqual(vb, va)
+		}
+		return false
+	}
+
+	if ma, ok := asMap(a); ok {
+		mb, ok := asMap(b)
+		if !ok || len(ma) != len(mb) {
+			return false
+		}
+		for k, v := range ma {
+			w, ok := mb[k]
+			if !ok || !valuesEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if aa, ok := asArray(a); ok {
+		ab, ok := asArray(b)
+		if !ok || len(aa) != len(ab) {
+			return false
+		}
+		for i := range aa {
+			if !valuesEqual(aa[i], ab[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func derefString(v types.FieldValue) types.FieldValue {
+	if p, ok := v.(*string); ok {
+		if p == nil {
+			return nil
+		}
+		return *p
+	}
+	return v
+}
+
+func isNullValue(v types.FieldValue) bool {
+	switch v.(type) {
+	case nil, *types.NullValue, *types.JSONNullValue:
+		return true
+	}
+	return false
+}
+
+// nullsEqual reports whether the values, at least one of which is null, are
+// equal. A nil value, as read from JSON, is equal to both SQL NULL and JSON
+// null, which are not equal to each other.
+func nullsEqual(a, b types.FieldValue) bool {
+	if a == nil || b == nil {
+		return isNullValue(a) && isNullValue(b)
+	}
+	return reflect.TypeOf(a) == reflect.TypeOf(b)
+}
+
+// numericRat returns the value of a numeric value as a *big.Rat.
+func numericRat(v types.FieldValue) (*big.Rat, bool) {
+	switch v := v.(type) {
+	case int:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int8:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int16:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int32:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int64:
+		return new(big.Rat).SetInt64(v), true
+	case uint8:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case uint16:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case uint32:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case float32:
+		return ratFromFloat(float64(v))
+	case float64:
+		return ratFromFloat(v)
+	case *big.Rat:
+		return v, v != nil
+	case json.Number:
+		return new(big.Rat).SetString(string(v))
+	}
+	return nil, false
+}
+
+func ratFromFloat(f float64) (*big.Rat, bool) {
+	r := new(big.Rat)
+	if r.SetFloat64(f) == nil {
+		// NaN and infinities are not comparable.
+		return nil, false
+	}
+	return r, true
+}
+
+func timeEqual(t time.Time, v types.FieldValue) bool {
+	switch v := v.(type) {
+	case time.Time:
+		return t.Equal(v)
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, types.ISO8601ZLayout, types.ISO8601Layout,
+			types.ISO8601ZNoTLayout, types.ISO8601NoTLayout} {
+			if u, err := time.Parse(layout, v); err == nil {
+				return t.Equal(u)
+			}
+		}
+	}
+	return false
+}
+
+func bytesEqual(b []byte, v types.FieldValue) bool {
+	switch v := v.(type) {
+	case []byte:
+		return bytes.Equal(b, v)
+	case string:
+		return base64.StdEncoding.EncodeToString(b) == v
+	}
+	return false
+}
+
+func asMap(v types.FieldValue) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case *types.MapValue:
+		if v == nil {
+			return nil, false
+		}
+		return v.Map(), true
+	case types.MapValue:
+		return v.Map(), true
+	case map[string]interface{}:
+		return v, true
+	case map[string]types.FieldValue:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = e
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+func asArray(v types.FieldValue) ([]interface{}, bool) {
+	switch v := v.(type) {
+	case []interface{}:
+		return v, true
+	case []types.FieldValue:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = e
+		}
+		return a, true
+	case []*types.MapValue:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = e
+		}
+		return a, true
+	}
+	return nil, false
+}
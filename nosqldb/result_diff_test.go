@@ -0,0 +1,59 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffResults(t *testing.T) {
+	expected, err := ReadJSONLines(strings.NewReader(`
+{"id": 1, "name": "a", "price": 1.50, "created": "2024-05-01T10:00:00Z", "data": "AQI=", "tags": ["x"]}
+{"id": 2, "name": "b", "price": 2, "created": null}
+{"id": 3, "name": "c"}
+`))
+	require.NoError(t, err)
+	require.Len(t, expected, 3)
+
+	row := func(id int, name string) *types.MapValue {
+		return types.NewEmptyMapValue().Put("id", id).Put("name", name)
+	}
+	actual := []*types.MapValue{
+		row(1, "a").Put("price", big.NewRat(3, 2)).
+			Put("created", time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)).
+			Put("data", []byte{1, 2}).
+			Put("tags", []types.FieldValue{"x"}),
+		row(2, "B").Put("price", int64(2)).Put("created", types.NullValueInstance),
+		row(4, "d"),
+	}
+
+	d, err := DiffResults(expected, actual, "id")
+	require.NoError(t, err)
+	assert.False(t, d.Equal())
+	assert.Equal(t, 1, d.Unchanged)
+	assert.Equal(t, []*types.MapValue{expected[2]}, d.Removed)
+	assert.Equal(t, []*types.MapValue{actual[2]}, d.Added)
+	require.Len(t, d.Changed, 1)
+	assert.Equal(t, []string{"name"}, d.Changed[0].Fields)
+
+	d, err = DiffResults(actual, actual, "id")
+	require.NoError(t, err)
+	assert.True(t, d.Equal())
+
+	_, err = DiffResults(append(actual, row(1, "z")), actual, "id")
+	assert.Error(t, err)
+	_, err = DiffResults(actual, actual, "uid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultRelease(t *testing.T) {
+	pool := &types.MapValuePool{Debug: true}
+	row := pool.Get().Put("id", 1)
+	res := &GetResult{Value: row}
+	res.Release()
+	assert.Equal(t, row, res.Value, "Release() without a pool must not release the row")
+
+	res.setMapValuePool(pool)
+	res.Release()
+	assert.Nil(t, res.Value)
+	assert.Panics(t, func() { row.Get("id") }, "use of a released row")
+
+	rows := []*types.MapValue{pool.Get().Put("id", 1), pool.Get().Put("id", 2)}
+	qres := &QueryResult{results: rows, isComputed: true}
+	qres.setMapValuePool(pool)
+	qres.Release()
+	for _, row := range rows {
+		assert.Panics(t, func() { row.Len() }, "use of a released row")
+	}
+	got, err := qres.GetResults()
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -100,9 +100,15 @@ func (r DefaultRetryHandler) Delay(req Request, numRetries uint, err error) {
 		d = computeBackoffDelay(req)
 	}
 
-	if req.timeout() > 0 {
-		if (d + req.GetRetryTime()) > req.timeout() {
-			d = req.timeout() - req.GetRetryTime()
+	// The request can be retried until its timeout, or its retry duration
+	// if it is longer, elapses.
+	limit := req.timeout()
+	if rd := req.GetRetryDuration(); rd > limit {
+		limit = rd
+	}
+	if limit > 0 {
+		if (d + req.GetRetryTime()) > limit {
+			d = limit - req.GetRetryTime()
 			if d < 0 {
 				return
 			}
@@ -0,0 +1,197 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+)
+
+// ErrorClass represents a class of errors returned by the requests of a
+// Client, used to aggregate them in RetryStats.
+type ErrorClass string
+
+const (
+	// ErrorClassThrottle represents the errors returned when a request
+	// exceeds the read, write or operation limits, or a 429 response.
+	ErrorClassThrottle ErrorClass = "throttle"
+
+	// ErrorClassClient represents the errors caused by the request, such as
+	// an illegal argument or a table that does not exist, or a 4xx response.
+	ErrorClassClient ErrorClass = "4xx"
+
+	// ErrorClassServer represents the errors caused by the server, such as
+	// a server error or a busy table, or a 5xx response.
+	ErrorClassServer ErrorClass = "5xx"
+
+	// ErrorClassNetwork represents the errors that occur sending the request
+	// or receiving the response, for which no response is received.
+	ErrorClassNetwork ErrorClass = "network"
+
+	// ErrorClassTimeout represents the errors returned when a request times
+	// out.
+	ErrorClassTimeout ErrorClass = "timeout"
+)
+
+// retryErrorsMetric is the name of the counter of errors reported to the
+// MetricsSink of a Client.
+const retryErrorsMetric = "nosql_request_errors"
+
+// MetricsSink receives the metrics collected by a Client, to export them to a
+// monitoring system.
+//
+// The Client reports the errors returned by the server or the network as the
+// counter "nosql_request_errors", with the labels:
+//
+//	class    the ErrorClass of the error
+//	outcome  "retried" if the request was retried, "failed" if the error was returned
+//	request  the kind of request, such as "Get" or "Query"
+//
+// Implementations must be safe for concurrent use by multiple goroutines, and
+// should not block, as they are called on the goroutine that executes the
+// request.
+type MetricsSink interface {
+	// IncrCounter increments the counter with the specified name and labels
+	// by delta.
+	IncrCounter(name string, labels map[string]string, delta int64)
+}
+
+// RetryStats contains the number of errors of a class returned to the
+// requests of a Client, by outcome.
+type RetryStats struct {
+	// Retried represents the number of errors after which the request was
+	// retried.
+	Retried int64 `json:"retried"`
+
+	// Failed represents the number of errors that were returned to the
+	// application.
+	Failed int64 `json:"failed"`
+}
+
+// String returns a JSON string representation of the RetryStats.
+func (s RetryStats) String() string {
+	return jsonutil.AsJSON(s)
+}
+
+// retryStats aggregates errors by class.
+//
+// The zero value is ready to use.
+type retryStats struct {
+	mu    sync.Mutex
+	stats map[ErrorClass]*RetryStats
+}
+
+func (rs *retryStats) record(class ErrorClass, retried bool) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.stats == nil {
+		rs.stats = make(map[ErrorClass]*RetryStats)
+	}
+
+	s, ok := rs.stats[class]
+	if !ok {
+		s = &RetryStats{}
+		rs.stats[class] = s
+	}
+	if retried {
+		s.Retried++
+	} else {
+		s.Failed++
+	}
+}
+
+// snapshot returns a copy of the stats, and resets them if reset is true.
+func (rs *retryStats) snapshot(reset bool) map[ErrorClass]RetryStats {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	res := make(map[ErrorClass]RetryStats, len(rs.stats))
+	for class, s := range rs.stats {
+		res[class] = *s
+	}
+
+	if reset {
+		rs.stats = nil
+	}
+	return res
+}
+
+// RetryStats returns the number of errors returned to the requests of the
+// Client since it was created or the stats were last reset, by ErrorClass,
+// along with whether each error was retried or returned to the application.
+// This allows the effectiveness of retries to be measured, for example the
+// fraction of throttling errors that eventually fail.
+//
+// Errors returned by an attempt to execute a request are included, while
+// errors detected before the request is sent, such as an invalid request, are
+// not. The stats are also reported to Config.MetricsSink, if set.
+//
+// If reset is true, the stats are reset after they are returned.
+func (c *Client) RetryStats(reset bool) map[ErrorClass]RetryStats {
+	return c.retryStats.snapshot(reset)
+}
+
+// recordRequestError records an error returned by an attempt to execute the
+// request, whose response had the specified HTTP status code, or 0 if no
+// response was received.
+func (c *Client) recordRequestError(req Request, err error, statusCode int, retried bool) {
+	class := classifyError(err, statusCode)
+	c.retryStats.record(class, retried)
+	c.recentErrors.add(RecentError{
+		Time:    time.Now(),
+		Request: requestKind(req),
+		Class:   class,
+		Retried: retried,
+		Error:   c.Redaction.redactError(err, req).Error(),
+	})
+
+	if c.MetricsSink != nil {
+		outcome := "failed"
+		if retried {
+			outcome = "retried"
+		}
+		c.MetricsSink.IncrCounter(retryErrorsMetric, map[string]string{
+			"class":   string(class),
+			"outcome": outcome,
+			"request": requestKind(req),
+		}, 1)
+	}
+}
+
+// classifyError returns the class of an error returned by an attempt to
+// execute a request, whose response had the specified HTTP status code, or 0
+// if no response was received.
+func classifyError(err error, statusCode int) ErrorClass {
+	var netErr net.Error
+	isNetErr := errors.As(err, &netErr)
+	switch {
+	case nosqlerr.Is(err, nosqlerr.ReadLimitExceeded, nosqlerr.WriteLimitExceeded, nosqlerr.OperationLimitExceeded),
+		statusCode == http.StatusTooManyRequests:
+		return ErrorClassThrottle
+
+	case nosqlerr.Is(err, nosqlerr.RequestTimeout), errors.Is(err, context.DeadlineExceeded),
+		isNetErr && netErr.Timeout():
+		return ErrorClassTimeout
+
+	case statusCode == 0 && isNetErr:
+		return ErrorClassNetwork
+
+	case statusCode >= 500,
+		nosqlerr.Is(err, nosqlerr.ServerError, nosqlerr.ServiceUnavailable, nosqlerr.TableBusy,
+			nosqlerr.SecurityInfoUnavailable, nosqlerr.RetryAuthentication, nosqlerr.UnknownError):
+		return ErrorClassServer
+	}
+	return ErrorClassClient
+}
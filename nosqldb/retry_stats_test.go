@@ -0,0 +1,97 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMetricsSink struct {
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+func (s *mockMetricsSink) IncrCounter(name string, labels map[string]string, delta int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := fmt.Sprintf("%s{class=%s,outcome=%s,request=%s}", name, labels["class"], labels["outcome"], labels["request"])
+	s.counters[key] += delta
+}
+
+func TestRetryStats(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	client.SetSerialVersion(3)
+	sink := &mockMetricsSink{counters: make(map[string]int64)}
+	client.MetricsSink = sink
+	client.RetryHandler, err = NewDefaultRetryHandler(5, time.Millisecond)
+	require.NoError(t, err)
+
+	mockExec := &mockExecutor{errChan: make(chan error, 3)}
+	client.executor = mockExec
+	defer mockExec.close()
+
+	mockExec.errChan <- nosqlerr.New(nosqlerr.ServerError, "retryable ServerError")
+	mockExec.errChan <- nosqlerr.New(nosqlerr.ReadLimitExceeded, "retryable ReadLimitExceeded")
+	mockExec.errChan <- nosqlerr.New(nosqlerr.TableNotFound, "non-retryable TableNotFound")
+	getReq := &GetRequest{TableName: "T1", Key: types.NewMapValue(map[string]interface{}{"id": 1})}
+	_, err = client.Get(getReq)
+	assert.True(t, nosqlerr.IsTableNotFound(err), "expect TableNotFound, got %v", err)
+
+	mockExec.errChan <- mockErr{errCode: http.StatusBadGateway, msg: "HTTP 502 Bad Gateway"}
+	_, err = client.Get(getReq)
+	assert.Error(t, err)
+
+	expect := map[ErrorClass]RetryStats{
+		ErrorClassServer:   {Retried: 1, Failed: 1},
+		ErrorClassThrottle: {Retried: 1},
+		ErrorClassClient:   {Failed: 1},
+	}
+	assert.Equal(t, expect, client.RetryStats(true))
+	assert.Empty(t, client.RetryStats(false))
+
+	assert.Equal(t, map[string]int64{
+		"nosql_request_errors{class=5xx,outcome=retried,request=Get}":      1,
+		"nosql_request_errors{class=throttle,outcome=retried,request=Get}": 1,
+		"nosql_request_errors{class=4xx,outcome=failed,request=Get}":       1,
+		"nosql_request_errors{class=5xx,outcome=failed,request=Get}":       1,
+	}, sink.counters)
+
+	timeoutErr := &url.Error{Op: "Post", URL: "http://localhost", Err: context.DeadlineExceeded}
+	networkErr := &url.Error{Op: "Post", URL: "http://localhost", Err: errors.New("connection refused")}
+	tests := []struct {
+		err        error
+		statusCode int
+		expect     ErrorClass
+	}{
+		{nosqlerr.New(nosqlerr.WriteLimitExceeded, "throttled"), 200, ErrorClassThrottle},
+		{errors.New("error response: 429 Too Many Requests"), 429, ErrorClassThrottle},
+		{nosqlerr.New(nosqlerr.RequestTimeout, "timed out"), 200, ErrorClassTimeout},
+		{timeoutErr, 0, ErrorClassTimeout},
+		{networkErr, 0, ErrorClassNetwork},
+		{errors.New("error response: 503 Service Unavailable"), 503, ErrorClassServer},
+		{nosqlerr.New(nosqlerr.TableBusy, "busy"), 200, ErrorClassServer},
+		{nosqlerr.New(nosqlerr.InvalidAuthorization, "unauthorized"), 401, ErrorClassClient},
+		{nosqlerr.NewIllegalArgument("invalid"), 200, ErrorClassClient},
+	}
+	for _, r := range tests {
+		assert.Equalf(t, r.expect, classifyError(r.err, r.statusCode), "unexpected class of %v", r.err)
+	}
+}
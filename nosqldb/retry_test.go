@@ -13,6 +13,9 @@ import (
 	"time"
 
 	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDefaultRetryHandler(t *testing.T) {
@@ -131,3 +134,58 @@ func TestShouldRetry(t *testing.T) {
 		}
 	}
 }
+
+func TestMaxRetryDuration(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	client.SetSerialVersion(3)
+	client.RetryHandler, err = NewDefaultRetryHandler(100, 100*time.Millisecond)
+	require.NoError(t, err)
+
+	mockExec := &mockExecutor{
+		errChan: make(chan error),
+	}
+	client.executor = mockExec
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case mockExec.errChan <- nosqlerr.New(nosqlerr.ServerError, "retryable ServerError"):
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	key := types.NewMapValue(map[string]interface{}{"id": 1})
+	tests := []struct {
+		desc             string
+		maxRetryDuration time.Duration
+		minElapsed       time.Duration
+		maxElapsed       time.Duration
+	}{
+		// The request is retried until its timeout elapses.
+		{"no MaxRetryDuration", 0, 300 * time.Millisecond, 900 * time.Millisecond},
+		{"MaxRetryDuration less than the timeout", 100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond},
+		// Each attempt has the timeout of the request, but the request is
+		// retried until MaxRetryDuration elapses.
+		{"MaxRetryDuration over the timeout", 1200 * time.Millisecond, 1200 * time.Millisecond, 1800 * time.Millisecond},
+	}
+	for _, r := range tests {
+		client.MaxRetryDuration = r.maxRetryDuration
+		start := time.Now()
+		_, err = client.Get(&GetRequest{TableName: "T", Key: key, Timeout: 300 * time.Millisecond})
+		elapsed := time.Since(start)
+		if !assert.Truef(t, nosqlerr.Is(err, nosqlerr.RequestTimeout), "%s: got error %v", r.desc, err) {
+			continue
+		}
+		assert.Truef(t, elapsed >= r.minElapsed, "%s: request was retried for %v only", r.desc, elapsed)
+		assert.Truef(t, elapsed < r.maxElapsed, "%s: request was retried for %v", r.desc, elapsed)
+		if r.maxRetryDuration > 300*time.Millisecond {
+			assert.Contains(t, err.Error(), "MaxRetryDuration: "+r.maxRetryDuration.String())
+		} else {
+			assert.NotContains(t, err.Error(), "MaxRetryDuration")
+		}
+	}
+}
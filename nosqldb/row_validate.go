@@ -0,0 +1,293 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// schemaField represents a field of a table schema, or the element type of a
+// MAP or ARRAY, as described by the JSON schema returned by GetTable.
+type schemaField struct {
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Nullable   *bool         `json:"nullable"`
+	Collection *schemaField  `json:"collection"`
+	Fields     []schemaField `json:"fields"`
+	Symbols    []string      `json:"symbols"`
+	Size       int           `json:"size"`
+}
+
+// ValidateRow validates the values of a row against the schema of the
+// specified table before it is written, so that a value of the wrong type is
+// reported with the path of the element, such as
+// "info.scores[3]: expected INTEGER, got string", rather than by an error of
+// the server. The element types of MAP, ARRAY and RECORD columns are validated
+// recursively.
+//
+// Fields of the row that are not columns of the table are not validated,
+// neither are missing columns. The table metadata is retrieved with
+// GetTableCached. An IllegalArgument error is returned if a value is not
+// valid.
+//
+// Put validates rows with this method if Config.ValidateRowsOnPut is set.
+func (c *Client) ValidateRow(namespace, tableName string, value *types.MapValue) error {
+	if value == nil {
+		return nosqlerr.NewIllegalArgument("ValidateRow: value must be non-nil")
+	}
+
+	table, err := c.GetTableCached(namespace, tableName)
+	if err != nil {
+		return err
+	}
+
+	columns, err := tableColumns(table)
+	if err != nil {
+		return err
+	}
+	return validateRow(columns, value)
+}
+
+// tableColumns returns the columns of a table, in declaration order, as
+// described by its JSON schema.
+func tableColumns(table *TableResult) ([]schemaField, error) {
+	var schema struct {
+		Fields []schemaField `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(table.Schema), &schema); err != nil || len(schema.Fields) == 0 {
+		return nil, nosqlerr.New(nosqlerr.IllegalState,
+			"cannot determine the columns of table %q from its schema", table.TableName)
+	}
+	return schema.Fields, nil
+}
+
+// validateRow validates the values of row against the columns of a table.
+func validateRow(columns []schemaField, row *types.MapValue) error {
+	m := row.Map()
+	for _, k := range sortedKeys(m) {
+		if col := schemaFieldFold(columns, k); col != nil {
+			if err := validateFieldValue(k, m[k], col); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateFieldValue validates that v is a value of the type of f. The path
+// identifies the value in error messages.
+func validateFieldValue(path string, v types.FieldValue, f *schemaField) error {
+	switch v {
+	case nil, types.NullValueInstance, types.JSONNullValueInstance:
+		if f.Nullable != nil && !*f.Nullable {
+			return nosqlerr.NewIllegalArgument("%s: expected %s, got null", path, f.Type)
+		}
+		return nil
+	}
+
+	expected := func(what string) error {
+		return nosqlerr.NewIllegalArgument("%s: expected %s, got %T", path, what, v)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch t := strings.ToUpper(f.Type); t {
+	case "JSON", "ANY", "":
+		return nil
+
+	case "INTEGER", "LONG":
+		i, ok := integerValue(v)
+		if !ok {
+			return expected(t)
+		}
+		if t == "INTEGER" && (i < math.MinInt32 || i > math.MaxInt32) {
+			return nosqlerr.NewIllegalArgument("%s: expected INTEGER, got %d which overflows INTEGER", path, i)
+		}
+		return nil
+
+	case "FLOAT", "DOUBLE", "NUMBER":
+		if _, ok := integerValue(v); ok {
+			return nil
+		}
+		switch v.(type) {
+		case float32, float64, json.Number:
+			return nil
+		case *big.Rat:
+			if t == "NUMBER" {
+				return nil
+			}
+		}
+		return expected(t)
+
+	case "STRING":
+		if rv.Kind() == reflect.String || rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.String {
+			return nil
+		}
+		return expected(t)
+
+	case "ENUM":
+		s, ok := v.(string)
+		if !ok {
+			return expected(t)
+		}
+		for _, sym := range f.Symbols {
+			if s == sym {
+				return nil
+			}
+		}
+		return nosqlerr.NewIllegalArgument("%s: %q is not a value of ENUM(%s)", path, s, strings.Join(f.Symbols, ", "))
+
+	case "BOOLEAN":
+		if rv.Kind() == reflect.Bool {
+			return nil
+		}
+		return expected(t)
+
+	case "BINARY", "FIXED_BINARY":
+		b, ok := v.([]byte)
+		if !ok {
+			return expected(t)
+		}
+		if t == "FIXED_BINARY" && f.Size > 0 && len(b) != f.Size {
+			return nosqlerr.NewIllegalArgument("%s: expected FIXED_BINARY(%d), got %d bytes", path, f.Size, len(b))
+		}
+		return nil
+
+	case "TIMESTAMP":
+		switch v := v.(type) {
+		case time.Time:
+			return nil
+		case string:
+			if _, err := types.ParseDateTime(v); err == nil {
+				return nil
+			}
+			return nosqlerr.NewIllegalArgument("%s: expected TIMESTAMP, got %q", path, v)
+		}
+		return expected(t)
+
+	case "ARRAY":
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array || rv.Type().Elem().Kind() == reflect.Uint8 {
+			return expected(t)
+		}
+		if f.Collection == nil {
+			return nil
+		}
+		for i := 0; i < rv.Len(); i++ {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+			if err := validateFieldValue(elemPath, rv.Index(i).Interface(), f.Collection); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "MAP", "RECORD":
+		m, ok := mapEntries(v)
+		if !ok {
+			return expected(t)
+		}
+		if t == "MAP" {
+			if f.Collection == nil {
+				return nil
+			}
+			for _, k := range sortedKeys(m) {
+				if err := validateFieldValue(path+"."+k, m[k], f.Collection); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		for _, k := range sortedKeys(m) {
+			field := schemaFieldFold(f.Fields, k)
+			if field == nil {
+				return nosqlerr.NewIllegalArgument("%s: %s is not a field of the RECORD", path, k)
+			}
+			if err := validateFieldValue(path+"."+k, m[k], field); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		// Types unknown to this version of the SDK are validated by the server.
+		return nil
+	}
+}
+
+// integerValue returns the value of v if it is an integer that fits in an
+// int64.
+func integerValue(v types.FieldValue) (int64, bool) {
+	if n, ok := v.(json.Number); ok {
+		i, err := n.Int64()
+		return i, err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := rv.Uint(); u <= math.MaxInt64 {
+			return int64(u), true
+		}
+	}
+	return 0, false
+}
+
+// mapEntries returns the entries of v if it is a MapValue or a map with string
+// keys.
+func mapEntries(v types.FieldValue) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case *types.MapValue:
+		return v.Map(), true
+	case map[string]interface{}:
+		return v, true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+// sortedKeys returns the keys of m in sorted order, so that the first invalid
+// value is reported consistently.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// schemaFieldFold returns the field with the specified name, under
+// case-folding, or nil if there is no such field.
+func schemaFieldFold(fields []schemaField, name string) *schemaField {
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, name) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
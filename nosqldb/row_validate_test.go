@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRow(t *testing.T) {
+	var schema struct {
+		Fields []schemaField `json:"fields"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"fields": [
+		{"name": "id", "type": "INTEGER", "nullable": false},
+		{"name": "info", "type": "RECORD", "fields": [
+			{"name": "name", "type": "STRING"},
+			{"name": "scores", "type": "ARRAY", "collection": {"type": "INTEGER"}}
+		]},
+		{"name": "counts", "type": "MAP", "collection": {"type": "LONG"}},
+		{"name": "items", "type": "ARRAY", "collection": {"type": "RECORD", "fields": [
+			{"name": "sku", "type": "STRING"},
+			{"name": "created", "type": "TIMESTAMP"}
+		]}},
+		{"name": "status", "type": "ENUM", "symbols": ["ACTIVE", "INACTIVE"]},
+		{"name": "doc", "type": "JSON"}
+	]}`), &schema))
+
+	info := types.NewMapValue(map[string]interface{}{
+		"name":   "a",
+		"scores": []types.FieldValue{1, int64(2), json.Number("3")},
+	})
+	valid := types.NewMapValue(map[string]interface{}{
+		"id":     1,
+		"info":   info,
+		"counts": map[string]interface{}{"x": int64(1)},
+		"items": []interface{}{
+			map[string]interface{}{"sku": "s1", "created": time.Now()},
+			types.NewMapValue(map[string]interface{}{"sku": "s2", "created": "2024-01-01T00:00:00"}),
+		},
+		"status": "ACTIVE",
+		"doc":    map[string]interface{}{"any": []interface{}{1, "x"}},
+		"other":  "not a column",
+	})
+	assert.NoError(t, validateRow(schema.Fields, valid))
+
+	tests := []struct {
+		field string
+		value types.FieldValue
+		msg   string
+	}{
+		{"id", nil, "id: expected INTEGER, got null"},
+		{"id", int64(math.MaxInt32) + 1, "id: expected INTEGER, got 2147483648 which overflows INTEGER"},
+		{"info", types.NewMapValue(map[string]interface{}{"scores": []types.FieldValue{1, 2, 3, "4"}}),
+			"info.scores[3]: expected INTEGER, got string"},
+		{"info", map[string]interface{}{"age": 1}, "info: age is not a field of the RECORD"},
+		{"counts", map[string]int{"x": 1, "y": 2}, ""},
+		{"counts", map[string]interface{}{"x": 1.5}, "counts.x: expected LONG, got float64"},
+		{"items", []interface{}{map[string]interface{}{"created": "yesterday"}},
+			`items[0].created: expected TIMESTAMP, got "yesterday"`},
+		{"items", "s1", "items: expected ARRAY, got string"},
+		{"status", "DELETED", `status: "DELETED" is not a value of ENUM(ACTIVE, INACTIVE)`},
+	}
+	for _, r := range tests {
+		row := types.NewMapValue(map[string]interface{}{r.field: r.value})
+		err := validateRow(schema.Fields, row)
+		if r.msg == "" {
+			assert.NoErrorf(t, err, "%s=%v", r.field, r.value)
+			continue
+		}
+		if assert.Truef(t, nosqlerr.IsIllegalArgument(err), "%s=%v: unexpected error %v", r.field, r.value, err) {
+			assert.Contains(t, err.Error(), r.msg)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// SkipRow is used as a return value from the methods of a RowVisitor to
+// indicate that the rest of the current row is to be skipped. It is not
+// returned as an error by any function.
+var SkipRow = errors.New("skip this row")
+
+// FieldDecoder decodes the value of a field of a row, see RowVisitor.OnField.
+type FieldDecoder func() (types.FieldValue, error)
+
+// RowVisitor receives the rows of the results of a query as they are decoded
+// from the responses of the server, see QueryRequest.RowVisitor.
+//
+// For each row, OnRowStart is called, then OnField for each top-level field
+// of the row, in order, then OnRowEnd. If OnRowStart or OnField returns
+// SkipRow, the rest of the row is skipped without being decoded, and OnRowEnd
+// is not called. If a method returns another non-nil error, decoding stops and
+// the error is returned by Client.Query or QueryResult.GetResults.
+type RowVisitor interface {
+	// OnRowStart is called at the start of each row.
+	OnRowStart() error
+
+	// OnField is called for each top-level field of the row. The value of the
+	// field is decoded only if OnField calls value, otherwise it is skipped,
+	// which allows wide rows to be filtered without allocating the values of
+	// the fields that are not used. The value function must not be called
+	// after OnField returns.
+	OnField(name string, value FieldDecoder) error
+
+	// OnRowEnd is called at the end of each row.
+	OnRowEnd() error
+}
+
+// visitNsonRow decodes a row of query results from r, and passes it to v.
+func visitNsonRow(r proto.Reader, v RowVisitor) error {
+	t, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if types.DbType(t) != types.Map {
+		return fmt.Errorf("row value is not of type MapValue")
+	}
+
+	// The number of bytes consumed by the map, which is used to skip rows.
+	length, err := r.ReadInt()
+	if err != nil {
+		return err
+	}
+	if err = v.OnRowStart(); err != nil {
+		if err == SkipRow {
+			r.GetBuffer().Next(length)
+			return nil
+		}
+		return err
+	}
+
+	size, err := r.ReadInt()
+	if err != nil {
+		return err
+	}
+
+	skip := false
+	for i := 0; i < size; i++ {
+		k, err := r.ReadString()
+		if err != nil {
+			return err
+		}
+		var name string
+		if k != nil {
+			name = *k
+		}
+
+		decoded := false
+		var val types.FieldValue
+		var decodeErr error
+		decode := func() (types.FieldValue, error) {
+			if !decoded {
+				decoded = true
+				val, decodeErr = r.ReadFieldValue()
+			}
+			return val, decodeErr
+		}
+
+		if !skip {
+			if err = v.OnField(name, decode); err == SkipRow {
+				skip = true
+			} else if err != nil {
+				return err
+			}
+		}
+		if decodeErr != nil {
+			return decodeErr
+		}
+		if !decoded {
+			if err = skipNsonField(r, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if skip {
+		return nil
+	}
+	return v.OnRowEnd()
+}
+
+// visitRow passes a row that has already been decoded to v.
+func visitRow(row *types.MapValue, v RowVisitor) error {
+	if err := v.OnRowStart(); err != nil {
+		if err == SkipRow {
+			return nil
+		}
+		return err
+	}
+
+	onField := func(name string, val types.FieldValue) error {
+		return v.OnField(name, func() (types.FieldValue, error) { return val, nil })
+	}
+	var err error
+	if row.IsOrdered() {
+		for i := 1; i <= row.Len() && err == nil; i++ {
+			name, val, _ := row.GetByIndex(i)
+			err = onField(name, val)
+		}
+	} else {
+		for name, val := range row.Map() {
+			if err = onField(name, val); err != nil {
+				break
+			}
+		}
+	}
+
+	switch err {
+	case nil:
+		return v.OnRowEnd()
+	case SkipRow:
+		return nil
+	default:
+		return err
+	}
+}
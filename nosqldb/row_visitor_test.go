@@ -0,0 +1,104 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto/binary"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldFilter is a RowVisitor that keeps the specified fields of the rows.
+type fieldFilter struct {
+	fields  map[string]bool
+	skipID  int
+	failOn  string
+	rows    []*types.MapValue
+	current *types.MapValue
+}
+
+func (f *fieldFilter) OnRowStart() error {
+	f.current = types.NewOrderedMapValue()
+	return nil
+}
+
+func (f *fieldFilter) OnField(name string, value FieldDecoder) error {
+	if name == f.failOn {
+		return errors.New("visitor error")
+	}
+	if name == "id" {
+		v, err := value()
+		if err != nil {
+			return err
+		}
+		if v == f.skipID {
+			return SkipRow
+		}
+	}
+	if !f.fields[name] {
+		return nil
+	}
+	v, err := value()
+	if err != nil {
+		return err
+	}
+	f.current.Put(name, v)
+	return nil
+}
+
+func (f *fieldFilter) OnRowEnd() error {
+	f.rows = append(f.rows, f.current)
+	return nil
+}
+
+func TestQueryRowVisitor(t *testing.T) {
+	newRow := func(id int) *types.MapValue {
+		return types.NewOrderedMapValue().Put("id", id).Put("name", fmt.Sprintf("name%d", id)).
+			Put("blob", make([]byte, 64)).Put("tags", []types.FieldValue{"a", "b"})
+	}
+	w := binary.NewWriter()
+	_, err := w.WriteFieldValue(types.NewOrderedMapValue().Put(QUERY_RESULTS,
+		[]types.FieldValue{newRow(1), newRow(2), newRow(3)}).Put(ERROR_CODE, 0))
+	require.NoError(t, err)
+	data := w.Bytes()
+
+	decode := func(v RowVisitor) (*QueryResult, error) {
+		req := &QueryRequest{RowVisitor: v, PreparedStatement: &PreparedStatement{statement: []byte{1}}}
+		qres := &QueryResult{request: req}
+		_, err := readNsonPrepareOrQuery(req, qres, nil, nil, binary.NewReader(bytes.NewBuffer(data)), 4, 4)
+		return qres, err
+	}
+
+	// The rows are passed to the visitor, and are not returned as results.
+	f := &fieldFilter{fields: map[string]bool{"name": true}, skipID: 2}
+	qres, err := decode(f)
+	require.NoError(t, err)
+	assert.Empty(t, qres.results)
+	require.Len(t, f.rows, 2)
+	assert.Equal(t, types.NewOrderedMapValue().Put("name", "name1"), f.rows[0])
+	assert.Equal(t, types.NewOrderedMapValue().Put("name", "name3"), f.rows[1])
+
+	// Errors of the visitor are returned.
+	_, err = decode(&fieldFilter{failOn: "blob"})
+	assert.EqualError(t, err, "visitor error")
+
+	// Rows that are already decoded are visited the same way.
+	f = &fieldFilter{fields: map[string]bool{"id": true, "tags": true}, skipID: 1}
+	for id := 1; id <= 3; id++ {
+		require.NoError(t, visitRow(newRow(id), f))
+	}
+	require.Len(t, f.rows, 2)
+	assert.Equal(t, types.NewOrderedMapValue().Put("id", 2).Put("tags", []types.FieldValue{"a", "b"}), f.rows[0])
+	assert.EqualError(t, visitRow(newRow(1), &fieldFilter{failOn: "name"}), "visitor error")
+}
@@ -0,0 +1,169 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// defaultSampleScanFactor is the number of rows scanned by SampleRows for
+// each row of the sample, if SampleOptions.MaxScan is not set.
+const defaultSampleScanFactor = 100
+
+// SampleOptions specifies options for Client.SampleRows.
+type SampleOptions struct {
+	// Fields specifies the fields returned for each row.
+	// It is optional. If not set, all fields are returned.
+	Fields []string
+
+	// MaxScan specifies the maximum number of rows scanned. Use a negative
+	// value to scan the entire table.
+	// It is optional. If set to 0, 100 times the size of the sample is used.
+	MaxScan int
+
+	// MaxReadKB specifies the limit on the amount of data read by each of the
+	// query requests used to scan the table. See QueryRequest.MaxReadKB.
+	// It is optional. If set to 0, the default limit is used.
+	MaxReadKB uint
+
+	// Rand specifies the source of randomness used to select the rows, for
+	// example to make a sample reproducible with a fixed seed.
+	// It is optional. If not set, a source seeded with the current time is
+	// used.
+	Rand *rand.Rand
+}
+
+// SampleRows returns a random sample of at most n rows of the specified table,
+// for data profiling jobs that do not need to read every row.
+//
+// The rows are selected by reservoir sampling over a scan of the table, so
+// every scanned row has the same probability of being in the sample. The scan
+// is bounded by opts.MaxScan, which may be nil to use the default options. If
+// the table has more rows than are scanned, the sample is only uniform among
+// the scanned rows, which are the rows the query returns first and may favor
+// some shards. Use a negative MaxScan for a uniform sample of a large table.
+//
+// The rows are returned in scan order. The scan consumes read units for all
+// scanned rows.
+func (c *Client) SampleRows(ctx context.Context, tableName string, n int, opts *SampleOptions) ([]*types.MapValue, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	if err := validateTableName(tableName); err != nil {
+		return nil, err
+	}
+
+	if n <= 0 {
+		return nil, nosqlerr.NewIllegalArgument("SampleRows: n must be positive")
+	}
+
+	if opts == nil {
+		opts = &SampleOptions{}
+	}
+
+	maxScan := opts.MaxScan
+	if maxScan == 0 {
+		maxScan = defaultSampleScanFactor * n
+	}
+
+	rnd := opts.Rand
+	if rnd == nil {
+		rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	fields := "*"
+	if len(opts.Fields) > 0 {
+		fields = strings.Join(opts.Fields, ", ")
+	}
+	req := &QueryRequest{
+		Statement: "SELECT " + fields + " FROM " + tableName,
+		MaxReadKB: opts.MaxReadKB,
+	}
+	defer req.Close()
+
+	r := newReservoir(n, rnd)
+	for maxScan < 0 || r.seen < maxScan {
+		res, err := c.QueryWithContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := res.GetResults()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			if maxScan >= 0 && r.seen == maxScan {
+				break
+			}
+			r.add(row)
+		}
+
+		if req.IsDone() {
+			break
+		}
+	}
+
+	return r.sample(), nil
+}
+
+// reservoir selects a uniform random sample of a fixed size from a stream of
+// rows of unknown length, by reservoir sampling.
+type reservoir struct {
+	rnd  *rand.Rand
+	size int
+	seen int
+
+	// rows holds the sampled rows, with their positions in the stream.
+	rows []sampledRow
+}
+
+type sampledRow struct {
+	row *types.MapValue
+	pos int
+}
+
+func newReservoir(size int, rnd *rand.Rand) *reservoir {
+	return &reservoir{rnd: rnd, size: size}
+}
+
+// add offers a row to the reservoir. The i-th row offered is kept with a
+// probability of size/i, replacing a random row of the sample.
+func (r *reservoir) add(row *types.MapValue) {
+	r.seen++
+	if len(r.rows) < r.size {
+		r.rows = append(r.rows, sampledRow{row, r.seen})
+		return
+	}
+
+	if j := r.rnd.Intn(r.seen); j < r.size {
+		r.rows[j] = sampledRow{row, r.seen}
+	}
+}
+
+// sample returns the rows of the sample in the order they were offered.
+func (r *reservoir) sample() []*types.MapValue {
+	sort.Slice(r.rows, func(i, j int) bool {
+		return r.rows[i].pos < r.rows[j].pos
+	})
+
+	rows := make([]*types.MapValue, len(r.rows))
+	for i, s := range r.rows {
+		rows[i] = s.row
+	}
+	return rows
+}
@@ -0,0 +1,58 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleRows(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+
+	_, err = client.SampleRows(context.Background(), "", 10, nil)
+	assert.True(t, nosqlerr.IsIllegalArgument(err), "got error %v", err)
+	_, err = client.SampleRows(context.Background(), "orders", 0, nil)
+	assert.True(t, nosqlerr.IsIllegalArgument(err), "got error %v", err)
+
+	// Each of 10 rows should be in a sample of 3 rows about 30% of the time.
+	const rounds = 3000
+	counts := make(map[int]int)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < rounds; i++ {
+		r := newReservoir(3, rnd)
+		for id := 0; id < 10; id++ {
+			r.add(types.NewMapValue(map[string]interface{}{"id": id}))
+		}
+
+		sample := r.sample()
+		require.Len(t, sample, 3)
+		prev := -1
+		for _, row := range sample {
+			id, _ := row.GetInt("id")
+			require.Greater(t, id, prev, "sample should be in scan order")
+			prev = id
+			counts[id]++
+		}
+	}
+	for id := 0; id < 10; id++ {
+		assert.InDeltaf(t, 0.3, float64(counts[id])/rounds, 0.05, "row %d", id)
+	}
+
+	// A sample larger than the rows contains all rows.
+	r := newReservoir(5, rnd)
+	r.add(types.NewMapValue(map[string]interface{}{"id": 1}))
+	assert.Len(t, r.sample(), 1)
+}
@@ -0,0 +1,186 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/internal/proto"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// schemaCache is a client side cache of table metadata.
+//
+// Entries expire after the configured ttl. An entry is invalidated when a
+// request on the table fails with a TableNotFound or IllegalState error, or
+// when a table request that modifies the table is executed.
+type schemaCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]schemaCacheEntry
+}
+
+type schemaCacheEntry struct {
+	table     *TableResult
+	expiresAt time.Time
+
+	// layout is the layout of the RECORD columns of the table, used to write
+	// native structs. It is nil if the table has no RECORD columns.
+	layout *proto.RecordLayout
+}
+
+func newSchemaCache(ttl time.Duration) *schemaCache {
+	return &schemaCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]schemaCacheEntry),
+	}
+}
+
+// schemaCacheKey returns the cache key for the table. Table and namespace
+// names are case-insensitive.
+func schemaCacheKey(namespace, tableName string) string {
+	if namespace != "" && !strings.Contains(tableName, ":") {
+		tableName = namespace + ":" + tableName
+	}
+	return strings.ToLower(tableName)
+}
+
+func (sc *schemaCache) get(namespace, tableName string) (*TableResult, bool) {
+	e, ok := sc.entry(namespace, tableName)
+	return e.table, ok
+}
+
+// recordLayout returns the layout of the RECORD columns of the table.
+func (sc *schemaCache) recordLayout(namespace, tableName string) (*proto.RecordLayout, bool) {
+	e, ok := sc.entry(namespace, tableName)
+	return e.layout, ok
+}
+
+func (sc *schemaCache) entry(namespace, tableName string) (schemaCacheEntry, bool) {
+	if sc == nil {
+		return schemaCacheEntry{}, false
+	}
+
+	key := schemaCacheKey(namespace, tableName)
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	e, ok := sc.entries[key]
+	if !ok {
+		return schemaCacheEntry{}, false
+	}
+
+	if !sc.now().Before(e.expiresAt) {
+		delete(sc.entries, key)
+		return schemaCacheEntry{}, false
+	}
+
+	return e, true
+}
+
+func (sc *schemaCache) put(namespace, tableName string, table *TableResult) {
+	if sc == nil || table == nil {
+		return
+	}
+
+	layout := tableRecordLayout(table)
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.entries[schemaCacheKey(namespace, tableName)] = schemaCacheEntry{
+		table:     table,
+		expiresAt: sc.now().Add(sc.ttl),
+		layout:    layout,
+	}
+}
+
+// invalidate removes the entry of the specified table. If tableName is empty,
+// all entries are removed.
+func (sc *schemaCache) invalidate(namespace, tableName string) {
+	if sc == nil {
+		return
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if tableName == "" {
+		sc.entries = make(map[string]schemaCacheEntry)
+		return
+	}
+
+	delete(sc.entries, schemaCacheKey(namespace, tableName))
+}
+
+// onRequestDone updates the cache according to the outcome of a request.
+func (sc *schemaCache) onRequestDone(req Request, err error) {
+	if sc == nil {
+		return
+	}
+
+	switch req.(type) {
+	case *TableRequest, *AddReplicaRequest, *DropReplicaRequest:
+		// The table may be modified regardless of the outcome.
+		sc.invalidate(req.getNamespace(), req.getTableName())
+		return
+	case *SystemRequest:
+		// A system request may drop a namespace and its tables.
+		sc.invalidate("", "")
+		return
+	}
+
+	if nosqlerr.Is(err, nosqlerr.TableNotFound, nosqlerr.IllegalState) {
+		sc.invalidate(req.getNamespace(), req.getTableName())
+	}
+}
+
+// GetTableCached is like GetTable, but returns the metadata of the specified
+// table from a client side cache if it is available.
+//
+// The cache is enabled by setting Config.SchemaCacheTTL to a positive
+// duration. If the cache is not enabled, this method always retrieves the
+// table metadata from the server.
+//
+// The returned TableResult is shared by the callers of this method and must
+// not be modified.
+func (c *Client) GetTableCached(namespace, tableName string) (*TableResult, error) {
+	return c.getTableCachedWithContext(context.Background(), namespace, tableName)
+}
+
+func (c *Client) getTableCachedWithContext(ctx context.Context, namespace, tableName string) (*TableResult, error) {
+	if res, ok := c.schemaCache.get(namespace, tableName); ok {
+		return res, nil
+	}
+
+	// The table metadata may be needed to execute a request that holds the
+	// permits of the table, so they are not acquired again.
+	res, err := c.getTableWithContext(withoutConcurrencyLimit(ctx), &GetTableRequest{
+		TableName: tableName,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.State == types.Active {
+		c.schemaCache.put(namespace, tableName, res)
+	}
+
+	return res, nil
+}
+
+// InvalidateTableCache removes the metadata of the specified table from the
+// client side cache used by GetTableCached. If tableName is empty, the
+// metadata of all tables are removed.
+func (c *Client) InvalidateTableCache(namespace, tableName string) {
+	c.schemaCache.invalidate(namespace, tableName)
+}
@@ -0,0 +1,90 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCache(t *testing.T) {
+	now := time.Now()
+	sc := newSchemaCache(time.Minute)
+	sc.now = func() time.Time { return now }
+
+	t1 := &TableResult{TableName: "T1"}
+	sc.put("", "T1", t1)
+	sc.put("ns1", "T2", &TableResult{TableName: "T2"})
+
+	res, ok := sc.get("", "t1")
+	assert.Truef(t, ok && res == t1, "table names should be case-insensitive")
+	_, ok = sc.get("", "ns1:T2")
+	assert.Truef(t, ok, "namespace may be specified in the table name")
+
+	// Entries expire after ttl.
+	now = now.Add(time.Minute)
+	_, ok = sc.get("", "T1")
+	assert.Falsef(t, ok, "the entry should have expired")
+
+	// Entries are invalidated upon errors and table requests.
+	tests := []struct {
+		desc        string
+		namespace   string
+		req         Request
+		err         error
+		invalidated bool
+	}{
+		{"other errors", "", &GetRequest{TableName: "T1"}, nosqlerr.New(nosqlerr.TableBusy, "busy"), false},
+		{"TableNotFound", "", &GetRequest{TableName: "T1"}, nosqlerr.New(nosqlerr.TableNotFound, "not found"), true},
+		{"IllegalState", "", &PutRequest{TableName: "T1"}, nosqlerr.New(nosqlerr.IllegalState, "altered"), true},
+		{"a table request", "", &TableRequest{TableName: "T1"}, nil, true},
+		{"a system request", "ns1", &SystemRequest{Statement: "drop namespace ns1 cascade"}, nil, true},
+	}
+	for _, r := range tests {
+		sc.put(r.namespace, "T1", t1)
+		sc.onRequestDone(r.req, r.err)
+		_, ok = sc.get(r.namespace, "T1")
+		assert.Equalf(t, !r.invalidated, ok, "%s: the entry should be invalidated: %t", r.desc, r.invalidated)
+	}
+
+	// A nil cache is disabled.
+	var nilCache *schemaCache
+	nilCache.put("", "T1", t1)
+	_, ok = nilCache.get("", "T1")
+	assert.False(t, ok)
+}
+
+// TestSchemaCacheConcurrencyLimit checks that the GetTable request that
+// fills the schema cache does not wait for the permits held by the request
+// that needs the table metadata.
+func TestSchemaCacheConcurrencyLimit(t *testing.T) {
+	client, err := newMockClient()
+	require.NoError(t, err)
+	client.SetSerialVersion(3)
+	client.schemaCache = newSchemaCache(time.Minute)
+	client.limiter = newConcurrencyLimiter(&Config{MaxConcurrentRequestsPerTable: 1, QueueWhenBusy: true})
+	mockExec := &mockExecutor{errChan: make(chan error, 1)}
+	client.executor = mockExec
+	defer mockExec.close()
+
+	ctx := context.Background()
+	release, err := client.limiter.acquire(ctx, &PutRequest{TableName: "users"})
+	require.NoError(t, err)
+	defer release()
+
+	mockExec.errChan <- nosqlerr.New(nosqlerr.TableNotFound, "table users not found")
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err = client.getTableCachedWithContext(ctx, "", "users")
+	assert.Truef(t, nosqlerr.Is(err, nosqlerr.TableNotFound), "got error %v", err)
+}
@@ -0,0 +1,40 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeRequestCancelled(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+
+	value := &types.MapValue{}
+	for i := 0; i < 4096; i++ {
+		value.Put(fmt.Sprintf("field%04d", i), strings.Repeat("x", 64))
+	}
+	req := &PutRequest{TableName: "T", Value: value}
+	req.setDefaults(&client.RequestConfig)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, _, err = client.serializeRequest(ctx, req)
+	assert.Truef(t, errors.Is(err, context.Canceled), "serializeRequest() got error %v, want %v", err, context.Canceled)
+
+	_, _, _, err = client.serializeRequest(context.Background(), req)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,480 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+// Package shell provides an interactive SQL shell for the Oracle NoSQL
+// Database that can be embedded in command line tools.
+//
+// The shell reads statements terminated by a semicolon, which can span
+// multiple lines, and executes them with a nosqldb.Client. Queries are
+// executed with the query API and their results are printed as a table, JSON
+// or CSV by the format package; CREATE, ALTER and DROP statements are executed as table requests and
+// waited for. Lines that start with a backslash are meta-commands, such as
+// \describe to print the columns of a table; use \help for the list.
+//
+// For example:
+//
+//	sh := shell.New(client, os.Stdin, os.Stdout)
+//	if err := sh.Run(context.Background()); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Input lines are read by a LineReader. The default one reads lines from an
+// io.Reader with no line editing; to provide line editing and history, set
+// Shell.Reader to an adapter for a readline library.
+package shell
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb"
+	"github.com/oracle/nosql-go-sdk/nosqldb/format"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// ErrQuit is returned by Shell.Execute for the \quit meta-command.
+var ErrQuit = errors.New("shell: quit")
+
+// OutputMode represents the format of query results printed by the shell.
+type OutputMode int
+
+const (
+	// TableMode prints query results as a table, once all results are read.
+	TableMode OutputMode = iota // 0
+
+	// JSONMode prints each row of the query results as a JSON object on its
+	// own line, as the results are read.
+	JSONMode // 1
+
+	// CSVMode prints query results as comma-separated values, once all
+	// results are read.
+	CSVMode // 2
+)
+
+func (m OutputMode) format() format.Format {
+	switch m {
+	case JSONMode:
+		return format.JSON
+	case CSVMode:
+		return format.CSV
+	default:
+		return format.Text
+	}
+}
+
+// LineReader reads input lines for the shell.
+type LineReader interface {
+	// ReadLine displays the prompt, if the reader is interactive, and returns
+	// the next input line without the line terminator. It returns io.EOF at
+	// the end of input.
+	ReadLine(prompt string) (string, error)
+}
+
+// NewLineReader returns a LineReader that reads lines from in and writes the
+// prompts to out. It does not provide line editing or history.
+func NewLineReader(in io.Reader, out io.Writer) LineReader {
+	sc := bufio.NewScanner(in)
+	sc.Buffer(nil, 16<<20)
+	return &lineReader{sc: sc, out: out}
+}
+
+type lineReader struct {
+	sc  *bufio.Scanner
+	out io.Writer
+}
+
+func (r *lineReader) ReadLine(prompt string) (string, error) {
+	if prompt != "" && r.out != nil {
+		fmt.Fprint(r.out, prompt)
+	}
+	if !r.sc.Scan() {
+		if err := r.sc.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.sc.Text(), nil
+}
+
+// Shell represents an interactive SQL shell.
+type Shell struct {
+	// Client is used to execute the statements.
+	Client *nosqldb.Client
+
+	// Reader reads the input lines.
+	Reader LineReader
+
+	// Out is where the results are written.
+	Out io.Writer
+
+	// Err is where errors are written. If not set, Out is used.
+	Err io.Writer
+
+	// Prompt is displayed when reading the first line of a statement.
+	Prompt string
+
+	// ContinuationPrompt is displayed when reading the following lines of a
+	// statement.
+	ContinuationPrompt string
+
+	// Mode specifies the format of query results. It can be changed with the
+	// \mode meta-command.
+	Mode OutputMode
+
+	// Options specifies how values of query results are formatted, such as
+	// the layout of timestamps. Its Format is set according to Mode.
+	Options format.Options
+
+	// DDLTimeout specifies how long to wait for a CREATE, ALTER or DROP
+	// statement to complete. If set to 0, one minute is used.
+	DDLTimeout time.Duration
+}
+
+// New returns a shell that executes statements read from in with the client,
+// and writes results to out.
+func New(client *nosqldb.Client, in io.Reader, out io.Writer) *Shell {
+	return &Shell{
+		Client:             client,
+		Reader:             NewLineReader(in, out),
+		Out:                out,
+		Prompt:             "sql-> ",
+		ContinuationPrompt: "   -> ",
+	}
+}
+
+// Run reads and executes statements and meta-commands until the end of input
+// or the \quit meta-command. Errors of statements are written to the shell
+// and do not stop it. Run returns an error if the input cannot be read or the
+// context is done.
+func (s *Shell) Run(ctx context.Context) error {
+	var buf statementBuffer
+	for {
+		prompt := s.Prompt
+		if !buf.empty() {
+			prompt = s.ContinuationPrompt
+		}
+
+		line, err := s.Reader.ReadLine(prompt)
+		if err == io.EOF {
+			if prompt != "" {
+				fmt.Fprintln(s.Out)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		var stmts []string
+		if buf.empty() && isMetaCommand(line) {
+			stmts = []string{line}
+		} else {
+			stmts = buf.add(line)
+		}
+
+		for _, stmt := range stmts {
+			err = s.Execute(ctx, stmt)
+			if err == ErrQuit {
+				return nil
+			}
+			if err != nil {
+				fmt.Fprintf(s.errWriter(), "Error: %v\n", err)
+			}
+		}
+	}
+}
+
+// Execute executes a single statement, without the terminating semicolon, or
+// a meta-command, and writes the results.
+func (s *Shell) Execute(ctx context.Context, stmt string) error {
+	stmt = strings.TrimSpace(stmt)
+	switch {
+	case stmt == "":
+		return nil
+	case isMetaCommand(stmt):
+		return s.meta(stmt)
+	case isDDL(stmt):
+		return s.ddl(stmt)
+	default:
+		return s.query(ctx, stmt)
+	}
+}
+
+func (s *Shell) errWriter() io.Writer {
+	if s.Err != nil {
+		return s.Err
+	}
+	return s.Out
+}
+
+func (s *Shell) query(ctx context.Context, stmt string) error {
+	req := &nosqldb.QueryRequest{Statement: stmt}
+	defer req.Close()
+
+	opts := s.Options
+	opts.Format = s.Mode.format()
+
+	var rows []*types.MapValue
+	var n int
+	for {
+		res, err := s.Client.QueryWithContext(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		results, err := res.GetResults()
+		if err != nil {
+			return err
+		}
+
+		// JSON rows are written as they are read, other formats need all
+		// rows to determine the columns.
+		n += len(results)
+		if s.Mode == JSONMode {
+			if err = format.Write(s.Out, results, &opts); err != nil {
+				return err
+			}
+		} else {
+			rows = append(rows, results...)
+		}
+
+		if req.IsDone() {
+			break
+		}
+	}
+
+	if s.Mode != JSONMode {
+		if err := format.Write(s.Out, rows, &opts); err != nil {
+			return err
+		}
+	}
+
+	if n == 1 {
+		fmt.Fprintln(s.Out, "\n1 row returned")
+	} else {
+		fmt.Fprintf(s.Out, "\n%d rows returned\n", n)
+	}
+	return nil
+}
+
+func (s *Shell) ddl(stmt string) error {
+	timeout := s.DDLTimeout
+	if timeout == 0 {
+		timeout = time.Minute
+	}
+
+	res, err := s.Client.DoTableRequestAndWait(&nosqldb.TableRequest{Statement: stmt}, timeout, time.Second)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(s.Out, "Statement completed successfully: table %s is %s\n", res.TableName, res.State)
+	return nil
+}
+
+// meta executes a meta-command.
+func (s *Shell) meta(line string) error {
+	args := strings.Fields(strings.TrimSuffix(strings.TrimSpace(line), ";"))
+	name, args := args[0], args[1:]
+
+	switch name {
+	case `\q`, `\quit`, `\exit`:
+		return ErrQuit
+
+	case `\?`, `\h`, `\help`:
+		fmt.Fprint(s.Out, helpText)
+		return nil
+
+	case `\d`, `\describe`:
+		if len(args) != 1 {
+			return fmt.Errorf(`usage: \describe <table>`)
+		}
+		return s.describe(args[0])
+
+	case `\dt`, `\tables`:
+		res, err := s.Client.ListTables(&nosqldb.ListTablesRequest{})
+		if err != nil {
+			return err
+		}
+		for _, t := range res.Tables {
+			fmt.Fprintln(s.Out, t)
+		}
+		return nil
+
+	case `\mode`:
+		if len(args) != 1 {
+			return fmt.Errorf(`usage: \mode table|json|csv`)
+		}
+		switch strings.ToLower(args[0]) {
+		case "table":
+			s.Mode = TableMode
+		case "json":
+			s.Mode = JSONMode
+		case "csv":
+			s.Mode = CSVMode
+		default:
+			return fmt.Errorf("unknown output mode %q, expected table, json or csv", args[0])
+		}
+		return nil
+
+	default:
+		return fmt.Errorf(`unknown command %s, use \help for the list of commands`, name)
+	}
+}
+
+const helpText = `Statements are terminated by a semicolon and can span multiple lines.
+
+Commands:
+  \describe <table>, \d <table>   print the columns and primary key of a table
+  \tables, \dt                    list the tables
+  \mode table|json|csv            print query results as a table, JSON or CSV
+  \help, \?                       print this help
+  \quit, \q                       exit the shell
+`
+
+// tableSchema represents the JSON schema of a table returned by GetTable.
+type tableSchema struct {
+	Name       string   `json:"name"`
+	TTL        string   `json:"ttl"`
+	PrimaryKey []string `json:"primaryKey"`
+	ShardKey   []string `json:"shardKey"`
+	Fields     []struct {
+		Name     string          `json:"name"`
+		Type     string          `json:"type"`
+		Nullable *bool           `json:"nullable"`
+		Default  json.RawMessage `json:"default"`
+	} `json:"fields"`
+}
+
+func (s *Shell) describe(table string) error {
+	res, err := s.Client.GetTable(&nosqldb.GetTableRequest{TableName: table})
+	if err != nil {
+		return err
+	}
+
+	var schema tableSchema
+	if err = json.Unmarshal([]byte(res.Schema), &schema); err != nil || len(schema.Fields) == 0 {
+		// Print the DDL statement if the schema is not available.
+		if res.DDL != "" {
+			fmt.Fprintln(s.Out, res.DDL)
+			return nil
+		}
+		return fmt.Errorf("cannot read the schema of table %s", table)
+	}
+
+	keys := make(map[string]string)
+	for _, k := range schema.PrimaryKey {
+		keys[k] = "PRIMARY"
+	}
+	for _, k := range schema.ShardKey {
+		keys[k] = "SHARD"
+	}
+
+	rows := make([]*types.MapValue, len(schema.Fields))
+	for i, f := range schema.Fields {
+		nullable := "YES"
+		if f.Nullable != nil && !*f.Nullable {
+			nullable = "NO"
+		}
+		def := ""
+		if len(f.Default) > 0 && string(f.Default) != "null" {
+			def = string(f.Default)
+		}
+		rows[i] = types.NewOrderedMapValue().
+			Put("Column", f.Name).
+			Put("Type", f.Type).
+			Put("Nullable", nullable).
+			Put("Default", def).
+			Put("Key", keys[f.Name])
+	}
+
+	fmt.Fprintf(s.Out, "Table %s (%s)\n", res.TableName, res.State)
+	if err = format.Write(s.Out, rows, nil); err != nil {
+		return err
+	}
+	if schema.TTL != "" {
+		fmt.Fprintf(s.Out, "TTL: %s\n", schema.TTL)
+	}
+	return nil
+}
+
+func isMetaCommand(s string) bool {
+	return strings.HasPrefix(strings.TrimSpace(s), `\`)
+}
+
+// isDDL reports whether the statement is a table or index DDL statement, which
+// is executed as a table request.
+func isDDL(stmt string) bool {
+	kw := strings.ToUpper(strings.Fields(stmt)[0])
+	return kw == "CREATE" || kw == "ALTER" || kw == "DROP"
+}
+
+// statementBuffer accumulates input lines until they form complete
+// statements. Semicolons in string literals and comments do not terminate a
+// statement.
+type statementBuffer struct {
+	buf strings.Builder
+
+	// quote is the quote character of the string literal the input ends in,
+	// if any.
+	quote byte
+
+	// escaped reports whether the input ends in a backslash in a string
+	// literal.
+	escaped bool
+}
+
+// add adds a line of input and returns the statements it completes, without
+// the terminating semicolons.
+func (b *statementBuffer) add(line string) (stmts []string) {
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case b.escaped:
+			b.escaped = false
+		case b.quote != 0:
+			if c == '\\' {
+				b.escaped = true
+			} else if c == b.quote {
+				b.quote = 0
+			}
+		case c == '\'' || c == '"':
+			b.quote = c
+		case c == '-' && i+1 < len(line) && line[i+1] == '-':
+			// Skip the comment.
+			i = len(line)
+			continue
+		case c == ';':
+			if stmt := strings.TrimSpace(b.buf.String()); stmt != "" {
+				stmts = append(stmts, stmt)
+			}
+			b.buf.Reset()
+			continue
+		}
+		b.buf.WriteByte(c)
+	}
+
+	if strings.TrimSpace(b.buf.String()) == "" && b.quote == 0 {
+		b.buf.Reset()
+	} else {
+		b.buf.WriteByte('\n')
+	}
+	return stmts
+}
+
+// empty reports whether there is no incomplete statement in the buffer.
+func (b *statementBuffer) empty() bool {
+	return b.buf.Len() == 0
+}
@@ -0,0 +1,72 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package shell
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementBuffer(t *testing.T) {
+	var b statementBuffer
+	assert.Empty(t, b.add("SELECT *"))
+	assert.False(t, b.empty())
+	assert.Equal(t, []string{"SELECT *\nFROM users"}, b.add("FROM users;"))
+	assert.True(t, b.empty())
+
+	// Several statements on a line, and an incomplete one.
+	assert.Equal(t, []string{"SELECT 1", "SELECT 2"}, b.add("SELECT 1; SELECT 2 ;SELECT"))
+	assert.Equal(t, []string{"SELECT\n 3"}, b.add(" 3;"))
+
+	// Semicolons in string literals and comments.
+	assert.Equal(t, []string{`SELECT * FROM t WHERE s = 'a;b' AND u = "c;\";d"`},
+		b.add(`SELECT * FROM t WHERE s = 'a;b' AND u = "c;\";d";`))
+	assert.Empty(t, b.add("SELECT * FROM t -- comment;"))
+	assert.Equal(t, []string{"SELECT * FROM t"}, b.add(";"))
+
+	// A string literal that spans lines.
+	assert.Empty(t, b.add("SELECT 'a;"))
+	assert.Equal(t, []string{"SELECT 'a;\nb'"}, b.add("b';"))
+
+	// Blank lines and empty statements.
+	assert.Empty(t, b.add("   "))
+	assert.Empty(t, b.add(";;"))
+	assert.True(t, b.empty())
+}
+
+func TestRunMetaCommands(t *testing.T) {
+	in := strings.NewReader("\\help\n\\mode json\n\\mode xml\n\\describe\n\\bogus\n\\q\nSELECT 1;\n")
+	var out bytes.Buffer
+	sh := New(nil, in, &out)
+	sh.Prompt = ""
+	require.NoError(t, sh.Run(context.Background()))
+
+	s := out.String()
+	assert.Contains(t, s, `\describe <table>`)
+	assert.Equal(t, JSONMode, sh.Mode)
+	assert.Contains(t, s, `Error: unknown output mode "xml"`)
+	assert.Contains(t, s, `Error: usage: \describe <table>`)
+	assert.Contains(t, s, `Error: unknown command \bogus`)
+	// The statement after \q is not executed.
+	assert.NotContains(t, s, "returned")
+
+	assert.Equal(t, ErrQuit, sh.Execute(context.Background(), `\quit;`))
+}
+
+func TestIsDDL(t *testing.T) {
+	assert.True(t, isDDL("create TABLE t(id INTEGER, PRIMARY KEY(id))"))
+	assert.True(t, isDDL("DROP INDEX idx ON t"))
+	assert.True(t, isDDL("ALTER TABLE t (ADD name STRING)"))
+	assert.False(t, isDDL("SELECT * FROM t"))
+	assert.False(t, isDDL("INSERT INTO t VALUES (1)"))
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+)
+
+// MessageSizeStats contains the aggregated sizes of the messages exchanged
+// with the server for the requests of a kind that completed successfully.
+type MessageSizeStats struct {
+	// Count represents the number of requests.
+	Count int64 `json:"count"`
+
+	// RequestBytes represents the total size in bytes of the requests.
+	RequestBytes int64 `json:"requestBytes"`
+
+	// ResponseBytes represents the total size in bytes of the responses.
+	ResponseBytes int64 `json:"responseBytes"`
+
+	// MaxRequestSize represents the size in bytes of the largest request.
+	MaxRequestSize int `json:"maxRequestSize"`
+
+	// MaxResponseSize represents the size in bytes of the largest response.
+	MaxResponseSize int `json:"maxResponseSize"`
+}
+
+// String returns a JSON string representation of the MessageSizeStats.
+func (s MessageSizeStats) String() string {
+	return jsonutil.AsJSON(s)
+}
+
+// messageSizeStats aggregates message sizes by kind of request.
+//
+// The zero value is ready to use.
+type messageSizeStats struct {
+	mu    sync.Mutex
+	stats map[string]*MessageSizeStats
+}
+
+// requestKind returns the kind of the request, which is the name of its type
+// without the "Request" suffix, such as "Get" or "Query".
+func requestKind(req Request) string {
+	t := reflect.TypeOf(req)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return strings.TrimSuffix(t.Name(), "Request")
+}
+
+func (ms *messageSizeStats) record(req Request, sizes *SizeInfo) {
+	kind := requestKind(req)
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.stats == nil {
+		ms.stats = make(map[string]*MessageSizeStats)
+	}
+
+	s, ok := ms.stats[kind]
+	if !ok {
+		s = &MessageSizeStats{}
+		ms.stats[kind] = s
+	}
+
+	s.Count++
+	s.RequestBytes += int64(sizes.RequestSize)
+	s.ResponseBytes += int64(sizes.ResponseSize)
+	if sizes.RequestSize > s.MaxRequestSize {
+		s.MaxRequestSize = sizes.RequestSize
+	}
+	if sizes.ResponseSize > s.MaxResponseSize {
+		s.MaxResponseSize = sizes.ResponseSize
+	}
+}
+
+// snapshot returns a copy of the stats, and resets them if reset is true.
+func (ms *messageSizeStats) snapshot(reset bool) map[string]MessageSizeStats {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	res := make(map[string]MessageSizeStats, len(ms.stats))
+	for kind, s := range ms.stats {
+		res[kind] = *s
+	}
+
+	if reset {
+		ms.stats = nil
+	}
+	return res
+}
+
+// MessageSizeStats returns the aggregated sizes of the messages exchanged
+// with the server since the Client was created or the stats were last reset.
+// The stats are keyed by the kind of request, which is the name of the request
+// type without the "Request" suffix, such as "Get", "Put" or "Query".
+//
+// Only requests that completed successfully are included. The sizes of the
+// messages of individual requests are available from Result.Sizes().
+//
+// If reset is true, the stats are reset after they are returned.
+func (c *Client) MessageSizeStats(reset bool) map[string]MessageSizeStats {
+	return c.sizeStats.snapshot(reset)
+}
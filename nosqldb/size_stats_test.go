@@ -0,0 +1,43 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageSizeStats(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+
+	client.sizeStats.record(&GetRequest{}, &SizeInfo{RequestSize: 50, ResponseSize: 300})
+	client.sizeStats.record(&GetRequest{}, &SizeInfo{RequestSize: 60, ResponseSize: 100})
+	client.sizeStats.record(&QueryRequest{}, &SizeInfo{RequestSize: 200, ResponseSize: 4000})
+
+	stats := client.MessageSizeStats(true)
+	assert.Equal(t, map[string]MessageSizeStats{
+		"Get": {
+			Count:           2,
+			RequestBytes:    110,
+			ResponseBytes:   400,
+			MaxRequestSize:  60,
+			MaxResponseSize: 300,
+		},
+		"Query": {
+			Count:           1,
+			RequestBytes:    200,
+			ResponseBytes:   4000,
+			MaxRequestSize:  200,
+			MaxResponseSize: 4000,
+		},
+	}, stats)
+	assert.Empty(t, client.MessageSizeStats(false), "stats should have been reset")
+}
@@ -0,0 +1,196 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/jsonutil"
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+)
+
+// maxRecentErrors is the number of the most recent request errors that a
+// Client keeps for SupportBundle.
+const maxRecentErrors = 32
+
+// RecentError represents an error returned by an attempt to execute a request.
+type RecentError struct {
+	// Time specifies when the error was returned.
+	Time time.Time `json:"time"`
+
+	// Request specifies the kind of request, such as "Get" or "Query".
+	Request string `json:"request"`
+
+	// Class specifies the class of the error.
+	Class ErrorClass `json:"class"`
+
+	// Retried specifies whether the request was retried after the error.
+	Retried bool `json:"retried"`
+
+	// Error specifies the message of the error, redacted with the
+	// RedactionPolicy of the Client.
+	Error string `json:"error"`
+}
+
+// recentErrors keeps the most recent request errors in a ring buffer.
+//
+// The zero value is ready to use.
+type recentErrors struct {
+	mu   sync.Mutex
+	errs []RecentError
+	next int
+}
+
+func (r *recentErrors) add(e RecentError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.errs) < maxRecentErrors {
+		r.errs = append(r.errs, e)
+		return
+	}
+	r.errs[r.next] = e
+	r.next = (r.next + 1) % maxRecentErrors
+}
+
+// snapshot returns a copy of the errors, from the oldest to the most recent.
+func (r *recentErrors) snapshot() []RecentError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]RecentError, 0, len(r.errs))
+	res = append(res, r.errs[r.next:]...)
+	return append(res, r.errs[:r.next]...)
+}
+
+// SupportBundle represents the information about a Client that is gathered
+// by Client.SupportBundle, to attach to support requests.
+type SupportBundle struct {
+	// Time specifies when the information was gathered.
+	Time time.Time `json:"time"`
+
+	// Compatibility specifies the versions of the SDK, the protocol and the
+	// server.
+	Compatibility CompatibilityInfo `json:"compatibility"`
+
+	// Config specifies the configuration of the Client, without its
+	// credentials and the values that are not represented in JSON, such as
+	// the AuthorizationProvider and the Logger.
+	Config Config `json:"config"`
+
+	// AuthorizationProvider specifies the type of the AuthorizationProvider
+	// of the Client, such as "*iam.SignatureProvider".
+	AuthorizationProvider string `json:"authorizationProvider,omitempty"`
+
+	// RetryHandler specifies the type of the RetryHandler of the Client.
+	RetryHandler string `json:"retryHandler,omitempty"`
+
+	// Diagnostics specifies the results of Client.Diagnose.
+	Diagnostics *DiagnosticReport `json:"diagnostics,omitempty"`
+
+	// RecentErrors specifies the most recent errors returned to the requests
+	// of the Client, from the oldest to the most recent.
+	RecentErrors []RecentError `json:"recentErrors,omitempty"`
+
+	// RetryStats specifies the stats returned by Client.RetryStats.
+	RetryStats map[ErrorClass]RetryStats `json:"retryStats,omitempty"`
+
+	// MessageSizeStats specifies the stats returned by
+	// Client.MessageSizeStats.
+	MessageSizeStats map[string]MessageSizeStats `json:"messageSizeStats,omitempty"`
+}
+
+// String returns a JSON string representation of the SupportBundle.
+func (b SupportBundle) String() string {
+	return jsonutil.AsPrettyJSON(b)
+}
+
+// ParseSupportBundle parses a support bundle from its JSON representation,
+// which is returned by SupportBundle.String.
+//
+// The Config of the bundle can be used to create a Client with the same
+// configuration, such as to reproduce a problem, once its credentials are
+// specified.
+func ParseSupportBundle(data []byte) (*SupportBundle, error) {
+	b := &SupportBundle{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, nosqlerr.NewIllegalArgument("invalid support bundle: %v", err)
+	}
+	return b, nil
+}
+
+// SupportBundle gathers the configuration of the Client, the results of
+// Diagnose, the most recent request errors and the stats of the Client into a
+// SupportBundle, whose JSON representation can be attached to support
+// requests to accelerate their resolution.
+//
+// The credentials of the configuration, such as the password, are removed,
+// and the errors are redacted with Config.Redaction. The stats are not reset.
+//
+// The checks of Diagnose send requests to the server, which honor the
+// deadline and cancellation of ctx. The returned error is non-nil only if ctx
+// is nil.
+func (c *Client) SupportBundle(ctx context.Context) (*SupportBundle, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	diagnostics, err := c.Diagnose(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &SupportBundle{
+		Time:             time.Now(),
+		Compatibility:    c.CompatibilityInfo(),
+		Config:           c.sanitizedConfig(),
+		Diagnostics:      diagnostics,
+		RecentErrors:     c.recentErrors.snapshot(),
+		RetryStats:       c.RetryStats(false),
+		MessageSizeStats: c.MessageSizeStats(false),
+	}
+	if c.AuthorizationProvider != nil {
+		b.AuthorizationProvider = fmt.Sprintf("%T", c.AuthorizationProvider)
+	}
+	if c.RetryHandler != nil {
+		b.RetryHandler = fmt.Sprintf("%T", c.RetryHandler)
+	}
+	return b, nil
+}
+
+// sanitizedConfig returns a copy of the configuration of the client without
+// its credentials, and without the values that are not represented in JSON
+// or that are set from the other values when a Client is created.
+func (c *Client) sanitizedConfig() Config {
+	cfg := c.Config
+	cfg.Password = nil
+	cfg.AuthorizationProvider = nil
+	cfg.RequestSigner = nil
+	cfg.RetryHandler = nil
+	cfg.Logger = nil
+	cfg.ClientCertificate = nil
+	cfg.GetClientCertificate = nil
+	cfg.RequestWeight = nil
+	cfg.TracePropagator = nil
+	cfg.MetricsSink = nil
+	cfg.MapValuePool = nil
+	cfg.Deterministic = nil
+	cfg.host, cfg.port, cfg.protocol = "", "", ""
+	cfg.httpClient = nil
+
+	if cfg.ProxyPassword != "" {
+		cfg.ProxyPassword = defaultRedactionPlaceholder
+	}
+	if u, err := url.Parse(cfg.ProxyURL); err == nil && u.User != nil {
+		cfg.ProxyURL = u.Redacted()
+	}
+	return cfg
+}
@@ -0,0 +1,148 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+const (
+	// defaultEnsureActiveTimeout is the time EnsureTableActive waits for a
+	// table to become active if the context has no deadline.
+	defaultEnsureActiveTimeout = 2 * time.Minute
+
+	// Bounds of the delay between checks of the table state.
+	ensureActiveMinDelay = 100 * time.Millisecond
+	ensureActiveMaxDelay = 2 * time.Second
+)
+
+// activeTables records the tables last known to be active. A table is
+// removed when a table request on the table is executed, or when a request
+// on the table fails with an error indicating the table is not usable.
+//
+// The zero value is ready to use.
+type activeTables struct {
+	mu     sync.Mutex
+	tables map[string]struct{}
+}
+
+func (at *activeTables) contains(namespace, tableName string) bool {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	_, ok := at.tables[schemaCacheKey(namespace, tableName)]
+	return ok
+}
+
+func (at *activeTables) add(namespace, tableName string) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	if at.tables == nil {
+		at.tables = make(map[string]struct{})
+	}
+	at.tables[schemaCacheKey(namespace, tableName)] = struct{}{}
+}
+
+// remove removes the specified table. If tableName is empty, all tables are
+// removed.
+func (at *activeTables) remove(namespace, tableName string) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	if tableName == "" {
+		at.tables = nil
+		return
+	}
+	delete(at.tables, schemaCacheKey(namespace, tableName))
+}
+
+// onRequestDone updates the known states according to the outcome of a
+// request.
+func (at *activeTables) onRequestDone(req Request, err error) {
+	switch req.(type) {
+	case *TableRequest, *AddReplicaRequest, *DropReplicaRequest:
+		at.remove(req.getNamespace(), req.getTableName())
+		return
+	case *SystemRequest:
+		at.remove("", "")
+		return
+	}
+
+	if nosqlerr.Is(err, nosqlerr.TableNotFound, nosqlerr.TableNotReady, nosqlerr.IllegalState) {
+		at.remove(req.getNamespace(), req.getTableName())
+	}
+}
+
+// EnsureTableActive verifies that the specified table is in the Active state,
+// waiting for it to become active if necessary. It is intended to be called
+// before the first operation on a table after a DDL operation, for example
+// during a deployment, to avoid TableNotReady and TableNotFound errors.
+//
+// The Client remembers the tables found to be active, so that subsequent
+// calls for the same table return immediately without contacting the server
+// until a table request is executed on the table, or a request on the table
+// fails with an error indicating the table is not usable.
+//
+// While the table is not active, or does not exist yet, its state is checked
+// with an increasing delay between checks until ctx is done. If ctx has no
+// deadline, the wait is limited to 2 minutes. A RequestTimeout error is
+// returned if the table does not become active in time. A TableNotFound error
+// is returned if the table is being dropped.
+//
+// The table name may be prefixed with a namespace, as in "ns1:table1".
+func (c *Client) EnsureTableActive(ctx context.Context, tableName string) error {
+	if ctx == nil {
+		return errNilContext
+	}
+
+	if err := validateTableName(tableName); err != nil {
+		return err
+	}
+
+	if c.activeTables.contains("", tableName) {
+		return nil
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultEnsureActiveTimeout)
+		defer cancel()
+	}
+
+	req := &GetTableRequest{TableName: tableName}
+	delay := ensureActiveMinDelay
+	for {
+		res, err := c.getTableWithContext(ctx, req)
+		switch {
+		case err == nil && res.State == types.Active:
+			c.activeTables.add("", tableName)
+			return nil
+
+		case err == nil && (res.State == types.Dropping || res.State == types.Dropped):
+			return nosqlerr.New(nosqlerr.TableNotFound, "table %q is in %s state", tableName, res.State)
+
+		case err != nil && !nosqlerr.IsTableNotFound(err):
+			return err
+		}
+
+		if !shouldRetryAfter(ctx, delay) {
+			if err == nil {
+				err = nosqlerr.New(nosqlerr.TableNotReady, "table %q is in %s state", tableName, res.State)
+			}
+			return nosqlerr.NewWithCause(nosqlerr.RequestTimeout, err,
+				"table %q did not become active before the deadline", tableName)
+		}
+
+		if delay *= 2; delay > ensureActiveMaxDelay {
+			delay = ensureActiveMaxDelay
+		}
+	}
+}
@@ -0,0 +1,60 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureTableActive(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	client.SetSerialVersion(3)
+
+	mockExec := &mockExecutor{
+		errChan: make(chan error),
+	}
+	client.executor = mockExec
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case mockExec.errChan <- nosqlerr.New(nosqlerr.TableNotFound, "not found"):
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// The table does not exist until the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	err = client.EnsureTableActive(ctx, "T1")
+	assert.Truef(t, nosqlerr.Is(err, nosqlerr.RequestTimeout), "got error %v", err)
+
+	// Tables known to be active are not checked again.
+	client.activeTables.add("", "T1")
+	assert.NoError(t, client.EnsureTableActive(context.Background(), "t1"))
+
+	// Until a table request is executed on the table.
+	client.activeTables.onRequestDone(&GetRequest{TableName: "T1"}, nosqlerr.New(nosqlerr.TableBusy, "busy"))
+	assert.True(t, client.activeTables.contains("", "T1"))
+	client.activeTables.onRequestDone(&TableRequest{TableName: "T1"}, nil)
+	assert.False(t, client.activeTables.contains("", "T1"))
+
+	client.activeTables.add("ns1", "T2")
+	client.activeTables.onRequestDone(&GetRequest{TableName: "ns1:T2"}, nosqlerr.New(nosqlerr.TableNotReady, "not ready"))
+	assert.False(t, client.activeTables.contains("ns1", "T2"))
+}
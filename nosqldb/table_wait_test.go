@@ -0,0 +1,88 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableResultWaitForCompletionWithContext(t *testing.T) {
+	requests := make(chan struct{}, 10)
+	stop := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Do not complete the request until the test ends, as for a table
+		// operation in progress.
+		select {
+		case requests <- struct{}{}:
+		default:
+		}
+		select {
+		case <-r.Context().Done():
+		case <-stop:
+		}
+	}))
+	defer srv.Close()
+	defer close(stop)
+
+	client, err := NewClient(Config{
+		Endpoint:              srv.URL,
+		AuthorizationProvider: &DummyAccessTokenProvider{TenantID: "TestTenantId"},
+	})
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+	defer client.Close()
+
+	res := &TableResult{TableName: "users", OperationID: "op1", State: types.Creating}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requests
+		cancel()
+	}()
+	_, err = res.WaitForCompletionWithContext(ctx, client, 10*time.Millisecond)
+	assert.Equal(t, context.Canceled, err)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = res.WaitForCompletionWithContext(ctx, client, 10*time.Millisecond)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	err = res.Cancel(context.Background(), client)
+	assert.Truef(t, nosqlerr.Is(err, nosqlerr.OperationNotSupported), "Cancel() got error %v", err)
+
+	// Validation of arguments.
+	tests := []struct {
+		desc     string
+		res      *TableResult
+		ctx      context.Context
+		client   *Client
+		interval time.Duration
+	}{
+		{"nil context", res, nil, client, 0},
+		{"nil client", res, context.Background(), nil, 0},
+		{"interval too short", res, context.Background(), client, time.Microsecond},
+		{"no operation id", &TableResult{TableName: "users", State: types.Creating}, context.Background(), client, 0},
+	}
+	for _, r := range tests {
+		_, err = r.res.WaitForCompletionWithContext(r.ctx, r.client, r.interval)
+		assert.Errorf(t, err, "%s: WaitForCompletionWithContext() should have failed", r.desc)
+	}
+
+	// A completed operation is returned without polling.
+	done := &TableResult{TableName: "users", State: types.Active}
+	got, err := done.WaitForCompletionWithContext(context.Background(), nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, done, got)
+}
@@ -0,0 +1,119 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const (
+	traceParentHeader = "traceparent"
+	traceStateHeader  = "tracestate"
+)
+
+// TraceContext represents a W3C trace context, as carried by the "traceparent"
+// and "tracestate" HTTP headers. See https://www.w3.org/TR/trace-context/.
+type TraceContext struct {
+	// TraceParent specifies the value of the traceparent header, such as
+	// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+	TraceParent string
+
+	// TraceState specifies the value of the tracestate header. It is optional.
+	TraceState string
+}
+
+// traceContextKey is the context key for TraceContext values.
+type traceContextKey struct{}
+
+// WithTraceContext returns a copy of ctx that carries the specified trace
+// context. Requests executed by a Client with the returned context propagate
+// the trace context to the server if Config.PropagateTraceContext is set and
+// Config.TracePropagator is not.
+//
+// This is for applications that do not use a tracing library. Applications
+// that use OpenTelemetry should set Config.TracePropagator instead.
+func WithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext returns the TraceContext carried by ctx, if any.
+func TraceContextFromContext(ctx context.Context) (tc TraceContext, ok bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	tc, ok = ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// TracePropagator is a function that adds the headers that propagate the trace
+// context carried by ctx to the headers of a request. It is used to integrate
+// the Client with a tracing library, for example with OpenTelemetry:
+//
+//	cfg.PropagateTraceContext = true
+//	cfg.TracePropagator = func(ctx context.Context, h http.Header) {
+//		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(h))
+//	}
+//
+// The function is called for each HTTP request sent by the Client, including
+// retries, with the context of the operation.
+type TracePropagator func(ctx context.Context, header http.Header)
+
+// addTraceHeaders adds the headers that propagate the trace context of ctx to
+// the request, if propagation is enabled.
+func (c *Client) addTraceHeaders(ctx context.Context, httpReq *http.Request) {
+	if !c.PropagateTraceContext || ctx == nil {
+		return
+	}
+
+	if c.TracePropagator != nil {
+		c.TracePropagator(ctx, httpReq.Header)
+		return
+	}
+
+	tc, ok := TraceContextFromContext(ctx)
+	if !ok || !validTraceParent(tc.TraceParent) {
+		return
+	}
+	httpReq.Header.Set(traceParentHeader, tc.TraceParent)
+	if tc.TraceState != "" {
+		httpReq.Header.Set(traceStateHeader, tc.TraceState)
+	}
+}
+
+// validTraceParent reports whether s is a traceparent header value of the
+// form version-traceid-parentid-flags, where the trace ID and parent ID are
+// not all zeros. Values of unknown versions are accepted if they start with
+// these fields, as specified by the W3C recommendation.
+func validTraceParent(s string) bool {
+	parts := strings.SplitN(s, "-", 5)
+	if len(parts) < 4 || parts[0] == "ff" {
+		return false
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return false
+	}
+
+	for i, n := range []int{2, 32, 16, 2} {
+		if len(parts[i]) != n || !isLowerHex(parts[i]) {
+			return false
+		}
+	}
+	zeros := func(s string) bool { return strings.Trim(s, "0") == "" }
+	return !zeros(parts[1]) && !zeros(parts[2])
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
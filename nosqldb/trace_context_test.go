@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceContextPropagation(t *testing.T) {
+	client, err := newMockClient()
+	require.NoErrorf(t, err, "failed to create client, got error %v.", err)
+
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := WithTraceContext(context.Background(), TraceContext{TraceParent: traceParent, TraceState: "vendor=1"})
+	tc, ok := TraceContextFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, traceParent, tc.TraceParent)
+
+	newRequest := func(ctx context.Context) *http.Request {
+		httpReq, _ := http.NewRequest(http.MethodPost, client.requestURL, nil)
+		client.addTraceHeaders(ctx, httpReq)
+		return httpReq
+	}
+
+	// Propagation is disabled by default.
+	assert.Empty(t, newRequest(ctx).Header.Get("traceparent"))
+
+	client.PropagateTraceContext = true
+	httpReq := newRequest(ctx)
+	assert.Equal(t, traceParent, httpReq.Header.Get("traceparent"))
+	assert.Equal(t, "vendor=1", httpReq.Header.Get("tracestate"))
+	assert.Empty(t, newRequest(context.Background()).Header.Get("traceparent"))
+
+	invalid := []struct {
+		desc        string
+		traceParent string
+	}{
+		{"empty", ""},
+		{"missing flags", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7"},
+		{"zero trace id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{"zero parent id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{"upper case hex", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{"extra field in version 00", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"},
+		{"invalid version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}
+	for _, r := range invalid {
+		ctx := WithTraceContext(context.Background(), TraceContext{TraceParent: r.traceParent})
+		assert.Emptyf(t, newRequest(ctx).Header.Get("traceparent"), "%s: traceparent %q was propagated", r.desc, r.traceParent)
+	}
+	assert.True(t, validTraceParent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"))
+
+	// The propagator takes precedence over the trace context of ctx.
+	client.TracePropagator = func(ctx context.Context, h http.Header) {
+		h.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	}
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", newRequest(ctx).Header.Get("traceparent"))
+}
@@ -0,0 +1,291 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// NewFieldRange creates a FieldRange on the specified field, with no bounds.
+// Use the builder methods to set the bounds, for example:
+//
+//	// 2024-01-01 <= ts < 2024-02-01
+//	r := types.NewFieldRange("ts").GreaterThanOrEqual(jan).LessThan(feb)
+//
+// The bounds are validated against the type of the field with ValidateFor,
+// which Client.ValidateFieldRange calls with the type declared in the table
+// schema.
+func NewFieldRange(fieldPath string) *FieldRange {
+	return &FieldRange{FieldPath: fieldPath}
+}
+
+// GreaterThan sets the start of the range to v, exclusive.
+func (r *FieldRange) GreaterThan(v FieldValue) *FieldRange {
+	r.Start, r.StartInclusive = v, false
+	return r
+}
+
+// GreaterThanOrEqual sets the start of the range to v, inclusive.
+func (r *FieldRange) GreaterThanOrEqual(v FieldValue) *FieldRange {
+	r.Start, r.StartInclusive = v, true
+	return r
+}
+
+// LessThan sets the end of the range to v, exclusive.
+func (r *FieldRange) LessThan(v FieldValue) *FieldRange {
+	r.End, r.EndInclusive = v, false
+	return r
+}
+
+// LessThanOrEqual sets the end of the range to v, inclusive.
+func (r *FieldRange) LessThanOrEqual(v FieldValue) *FieldRange {
+	r.End, r.EndInclusive = v, true
+	return r
+}
+
+// Between sets the start and end of the range to start and end, inclusive.
+func (r *FieldRange) Between(start, end FieldValue) *FieldRange {
+	return r.GreaterThanOrEqual(start).LessThanOrEqual(end)
+}
+
+// ValidateFor validates the range for a field of the specified type. It
+// checks that the range has a field path and at least one bound, that the
+// bounds are values of the type, such as an integer that fits in 32 bits for
+// Integer or a time.Time or a timestamp string for Timestamp, and that the
+// range is not empty.
+//
+// The supported types are Integer, Long, Double, Number, String, Timestamp
+// and Boolean.
+func (r *FieldRange) ValidateFor(t DbType) error {
+	if r.FieldPath == "" {
+		return fmt.Errorf("FieldRange: FieldPath must be non-empty")
+	}
+	if r.Start == nil && r.End == nil {
+		return fmt.Errorf("FieldRange on %s: must specify a Start or End value", r.FieldPath)
+	}
+
+	var start, end interface{}
+	var err error
+	if r.Start != nil {
+		if start, err = rangeBound(r.Start, t); err != nil {
+			return fmt.Errorf("FieldRange on %s: invalid Start value: %v", r.FieldPath, err)
+		}
+	}
+	if r.End != nil {
+		if end, err = rangeBound(r.End, t); err != nil {
+			return fmt.Errorf("FieldRange on %s: invalid End value: %v", r.FieldPath, err)
+		}
+	}
+
+	if start != nil && end != nil {
+		c := compareBounds(start, end)
+		if c > 0 || c == 0 && !(r.StartInclusive && r.EndInclusive) {
+			return fmt.Errorf("FieldRange on %s: the range is empty", r.FieldPath)
+		}
+	}
+	return nil
+}
+
+// Condition returns the SQL condition that selects the values of the range,
+// such as "ts >= '2024-01-01T00:00:00Z' AND ts < '2024-02-01T00:00:00Z'", for
+// queries that scan an index on the field. The bounds are formatted with
+// FormatLiteral.
+func (r *FieldRange) Condition() (string, error) {
+	if r.FieldPath == "" {
+		return "", fmt.Errorf("FieldRange: FieldPath must be non-empty")
+	}
+	if r.Start == nil && r.End == nil {
+		return "", fmt.Errorf("FieldRange on %s: must specify a Start or End value", r.FieldPath)
+	}
+
+	var conds []string
+	if r.Start != nil {
+		lit, err := FormatLiteral(r.Start)
+		if err != nil {
+			return "", fmt.Errorf("FieldRange on %s: invalid Start value: %v", r.FieldPath, err)
+		}
+		op := ">"
+		if r.StartInclusive {
+			op = ">="
+		}
+		conds = append(conds, r.FieldPath+" "+op+" "+lit)
+	}
+	if r.End != nil {
+		lit, err := FormatLiteral(r.End)
+		if err != nil {
+			return "", fmt.Errorf("FieldRange on %s: invalid End value: %v", r.FieldPath, err)
+		}
+		op := "<"
+		if r.EndInclusive {
+			op = "<="
+		}
+		conds = append(conds, r.FieldPath+" "+op+" "+lit)
+	}
+	return strings.Join(conds, " AND "), nil
+}
+
+// rangeBound converts a bound of a range on a field of type t to a value that
+// can be compared by compareBounds: an int64, a float64, a *big.Rat, a
+// string, a time.Time or a bool.
+func rangeBound(v FieldValue, t DbType) (interface{}, error) {
+	switch t {
+	case Integer, Long:
+		i, ok := toInt64(v)
+		if !ok {
+			return nil, fmt.Errorf("%v of type %T is not an integer", v, v)
+		}
+		if t == Integer && (i < math.MinInt32 || i > math.MaxInt32) {
+			return nil, fmt.Errorf("%d overflows INTEGER", i)
+		}
+		return i, nil
+
+	case Double:
+		switch v := v.(type) {
+		case float32:
+			return float64(v), nil
+		case float64:
+			if math.IsNaN(v) {
+				return nil, fmt.Errorf("NaN is not a valid bound")
+			}
+			return v, nil
+		}
+		if i, ok := toInt64(v); ok {
+			return float64(i), nil
+		}
+		return nil, fmt.Errorf("%v of type %T is not a floating point number", v, v)
+
+	case Number:
+		switch v := v.(type) {
+		case *big.Rat:
+			if v == nil {
+				return nil, fmt.Errorf("nil *big.Rat")
+			}
+			return v, nil
+		case json.Number:
+			if r, ok := new(big.Rat).SetString(string(v)); ok {
+				return r, nil
+			}
+			return nil, fmt.Errorf("invalid number %q", v)
+		case float32, float64:
+			f := toFloat64(v)
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("%v is not a valid NUMBER", f)
+			}
+			return new(big.Rat).SetFloat64(f), nil
+		}
+		if i, ok := toInt64(v); ok {
+			return new(big.Rat).SetInt64(i), nil
+		}
+		return nil, fmt.Errorf("%v of type %T is not a number", v, v)
+
+	case String:
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case *string:
+			if v != nil {
+				return *v, nil
+			}
+		}
+		return nil, fmt.Errorf("%v of type %T is not a string", v, v)
+
+	case Timestamp:
+		switch v := v.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			ts, err := ParseDateTime(v)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a timestamp", v)
+			}
+			return ts, nil
+		}
+		return nil, fmt.Errorf("%v of type %T is not a timestamp", v, v)
+
+	case Boolean:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("%v of type %T is not a boolean", v, v)
+
+	default:
+		return nil, fmt.Errorf("a range cannot be specified on a field of type %v", t)
+	}
+}
+
+func toInt64(v FieldValue) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint:
+		if uint64(v) <= math.MaxInt64 {
+			return int64(v), true
+		}
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v), true
+		}
+	}
+	return 0, false
+}
+
+func toFloat64(v FieldValue) float64 {
+	if f, ok := v.(float32); ok {
+		return float64(f)
+	}
+	return v.(float64)
+}
+
+// compareBounds compares two values returned by rangeBound for the same type.
+func compareBounds(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		return compareOrdered(a < b.(int64), a > b.(int64))
+	case float64:
+		return compareOrdered(a < b.(float64), a > b.(float64))
+	case *big.Rat:
+		return a.Cmp(b.(*big.Rat))
+	case string:
+		return strings.Compare(a, b.(string))
+	case time.Time:
+		return compareOrdered(a.Before(b.(time.Time)), a.After(b.(time.Time)))
+	case bool:
+		return compareOrdered(!a && b.(bool), a && !b.(bool))
+	}
+	return 0
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
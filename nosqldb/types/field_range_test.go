@@ -0,0 +1,84 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package types
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldRangeBuilder(t *testing.T) {
+	r := NewFieldRange("id").GreaterThan(1).LessThanOrEqual(10)
+	assert.Equal(t, &FieldRange{FieldPath: "id", Start: 1, End: 10, EndInclusive: true}, r)
+
+	r = NewFieldRange("id").Between(1, 10)
+	assert.Equal(t, &FieldRange{FieldPath: "id", Start: 1, StartInclusive: true, End: 10, EndInclusive: true}, r)
+
+	cond, err := NewFieldRange("name").GreaterThanOrEqual("a").LessThan("b").Condition()
+	require.NoError(t, err)
+	assert.Equal(t, "name >= 'a' AND name < 'b'", cond)
+
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cond, err = NewFieldRange("ts").GreaterThan(jan).Condition()
+	require.NoError(t, err)
+	assert.Equal(t, "ts > '2024-01-01T00:00:00Z'", cond)
+
+	_, err = NewFieldRange("id").Condition()
+	assert.Error(t, err)
+}
+
+func TestFieldRangeValidateFor(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := jan.AddDate(0, 1, 0)
+
+	tests := []struct {
+		r     *FieldRange
+		t     DbType
+		valid bool
+	}{
+		{NewFieldRange("id").GreaterThan(1), Integer, true},
+		{NewFieldRange("id").Between(int64(1), uint8(2)), Integer, true},
+		{NewFieldRange("id").GreaterThan(int64(math.MaxInt32) + 1), Integer, false},
+		{NewFieldRange("id").GreaterThan(int64(math.MaxInt32) + 1), Long, true},
+		{NewFieldRange("id").GreaterThan("1"), Long, false},
+		{NewFieldRange("id").GreaterThan(1.5), Long, false},
+		{NewFieldRange("d").Between(1, 2.5), Double, true},
+		{NewFieldRange("d").GreaterThan(math.NaN()), Double, false},
+		{NewFieldRange("n").Between(1, new(big.Rat).SetFrac64(3, 2)), Number, true},
+		{NewFieldRange("n").GreaterThan(math.Inf(1)), Number, false},
+		{NewFieldRange("s").Between("a", "b"), String, true},
+		{NewFieldRange("s").Between("b", "a"), String, false},
+		{NewFieldRange("ts").GreaterThanOrEqual(jan).LessThan(feb), Timestamp, true},
+		{NewFieldRange("ts").GreaterThanOrEqual("2024-01-01T00:00:00").LessThan(feb), Timestamp, true},
+		{NewFieldRange("ts").GreaterThan("not a date"), Timestamp, false},
+		{NewFieldRange("ts").GreaterThan(1), Timestamp, false},
+		{NewFieldRange("b").Between(false, true), Boolean, true},
+		// Empty ranges.
+		{NewFieldRange("id").Between(2, 1), Integer, false},
+		{NewFieldRange("id").GreaterThanOrEqual(1).LessThan(1), Integer, false},
+		{NewFieldRange("id").Between(1, 1), Integer, true},
+		// Missing field path or bounds, and unsupported types.
+		{NewFieldRange("").GreaterThan(1), Integer, false},
+		{NewFieldRange("id"), Integer, false},
+		{NewFieldRange("m").GreaterThan(1), Map, false},
+	}
+
+	for i, r := range tests {
+		err := r.r.ValidateFor(r.t)
+		if r.valid {
+			assert.NoErrorf(t, err, "Test %d: ValidateFor(%v) got error", i, r.t)
+		} else {
+			assert.Errorf(t, err, "Test %d: ValidateFor(%v) should have failed", i, r.t)
+		}
+	}
+}
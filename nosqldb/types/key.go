@@ -0,0 +1,297 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Key represents a primary key of a table row, which may be composed of
+// multiple fields. The fields of a Key are kept in the order they are added,
+// which should be the declaration order of the primary key columns.
+//
+// A Key can be encoded as a string that is safe to use in a URL without
+// further escaping, for example as the identifier of a REST resource, and
+// decoded back to the same Key. The encoding is stable: equal keys always
+// produce the same string.
+//
+// Key fields must be of the atomic types valid for primary keys: int, int64,
+// float64, *big.Rat, string, []byte, bool and time.Time. Other integer types
+// are converted to int or int64, float32 is converted to float64.
+type Key struct {
+	names  []string
+	values []FieldValue
+}
+
+// NewKey creates an empty Key.
+func NewKey() *Key {
+	return &Key{}
+}
+
+// KeyFromMapValue creates a Key with the specified fields of m, in the
+// specified order. It returns an error if a field is missing from m.
+func KeyFromMapValue(m *MapValue, fields ...string) (*Key, error) {
+	if m == nil {
+		return nil, fmt.Errorf("MapValue must be non-nil")
+	}
+
+	k := NewKey()
+	for _, name := range fields {
+		v, ok := m.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("missing key field %q", name)
+		}
+		k.Add(name, v)
+	}
+
+	return k, nil
+}
+
+// Add appends a field to the Key and returns the Key.
+func (k *Key) Add(name string, value FieldValue) *Key {
+	k.names = append(k.names, name)
+	k.values = append(k.values, value)
+	return k
+}
+
+// Len returns the number of fields in the Key.
+func (k *Key) Len() int {
+	return len(k.names)
+}
+
+// Field returns the name and value of the i'th field, 0 <= i < Len().
+func (k *Key) Field(i int) (name string, value FieldValue) {
+	return k.names[i], k.values[i]
+}
+
+// MapValue returns an ordered MapValue that contains the fields of the Key,
+// which can be used as the key of a get or delete operation.
+func (k *Key) MapValue() *MapValue {
+	m := NewOrderedMapValue()
+	for i, name := range k.names {
+		m.Put(name, k.values[i])
+	}
+	return m
+}
+
+// Type tags of the encoded key field values.
+const (
+	keyTagInt       = 'i'
+	keyTagLong      = 'l'
+	keyTagDouble    = 'd'
+	keyTagNumber    = 'n'
+	keyTagString    = 's'
+	keyTagBinary    = 'b'
+	keyTagBoolean   = 'z'
+	keyTagTimestamp = 't'
+)
+
+// Encode returns the string encoding of the Key.
+//
+// The encoding consists of the fields of the Key separated by ".", where each
+// field is encoded as:
+//
+//	<name>~<type tag><value>
+//
+// All characters of names and values other than ASCII letters, digits and "-"
+// are escaped as "_" followed by two hexadecimal digits.
+func (k *Key) Encode() (string, error) {
+	if k.Len() == 0 {
+		return "", fmt.Errorf("cannot encode an empty key")
+	}
+
+	var sb strings.Builder
+	for i, name := range k.names {
+		tag, s, err := encodeKeyValue(k.values[i])
+		if err != nil {
+			return "", fmt.Errorf("cannot encode key field %q: %v", name, err)
+		}
+
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		escapeKeyPart(&sb, name)
+		sb.WriteByte('~')
+		sb.WriteByte(tag)
+		escapeKeyPart(&sb, s)
+	}
+
+	return sb.String(), nil
+}
+
+// DecodeKey decodes a Key from the string encoding returned by Key.Encode().
+func DecodeKey(s string) (*Key, error) {
+	if s == "" {
+		return nil, fmt.Errorf("cannot decode an empty key")
+	}
+
+	k := NewKey()
+	for _, field := range strings.Split(s, ".") {
+		encName, encValue, found := strings.Cut(field, "~")
+		if !found || encValue == "" {
+			return nil, fmt.Errorf("invalid key field %q", field)
+		}
+
+		name, err := unescapeKeyPart(encName)
+		if err != nil {
+			return nil, err
+		}
+
+		str, err := unescapeKeyPart(encValue[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := decodeKeyValue(encValue[0], str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of key field %q: %v", name, err)
+		}
+
+		k.Add(name, v)
+	}
+
+	return k, nil
+}
+
+func encodeKeyValue(v FieldValue) (tag byte, s string, err error) {
+	switch v := v.(type) {
+	case int:
+		if v >= math.MinInt32 && v <= math.MaxInt32 {
+			return keyTagInt, strconv.Itoa(v), nil
+		}
+		return keyTagLong, strconv.Itoa(v), nil
+	case int8:
+		return keyTagInt, strconv.Itoa(int(v)), nil
+	case int16:
+		return keyTagInt, strconv.Itoa(int(v)), nil
+	case int32:
+		return keyTagInt, strconv.Itoa(int(v)), nil
+	case uint8:
+		return keyTagInt, strconv.Itoa(int(v)), nil
+	case uint16:
+		return keyTagInt, strconv.Itoa(int(v)), nil
+	case uint32:
+		return encodeKeyValue(int64(v))
+	case int64:
+		if v >= math.MinInt32 && v <= math.MaxInt32 {
+			return keyTagInt, strconv.FormatInt(v, 10), nil
+		}
+		return keyTagLong, strconv.FormatInt(v, 10), nil
+	case json.Number:
+		return encodeJSONNumber(v)
+	case float32:
+		return keyTagDouble, strconv.FormatFloat(float64(v), 'g', -1, 64), nil
+	case float64:
+		return keyTagDouble, strconv.FormatFloat(v, 'g', -1, 64), nil
+	case *big.Rat:
+		if v == nil {
+			return 0, "", fmt.Errorf("nil value")
+		}
+		return keyTagNumber, v.RatString(), nil
+	case string:
+		return keyTagString, v, nil
+	case *string:
+		if v == nil {
+			return 0, "", fmt.Errorf("nil value")
+		}
+		return keyTagString, *v, nil
+	case []byte:
+		return keyTagBinary, base64.RawURLEncoding.EncodeToString(v), nil
+	case bool:
+		return keyTagBoolean, strconv.FormatBool(v), nil
+	case time.Time:
+		return keyTagTimestamp, v.UTC().Format(time.RFC3339Nano), nil
+	default:
+		return 0, "", fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+func encodeJSONNumber(n json.Number) (tag byte, s string, err error) {
+	if i64, err := n.Int64(); err == nil {
+		return encodeKeyValue(i64)
+	}
+	if f64, err := n.Float64(); err == nil {
+		return encodeKeyValue(f64)
+	}
+	return 0, "", fmt.Errorf("invalid number %q", n)
+}
+
+func decodeKeyValue(tag byte, s string) (FieldValue, error) {
+	switch tag {
+	case keyTagInt:
+		i, err := strconv.ParseInt(s, 10, 32)
+		return int(i), err
+	case keyTagLong:
+		return strconv.ParseInt(s, 10, 64)
+	case keyTagDouble:
+		return strconv.ParseFloat(s, 64)
+	case keyTagNumber:
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", s)
+		}
+		return r, nil
+	case keyTagString:
+		return s, nil
+	case keyTagBinary:
+		return base64.RawURLEncoding.DecodeString(s)
+	case keyTagBoolean:
+		return strconv.ParseBool(s)
+	case keyTagTimestamp:
+		return time.Parse(time.RFC3339Nano, s)
+	default:
+		return nil, fmt.Errorf("unknown type tag %q", tag)
+	}
+}
+
+const hexDigits = "0123456789ABCDEF"
+
+func escapeKeyPart(sb *strings.Builder, s string) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' {
+			sb.WriteByte(c)
+			continue
+		}
+		sb.WriteByte('_')
+		sb.WriteByte(hexDigits[c>>4])
+		sb.WriteByte(hexDigits[c&0xF])
+	}
+}
+
+func unescapeKeyPart(s string) (string, error) {
+	if !strings.Contains(s, "_") {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '_' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if i+2 >= len(s) {
+			return "", fmt.Errorf("invalid escape sequence in %q", s)
+		}
+		b, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("invalid escape sequence in %q", s)
+		}
+		sb.WriteByte(byte(b))
+		i += 2
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,223 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatLiteral returns the SQL literal that represents the specified value,
+// for the cases where bind variables cannot be used, such as the DEFAULT
+// clause of a column definition in a CREATE TABLE statement. Bind variables
+// should be preferred whenever possible.
+//
+// The value is formatted as follows:
+//
+//	string, *string:         a single-quoted string, with special characters escaped
+//	int and other integers:  a decimal integer
+//	float32, float64:        a floating point number, which always contains a
+//	                         decimal point or an exponent
+//	*big.Rat, json.Number:   a NUMBER literal, such as 1.5N
+//	bool:                    true or false
+//	time.Time:               a single-quoted ISO 8601 string in UTC
+//	*JSONNullValue:          null
+//	*MapValue, map:          a map constructor, such as {'a' : 1}
+//	slice, array:            an array constructor, such as [1, 2]
+//
+// An error is returned for values that have no literal form, such as SQL NULL,
+// binary values, NaN and infinities, and numbers that cannot be represented
+// exactly as decimals.
+func FormatLiteral(v FieldValue) (string, error) {
+	var sb strings.Builder
+	if err := formatLiteral(&sb, v); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func formatLiteral(sb *strings.Builder, v FieldValue) error {
+	switch v := v.(type) {
+	case string:
+		quoteLiteral(sb, v)
+	case *string:
+		if v == nil {
+			return fmt.Errorf("cannot format a nil *string")
+		}
+		quoteLiteral(sb, *v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		fmt.Fprintf(sb, "%d", v)
+	case float32:
+		return formatFloat(sb, float64(v), 32)
+	case float64:
+		return formatFloat(sb, v, 64)
+	case json.Number:
+		r, ok := new(big.Rat).SetString(string(v))
+		if !ok {
+			return fmt.Errorf("invalid number %q", v)
+		}
+		return formatNumber(sb, r)
+	case *big.Rat:
+		if v == nil {
+			return fmt.Errorf("cannot format a nil *big.Rat")
+		}
+		return formatNumber(sb, v)
+	case bool:
+		sb.WriteString(strconv.FormatBool(v))
+	case time.Time:
+		quoteLiteral(sb, v.UTC().Format(ISO8601ZLayout))
+	case *JSONNullValue:
+		sb.WriteString("null")
+	case *NullValue:
+		return fmt.Errorf("there is no literal for SQL NULL")
+	case *EmptyValue:
+		return fmt.Errorf("there is no literal for an EMPTY value")
+	case []byte:
+		return fmt.Errorf("there is no literal for a binary value")
+	case *MapValue:
+		if v == nil {
+			return fmt.Errorf("cannot format a nil *MapValue")
+		}
+		keys := v.keys
+		if !v.IsOrdered() {
+			keys = sortedKeys(v.Map())
+		}
+		return formatMap(sb, keys, v.Map())
+	case map[string]interface{}:
+		return formatMap(sb, sortedKeys(v), v)
+	default:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return fmt.Errorf("cannot format a value of type %T as a literal", v)
+		}
+		sb.WriteByte('[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if err := formatLiteral(sb, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		sb.WriteByte(']')
+	}
+
+	return nil
+}
+
+func formatMap(sb *strings.Builder, keys []string, m map[string]interface{}) error {
+	sb.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		quoteLiteral(sb, k)
+		sb.WriteString(" : ")
+		if err := formatLiteral(sb, m[k]); err != nil {
+			return err
+		}
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(sb *strings.Builder, f float64, bitSize int) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("there is no literal for %v", f)
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, bitSize)
+	sb.WriteString(s)
+	// Make sure the literal is not parsed as an integer.
+	if !strings.ContainsAny(s, ".e") {
+		sb.WriteString(".0")
+	}
+	return nil
+}
+
+// formatNumber writes r as a NUMBER literal, which requires r to have a
+// finite decimal representation.
+func formatNumber(sb *strings.Builder, r *big.Rat) error {
+	if r.IsInt() {
+		sb.WriteString(r.Num().String())
+		sb.WriteByte('N')
+		return nil
+	}
+
+	// The number of decimal digits required is the larger of the exponents
+	// of 2 and 5 in the denominator, which must have no other prime factor.
+	d := new(big.Int).Set(r.Denom())
+	two, five := big.NewInt(2), big.NewInt(5)
+	var n2, n5 int
+	m := new(big.Int)
+	for m.Mod(d, two).Sign() == 0 {
+		d.Quo(d, two)
+		n2++
+	}
+	for m.Mod(d, five).Sign() == 0 {
+		d.Quo(d, five)
+		n5++
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("%s cannot be represented exactly as a decimal number", r.RatString())
+	}
+
+	if n5 > n2 {
+		n2 = n5
+	}
+	sb.WriteString(r.FloatString(n2))
+	sb.WriteByte('N')
+	return nil
+}
+
+// quoteLiteral writes s as a single-quoted string literal. Invalid UTF-8
+// sequences are written as U+FFFD.
+func quoteLiteral(sb *strings.Builder, s string) {
+	sb.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\'':
+			sb.WriteString(`\'`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(sb, `\u%04x`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('\'')
+}
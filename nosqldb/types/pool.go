@@ -0,0 +1,88 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package types
+
+import (
+	"sync"
+)
+
+// MapValuePool is a pool of ordered MapValues, which can be used to reduce
+// the allocations of applications that read many small rows. A MapValue
+// released to the pool is emptied and reused by a following Get, so it must
+// not be used after it is released.
+//
+// The zero value of MapValuePool is an empty pool ready to use.
+// A MapValuePool is safe for concurrent use by multiple goroutines.
+type MapValuePool struct {
+	// Debug specifies whether to detect every use of MapValues after they
+	// are released. The methods of a MapValue panic while it is in the pool,
+	// as does releasing it again, but the uses after it is reused by Get go
+	// undetected. If Debug is set, released MapValues are never reused, so
+	// that any use of them panics. It should only be set in tests, as it
+	// disables the reuse of MapValues.
+	Debug bool
+
+	pool sync.Pool
+}
+
+// Get returns an empty, ordered MapValue from the pool, or a new one if the
+// pool is empty.
+func (p *MapValuePool) Get() *MapValue {
+	if m, ok := p.pool.Get().(*MapValue); ok {
+		m.released = false
+		return m
+	}
+	return NewOrderedMapValue()
+}
+
+// Put releases m, and the MapValues nested in the values of m, to the pool.
+// It does nothing if m is nil.
+func (p *MapValuePool) Put(m *MapValue) {
+	if m == nil {
+		return
+	}
+	m.checkReleased()
+
+	for _, v := range m.m {
+		p.putNested(v)
+	}
+
+	m.released = true
+	if p.Debug {
+		return
+	}
+
+	for k := range m.m {
+		delete(m.m, k)
+	}
+	for i := range m.keys {
+		m.keys[i] = ""
+	}
+	m.keys = m.keys[:0]
+	m.keepInsertionOrder = true
+	p.pool.Put(m)
+}
+
+// putNested releases the MapValues in v to the pool.
+func (p *MapValuePool) putNested(v interface{}) {
+	switch v := v.(type) {
+	case *MapValue:
+		p.Put(v)
+	case []FieldValue:
+		for _, e := range v {
+			p.putNested(e)
+		}
+	}
+}
+
+// checkReleased panics if m was released to a MapValuePool in Debug mode.
+func (m *MapValue) checkReleased() {
+	if m.released {
+		panic("types: use of a MapValue after it was released to a MapValuePool")
+	}
+}
@@ -0,0 +1,51 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapValuePool(t *testing.T) {
+	var p MapValuePool
+	m := p.Get()
+	assert.True(t, m.IsOrdered())
+	m.Put("id", 1).Put("name", "Jack")
+	nested := p.Get().Put("city", "Lisbon")
+	m.Put("address", nested).Put("tags", []FieldValue{p.Get().Put("tag", "a")})
+
+	p.Put(m)
+	assert.Panics(t, func() { m.Get("id") }, "Get() of a released MapValue")
+	assert.Panics(t, func() { nested.Len() }, "Len() of a released nested MapValue")
+	assert.Panics(t, func() { p.Put(m) }, "Put() of a released MapValue")
+	p.Put(nil)
+
+	// A reused MapValue is empty.
+	for i := 0; i < 4; i++ {
+		m = p.Get()
+		assert.Equal(t, 0, m.Len())
+		_, _, ok := m.GetByIndex(1)
+		assert.False(t, ok)
+		m.Put("k", i)
+		v, ok := m.Get("k")
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+
+	// In Debug mode, released MapValues are never reused.
+	p = MapValuePool{Debug: true}
+	m = p.Get().Put("id", 1)
+	p.Put(m)
+	for i := 0; i < 4; i++ {
+		assert.False(t, p.Get() == m, "Get() reused a released MapValue")
+	}
+	assert.Panics(t, func() { m.Map() }, "Map() of a released MapValue")
+	assert.Panics(t, func() { m.MarshalJSON() }, "MarshalJSON() of a released MapValue")
+}
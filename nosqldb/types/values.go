@@ -106,19 +106,85 @@
 // on the value.
 //
 // The special values JSONNullValueInstance, NullValueInstance and EmptyValueInstance
-// are served as output of the queries for the Oracle NoSQL database, they should not
-// be used as input values.
+// are served as output of the queries for the Oracle NoSQL database.
+//
+// # Null and Absent Values
+//
+// A JSON null, an SQL NULL and an absent field have different meanings, and
+// are represented by different values:
+//
+//	Value                     Meaning
+//	=====================     ==================================================
+//	JSONNullValueInstance     a JSON null in a field of type JSON or in a JSON
+//	                          document
+//	nil                       same as JSONNullValueInstance on input
+//	SQLNull                   the SQL NULL value of a field of the table schema,
+//	                          such as a nullable INTEGER column
+//	Absent                    a field that does not exist
+//
+// On input, a map entry whose value is Absent is omitted, so a field can be
+// conditionally excluded from a row without deleting it from the MapValue. On
+// output, an absent field has no entry in the MapValue, and the Get methods
+// report that it is not found.
+//
+// In a struct, a field of an interface type such as FieldValue that holds
+// Absent is omitted on input. On output, a JSON null or an SQL NULL stored in
+// a field of an interface type is decoded as JSONNullValueInstance or SQLNull,
+// and an absent field is left unchanged, which is nil for a new struct. Fields
+// of other types are set to their zero value for a JSON null or an SQL NULL.
+//
+// JSON cannot represent an SQL NULL, so MarshalJSON encodes both JSON null and
+// SQL NULL as null, and omits the map entries whose value is Absent.
 package types
 
 import (
 	"encoding/json"
+	"math/big"
 	"strings"
+	"time"
 )
 
 // FieldValue represents a field value of NoSQL database tables.
 // This is an empty interface.
 type FieldValue interface{}
 
+// TypeOf returns the database type of a field value returned by the database,
+// such as a value of a query result, and reports whether v is of such a type.
+// A nil value is reported as JSONNull, and a string that represents a number
+// too large for *big.Rat is reported as String.
+func TypeOf(v FieldValue) (t DbType, ok bool) {
+	switch v.(type) {
+	case []FieldValue:
+		return Array, true
+	case []byte:
+		return Binary, true
+	case bool:
+		return Boolean, true
+	case float64:
+		return Double, true
+	case int:
+		return Integer, true
+	case int64:
+		return Long, true
+	case *MapValue:
+		return Map, true
+	case string:
+		return String, true
+	case time.Time:
+		return Timestamp, true
+	case *big.Rat:
+		return Number, true
+	case *JSONNullValue, nil:
+		return JSONNull, true
+	case *NullValue:
+		return Null, true
+	case *EmptyValue:
+		return Empty, true
+	default:
+		return 0, false
+	}
+}
+
 // JSONNullValue represents an explicit JSON null value in a JSON object or array.
 // On input this type can only be used in a table field of type JSON.
 //
@@ -132,9 +198,9 @@ func (jn *JSONNullValue) MarshalJSON() ([]byte, error) {
 	return []byte("null"), nil
 }
 
-// NullValue represents a null value or missing value in a fully-typed schema.
-//
-// This type only exists in index keys on a fully-typed field and never inside indexed JSON.
+// NullValue represents the SQL NULL value of a field in a fully-typed schema,
+// or a missing value in an index key on a fully-typed field. It never exists
+// inside indexed JSON.
 type NullValue struct{}
 
 // MarshalJSON returns the JSON encoding of NullValue.
@@ -164,6 +230,20 @@ func (e *EmptyValue) MarshalJSON() ([]byte, error) {
 	return []byte("null"), nil
 }
 
+// AbsentValue represents a field that does not exist, as opposed to a field
+// whose value is a JSON null or an SQL NULL.
+//
+// This should be used as an immutable singleton object, see Absent.
+type AbsentValue struct{}
+
+// MarshalJSON returns the JSON encoding of AbsentValue, which is only used if
+// it is not the value of a MapValue entry.
+//
+// This implements the json.Marshaler interface.
+func (a *AbsentValue) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
 var (
 	// JSONNullValueInstance represents an instance of JSONNullValue.
 	// This should be used as an immutable singleton object.
@@ -176,8 +256,38 @@ var (
 	// EmptyValueInstance represents an instance of EmptyValue.
 	// This should be used as an immutable singleton object.
 	EmptyValueInstance = &EmptyValue{}
+
+	// SQLNull represents the SQL NULL value. It is the same as
+	// NullValueInstance.
+	SQLNull = NullValueInstance
+
+	// Absent represents a field that does not exist.
+	// This should be used as an immutable singleton object.
+	Absent = &AbsentValue{}
 )
 
+// IsJSONNull reports whether v is a JSON null, which is either nil or
+// JSONNullValueInstance.
+func IsJSONNull(v FieldValue) bool {
+	switch v.(type) {
+	case nil, *JSONNullValue:
+		return true
+	}
+	return false
+}
+
+// IsSQLNull reports whether v is the SQL NULL value.
+func IsSQLNull(v FieldValue) bool {
+	_, ok := v.(*NullValue)
+	return ok
+}
+
+// IsAbsent reports whether v represents a field that does not exist.
+func IsAbsent(v FieldValue) bool {
+	_, ok := v.(*AbsentValue)
+	return ok
+}
+
 // MapValue represents a row in a NoSQL Database table. A top-level row is
 // always a MapValue instance that contains FieldValue objects which may be
 // instances of atomic types, embedded MapValue or an array of aforementioned
@@ -206,6 +316,9 @@ type MapValue struct {
 
 	// keys is a slice of string that contains keys in insertion order.
 	keys []string
+
+	// released specifies whether the MapValue was released to a MapValuePool.
+	released bool
 }
 
 // NewMapValue creates a MapValue with the specified map m.
@@ -249,8 +362,11 @@ func NewOrderedMapValue() *MapValue {
 	}
 }
 
-// Len returns the number of key/value pairs stored in MapValue.
+// Len returns the number of key/value pairs stored in MapValue, including
+// the pairs whose value is Absent, which are omitted when the MapValue is
+// written.
 func (m *MapValue) Len() int {
+	m.checkReleased()
 	return len(m.m)
 }
 
@@ -261,6 +377,7 @@ func (m *MapValue) IsOrdered() bool {
 
 // Map returns the underlying map of MapValue.
 func (m *MapValue) Map() map[string]interface{} {
+	m.checkReleased()
 	return m.m
 }
 
@@ -271,13 +388,43 @@ func (m *MapValue) MarshalJSON() ([]byte, error) {
 	if m == nil || m.m == nil {
 		return []byte("null"), nil
 	}
+	m.checkReleased()
+
+	for _, v := range m.m {
+		if IsAbsent(v) {
+			return json.Marshal(m.presentFields())
+		}
+	}
 
 	return json.Marshal(m.m)
 }
 
+// presentFields returns the entries of m whose value is not Absent.
+func (m *MapValue) presentFields() map[string]interface{} {
+	present := make(map[string]interface{}, len(m.m))
+	for k, v := range m.m {
+		if !IsAbsent(v) {
+			present[k] = v
+		}
+	}
+	return present
+}
+
+// GetField returns the value with specified key k, or Absent if there is no
+// such value. Unlike Get, it can be used to distinguish an absent field from
+// a field whose value is a JSON null or an SQL NULL with a single value.
+func (m *MapValue) GetField(k string) FieldValue {
+	m.checkReleased()
+	if v, ok := m.m[k]; ok {
+		return v
+	}
+	return Absent
+}
+
 // Put inserts a value v indexed by key k into MapValue.
 // If MapValue is ordered, it keeps track of the insertion order.
 func (m *MapValue) Put(k string, v interface{}) *MapValue {
+	m.checkReleased()
 	if m.m == nil {
 		m.m = make(map[string]interface{})
 	}
@@ -296,6 +443,7 @@ func (m *MapValue) Put(k string, v interface{}) *MapValue {
 // returns that value and sets ok to true. Otherwise, it returns nil and sets ok
 // to false.
 func (m *MapValue) Get(k string) (v interface{}, ok bool) {
+	m.checkReleased()
 	v, ok = m.m[k]
 	return
 }
@@ -303,6 +451,7 @@ func (m *MapValue) Get(k string) (v interface{}, ok bool) {
 // Contains checks for existence of a key in a MapValue.
 // If the key is present, it returns true, otherwise false.
 func (m *MapValue) Contains(k string) (ok bool) {
+	m.checkReleased()
 	_, ok = m.m[k]
 	return
 }
@@ -314,6 +463,7 @@ func (m *MapValue) Contains(k string) (ok bool) {
 // If the method finds k and v, it returns them and sets ok to true. Otherwise,
 // it returns zero value for k and v, and sets ok to false.
 func (m *MapValue) GetByIndex(idx int) (k string, v interface{}, ok bool) {
+	m.checkReleased()
 	if !m.keepInsertionOrder {
 		return
 	}
@@ -336,6 +486,7 @@ func (m *MapValue) GetByIndex(idx int) (k string, v interface{}, ok bool) {
 // is inefficient to keep track of and adjust insertion order after a value is
 // deleted.
 func (m *MapValue) Delete(k string) {
+	m.checkReleased()
 	var ok bool
 	if m.keepInsertionOrder {
 		_, ok = m.Get(k)
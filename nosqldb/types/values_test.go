@@ -9,8 +9,12 @@ package types
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -346,6 +350,158 @@ func (suite *MapValueTestSuite) doMapValueTest(ordered bool) {
 	}
 }
 
+func (suite *MapValueTestSuite) TestKeyEncoding() {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	k := NewKey().
+		Add("tenant", "acme/eu~1.x_y").
+		Add("id", int64(1)<<40).
+		Add("seq", 7).
+		Add("score", 0.5).
+		Add("num", big.NewRat(1, 3)).
+		Add("bin", []byte{0xff, 0x00}).
+		Add("flag", true).
+		Add("ts", ts)
+
+	s, err := k.Encode()
+	suite.Require().NoError(err)
+	suite.Equal("tenant~sacme_2Feu_7E1_2Ex_5Fy.id~l1099511627776.seq~i7.score~d0_2E5."+
+		"num~n1_2F3.bin~b_5FwA.flag~ztrue.ts~t2024-01-02T03_3A04_3A05_2E000000006Z", s)
+	suite.Equal(url.PathEscape(s), s, "the encoded key should be URL-safe")
+
+	decoded, err := DecodeKey(s)
+	suite.Require().NoError(err)
+	suite.Equal(k.Len(), decoded.Len())
+	for i := 0; i < k.Len(); i++ {
+		name, want := k.Field(i)
+		gotName, got := decoded.Field(i)
+		suite.Equal(name, gotName)
+		if r, ok := want.(*big.Rat); ok {
+			suite.Equal(0, r.Cmp(got.(*big.Rat)))
+			continue
+		}
+		suite.Equalf(want, got, "unexpected value of %s", name)
+	}
+
+	m := NewMapValue(map[string]interface{}{"b": "x", "a": 1, "c": true})
+	k, err = KeyFromMapValue(m, "a", "b")
+	suite.Require().NoError(err)
+	s, _ = k.Encode()
+	suite.Equal("a~i1.b~sx", s)
+	suite.Equal([]string{"a", "b"}, k.MapValue().keys)
+
+	_, err = KeyFromMapValue(m, "d")
+	suite.Error(err)
+	_, err = NewKey().Add("a", []int{1}).Encode()
+	suite.Error(err)
+	for _, invalid := range []string{"", "a", "a~", "a~x1", "a~i_4", "a~iabc", "a~i1.b"} {
+		_, err = DecodeKey(invalid)
+		suite.Errorf(err, "DecodeKey(%q) should have failed", invalid)
+	}
+}
+
+func (suite *MapValueTestSuite) TestFormatLiteral() {
+	ts := time.Date(2024, 2, 29, 10, 30, 0, 500000000, time.FixedZone("", 3600))
+	ordered := NewOrderedMapValue().Put("z", 1).Put("a", "x")
+	tests := []struct {
+		v   FieldValue
+		exp string
+	}{
+		{"it's", `'it\'s'`},
+		{"a\\b\n\t\x01", `'a\\b\n\t\u0001'`},
+		{"héllo", `'héllo'`},
+		{-42, "-42"},
+		{int64(1) << 40, "1099511627776"},
+		{1.5, "1.5"},
+		{float64(3), "3.0"},
+		{1e21, "1e+21"},
+		{big.NewRat(3, 8), "0.375N"},
+		{big.NewRat(-10, 1), "-10N"},
+		{true, "true"},
+		{ts, `'2024-02-29T09:30:00.5Z'`},
+		{JSONNullValueInstance, "null"},
+		{ordered, `{'z' : 1, 'a' : 'x'}`},
+		{[]interface{}{1, "a", []string{"b"}}, `[1, 'a', ['b']]`},
+	}
+	for _, r := range tests {
+		s, err := FormatLiteral(r.v)
+		if suite.NoErrorf(err, "FormatLiteral(%v) got error", r.v) {
+			suite.Equalf(r.exp, s, "FormatLiteral(%v) returned unexpected literal", r.v)
+		}
+	}
+
+	s, err := FormatLiteral(map[string]interface{}{"b": []int{1, 2}, "a": 1.25})
+	suite.NoError(err)
+	suite.Equal(`{'a' : 1.25, 'b' : [1, 2]}`, s)
+
+	for _, v := range []FieldValue{nil, NullValueInstance, []byte{1}, math.NaN(), big.NewRat(1, 3), struct{}{}} {
+		_, err = FormatLiteral(v)
+		suite.Errorf(err, "FormatLiteral(%v) should have failed", v)
+	}
+}
+
+func (suite *MapValueTestSuite) TestNullAndAbsent() {
+	m := NewMapValue(map[string]interface{}{
+		"jsonNull": JSONNullValueInstance,
+		"nil":      nil,
+		"sqlNull":  SQLNull,
+		"absent":   Absent,
+	})
+
+	tests := []struct {
+		key      string
+		jsonNull bool
+		sqlNull  bool
+		absent   bool
+	}{
+		{"jsonNull", true, false, false},
+		{"nil", true, false, false},
+		{"sqlNull", false, true, false},
+		{"absent", false, false, true},
+		{"missing", false, false, true},
+	}
+	for _, r := range tests {
+		v := m.GetField(r.key)
+		suite.Equalf(r.jsonNull, IsJSONNull(v), "IsJSONNull(%q)", r.key)
+		suite.Equalf(r.sqlNull, IsSQLNull(v), "IsSQLNull(%q)", r.key)
+		suite.Equalf(r.absent, IsAbsent(v), "IsAbsent(%q)", r.key)
+	}
+
+	b, err := m.MarshalJSON()
+	if suite.NoError(err) {
+		suite.Equal(`{"jsonNull":null,"nil":null,"sqlNull":null}`, string(b))
+	}
+}
+
+func (suite *MapValueTestSuite) TestTypeOf() {
+	tests := []struct {
+		v    FieldValue
+		want DbType
+	}{
+		{[]FieldValue{1}, Array},
+		{[]byte("a"), Binary},
+		{true, Boolean},
+		{1.5, Double},
+		{1, Integer},
+		{int64(1), Long},
+		{NewMapValue(nil), Map},
+		{"a", String},
+		{time.Now(), Timestamp},
+		{big.NewRat(1, 3), Number},
+		{JSONNullValueInstance, JSONNull},
+		{nil, JSONNull},
+		{SQLNull, Null},
+		{EmptyValueInstance, Empty},
+	}
+	for _, tt := range tests {
+		t, ok := TypeOf(tt.v)
+		suite.Truef(ok, "TypeOf(%v) is not ok", tt.v)
+		suite.Equalf(tt.want, t, "TypeOf(%v)", tt.v)
+	}
+
+	_, ok := TypeOf(struct{}{})
+	suite.False(ok)
+}
+
 func TestMapValue(t *testing.T) {
 	suite.Run(t, &MapValueTestSuite{})
 }
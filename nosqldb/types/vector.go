@@ -0,0 +1,171 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package types
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Vector represents a fixed-length vector of float32 values, such as an
+// embedding computed by a machine learning model.
+//
+// A Vector can be stored in a field of type ARRAY(FLOAT), using the value
+// returned by ToArray, or in a field of type BINARY or FIXED_BINARY(4*n),
+// using the value returned by ToBinary, which is more compact. The binary
+// format is the sequence of the IEEE 754 representations of the elements, in
+// little-endian byte order.
+type Vector []float32
+
+// Validate checks that the vector has the specified number of dimensions, and
+// that its elements are finite numbers. If dim is 0, the vector can have any
+// non-zero number of dimensions.
+func (v Vector) Validate(dim int) error {
+	if len(v) == 0 {
+		return fmt.Errorf("vector must be non-empty")
+	}
+	if dim > 0 && len(v) != dim {
+		return fmt.Errorf("vector has %d dimensions, expected %d", len(v), dim)
+	}
+	for i, f := range v {
+		if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+			return fmt.Errorf("vector element %d is not a finite number: %v", i, f)
+		}
+	}
+	return nil
+}
+
+// ToArray returns the value to store the vector in a field of type
+// ARRAY(FLOAT).
+func (v Vector) ToArray() []FieldValue {
+	a := make([]FieldValue, len(v))
+	for i, f := range v {
+		a[i] = f
+	}
+	return a
+}
+
+// ToBinary returns the value to store the vector in a field of type BINARY or
+// FIXED_BINARY.
+func (v Vector) ToBinary() []byte {
+	b := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(f))
+	}
+	return b
+}
+
+// VectorFromBinary returns the vector stored in binary format by ToBinary.
+func VectorFromBinary(b []byte) (Vector, error) {
+	if len(b)%4 != 0 {
+		return nil, fmt.Errorf("the length of a binary vector must be a multiple of 4, got %d", len(b))
+	}
+	v := make(Vector, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v, nil
+}
+
+// VectorFromFieldValue returns the vector stored in a field value, as it is
+// returned by the server or decoded from JSON, and validates it with
+// Validate(dim).
+//
+// The value can be an array of numbers, as stored by ToArray, or a []byte or
+// a base64 encoded string, as stored by ToBinary.
+func VectorFromFieldValue(value FieldValue, dim int) (v Vector, err error) {
+	switch value := value.(type) {
+	case Vector:
+		v = value
+	case []float32:
+		v = value
+	case []float64:
+		v = make(Vector, len(value))
+		for i, f := range value {
+			v[i] = float32(f)
+		}
+	case []FieldValue:
+		v, err = vectorFromArray(value)
+	case []byte:
+		v, err = VectorFromBinary(value)
+	case string:
+		var b []byte
+		if b, err = base64.StdEncoding.DecodeString(value); err != nil {
+			return nil, fmt.Errorf("invalid binary vector: %v", err)
+		}
+		v, err = VectorFromBinary(b)
+	default:
+		return nil, fmt.Errorf("cannot convert a value of type %T to a vector", value)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if err = v.Validate(dim); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func vectorFromArray(a []FieldValue) (Vector, error) {
+	v := make(Vector, len(a))
+	for i, e := range a {
+		var f float64
+		switch e := e.(type) {
+		case float32:
+			f = float64(e)
+		case float64:
+			f = e
+		case int:
+			f = float64(e)
+		case int64:
+			f = float64(e)
+		case json.Number:
+			var err error
+			if f, err = e.Float64(); err != nil {
+				return nil, fmt.Errorf("vector element %d: %v", i, err)
+			}
+		default:
+			return nil, fmt.Errorf("vector element %d is not a number: %T", i, e)
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
+
+// Dot returns the dot product of the vectors, which must have the same number
+// of dimensions.
+func Dot(a, b Vector) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("vectors have different dimensions: %d and %d", len(a), len(b))
+	}
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between the vectors, which
+// must have the same number of dimensions. The result is between -1 and 1. It
+// is 0 if either vector is zero.
+func CosineSimilarity(a, b Vector) (float64, error) {
+	dot, err := Dot(a, b)
+	if err != nil {
+		return 0, err
+	}
+	na, _ := Dot(a, a)
+	nb, _ := Dot(b, b)
+	if na == 0 || nb == 0 {
+		return 0, nil
+	}
+	return dot / math.Sqrt(na*nb), nil
+}
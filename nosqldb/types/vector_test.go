@@ -0,0 +1,73 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVector(t *testing.T) {
+	v := Vector{1, -2.5, 3}
+	require.NoError(t, v.Validate(3))
+	require.NoError(t, v.Validate(0))
+	assert.Error(t, v.Validate(4))
+	assert.Error(t, Vector{}.Validate(0))
+	assert.Error(t, Vector{1, float32(math.NaN())}.Validate(2))
+	assert.Error(t, Vector{float32(math.Inf(1))}.Validate(1))
+
+	b := v.ToBinary()
+	assert.Len(t, b, 12)
+	got, err := VectorFromBinary(b)
+	require.NoError(t, err)
+	assert.Equal(t, v, got)
+	_, err = VectorFromBinary(b[:5])
+	assert.Error(t, err)
+
+	tests := []FieldValue{
+		v.ToArray(),
+		[]FieldValue{1, float64(-2.5), json.Number("3")},
+		b,
+		base64.StdEncoding.EncodeToString(b),
+		[]float64{1, -2.5, 3},
+	}
+	for i, value := range tests {
+		got, err = VectorFromFieldValue(value, 3)
+		if assert.NoErrorf(t, err, "Testcase %d: VectorFromFieldValue(%T)", i+1, value) {
+			assert.Equalf(t, v, got, "Testcase %d: VectorFromFieldValue(%T)", i+1, value)
+		}
+	}
+
+	_, err = VectorFromFieldValue(v.ToArray(), 2)
+	assert.Error(t, err)
+	_, err = VectorFromFieldValue([]FieldValue{1, "a"}, 0)
+	assert.Error(t, err)
+	_, err = VectorFromFieldValue(true, 0)
+	assert.Error(t, err)
+
+	dot, err := Dot(Vector{1, 2}, Vector{3, 4})
+	require.NoError(t, err)
+	assert.Equal(t, 11.0, dot)
+	_, err = Dot(Vector{1}, Vector{1, 2})
+	assert.Error(t, err)
+
+	cos, err := CosineSimilarity(Vector{1, 0}, Vector{2, 0})
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, cos, 1e-9)
+	cos, err = CosineSimilarity(Vector{1, 0}, Vector{0, 3})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0, cos, 1e-9)
+	cos, err = CosineSimilarity(Vector{0, 0}, Vector{1, 1})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, cos)
+}
@@ -460,7 +460,12 @@ func (req *PutRequest) serializeV3(w proto.Writer, serialVersion int16) (err err
 		return
 	}
 
-	if _, err = w.WriteFieldValue(req.Value); err != nil {
+	if req.EncodedValue != nil {
+		_, err = req.EncodedValue.write(w, req.value())
+	} else {
+		_, err = w.WriteFieldValue(req.value())
+	}
+	if err != nil {
 		return
 	}
 
@@ -1005,7 +1010,7 @@ func (req *QueryRequest) serializeV3(w proto.Writer, serialVersion int16) (err e
 		return
 	}
 
-	if err = w.WriteByte(byte(req.traceLevel)); err != nil {
+	if err = w.WriteByte(byte(req.TraceLevel)); err != nil {
 		return
 	}
 
@@ -1152,6 +1157,12 @@ func (req *QueryRequest) deserializeV3(r proto.Reader, serialVersion int16) (Res
 		}
 
 		if v, ok := v.(*types.MapValue); ok {
+			if req.RowVisitor != nil && !req.isInternalRequest() {
+				if err = visitRow(v, req.RowVisitor); err != nil {
+					return nil, err
+				}
+				continue
+			}
 			res.results = append(res.results, v)
 		}
 	}
@@ -0,0 +1,94 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+)
+
+// SimilarityMetric represents a measure of the similarity of two vectors.
+type SimilarityMetric int
+
+const (
+	// SimilarityCosine measures similarity by the cosine of the angle between
+	// the vectors.
+	SimilarityCosine SimilarityMetric = iota // 0
+
+	// SimilarityDotProduct measures similarity by the dot product of the
+	// vectors. It is equivalent to SimilarityCosine for normalized vectors,
+	// and cheaper to compute.
+	SimilarityDotProduct // 1
+)
+
+// ScoredRow represents a row ranked by RankBySimilarity.
+type ScoredRow struct {
+	// Row represents the row.
+	Row *types.MapValue `json:"row"`
+
+	// Score represents the similarity of the vector of the row to the query
+	// vector. Higher scores indicate more similar vectors.
+	Score float64 `json:"score"`
+}
+
+// RankBySimilarity scores rows, such as the results of a query, by the
+// similarity of the vector stored in the specified field to the query vector,
+// and returns the k rows with the highest scores, in descending order of
+// score. If k is 0 or negative, all rows are returned.
+//
+// The vectors are read with types.VectorFromFieldValue, so they can be stored
+// as ARRAY(FLOAT) or as binary values, and must have the same number of
+// dimensions as the query vector. Rows in which the field is missing or null
+// are skipped.
+//
+// The rows are scored on the client, so the query must return all candidate
+// rows. This is intended for small data sets, or for ranking the rows selected
+// by a more specific query.
+func RankBySimilarity(rows []*types.MapValue, field string, query types.Vector, metric SimilarityMetric, k int) ([]ScoredRow, error) {
+	if err := query.Validate(0); err != nil {
+		return nil, fmt.Errorf("RankBySimilarity: invalid query vector: %v", err)
+	}
+
+	var score func(a, b types.Vector) (float64, error)
+	switch metric {
+	case SimilarityCosine:
+		score = types.CosineSimilarity
+	case SimilarityDotProduct:
+		score = types.Dot
+	default:
+		return nil, fmt.Errorf("RankBySimilarity: unsupported similarity metric %d", metric)
+	}
+
+	scored := make([]ScoredRow, 0, len(rows))
+	for i, row := range rows {
+		if row == nil {
+			continue
+		}
+		value, ok := row.Get(field)
+		if !ok || isNullValue(value) {
+			continue
+		}
+
+		v, err := types.VectorFromFieldValue(value, len(query))
+		if err != nil {
+			return nil, fmt.Errorf("RankBySimilarity: row %d: %v", i, err)
+		}
+		s, _ := score(query, v)
+		scored = append(scored, ScoredRow{Row: row, Score: s})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+	if k > 0 && k < len(scored) {
+		scored = scored[:k]
+	}
+	return scored, nil
+}
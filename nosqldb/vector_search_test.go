@@ -0,0 +1,56 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankBySimilarity(t *testing.T) {
+	row := func(id int, v types.FieldValue) *types.MapValue {
+		m := types.NewMapValue(map[string]interface{}{"id": id})
+		if v != nil {
+			m.Put("embedding", v)
+		}
+		return m
+	}
+	rows := []*types.MapValue{
+		row(1, types.Vector{0, 1}.ToArray()),
+		row(2, types.Vector{1, 0.1}.ToBinary()),
+		row(3, nil),
+		row(4, types.Vector{1, 1}.ToArray()),
+		row(5, types.NullValueInstance),
+	}
+
+	ranked, err := RankBySimilarity(rows, "embedding", types.Vector{1, 0}, SimilarityCosine, 0)
+	require.NoError(t, err)
+	var ids []int
+	for _, r := range ranked {
+		id, _ := r.Row.GetInt("id")
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []int{2, 4, 1}, ids)
+	assert.InDelta(t, 0.0, ranked[2].Score, 1e-9)
+
+	ranked, err = RankBySimilarity(rows, "embedding", types.Vector{2, 0}, SimilarityDotProduct, 1)
+	require.NoError(t, err)
+	require.Len(t, ranked, 1)
+	assert.Same(t, rows[1], ranked[0].Row)
+	assert.InDelta(t, 2.0, ranked[0].Score, 1e-9)
+
+	_, err = RankBySimilarity(rows, "embedding", types.Vector{1, 0, 0}, SimilarityCosine, 0)
+	assert.Error(t, err)
+	_, err = RankBySimilarity(rows, "embedding", types.Vector{}, SimilarityCosine, 0)
+	assert.Error(t, err)
+	_, err = RankBySimilarity(rows, "embedding", types.Vector{1, 0}, SimilarityMetric(9), 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"context"
+
+	"github.com/oracle/nosql-go-sdk/nosqldb/nosqlerr"
+)
+
+// maxCloudBatchOps is the maximum number of operations in a WriteMultiple
+// request on the cloud service.
+const maxCloudBatchOps = 50
+
+// writeMultipleSplit executes the specified operations of req, splitting them
+// into multiple requests if they exceed the limits on the number of operations
+// or the size of a request. The results of the requests are merged.
+//
+// The operations are first split into chunks of the maximum number of
+// operations of the cloud service. A request that is rejected because it has
+// too many operations or is too large is split in two halves, recursively.
+func (c *Client) writeMultipleSplit(ctx context.Context, req *WriteMultipleRequest, ops []*WriteOperation) (*WriteMultipleResult, error) {
+	if c.isCloud && len(ops) > maxCloudBatchOps {
+		var res *WriteMultipleResult
+		for start := 0; start < len(ops); start += maxCloudBatchOps {
+			end := start + maxCloudBatchOps
+			if end > len(ops) {
+				end = len(ops)
+			}
+
+			subRes, err := c.writeMultipleSplit(ctx, req, ops[start:end])
+			if err != nil {
+				return nil, err
+			}
+
+			if res = mergeWriteMultipleResults(res, subRes, start); !res.IsSuccess() {
+				break
+			}
+		}
+		return res, nil
+	}
+
+	subReq := &WriteMultipleRequest{
+		TableName:  req.TableName,
+		Operations: ops,
+		Timeout:    req.Timeout,
+		Durability: req.Durability,
+		Namespace:  req.Namespace,
+		coalesced:  req.coalesced,
+	}
+	res, err := c.writeMultiple(ctx, subReq)
+	if err == nil || len(ops) == 1 ||
+		!nosqlerr.Is(err, nosqlerr.BatchOpNumberLimitExceeded, nosqlerr.RequestSizeLimitExceeded) {
+		return res, err
+	}
+
+	mid := len(ops) / 2
+	res, err = c.writeMultipleSplit(ctx, req, ops[:mid])
+	if err != nil || !res.IsSuccess() {
+		return res, err
+	}
+
+	subRes, err := c.writeMultipleSplit(ctx, req, ops[mid:])
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeWriteMultipleResults(res, subRes, mid), nil
+}
+
+// mergeWriteMultipleResults merges the result of a request whose operations
+// start at the specified offset into the result of the preceding operations,
+// which is nil for the first request.
+func mergeWriteMultipleResults(res, subRes *WriteMultipleResult, offset int) *WriteMultipleResult {
+	if res == nil {
+		return subRes
+	}
+
+	res.ReadKB += subRes.ReadKB
+	res.WriteKB += subRes.WriteKB
+	res.ReadUnits += subRes.ReadUnits
+	res.RateLimitTime += subRes.RateLimitTime
+	res.RetryTime += subRes.RetryTime
+	res.RequestSize += subRes.RequestSize
+	res.ResponseSize += subRes.ResponseSize
+
+	if subRes.IsSuccess() {
+		res.ResultSet = append(res.ResultSet, subRes.ResultSet...)
+		return res
+	}
+
+	// The operations of res were committed. As for a request that is not
+	// split, the result set of an aborted request only contains the result
+	// of the failed operation.
+	res.FailedOperationIndex = offset + subRes.FailedOperationIndex
+	res.CommittedOperations = offset + subRes.CommittedOperations
+	res.ResultSet = subRes.ResultSet
+	return res
+}
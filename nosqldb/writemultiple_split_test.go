@@ -0,0 +1,35 @@
+//
+// Copyright (c) 2019, 2025 Oracle and/or its affiliates. All rights reserved.
+//
+// Licensed under the Universal Permissive License v 1.0 as shown at
+//  https://oss.oracle.com/licenses/upl/
+//
+
+package nosqldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeWriteMultipleResults(t *testing.T) {
+	ok := func(n int) *WriteMultipleResult {
+		res := &WriteMultipleResult{FailedOperationIndex: -1, ResultSet: make([]OperationResult, n)}
+		res.WriteKB = n
+		return res
+	}
+
+	res := mergeWriteMultipleResults(nil, ok(50), 0)
+	res = mergeWriteMultipleResults(res, ok(20), 50)
+	assert.True(t, res.IsSuccess())
+	assert.Len(t, res.ResultSet, 70)
+	assert.Equal(t, 70, res.WriteKB)
+
+	aborted := &WriteMultipleResult{FailedOperationIndex: 3, ResultSet: []OperationResult{{Success: false}}}
+	res = mergeWriteMultipleResults(res, aborted, 70)
+	assert.False(t, res.IsSuccess())
+	assert.Equal(t, 73, res.FailedOperationIndex)
+	assert.Equal(t, 70, res.CommittedOperations)
+	assert.Equal(t, &aborted.ResultSet[0], res.GetFailedOperationResult())
+}
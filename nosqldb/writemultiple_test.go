@@ -419,6 +419,57 @@ func (suite *WriteMultipleTestSuite) TestOpAborted() {
 	suite.runOpAbortedTest(requests, failedOpIdx, recordKB, rowPresents, newVersion, newValue)
 }
 
+// TestOpSplit tests the WriteMultiple operation with a number of operations
+// that exceeds the limit, which is split into multiple requests.
+func (suite *WriteMultipleTestSuite) TestOpSplit() {
+	sid := 30
+	recordKB := 1
+	n := 2*test.MaxBatchOpNumberLimit + 1
+
+	wmReq := &nosqldb.WriteMultipleRequest{
+		TableName:  suite.table,
+		AllowSplit: true,
+	}
+	for i := 0; i < n; i++ {
+		putReq := &nosqldb.PutRequest{
+			TableName: suite.table,
+			Value:     suite.genRow(sid, i, recordKB, false),
+		}
+		wmReq.AddPutRequest(putReq, true)
+	}
+
+	wmRes, err := suite.Client.WriteMultiple(wmReq)
+	if suite.NoErrorf(err, "WriteMultiple() failed, got error: %v", err) {
+		suite.Truef(wmRes.IsSuccess(), "WriteMultiple() should have succeeded")
+		suite.Equalf(n, len(wmRes.ResultSet), "unexpected number of results")
+	}
+
+	// The operations after the first failed operation are not executed.
+	wmReq.Clear()
+	wmReq.TableName = suite.table
+	for i := n; i < 2*n; i++ {
+		putReq := &nosqldb.PutRequest{
+			TableName: suite.table,
+			Value:     suite.genRow(sid, i, recordKB, false),
+			PutOption: types.PutIfAbsent,
+		}
+		if i == n+test.MaxBatchOpNumberLimit+1 {
+			// This row already exists.
+			putReq.Value = suite.genRow(sid, 0, recordKB, false)
+		}
+		wmReq.AddPutRequest(putReq, true)
+	}
+
+	wmRes, err = suite.Client.WriteMultiple(wmReq)
+	if suite.NoErrorf(err, "WriteMultiple() failed, got error: %v", err) {
+		suite.Falsef(wmRes.IsSuccess(), "WriteMultiple() should have aborted")
+		suite.Equalf(test.MaxBatchOpNumberLimit+1, wmRes.FailedOperationIndex, "unexpected failed operation index")
+		if test.IsCloud() {
+			suite.Equalf(test.MaxBatchOpNumberLimit, wmRes.CommittedOperations, "unexpected number of committed operations")
+		}
+	}
+}
+
 func (suite *WriteMultipleTestSuite) TestOpInvalid() {
 	sid := 30
 	recordKB := 2